@@ -16,6 +16,8 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"flag"
 	"fmt"
 	"io"
@@ -24,6 +26,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 
 	// NOTE(tnthornton) we are making an active choice to have a pprof endpoint
@@ -50,12 +53,16 @@ import (
 	"go.opentelemetry.io/otel/sdk/resource"
 	"go.opentelemetry.io/otel/sdk/trace"
 	"go.uber.org/zap/zapcore"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 	"gopkg.in/alecthomas/kingpin.v2"
 	appsv1 "k8s.io/api/apps/v1"
+	authnv1 "k8s.io/api/authentication/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
-	kextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
-	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/rest"
 	"k8s.io/klog/v2"
@@ -65,8 +72,6 @@ import (
 
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
-	extv1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
-	pkgv1 "github.com/crossplane/crossplane/apis/pkg/v1"
 
 	"github.com/upbound/xgql/internal"
 	"github.com/upbound/xgql/internal/auth"
@@ -78,6 +83,7 @@ import (
 	"github.com/upbound/xgql/internal/live_query"
 	"github.com/upbound/xgql/internal/opentelemetry"
 	"github.com/upbound/xgql/internal/request"
+	"github.com/upbound/xgql/internal/scheme"
 	hprobe "github.com/upbound/xgql/internal/server/health"
 	"github.com/upbound/xgql/internal/version"
 )
@@ -103,51 +109,165 @@ var noCache = []client.Object{
 	&appsv1.DaemonSet{},
 	&rbacv1.RoleBinding{},
 	&rbacv1.ClusterRoleBinding{},
+
+	// We don't cache secrets because they're sensitive. Reading them from the
+	// API server ensures each read is subject to the caller's RBAC access, and
+	// that secret values aren't held in memory longer than necessary.
+	&corev1.Secret{},
+
+	// We don't cache resource quotas because the callers who read them are
+	// typically only allowed to list them in their own namespace, and a cache
+	// would need to watch them in all namespaces.
+	&corev1.ResourceQuota{},
 }
 
 func main() { //nolint:gocyclo
 	var (
-		app             = kingpin.New(filepath.Base(os.Args[0]), "A GraphQL API for Crossplane.").DefaultEnvars()
-		debug           = app.Flag("debug", "Enable debug logging.").Short('d').Counter()
-		listen          = app.Flag("listen", "Address at which to listen for TLS connections. Requires TLS cert and key.").Default(":8443").String()
-		tlsCert         = app.Flag("tls-cert", "Path to the TLS certificate file used to serve TLS connections.").ExistingFile()
-		tlsKey          = app.Flag("tls-key", "Path to the TLS key file used to serve TLS connections.").ExistingFile()
-		insecure        = app.Flag("listen-insecure", "Address at which to listen for insecure connections.").Default("127.0.0.1:8080").String()
-		play            = app.Flag("enable-playground", "Serve a GraphQL Playground.").Bool()
-		tracer          = app.Flag("trace-backend", "Tracer to use.").Default("jaeger").Enum("jaeger", "gcp", "stdout")
-		ratio           = app.Flag("trace-ratio", "Ratio of queries that should be traced.").Default("0.01").Float()
-		agent           = app.Flag("trace-agent", "Address of the Jaeger trace agent as [host]:[port]").TCP()
-		health          = app.Flag("health", "Enable health endpoints.").Default("true").Bool()
-		healthPort      = app.Flag("health-port", "Port used for readyz and livez requests.").Default("8088").Int()
-		cacheExpiry     = app.Flag("cache-expiry", "The duration since last activity by a user until that users client expires.").Default("30m").Duration()
-		profiling       = app.Flag("profiling", "Enable profiling via web interface host:port/debug/pprof/.").Default("true").Bool()
-		cacheFile       = app.Flag("cache-file", "Path to the file used to persist client caches, set to reduce memory usage.").Default("").String()
-		noApolloTracing = app.Flag("disable-apollo-tracing", "Disable apollo tracing.").Bool()
+		app              = kingpin.New(filepath.Base(os.Args[0]), "A GraphQL API for Crossplane.").DefaultEnvars()
+		debug            = app.Flag("debug", "Enable debug logging.").Short('d').Counter()
+		logFormat        = app.Flag("log-format", "Format of log output. Defaults to console when --debug is set, and json otherwise.").Enum("json", "console")
+		logLevel         = app.Flag("log-level", "Minimum level of xgql's logs. Defaults to debug when --debug is set, and info otherwise.").Enum("debug", "info", "warn", "error")
+		listen           = app.Flag("listen", "Address at which to listen for TLS connections. Requires TLS cert and key.").Default(":8443").String()
+		tlsCert          = app.Flag("tls-cert", "Path to the TLS certificate file used to serve TLS connections.").ExistingFile()
+		tlsKey           = app.Flag("tls-key", "Path to the TLS key file used to serve TLS connections.").ExistingFile()
+		apiCAFile        = app.Flag("api-ca-file", "Path to a PEM encoded CA bundle used to verify the API server's certificate, for example when running outside the cluster against an API server with a self-signed certificate.").ExistingFile()
+		apiInsecure      = app.Flag("insecure-skip-tls-verify", "Don't verify the API server's certificate. This is insecure; only use it for development against a local API server. Cannot be combined with --api-ca-file.").Bool()
+		tokenHeader      = app.Flag("token-header", "The HTTP header from which to read the caller's bearer token. The Bearer scheme is stripped from the Authorization header; the raw value of any other header is used as the token.").Default("Authorization").String()
+		oidcJWKSURL      = app.Flag("oidc-jwks-url", "URL of a JSON web key set used to verify the signatures of bearer tokens, which must be JWTs. Requests with invalid tokens are rejected without reaching the API server. Tokens are passed through unverified if unset.").String()
+		oidcCAFile       = app.Flag("oidc-ca-file", "Path to a PEM encoded root CA bundle trusted when fetching the --oidc-jwks-url. The system's root CAs are trusted if unset. Requires --oidc-jwks-url.").ExistingFile()
+		oidcIssuer       = app.Flag("oidc-issuer", "Reject bearer tokens not issued by this issuer. Requires --oidc-jwks-url.").String()
+		oidcAudience     = app.Flag("oidc-audience", "Reject bearer tokens whose audience doesn't include this audience. Requires --oidc-jwks-url.").String()
+		exchangeAudience = app.Flag("token-exchange-audience", "Exchange each caller's bearer token for a token with this audience using the TokenRequest API, and use the exchanged token to talk to the API server. Only service account tokens may be exchanged. Tokens are expected to have the --oidc-audience, if set. xgql must be allowed to create token reviews, and to create tokens for the service accounts it exchanges tokens for.").String()
+		exchangeTTL      = app.Flag("token-exchange-ttl", "How long exchanged tokens are requested to be valid for. Zero uses the API server's default. Requires --token-exchange-audience.").Default("0").Duration()
+		exchangeReview   = app.Flag("token-exchange-review-interval", "How often a caller's bearer token is reviewed again while its exchanged token is in use. A token that expires or is revoked may still be exchanged for up to this long afterward, or until it expires if it's a JWT that expires sooner. Has no effect without --token-exchange-audience.").Default("1m").Duration()
+		userAgent        = app.Flag("user-agent", "The user-agent xgql uses to identify itself to the API server. Defaults to xgql/<version>.").String()
+		insecure         = app.Flag("listen-insecure", "Address at which to listen for insecure connections.").Default("127.0.0.1:8080").String()
+		readTimeout      = app.Flag("read-timeout", "The maximum duration for reading an entire request, including the body.").Default("5s").Duration()
+		writeTimeout     = app.Flag("write-timeout", "The maximum duration before timing out writes of a response. Generous by default so that large list responses can be written to slow clients.").Default("60s").Duration()
+		idleTimeout      = app.Flag("idle-timeout", "The maximum amount of time to wait for the next request on a keep-alive connection.").Default("120s").Duration()
+		maxHeaderBytes   = app.Flag("max-header-bytes", "The maximum size in bytes of a request's headers, including the request line.").Default("1048576").Int()
+		wsKeepAlive      = app.Flag("ws-keepalive", "How often to ping subscription websockets, so that proxies and load balancers don't close them as idle. Websockets using the graphql-transport-ws protocol are closed if the client doesn't respond within twice this interval. Zero disables pings.").Default("10s").Duration()
+		enableH2C        = app.Flag("h2c", "Accept HTTP/2 over cleartext (h2c), with prior knowledge or via an HTTP/1.1 upgrade, on insecure connections. HTTP/1.1 requests are still served.").Bool()
+		play             = app.Flag("enable-playground", "Serve a GraphQL Playground.").Bool()
+		noIntrospection  = app.Flag("disable-introspection", "Disable GraphQL schema introspection, and serving the schema at /schema.graphql. Cannot be combined with --enable-playground, which relies on introspection.").Bool()
+		tracer           = app.Flag("trace-backend", "Tracer to use.").Default("jaeger").Enum("jaeger", "gcp", "stdout")
+		ratio            = app.Flag("trace-ratio", "Ratio of queries that should be traced.").Default("0.01").Float()
+		agent            = app.Flag("trace-agent", "Address of the Jaeger trace agent as [host]:[port]").TCP()
+		health           = app.Flag("health", "Enable health endpoints.").Default("true").Bool()
+		healthPort       = app.Flag("health-port", "Port used for readyz and livez requests.").Default("8088").Int()
+		cacheExpiry      = app.Flag("cache-expiry", "The duration since last activity by a user until that users client expires.").Default("30m").Duration()
+		cacheJitter      = app.Flag("cache-expiry-jitter", "The fraction by which each client's expiry randomly varies, so that clients created together don't expire together. At most 0.5. Zero disables jitter.").Default("0.1").Float64()
+		disableCache     = app.Flag("no-cache", "Disable client caches, sending every read to the API server. Useful for debugging.").Bool()
+		readOnly         = app.Flag("read-only", "Disable all writes. Mutations return an error without reaching the API server, regardless of the caller's RBAC permissions.").Bool()
+		finalizers       = app.Flag("enable-finalizer-removal", "Allow the removeFinalizer mutation to remove finalizers from resources. Removing a finalizer skips the cleanup it guards, which may orphan external resources. Every removal is logged. Has no effect if --read-only is set.").Bool()
+		exposeSecrets    = app.Flag("expose-connection-secrets", "Allow the data field of a secret to return the secret's values to callers who may get the secret. Only the secret's keys are returned otherwise. Every access that returns values is logged.").Bool()
+		exposeCMs        = app.Flag("expose-config-map-values", "Allow the data field of a config map to return the config map's values to callers who may get the config map. Only the config map's keys are returned otherwise.").Bool()
+		managedFields    = app.Flag("include-managed-fields", "Include the metadata.managedFields of Kubernetes resources, which are stripped by default, and the kubectl last-applied-configuration annotation of resources returned by mutations. Useful for debugging.").Bool()
+		cacheHealth      = app.Flag("cache-health-interval", "How often to check that client caches are still synced, removing those that are not. Zero disables health checks.").Default("1m").Duration()
+		discoveryRefresh = app.Flag("discovery-refresh", "How often to discard and rediscover the API resources offered by the API server. Zero disables periodic rediscovery.").Default("10m").Duration()
+		discoveryDir     = app.Flag("discovery-cache-dir", "Path to a directory, for example a volume, in which to persist the API resources discovered at startup so that restarts reuse them rather than rediscovering. Cached discovery expires after --discovery-refresh, or after 6h if periodic rediscovery is disabled. Kinds whose CRDs were installed after discovery was cached trigger a single rediscovery when first used; later new kinds wait for the next --discovery-refresh.").String()
+		discoveryTTL     = app.Flag("discovery-cache-ttl", "How long the API resources returned by the apiResources query are cached.").Default("30s").Duration()
+		shareDiscovery   = app.Flag("share-discovery", "Cache the API resources returned by the apiResources query once for all users, rather than once per user. Resources discovered using one user's credentials are returned to all users, so don't share discovery if the kinds the API server serves are sensitive.").Bool()
+		cacheResync      = app.Flag("cache-resync-period", "Minimum frequency at which client caches resync. Shorter periods correct drift sooner at the cost of API server load. Zero uses the controller-runtime default.").Default("0").Duration()
+		indexOwned       = app.Flag("cache-index-owned", "A kind, as apiVersion/Kind (for example apps/v1/Deployment), whose resources client caches index by owner so that related owned resources of the kind are found without listing every resource of the kind. Every caller must be allowed to list and watch the kind. Kinds the API server doesn't serve are ignored. May be repeated.").Strings()
+		cacheFallback    = app.Flag("cache-fallback-timeout", "How long to wait for a client cache to sync before reading directly from the API server instead, for callers who may get resources but not list or watch them. Zero disables fallback.").Default("0").Duration()
+		listPageSize     = app.Flag("cache-list-page-size", "The number of resources client caches request per page when they list the resources they watch. Zero uses the client-go default.").Default("0").Int64()
+		quotaTTL         = app.Flag("resource-quota-cache-ttl", "How long resource quotas listed by the resourceQuotas query are reused. Never shared between users. Zero disables.").Default("10s").Duration()
+		notFoundTTL      = app.Flag("not-found-cache-ttl", "How long reads made while serving a request remember that a resource was not found, so that a missing resource referenced several times is read only once. Never shared between users. Zero disables.").Default("2s").Duration()
+		maxCreates       = app.Flag("max-concurrent-creates", "The maximum number of client caches that may be created, and synced, concurrently. Requests that can use an existing client never wait. Zero disables the limit.").Default("0").Int()
+		profiling        = app.Flag("profiling", "Enable profiling via web interface host:port/debug/pprof/.").Default("true").Bool()
+		cacheFile        = app.Flag("cache-file", "Path to the file used to persist client caches, set to reduce memory usage.").Default("").String()
+		noApolloTracing  = app.Flag("disable-apollo-tracing", "Disable apollo tracing.").Bool()
+		debugSessions    = app.Flag("debug-sessions", "Serve details of active client sessions at localhost:6060/debug/sessions.").Bool()
+
+		maxBodyBytes   = app.Flag("max-body-bytes", "The maximum size in bytes of a GraphQL request body. Larger requests are rejected. Zero disables the limit.").Default("1048576").Int64()
+		trustedProxies = app.Flag("trusted-proxies", "CIDR ranges of proxies whose X-Forwarded-For and X-Real-IP headers are trusted when logging a request's remote address. May be repeated.").Strings()
+
+		perUserRPS   = app.Flag("per-user-rps", "The average number of requests per second each user may make. Zero disables rate limiting.").Default("0").Float()
+		perUserBurst = app.Flag("per-user-burst", "The maximum number of requests each user may make at once when rate limiting.").Default("50").Int()
 
 		globalEventsTarget = app.Flag("global-events-target", "The targeted number of events returned for global scope, potentially more if there are few warnings.").Default("500").Int()
 		globalEventsCap    = app.Flag("global-events-cap", "The maximum number of events returned for global scope.").Default("2000").Int()
+		maxTreeDepth       = app.Flag("max-tree-depth", "The maximum depth to which the tree of resources rooted at a claim or composite resource is resolved.").Default("10").Int()
+		treeConcurrency    = app.Flag("tree-concurrency", "The maximum number of resources each tree of resources rooted at a claim or composite resource reads concurrently. Further reads queue until one completes. Bounds how much of the API server's rate limit a single large tree may use.").Default("16").Int()
+		fieldManager       = app.Flag("field-manager", "The name of the field manager used by server-side apply patches. Fields set by xgql are owned by this manager, distinct from those owned by Crossplane's controllers.").Default("xgql").String()
 	)
 	app.Version(version.Version)
 	kingpin.MustParse(app.Parse(os.Args[1:]))
+	if *play && *noIntrospection {
+		kingpin.Fatalf("--enable-playground requires introspection and cannot be combined with --disable-introspection")
+	}
+	if *apiInsecure && *apiCAFile != "" {
+		kingpin.Fatalf("--insecure-skip-tls-verify cannot be combined with --api-ca-file")
+	}
+	if *cacheJitter < 0 || *cacheJitter > 0.5 {
+		kingpin.Fatalf("--cache-expiry-jitter must be between 0 and 0.5")
+	}
+	if *exchangeAudience == "" && *exchangeTTL != 0 {
+		kingpin.Fatalf("--token-exchange-ttl requires --token-exchange-audience")
+	}
+	if *exchangeReview <= 0 {
+		kingpin.Fatalf("--token-exchange-review-interval must be positive")
+	}
+	if *listPageSize < 0 {
+		kingpin.Fatalf("--cache-list-page-size must not be negative")
+	}
+	idx, err := ownedIndexes(*indexOwned)
+	kingpin.FatalIfError(err, "cannot parse --cache-index-owned")
+	if *perUserRPS > 0 && *perUserBurst < 1 {
+		kingpin.Fatalf("--per-user-burst must be at least 1 when rate limiting")
+	}
+	if *treeConcurrency < 1 {
+		kingpin.Fatalf("--tree-concurrency must be at least 1")
+	}
+	if *wsKeepAlive < 0 {
+		kingpin.Fatalf("--ws-keepalive must not be negative")
+	}
+	if *oidcJWKSURL == "" && (*oidcCAFile != "" || *oidcIssuer != "" || *oidcAudience != "") {
+		kingpin.Fatalf("--oidc-ca-file, --oidc-issuer, and --oidc-audience require --oidc-jwks-url")
+	}
 
 	fs := flag.NewFlagSet("klog", flag.ExitOnError)
 	klog.InitFlags(fs)
 	kingpin.FatalIfError(fs.Parse([]string{fmt.Sprintf("--v=%d", *debug)}), "cannot parse klog flags")
 
-	zl := zap.New(zap.UseDevMode(*debug > 0))
+	// Options are applied in order, so the format and level flags override
+	// the defaults implied by --debug.
+	dev := zap.UseDevMode(*debug > 0)
+	enc := func(*zap.Options) {}
+	switch *logFormat {
+	case "json":
+		enc = zap.JSONEncoder()
+	case "console":
+		enc = zap.ConsoleEncoder()
+	}
+	lvl := func(*zap.Options) {}
+	if *logLevel != "" {
+		l, err := zapcore.ParseLevel(*logLevel)
+		kingpin.FatalIfError(err, "cannot parse log level")
+		lvl = zap.Level(l)
+	}
+
+	zl := zap.New(dev, enc, lvl)
 	if *debug > 0 {
-		klog.SetLogger(zap.New(zap.UseDevMode(*debug > 0)))
-		ctrl.SetLogger(zap.New(zap.UseDevMode(*debug > 0)))
+		klog.SetLogger(zap.New(dev, enc))
+		ctrl.SetLogger(zap.New(dev, enc))
 	} else {
-		klog.SetLogger(zap.New(zap.Level(zapcore.ErrorLevel)))
-		ctrl.SetLogger(zap.New(zap.Level(zapcore.ErrorLevel)))
+		klog.SetLogger(zap.New(enc, zap.Level(zapcore.ErrorLevel)))
+		ctrl.SetLogger(zap.New(enc, zap.Level(zapcore.ErrorLevel)))
 	}
 	log := logging.NewLogrLogger(zl.WithName("xgql"))
 
-	// Start a pprof endpoint to ensure we can gather pprofs when needed.
+	// Debug endpoints are served only on localhost, never by the listeners
+	// that serve the API. Start a pprof endpoint to ensure we can gather
+	// pprofs when needed.
+	dmux := http.NewServeMux()
 	if *profiling {
+		dmux.Handle("/debug/pprof/", http.DefaultServeMux)
+	}
+	if *profiling || *debugSessions {
 		go func() {
-			log.Info("pprof", "error", http.ListenAndServe("localhost:6060", nil)) //nolint:gosec
+			log.Info("debug", "error", http.ListenAndServe("localhost:6060", dmux)) //nolint:gosec
 		}()
 	}
 
@@ -197,15 +317,21 @@ func main() { //nolint:gocyclo
 		},
 	}
 
-	s := runtime.NewScheme()
-	kingpin.FatalIfError(corev1.AddToScheme(s), "cannot add Kubernetes core/v1 to scheme")
-	kingpin.FatalIfError(kextv1.AddToScheme(s), "cannot add Kubernetes apiextensions/v1 to scheme")
-	kingpin.FatalIfError(pkgv1.AddToScheme(s), "cannot add Crossplane pkg/v1 to scheme")
-	kingpin.FatalIfError(extv1.AddToScheme(s), "cannot add Crossplane apiextensions/v1 to scheme")
-	kingpin.FatalIfError(appsv1.AddToScheme(s), "cannot add Kubernetes apps/v1 to scheme")
-	kingpin.FatalIfError(rbacv1.AddToScheme(s), "cannot add Kubernetes rbac/v1 to scheme")
+	s, err := scheme.NewScheme()
+	kingpin.FatalIfError(err, "cannot create scheme")
 
-	cfg, err := clients.Config()
+	var cfgopts []clients.ConfigOption
+	if *apiCAFile != "" {
+		cfgopts = append(cfgopts, clients.WithCAFile(*apiCAFile))
+	}
+	if *apiInsecure {
+		log.Info("WARNING: Not verifying the API server's certificate. This is insecure and should only be used for development.")
+		cfgopts = append(cfgopts, clients.WithInsecureSkipTLSVerify())
+	}
+	if *userAgent != "" {
+		cfgopts = append(cfgopts, clients.WithUserAgent(*userAgent))
+	}
+	cfg, err := clients.Config(cfgopts...)
 	kingpin.FatalIfError(err, "cannot create client config")
 
 	httpClient, err := rest.HTTPClientFor(cfg)
@@ -218,8 +344,24 @@ func main() { //nolint:gocyclo
 	// a global REST mapper using our own credentials for all clients to share.
 	// Discovery happens once at startup, and then once any time a client asks
 	// for an unknown kind of API resource (subject to caching/rate limiting).
-	rm, err := clients.RESTMapper(cfg, httpClient)
+	// Discovery data for known kinds is otherwise never refreshed, so we
+	// periodically replace the REST mapper to pick up changed CRDs. Discovery
+	// may optionally be persisted to disk, trading freshness for a faster
+	// start. Persisted discovery expires when we'd otherwise refresh it.
+	rm, err := clients.NewRefreshingRESTMapper(func() (meta.RESTMapper, error) {
+		if *discoveryDir == "" {
+			return clients.RESTMapper(cfg, httpClient)
+		}
+		ttl := *discoveryRefresh
+		if ttl == 0 {
+			ttl = 6 * time.Hour
+		}
+		return clients.DiskCachedRESTMapper(cfg, *discoveryDir, ttl)
+	})
 	kingpin.FatalIfError(err, "cannot create REST mapper")
+	if *discoveryRefresh > 0 {
+		go rm.RefreshEvery(context.Background(), *discoveryRefresh, log)
+	}
 
 	var camid []clients.NewCacheMiddlewareFn
 	// wrap client.Cache in cache.*BBoltCache if cacheFile is specified.
@@ -234,32 +376,118 @@ func main() { //nolint:gocyclo
 		clients.DoNotCache(noCache),
 		clients.WithLogger(log),
 		clients.WithExpiry(*cacheExpiry),
+		clients.WithExpiryJitter(*cacheJitter),
+		clients.WithDiscoveryTTL(*discoveryTTL),
+		clients.WithKindTTL(map[schema.GroupVersionKind]time.Duration{corev1.SchemeGroupVersion.WithKind("ResourceQuota"): *quotaTTL}),
 		clients.UseNewCacheMiddleware(camid...),
 	}
+	if *disableCache {
+		caopts = append(caopts, clients.DisableCache())
+	}
+	if *managedFields {
+		caopts = append(caopts, clients.IncludeManagedFields())
+	}
+	if *readOnly {
+		caopts = append(caopts, clients.DisableWrites())
+	}
+	if *shareDiscovery {
+		caopts = append(caopts, clients.ShareDiscovery())
+	}
+	if *cacheResync > 0 {
+		caopts = append(caopts, clients.WithResyncPeriod(*cacheResync))
+	}
+	if *maxCreates > 0 {
+		caopts = append(caopts, clients.WithMaxConcurrentCreates(*maxCreates))
+	}
+	if *listPageSize > 0 {
+		caopts = append(caopts, clients.WithListPageSize(*listPageSize))
+	}
+	if len(idx) > 0 {
+		caopts = append(caopts, clients.WithIndexes(idx...))
+	}
+	if *cacheFallback > 0 {
+		caopts = append(caopts, clients.FallBackToLiveReads(*cacheFallback))
+	}
+	if *exchangeAudience != "" {
+		// Tokens are exchanged using our own credentials, not the caller's.
+		ec, err := client.New(cfg, client.Options{HTTPClient: httpClient, Scheme: s, Mapper: rm})
+		kingpin.FatalIfError(err, "cannot create token exchange client")
+		xopts := []auth.TokenExchangerOption{auth.WithReviewInterval(*exchangeReview)}
+		if *oidcAudience != "" {
+			xopts = append(xopts, auth.WithReviewAudiences(*oidcAudience))
+		}
+		if *exchangeTTL > 0 {
+			xopts = append(xopts, auth.WithExchangedTokenTTL(*exchangeTTL))
+		}
+		caopts = append(caopts, clients.WithTokenExchanger(auth.NewTokenExchanger(ec, *exchangeAudience, xopts...)))
+	}
 	ca := clients.NewCache(s, clients.Anonymize(cfg), caopts...)
-	h := handler.New(generated.NewExecutableSchema(generated.Config{Resolvers: resolvers.New(ca)}))
+	ropts := []resolvers.Option{resolvers.WithDiscoverer(ca), resolvers.WithDefinedKindCache(ca), resolvers.WithLogger(log)}
+	if *finalizers && !*readOnly {
+		log.Info("WARNING: Finalizer removal is enabled. Removing finalizers may orphan external resources.")
+		ropts = append(ropts, resolvers.EnableFinalizerRemoval())
+	}
+	if *exposeSecrets {
+		log.Info("WARNING: Secret values are exposed to callers who may get them.")
+		ropts = append(ropts, resolvers.ExposeSecretValues())
+	}
+	if *exposeCMs {
+		ropts = append(ropts, resolvers.ExposeConfigMapValues())
+	}
+	rs := resolvers.New(ca, ropts...)
+	es := generated.NewExecutableSchema(generated.Config{Resolvers: rs, Directives: rs.Directives()})
+	h := handler.New(es)
+
+	validate := validateCredentials(ca)
+	var tv *auth.TokenVerifier
+	if *oidcJWKSURL != "" {
+		hc, err := oidcHTTPClient(*oidcCAFile)
+		kingpin.FatalIfError(err, "cannot configure OIDC HTTP client")
+		tv = auth.NewTokenVerifier(*oidcJWKSURL, auth.WithIssuer(*oidcIssuer), auth.WithAudience(*oidcAudience), auth.WithHTTPClient(hc))
+
+		// Verify tokens before asking the API server to validate them.
+		next := validate
+		validate = func(ctx context.Context, cr auth.Credentials) error {
+			if err := tv.Validate(ctx, cr); err != nil {
+				return err
+			}
+			return next(ctx, cr)
+		}
+	}
 
 	h.AddTransport(transport.Websocket{
 		Upgrader: websocket.Upgrader{
 			// Enable per message compression.
 			EnableCompression: true,
 		},
-		PingPongInterval: 10 * time.Second,
-		InitFunc:         auth.WebsocketInit,
+		// The legacy graphql-ws protocol sends keepalives, while the newer
+		// graphql-transport-ws protocol sends pings and expects pongs.
+		KeepAlivePingInterval: *wsKeepAlive,
+		PingPongInterval:      *wsKeepAlive,
+		InitFunc:              auth.ValidatingWebsocketInit(validate),
 	})
 	h.AddTransport(transport.Options{})
+	// GET supports only queries, so that reads may be cached. Mutations and
+	// subscriptions are rejected.
 	h.AddTransport(transport.GET{})
+	// SSE delivers subscriptions to clients that can't use a websocket. It
+	// handles POST requests that accept an event stream, so it must be added
+	// before POST.
+	h.AddTransport(transport.SSE{})
 	h.AddTransport(transport.POST{})
 	h.AddTransport(transport.MultipartForm{})
 
 	h.SetQueryCache(lru.New(1000))
 
-	h.Use(extension.Introspection{})
+	if !*noIntrospection {
+		h.Use(extension.Introspection{})
+	}
 	h.Use(extension.AutomaticPersistedQuery{
 		Cache: lru.New(100),
 	})
 
 	h.SetErrorPresenter(present.Error)
+	h.SetRecoverFunc(present.Recover(log))
 	h.Use(opentelemetry.MetricEmitter{})
 	h.Use(opentelemetry.Tracer{})
 	if !*noApolloTracing {
@@ -269,64 +497,98 @@ func main() { //nolint:gocyclo
 		h.Use(&gqldebug.Tracer{})
 	}
 	h.Use(live_query.LiveQuery{})
+	h.Use(resolvers.AccessReviewCache{})
 
 	rt := chi.NewRouter()
 	rt.Use(middleware.RequestID)
+	// This must precede middleware that wraps the response writer.
+	rt.Use(request.ClearStreamWriteDeadline)
 	// if bbolt cache is enabled, add up bolt transaction request middleware
 	// to coalesce all concurrent reads from bolt db into a single transaction
 	// in the context of a given request.
 	if *cacheFile != "" {
 		rt.Use(cache.BoltTxMiddleware)
 	}
-	rt.Use(middleware.RequestLogger(&request.Formatter{Log: log}))
+	proxies, err := request.ParseCIDRs(*trustedProxies)
+	kingpin.FatalIfError(err, "cannot parse trusted proxies")
+	rt.Use(middleware.RequestLogger(&request.Formatter{Log: log, TrustedProxies: proxies}))
 	rt.Use(middleware.Compress(5)) // Chi recommends compression level 5.
-	rt.Use(auth.Middleware)
+	rt.Use(auth.TokenHeaderMiddleware(*tokenHeader))
+	if tv != nil {
+		rt.Use(auth.ValidatingMiddleware(tv.Validate))
+	}
+	if *perUserRPS > 0 {
+		rt.Use(request.NewRateLimiter(*perUserRPS, *perUserBurst).Middleware)
+	}
 	rt.Use(version.Middleware)
+	rt.Use(clients.NewLoaderMiddleware(*notFoundTTL))
 	rt.Use(resolvers.InjectConfig(&resolvers.Config{
 		GlobalEventsTarget: *globalEventsTarget,
 		GlobalEventsCap:    *globalEventsCap,
+		MaxTreeDepth:       *maxTreeDepth,
+		TreeConcurrency:    *treeConcurrency,
+		FieldManager:       *fieldManager,
 	}))
 
-	rt.Handle("/query", otelhttp.NewHandler(h, "/query"))
+	rt.Handle("/query", otelhttp.NewHandler(request.MaxBodyBytes(*maxBodyBytes)(request.ETag(*tokenHeader)(h)), "/query"))
 	rt.Handle("/metrics", promhttp.Handler())
 	rt.Handle("/version", version.Handler())
+	if !*noIntrospection {
+		// Serving the schema is equivalent to introspection.
+		rt.Handle("/schema.graphql", present.SchemaHandler(es.Schema()))
+	}
 	if *play {
 		rt.Handle("/", playground.Handler("GraphQL playground", "/query"))
 	}
+	if *debugSessions {
+		dmux.Handle("/debug/sessions", clients.SessionsHandler(ca))
+	}
 
-	// start health endpoints to aid in routing traffic to the pod
-	kingpin.FatalIfError(startHealth(internal.HealthOptions{Health: *health, HealthPort: *healthPort}, log), "cannot start health endpoints")
+	if *cacheHealth > 0 {
+		go ca.MonitorHealth(context.Background(), *cacheHealth, 5*time.Second)
+	}
 
-	if *tlsCert != "" && *tlsKey != "" {
-		srv := &http.Server{
-			Addr:              *listen,
-			Handler:           rt,
-			WriteTimeout:      10 * time.Second,
-			ReadTimeout:       5 * time.Second,
+	// start health endpoints to aid in routing traffic to the pod
+	kingpin.FatalIfError(startHealth(internal.HealthOptions{Health: *health, HealthPort: *healthPort}, log, hprobe.WithReadinessChecks(ca.Ready)), "cannot start health endpoints")
+
+	// Both the TLS and insecure servers bound how long slow clients may hold a
+	// connection open, so that they can't exhaust our connections.
+	newServer := func(addr string, h http.Handler) *http.Server {
+		return &http.Server{
+			Addr:              addr,
+			Handler:           h,
+			ReadTimeout:       *readTimeout,
 			ReadHeaderTimeout: 5 * time.Second,
+			WriteTimeout:      *writeTimeout,
+			IdleTimeout:       *idleTimeout,
+			MaxHeaderBytes:    *maxHeaderBytes,
 			ErrorLog:          stdlog.New(io.Discard, "", 0),
 		}
+	}
+
+	if *tlsCert != "" && *tlsKey != "" {
+		srv := newServer(*listen, rt)
 		go func() {
 			log.Debug("Listening for TLS connections", "address", *listen)
 			kingpin.FatalIfError(srv.ListenAndServeTLS(*tlsCert, *tlsKey), "cannot serve TLS HTTP")
 		}()
 	}
 
-	log.Debug("Listening for insecure connections", "address", *insecure)
-	srv := &http.Server{
-		Addr:              *insecure,
-		Handler:           rt,
-		WriteTimeout:      10 * time.Second,
-		ReadTimeout:       5 * time.Second,
-		ReadHeaderTimeout: 5 * time.Second,
-		ErrorLog:          stdlog.New(io.Discard, "", 0),
+	// TLS connections negotiate HTTP/2 using ALPN. Insecure connections must
+	// opt in to h2c explicitly.
+	var insecureHandler http.Handler = rt
+	if *enableH2C {
+		insecureHandler = h2c.NewHandler(rt, &http2.Server{IdleTimeout: *idleTimeout})
 	}
+
+	log.Debug("Listening for insecure connections", "address", *insecure, "h2c", *enableH2C)
+	srv := newServer(*insecure, insecureHandler)
 	kingpin.FatalIfError(srv.ListenAndServe(), "cannot serve insecure HTTP")
 }
 
 // startHealth starts the readyz and livez endpoints for this service.
-func startHealth(opts internal.HealthOptions, log logging.Logger) error {
-	p, err := hprobe.Server(opts, log)
+func startHealth(opts internal.HealthOptions, log logging.Logger, o ...hprobe.Opt) error {
+	p, err := hprobe.Server(opts, log, o...)
 
 	if err != nil {
 		return err
@@ -342,3 +604,57 @@ func startHealth(opts internal.HealthOptions, log logging.Logger) error {
 
 	return nil
 }
+
+// ownedIndexes returns indexes by owner UID of the supplied kinds, which must
+// be of the form apiVersion/Kind. Resolvers list owned resources as
+// unstructured objects, so that's what we index.
+func ownedIndexes(kinds []string) ([]clients.Index, error) {
+	out := make([]clients.Index, 0, len(kinds))
+	for _, k := range kinds {
+		i := strings.LastIndex(k, "/")
+		if i < 1 || i == len(k)-1 {
+			return nil, errors.Errorf("%q is not of the form apiVersion/Kind", k)
+		}
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion(k[:i])
+		u.SetKind(k[i+1:])
+		out = append(out, clients.OwnerUIDsIndex(u))
+	}
+	return out, nil
+}
+
+// oidcHTTPClient returns an HTTP client that trusts the root CAs in the
+// supplied PEM file, or the system's root CAs if the path is empty.
+func oidcHTTPClient(caFile string) (*http.Client, error) {
+	if caFile == "" {
+		return &http.Client{Timeout: 10 * time.Second}, nil
+	}
+	pem, err := os.ReadFile(filepath.Clean(caFile))
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot read CA file")
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, errors.New("no PEM encoded certificates found in CA file")
+	}
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	return &http.Client{Transport: t, Timeout: 10 * time.Second}, nil
+}
+
+// validateCredentials returns a validator that ensures credentials can be used
+// to authenticate to the API server, by asking the API server who they belong
+// to. The validating request uses the same client cache as GraphQL requests, so
+// the client it creates is reused by subsequent requests.
+func validateCredentials(ca *clients.Cache) auth.Validator {
+	return func(ctx context.Context, cr auth.Credentials) error {
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+
+		c, err := ca.GetWithContext(ctx, cr)
+		if err != nil {
+			return errors.Wrap(err, "cannot get client")
+		}
+		return errors.Wrap(c.Create(ctx, &authnv1.SelfSubjectReview{}), "cannot review credentials")
+	}
+}
@@ -24,8 +24,12 @@ import (
 	"github.com/99designs/gqlgen/graphql/handler/transport"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/rest"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
 )
 
+const errInvalidCredentials = "invalid credentials"
+
 type ctxkey int
 
 var key ctxkey
@@ -52,6 +56,10 @@ type Impersonation struct {
 	Extra    map[string][]string
 }
 
+func (i Impersonation) enabled() bool {
+	return i.Username != "" || len(i.Groups) > 0 || len(i.Extra) > 0
+}
+
 // Credentials that a caller may pass to xgql in order to authenticate to a
 // Kubernetes API server.
 type Credentials struct {
@@ -72,6 +80,13 @@ func (c Credentials) Inject(cfg *rest.Config) *rest.Config {
 		Groups:   c.Impersonate.Groups,
 		Extra:    c.Impersonate.Extra,
 	}
+
+	// Identify the authenticated user in API server audit logs. We only know
+	// who they are if they authenticated using basic auth; we never include
+	// a bearer token. The audit log already records impersonated users.
+	if c.BasicUsername != "" && !c.Impersonate.enabled() {
+		out.UserAgent = fmt.Sprintf("%s (%s)", out.UserAgent, c.BasicUsername)
+	}
 	return out
 }
 
@@ -96,6 +111,16 @@ func (c Credentials) Hash(extra []byte) string {
 	return fmt.Sprintf("%x", h.Sum(nil))
 }
 
+// TokenHash returns a SHA-256 hash of the credentials' bearer token, which
+// identifies the caller in logs without revealing their token. It returns an
+// empty string if there is no bearer token.
+func (c Credentials) TokenHash() string {
+	if c.BearerToken == "" {
+		return ""
+	}
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(c.BearerToken)))
+}
+
 // ExtractBearerToken (if any) from the supplied request.
 func ExtractBearerToken(r *http.Request) string {
 	h := strings.Split(r.Header.Get(headerAuthn), " ")
@@ -105,6 +130,17 @@ func ExtractBearerToken(r *http.Request) string {
 	return h[1]
 }
 
+// ExtractToken (if any) from the supplied header of the supplied request. The
+// Bearer scheme is stripped from the Authorization header. The raw value of any
+// other header is used as the token, for example when a gateway forwards the
+// token in a header like X-Auth-Token.
+func ExtractToken(r *http.Request, header string) string {
+	if http.CanonicalHeaderKey(header) == headerAuthn {
+		return ExtractBearerToken(r)
+	}
+	return r.Header.Get(header)
+}
+
 // ExtractImpersonation configuration (if any) from the supplied request.
 func ExtractImpersonation(r *http.Request) Impersonation {
 	extra := make(map[string][]string)
@@ -127,17 +163,26 @@ func ExtractImpersonation(r *http.Request) Impersonation {
 }
 
 // Middleware extracts credentials from the HTTP request and stashes them in its
-// context.
+// context. It reads the bearer token from the Authorization header.
 func Middleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		bu, bp, _ := r.BasicAuth()
-		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), key, Credentials{
-			BasicUsername: bu,
-			BasicPassword: bp,
-			BearerToken:   ExtractBearerToken(r),
-			Impersonate:   ExtractImpersonation(r),
-		})))
-	})
+	return TokenHeaderMiddleware(headerAuthn)(next)
+}
+
+// TokenHeaderMiddleware returns middleware that extracts credentials from the
+// HTTP request and stashes them in its context, like Middleware, except that it
+// reads the token from the supplied header per ExtractToken.
+func TokenHeaderMiddleware(header string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			bu, bp, _ := r.BasicAuth()
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), key, Credentials{
+				BasicUsername: bu,
+				BasicPassword: bp,
+				BearerToken:   ExtractToken(r, header),
+				Impersonate:   ExtractImpersonation(r),
+			})))
+		})
+	}
 }
 
 func WebsocketInit(ctx context.Context, initPayload transport.InitPayload) (context.Context, error) {
@@ -167,6 +212,47 @@ func WebsocketInit(ctx context.Context, initPayload transport.InitPayload) (cont
 	}), nil
 }
 
+// A Validator validates credentials, returning an error if they could not be
+// used to authenticate to the API server.
+type Validator func(ctx context.Context, cr Credentials) error
+
+// ValidatingWebsocketInit returns a websocket init function that extracts
+// credentials from the connection's init payload per WebsocketInit, then
+// validates them. Browsers can't set headers on websocket upgrades, so this is
+// typically the first time credentials are seen. Connections whose credentials
+// are invalid are rejected at connection init, rather than when they first
+// subscribe.
+func ValidatingWebsocketInit(v Validator) transport.WebsocketInitFunc {
+	return func(ctx context.Context, initPayload transport.InitPayload) (context.Context, error) {
+		ctx, err := WebsocketInit(ctx, initPayload)
+		if err != nil {
+			return ctx, err
+		}
+		cr, _ := FromContext(ctx)
+		if err := v(ctx, cr); err != nil {
+			return ctx, errors.Wrap(err, errInvalidCredentials)
+		}
+		return ctx, nil
+	}
+}
+
+// ValidatingMiddleware returns middleware that validates the credentials
+// stashed in the HTTP request's context by Middleware or TokenHeaderMiddleware,
+// which must run first. Requests whose credentials are invalid are rejected
+// with 401 Unauthorized without reaching the API server.
+func ValidatingMiddleware(v Validator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			cr, _ := FromContext(r.Context())
+			if err := v(r.Context(), cr); err != nil {
+				http.Error(w, errors.Wrap(err, errInvalidCredentials).Error(), http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 // FromContext extracts credentials from the supplied context.
 func FromContext(ctx context.Context) (Credentials, bool) {
 	c, ok := ctx.Value(key).(Credentials)
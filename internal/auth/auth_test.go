@@ -20,8 +20,13 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/99designs/gqlgen/graphql/handler/transport"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"k8s.io/client-go/rest"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
 )
 
 func TestCredentialsInject(t *testing.T) {
@@ -63,6 +68,46 @@ func TestCredentialsInject(t *testing.T) {
 				},
 			},
 		},
+		"BasicUserAgent": {
+			creds: Credentials{
+				BasicUsername: basicUser,
+				BasicPassword: basicPass,
+			},
+			cfg: &rest.Config{UserAgent: "xgql/v1"},
+			want: &rest.Config{
+				UserAgent: "xgql/v1 (so)",
+				Username:  basicUser,
+				Password:  basicPass,
+			},
+		},
+		"BearerUserAgent": {
+			creds: Credentials{
+				BearerToken: token,
+			},
+			cfg: &rest.Config{UserAgent: "xgql/v1"},
+			want: &rest.Config{
+				UserAgent:   "xgql/v1",
+				BearerToken: token,
+			},
+		},
+		"ImpersonatingUserAgent": {
+			creds: Credentials{
+				BasicUsername: basicUser,
+				BasicPassword: basicPass,
+				Impersonate: Impersonation{
+					Username: impUser,
+				},
+			},
+			cfg: &rest.Config{UserAgent: "xgql/v1"},
+			want: &rest.Config{
+				UserAgent: "xgql/v1",
+				Username:  basicUser,
+				Password:  basicPass,
+				Impersonate: rest.ImpersonationConfig{
+					UserName: impUser,
+				},
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -114,6 +159,31 @@ func TestCredentialsHash(t *testing.T) {
 
 }
 
+func TestCredentialsTokenHash(t *testing.T) {
+	cases := map[string]struct {
+		creds Credentials
+		want  string
+	}{
+		"BearerToken": {
+			creds: Credentials{BearerToken: "toke-one"},
+			want:  "5aa4c46e0ee7d0fc69f6487e0467ea03f55810ec02b389953752184698f46609",
+		},
+		"NoBearerToken": {
+			creds: Credentials{BasicUsername: "so", BasicPassword: "basic"},
+			want:  "",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := tc.creds.TokenHash()
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("c.TokenHash(): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestMiddleware(t *testing.T) {
 	token := "toke-one"
 
@@ -131,8 +201,9 @@ func TestMiddleware(t *testing.T) {
 	}
 
 	tests := map[string]struct {
-		r    *http.Request
-		want want
+		header string
+		r      *http.Request
+		want   want
 	}{
 		"WithWellFormedBearerHeader": {
 			r: func() *http.Request {
@@ -191,6 +262,46 @@ func TestMiddleware(t *testing.T) {
 				ok: true,
 			},
 		},
+		"WithLowercaseAuthzTokenHeader": {
+			header: "authorization",
+			r: func() *http.Request {
+				r := httptest.NewRequest("GET", "/", nil)
+				r.Header.Add("Authorization", "Bearer "+token)
+				return r
+			}(),
+			want: want{
+				c: Credentials{
+					BearerToken: token,
+				},
+				ok: true,
+			},
+		},
+		"WithCustomTokenHeader": {
+			header: "X-Auth-Token",
+			r: func() *http.Request {
+				r := httptest.NewRequest("GET", "/", nil)
+				r.Header.Add("X-Auth-Token", token)
+				return r
+			}(),
+			want: want{
+				c: Credentials{
+					BearerToken: token,
+				},
+				ok: true,
+			},
+		},
+		"WithCustomTokenHeaderIgnoresBearerHeader": {
+			header: "X-Auth-Token",
+			r: func() *http.Request {
+				r := httptest.NewRequest("GET", "/", nil)
+				r.Header.Add("Authorization", "Bearer "+token)
+				return r
+			}(),
+			want: want{
+				c:  Credentials{},
+				ok: true,
+			},
+		},
 		"WithoutHeaders": {
 			r: httptest.NewRequest("GET", "/", nil),
 			want: want{
@@ -201,7 +312,11 @@ func TestMiddleware(t *testing.T) {
 	}
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
-			h := Middleware(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			mw := Middleware
+			if tc.header != "" {
+				mw = TokenHeaderMiddleware(tc.header)
+			}
+			h := mw(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
 				c, ok := FromContext(r.Context())
 				if diff := cmp.Diff(tc.want.c, c); diff != "" {
 					t.Errorf("FromContext(...): -want, +got:\n%s", diff)
@@ -269,3 +384,102 @@ func TestFromContext(t *testing.T) {
 		})
 	}
 }
+
+func TestValidatingWebsocketInit(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		creds Credentials
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		v       Validator
+		payload transport.InitPayload
+		want    want
+	}{
+		"ValidCredentials": {
+			reason:  "Valid credentials from the init payload should be injected into the context.",
+			v:       func(_ context.Context, _ Credentials) error { return nil },
+			payload: transport.InitPayload{headerAuthn: "Bearer coolToken"},
+			want: want{
+				creds: Credentials{BearerToken: "coolToken"},
+			},
+		},
+		"InvalidCredentials": {
+			reason:  "Connections with invalid credentials should be rejected.",
+			v:       func(_ context.Context, _ Credentials) error { return errBoom },
+			payload: transport.InitPayload{headerAuthn: "Bearer coolToken"},
+			want: want{
+				creds: Credentials{BearerToken: "coolToken"},
+				err:   errors.Wrap(errBoom, errInvalidCredentials),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var validated Credentials
+			v := func(ctx context.Context, cr Credentials) error {
+				validated = cr
+				return tc.v(ctx, cr)
+			}
+
+			_, err := ValidatingWebsocketInit(v)(context.Background(), tc.payload)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nValidatingWebsocketInit(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.creds, validated, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("\n%s\nValidatingWebsocketInit(...): -want validated credentials, +got validated credentials:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestValidatingMiddleware(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		status int
+		served bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		v      Validator
+		want   want
+	}{
+		"ValidCredentials": {
+			reason: "Requests with valid credentials should be served.",
+			v:      func(_ context.Context, _ Credentials) error { return nil },
+			want:   want{status: http.StatusOK, served: true},
+		},
+		"InvalidCredentials": {
+			reason: "Requests with invalid credentials should be rejected as unauthorized.",
+			v:      func(_ context.Context, _ Credentials) error { return errBoom },
+			want:   want{status: http.StatusUnauthorized},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			served := false
+			h := Middleware(ValidatingMiddleware(tc.v)(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+				served = true
+			})))
+
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.Header.Set(headerAuthn, "Bearer coolToken")
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			if diff := cmp.Diff(tc.want.status, w.Code); diff != "" {
+				t.Errorf("\n%s\nValidatingMiddleware(...): -want status, +got status:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.served, served); diff != "" {
+				t.Errorf("\n%s\nValidatingMiddleware(...): -want served, +got served:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
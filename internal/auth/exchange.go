@@ -0,0 +1,276 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"strings"
+	"sync"
+	"time"
+
+	authnv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+)
+
+const (
+	errReviewToken         = "cannot review token"
+	errRequestToken        = "cannot request token"
+	errTokenNotAuthn       = "token is not authenticated"
+	errFmtNotServiceAcct   = "cannot exchange token for user %q: only service account tokens may be exchanged"
+	errFmtTokenReviewError = "token review failed: %s"
+)
+
+const (
+	// The prefix of the username of a service account.
+	prefixServiceAccount = "system:serviceaccount:"
+
+	// Exchanged tokens are exchanged again this long before they expire, so
+	// that they don't expire while in use.
+	exchangeRefresh = time.Minute
+
+	// Exchanged tokens are used for at most this long before the tokens they
+	// were exchanged for are reviewed again by default.
+	defaultReviewInterval = time.Minute
+)
+
+// A TokenExchanger exchanges bearer tokens for tokens with a particular
+// audience, using the Kubernetes TokenRequest API. This allows xgql to accept
+// tokens that the API server would reject because their audience isn't one the
+// API server accepts, for example tokens issued to xgql by an external identity
+// provider.
+//
+// A token is exchanged by asking the API server who it belongs to using the
+// TokenReview API, then requesting a new token for that subject with the
+// desired audience. The TokenRequest API can only issue tokens for service
+// accounts, so only tokens that belong to service accounts may be exchanged.
+// The TokenExchanger's client must be allowed to create token reviews, and to
+// create tokens for the service accounts whose tokens it exchanges.
+//
+// Exchanged tokens outlive the tokens they were exchanged for, so a token that
+// expires or is revoked could otherwise be used until its exchanged token
+// expires. Tokens are therefore reviewed again at a short interval, or when
+// they expire if they're JWTs that expire sooner, and their exchanged tokens
+// are only used while the review holds.
+type TokenExchanger struct {
+	client    client.Client
+	audience  string
+	reviewAud []string
+	ttl       *int64
+	review    time.Duration
+	now       func() time.Time
+
+	mx        sync.Mutex
+	exchanged map[[sha256.Size]byte]exchangedToken
+}
+
+type exchangedToken struct {
+	token   string
+	expires time.Time
+
+	// The user the token was exchanged for, and when that must be reviewed
+	// again.
+	username string
+	reviewBy time.Time
+}
+
+// A TokenExchangerOption configures a TokenExchanger.
+type TokenExchangerOption func(x *TokenExchanger)
+
+// WithReviewAudiences configures the audiences a TokenExchanger expects the
+// tokens it exchanges to have. The API server's audiences are expected by
+// default.
+func WithReviewAudiences(aud ...string) TokenExchangerOption {
+	return func(x *TokenExchanger) {
+		x.reviewAud = aud
+	}
+}
+
+// WithExchangedTokenTTL configures how long the tokens a TokenExchanger
+// requests are valid for. The API server's default is used if this option is
+// not supplied. The API server may issue tokens that are valid for longer or
+// shorter than requested.
+func WithExchangedTokenTTL(d time.Duration) TokenExchangerOption {
+	return func(x *TokenExchanger) {
+		s := int64(d.Seconds())
+		x.ttl = &s
+	}
+}
+
+// WithReviewInterval configures how often a TokenExchanger reviews a token it
+// has exchanged. A token that expires or is revoked may be exchanged for up to
+// this long afterward. Tokens are reviewed every minute by default.
+func WithReviewInterval(d time.Duration) TokenExchangerOption {
+	return func(x *TokenExchanger) {
+		x.review = d
+	}
+}
+
+// NewTokenExchanger returns a TokenExchanger that uses the supplied client to
+// exchange tokens for tokens with the supplied audience.
+func NewTokenExchanger(c client.Client, audience string, o ...TokenExchangerOption) *TokenExchanger {
+	x := &TokenExchanger{
+		client:    c,
+		audience:  audience,
+		review:    defaultReviewInterval,
+		now:       time.Now,
+		exchanged: make(map[[sha256.Size]byte]exchangedToken),
+	}
+	for _, fn := range o {
+		fn(x)
+	}
+	return x
+}
+
+// Exchange the supplied token for a token with the TokenExchanger's audience.
+// Exchanged tokens are cached, keyed by the supplied token, until shortly
+// before they expire. The supplied token is reviewed again before a cached
+// token is used if its review interval has passed.
+func (x *TokenExchanger) Exchange(ctx context.Context, token string) (string, error) {
+	// We key exchanged tokens by a hash of the supplied token, so that we
+	// don't keep the supplied tokens in memory.
+	k := sha256.Sum256([]byte(token))
+
+	x.mx.Lock()
+	e, ok := x.exchanged[k]
+	x.mx.Unlock()
+
+	now := x.now()
+	if ok && now.Add(exchangeRefresh).Before(e.expires) {
+		if now.Before(e.reviewBy) {
+			return e.token, nil
+		}
+
+		// The exchanged token is still valid, so we only need to check that
+		// the supplied token is too.
+		username, err := x.reviewToken(ctx, token)
+		if err != nil {
+			x.forget(k)
+			return "", err
+		}
+		if username == e.username {
+			e.reviewBy = x.reviewBy(token, now)
+			x.remember(k, e)
+			return e.token, nil
+		}
+	}
+
+	e, err := x.exchange(ctx, token)
+	if err != nil {
+		return "", err
+	}
+	x.remember(k, e)
+	return e.token, nil
+}
+
+// remember the supplied exchanged token.
+func (x *TokenExchanger) remember(k [sha256.Size]byte, e exchangedToken) {
+	x.mx.Lock()
+	defer x.mx.Unlock()
+
+	// Forget any tokens that have expired, so the cache doesn't grow without
+	// bound as callers' tokens are rotated.
+	now := x.now()
+	for k, e := range x.exchanged {
+		if !now.Before(e.expires) {
+			delete(x.exchanged, k)
+		}
+	}
+	x.exchanged[k] = e
+}
+
+// forget the exchanged token with the supplied key.
+func (x *TokenExchanger) forget(k [sha256.Size]byte) {
+	x.mx.Lock()
+	defer x.mx.Unlock()
+	delete(x.exchanged, k)
+}
+
+// reviewBy returns when the supplied token, reviewed at the supplied time,
+// must be reviewed again. That's after the review interval, or when the token
+// expires if it's a JWT that expires sooner. The token's signature isn't
+// verified; its expiry is only used to review it sooner.
+func (x *TokenExchanger) reviewBy(token string, now time.Time) time.Time {
+	by := now.Add(x.review)
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return by
+	}
+	c := tokenClaims{}
+	if err := decodeSegment(parts[1], &c); err != nil || c.Expiry == nil {
+		return by
+	}
+	if exp := unixTime(*c.Expiry); exp.Before(by) {
+		return exp
+	}
+	return by
+}
+
+// reviewToken returns the username of the user the supplied token belongs to.
+func (x *TokenExchanger) reviewToken(ctx context.Context, token string) (string, error) {
+	tr := &authnv1.TokenReview{Spec: authnv1.TokenReviewSpec{Token: token, Audiences: x.reviewAud}}
+	if err := x.client.Create(ctx, tr); err != nil {
+		return "", errors.Wrap(err, errReviewToken)
+	}
+	if tr.Status.Error != "" {
+		return "", errors.Errorf(errFmtTokenReviewError, tr.Status.Error)
+	}
+	if !tr.Status.Authenticated {
+		return "", errors.New(errTokenNotAuthn)
+	}
+	return tr.Status.User.Username, nil
+}
+
+func (x *TokenExchanger) exchange(ctx context.Context, token string) (exchangedToken, error) {
+	now := x.now()
+	username, err := x.reviewToken(ctx, token)
+	if err != nil {
+		return exchangedToken{}, err
+	}
+
+	ns, name, ok := serviceAccount(username)
+	if !ok {
+		return exchangedToken{}, errors.Errorf(errFmtNotServiceAcct, username)
+	}
+
+	sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: name}}
+	req := &authnv1.TokenRequest{Spec: authnv1.TokenRequestSpec{Audiences: []string{x.audience}, ExpirationSeconds: x.ttl}}
+	if err := x.client.SubResource("token").Create(ctx, sa, req); err != nil {
+		return exchangedToken{}, errors.Wrap(err, errRequestToken)
+	}
+	return exchangedToken{
+		token:    req.Status.Token,
+		expires:  req.Status.ExpirationTimestamp.Time,
+		username: username,
+		reviewBy: x.reviewBy(token, now),
+	}, nil
+}
+
+// serviceAccount returns the namespace and name of the service account with
+// the supplied username, if it is the username of a service account.
+func serviceAccount(username string) (namespace, name string, ok bool) {
+	if !strings.HasPrefix(username, prefixServiceAccount) {
+		return "", "", false
+	}
+	parts := strings.Split(strings.TrimPrefix(username, prefixServiceAccount), ":")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
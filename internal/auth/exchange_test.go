@@ -0,0 +1,220 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	authnv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestTokenExchangerExchange(t *testing.T) {
+	errBoom := errors.New("boom")
+	now := time.Unix(1700000000, 0)
+
+	authenticated := func(username string) test.MockCreateFn {
+		return test.NewMockCreateFn(nil, func(obj client.Object) error {
+			tr := obj.(*authnv1.TokenReview)
+			if diff := cmp.Diff([]string{"xgql"}, tr.Spec.Audiences); diff != "" {
+				t.Errorf("Create(...): -want audiences, +got audiences:\n%s", diff)
+			}
+			tr.Status = authnv1.TokenReviewStatus{Authenticated: true, User: authnv1.UserInfo{Username: username}}
+			return nil
+		})
+	}
+	issued := func(obj, sub client.Object) error {
+		sa := obj.(*corev1.ServiceAccount)
+		if sa.GetNamespace() != "default" || sa.GetName() != "cool" {
+			t.Errorf("SubResource(...).Create(...): want default/cool, got %s/%s", sa.GetNamespace(), sa.GetName())
+		}
+		req := sub.(*authnv1.TokenRequest)
+		if diff := cmp.Diff([]string{"https://kubernetes.default.svc"}, req.Spec.Audiences); diff != "" {
+			t.Errorf("SubResource(...).Create(...): -want audiences, +got audiences:\n%s", diff)
+		}
+		req.Status = authnv1.TokenRequestStatus{Token: "exchanged", ExpirationTimestamp: metav1.NewTime(now.Add(time.Hour))}
+		return nil
+	}
+
+	type want struct {
+		token string
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason string
+		c      client.Client
+		want   want
+	}{
+		"ReviewError": {
+			reason: "We should return any error encountered reviewing the token.",
+			c:      &test.MockClient{MockCreate: test.NewMockCreateFn(errBoom)},
+			want:   want{err: errors.Wrap(errBoom, errReviewToken)},
+		},
+		"NotAuthenticated": {
+			reason: "We should not exchange tokens that aren't authenticated.",
+			c:      &test.MockClient{MockCreate: test.NewMockCreateFn(nil)},
+			want:   want{err: errors.New(errTokenNotAuthn)},
+		},
+		"NotServiceAccount": {
+			reason: "We should not exchange tokens that don't belong to a service account.",
+			c:      &test.MockClient{MockCreate: authenticated("cool@example.org")},
+			want:   want{err: errors.Errorf(errFmtNotServiceAcct, "cool@example.org")},
+		},
+		"RequestError": {
+			reason: "We should return any error encountered requesting a token.",
+			c: &test.MockClient{
+				MockCreate:            authenticated("system:serviceaccount:default:cool"),
+				MockSubResourceCreate: test.NewMockSubResourceCreateFn(errBoom),
+			},
+			want: want{err: errors.Wrap(errBoom, errRequestToken)},
+		},
+		"Exchanged": {
+			reason: "We should return the token issued for the token's service account.",
+			c: &test.MockClient{
+				MockCreate: authenticated("system:serviceaccount:default:cool"),
+				MockSubResourceCreate: func(_ context.Context, obj, sub client.Object, _ ...client.SubResourceCreateOption) error {
+					return issued(obj, sub)
+				},
+			},
+			want: want{token: "exchanged"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			x := NewTokenExchanger(tc.c, "https://kubernetes.default.svc", WithReviewAudiences("xgql"))
+			x.now = func() time.Time { return now }
+
+			got, err := x.Exchange(context.Background(), "coolToken")
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nx.Exchange(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.token, got); diff != "" {
+				t.Errorf("\n%s\nx.Exchange(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestTokenExchangerCache(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	requests := 0
+	c := &test.MockClient{
+		MockCreate: test.NewMockCreateFn(nil, func(obj client.Object) error {
+			obj.(*authnv1.TokenReview).Status = authnv1.TokenReviewStatus{Authenticated: true, User: authnv1.UserInfo{Username: "system:serviceaccount:default:cool"}}
+			return nil
+		}),
+		MockSubResourceCreate: func(_ context.Context, _, sub client.Object, _ ...client.SubResourceCreateOption) error {
+			requests++
+			sub.(*authnv1.TokenRequest).Status = authnv1.TokenRequestStatus{Token: "exchanged", ExpirationTimestamp: metav1.NewTime(now.Add(time.Hour))}
+			return nil
+		},
+	}
+
+	x := NewTokenExchanger(c, "https://kubernetes.default.svc")
+	x.now = func() time.Time { return now }
+
+	// The token hasn't been exchanged, so we exchange it.
+	if _, err := x.Exchange(context.Background(), "coolToken"); err != nil {
+		t.Fatalf("x.Exchange(...): %s", err)
+	}
+
+	// The exchanged token is cached.
+	if _, err := x.Exchange(context.Background(), "coolToken"); err != nil {
+		t.Fatalf("x.Exchange(...): %s", err)
+	}
+
+	// The exchanged token is about to expire, so we exchange it again.
+	now = now.Add(time.Hour - exchangeRefresh)
+	if _, err := x.Exchange(context.Background(), "coolToken"); err != nil {
+		t.Fatalf("x.Exchange(...): %s", err)
+	}
+
+	if diff := cmp.Diff(2, requests); diff != "" {
+		t.Errorf("x.Exchange(...): -want token requests, +got token requests:\n%s", diff)
+	}
+}
+
+func TestTokenExchangerReview(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	reviews, requests := 0, 0
+	revoked := false
+	c := &test.MockClient{
+		MockCreate: test.NewMockCreateFn(nil, func(obj client.Object) error {
+			reviews++
+			if revoked {
+				return nil
+			}
+			obj.(*authnv1.TokenReview).Status = authnv1.TokenReviewStatus{Authenticated: true, User: authnv1.UserInfo{Username: "system:serviceaccount:default:cool"}}
+			return nil
+		}),
+		MockSubResourceCreate: func(_ context.Context, _, sub client.Object, _ ...client.SubResourceCreateOption) error {
+			requests++
+			sub.(*authnv1.TokenRequest).Status = authnv1.TokenRequestStatus{Token: "exchanged", ExpirationTimestamp: metav1.NewTime(now.Add(time.Hour))}
+			return nil
+		},
+	}
+
+	x := NewTokenExchanger(c, "https://kubernetes.default.svc", WithReviewInterval(5*time.Minute))
+	x.now = func() time.Time { return now }
+
+	// This JWT expires before the review interval passes.
+	jwt := b64([]byte(`{"alg":"RS256"}`)) + "." + b64([]byte(fmt.Sprintf(`{"exp":%d}`, now.Add(time.Minute).Unix()))) + ".sig"
+
+	for _, token := range []string{"coolToken", jwt} {
+		if _, err := x.Exchange(context.Background(), token); err != nil {
+			t.Fatalf("x.Exchange(...): %s", err)
+		}
+	}
+
+	// The JWT has expired, so it's reviewed again. The opaque token's review
+	// still holds.
+	now = now.Add(2 * time.Minute)
+	for _, token := range []string{"coolToken", jwt} {
+		if _, err := x.Exchange(context.Background(), token); err != nil {
+			t.Fatalf("x.Exchange(...): %s", err)
+		}
+	}
+	if diff := cmp.Diff(3, reviews); diff != "" {
+		t.Errorf("x.Exchange(...): -want token reviews, +got token reviews:\n%s", diff)
+	}
+
+	// The opaque token's review interval has passed, and it has been revoked
+	// since it was exchanged.
+	now = now.Add(5 * time.Minute)
+	revoked = true
+	_, err := x.Exchange(context.Background(), "coolToken")
+	if diff := cmp.Diff(errors.New(errTokenNotAuthn), err, test.EquateErrors()); diff != "" {
+		t.Errorf("x.Exchange(...): -want error exchanging a revoked token, +got error:\n%s", diff)
+	}
+
+	// Tokens that are still valid are reviewed again rather than exchanged
+	// again.
+	if diff := cmp.Diff(2, requests); diff != "" {
+		t.Errorf("x.Exchange(...): -want token requests, +got token requests:\n%s", diff)
+	}
+}
@@ -0,0 +1,444 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+)
+
+const (
+	errMalformedToken   = "token is not a well-formed JWT"
+	errDecodeHeader     = "cannot decode token header"
+	errDecodeClaims     = "cannot decode token claims"
+	errDecodeSignature  = "cannot decode token signature"
+	errInvalidSignature = "invalid token signature"
+	errExpired          = "token has expired"
+	errNotYetValid      = "token is not yet valid"
+	errFetchKeys        = "cannot fetch JSON web key set"
+	errDecodeKeys       = "cannot decode JSON web key set"
+	errInvalidKey       = "invalid JSON web key"
+
+	errFmtUnknownKey      = "unknown signing key %q"
+	errFmtUnsupportedAlg  = "unsupported signing algorithm %q"
+	errFmtWrongKeyType    = "signing algorithm %q does not match key type"
+	errFmtWrongIssuer     = "token issuer %q is not %q"
+	errFmtWrongAudience   = "token audience does not include %q"
+	errFmtFetchKeysStatus = "unexpected status %d"
+	errFmtUnsupportedKey  = "unsupported key type %q"
+	errFmtUnsupportedCrv  = "unsupported curve %q"
+)
+
+const (
+	// Tokens are considered valid this long before they're issued, and this
+	// long after they expire, to allow for clock skew.
+	clockSkew = time.Minute
+
+	// Keys are refetched at most this often when a token is signed by an
+	// unknown key, for example because the signing key was rotated.
+	minKeyRefresh = time.Minute
+
+	// Fetching keys times out after this long. Callers may stop waiting
+	// sooner; the fetch continues for the benefit of other callers.
+	keyFetchTimeout = 30 * time.Second
+)
+
+// A TokenVerifier verifies the signature of JWT bearer tokens using the keys
+// served at a JSON web key set (JWKS) URL, for example that of an OIDC
+// provider. It rejects obviously invalid tokens without involving the API
+// server. It's not a substitute for the API server's authentication; a token
+// that passes verification may still be rejected by the API server.
+type TokenVerifier struct {
+	url      string
+	issuer   string
+	audience string
+	client   *http.Client
+	now      func() time.Time
+
+	mx      sync.Mutex
+	keys    map[string]crypto.PublicKey
+	fetched time.Time
+
+	// Only one fetch of keys is in flight at a time. Callers that need keys
+	// while a fetch is in flight wait for it rather than starting another.
+	refresh singleflight.Group
+}
+
+// A TokenVerifierOption configures a TokenVerifier.
+type TokenVerifierOption func(v *TokenVerifier)
+
+// WithIssuer configures a TokenVerifier to reject tokens that weren't issued by
+// the supplied issuer.
+func WithIssuer(iss string) TokenVerifierOption {
+	return func(v *TokenVerifier) {
+		v.issuer = iss
+	}
+}
+
+// WithAudience configures a TokenVerifier to reject tokens whose audience
+// doesn't include the supplied audience.
+func WithAudience(aud string) TokenVerifierOption {
+	return func(v *TokenVerifier) {
+		v.audience = aud
+	}
+}
+
+// WithHTTPClient configures the HTTP client a TokenVerifier uses to fetch keys,
+// for example to trust a custom root CA.
+func WithHTTPClient(c *http.Client) TokenVerifierOption {
+	return func(v *TokenVerifier) {
+		v.client = c
+	}
+}
+
+// NewTokenVerifier returns a TokenVerifier that verifies tokens using the keys
+// served at the supplied JWKS URL. Keys are fetched when the first token is
+// verified, and refetched when a token is signed by an unknown key.
+func NewTokenVerifier(url string, o ...TokenVerifierOption) *TokenVerifier {
+	v := &TokenVerifier{url: url, client: http.DefaultClient, now: time.Now}
+	for _, fn := range o {
+		fn(v)
+	}
+	return v
+}
+
+// Validate the bearer token of the supplied credentials, if any. Credentials
+// without a bearer token are passed through to the API server.
+func (v *TokenVerifier) Validate(ctx context.Context, cr Credentials) error {
+	if cr.BearerToken == "" {
+		return nil
+	}
+	return v.Verify(ctx, cr.BearerToken)
+}
+
+type tokenHeader struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+}
+
+type tokenClaims struct {
+	Issuer    string   `json:"iss"`
+	Audience  audience `json:"aud"`
+	Expiry    *float64 `json:"exp"`
+	NotBefore *float64 `json:"nbf"`
+}
+
+// An audience may be either a single string or an array of strings.
+type audience []string
+
+func (a *audience) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*a = audience{s}
+		return nil
+	}
+	var ss []string
+	if err := json.Unmarshal(b, &ss); err != nil {
+		return err
+	}
+	*a = ss
+	return nil
+}
+
+// Verify the supplied JWT. An error is returned if the token is malformed, its
+// signature can't be verified, it has expired or isn't yet valid, or it wasn't
+// issued by or for the configured issuer and audience.
+func (v *TokenVerifier) Verify(ctx context.Context, token string) error { //nolint:gocyclo // Only slightly over.
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return errors.New(errMalformedToken)
+	}
+
+	h := tokenHeader{}
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return errors.Wrap(err, errDecodeHeader)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return errors.Wrap(err, errDecodeSignature)
+	}
+
+	key, err := v.key(ctx, h.KeyID)
+	if err != nil {
+		return err
+	}
+	if err := verifySignature(h.Algorithm, key, []byte(parts[0]+"."+parts[1]), sig); err != nil {
+		return err
+	}
+
+	c := tokenClaims{}
+	if err := decodeSegment(parts[1], &c); err != nil {
+		return errors.Wrap(err, errDecodeClaims)
+	}
+
+	now := v.now()
+	if c.Expiry != nil && now.After(unixTime(*c.Expiry).Add(clockSkew)) {
+		return errors.New(errExpired)
+	}
+	if c.NotBefore != nil && now.Before(unixTime(*c.NotBefore).Add(-clockSkew)) {
+		return errors.New(errNotYetValid)
+	}
+	if v.issuer != "" && c.Issuer != v.issuer {
+		return errors.Errorf(errFmtWrongIssuer, c.Issuer, v.issuer)
+	}
+	if v.audience != "" && !contains(c.Audience, v.audience) {
+		return errors.Errorf(errFmtWrongAudience, v.audience)
+	}
+	return nil
+}
+
+// key returns the key with the supplied ID, fetching keys if it's unknown.
+func (v *TokenVerifier) key(ctx context.Context, id string) (crypto.PublicKey, error) {
+	v.mx.Lock()
+	k, ok := v.keys[id]
+	recent := v.keys != nil && v.now().Before(v.fetched.Add(minKeyRefresh))
+	v.mx.Unlock()
+
+	if ok {
+		return k, nil
+	}
+
+	// Don't refetch keys every time we see a token signed by an unknown key;
+	// anyone can sign a token.
+	if recent {
+		return nil, errors.Errorf(errFmtUnknownKey, id)
+	}
+
+	// We don't hold the lock while we fetch keys, so tokens signed by known
+	// keys can be verified while we do.
+	ch := v.refresh.DoChan("", func() (any, error) {
+		// The fetch is shared by every caller waiting for it, so it shouldn't
+		// be cancelled when the caller that started it stops waiting.
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), keyFetchTimeout)
+		defer cancel()
+		return v.fetchKeys(ctx)
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, errors.Wrap(ctx.Err(), errFetchKeys)
+	case r := <-ch:
+		if r.Err != nil {
+			return nil, errors.Wrap(r.Err, errFetchKeys)
+		}
+		k, ok := r.Val.(map[string]crypto.PublicKey)[id]
+		if !ok {
+			return nil, errors.Errorf(errFmtUnknownKey, id)
+		}
+		return k, nil
+	}
+}
+
+// fetchKeys fetches and stores keys, unless they were fetched recently.
+func (v *TokenVerifier) fetchKeys(ctx context.Context) (map[string]crypto.PublicKey, error) {
+	// Another caller may have fetched keys while we were deciding to.
+	v.mx.Lock()
+	if v.keys != nil && v.now().Before(v.fetched.Add(minKeyRefresh)) {
+		keys := v.keys
+		v.mx.Unlock()
+		return keys, nil
+	}
+	v.mx.Unlock()
+
+	keys, err := v.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	v.mx.Lock()
+	v.keys = keys
+	v.fetched = v.now()
+	v.mx.Unlock()
+	return keys, nil
+}
+
+type jsonWebKey struct {
+	KeyType string `json:"kty"`
+	KeyID   string `json:"kid"`
+	Use     string `json:"use"`
+	N       string `json:"n"`
+	E       string `json:"e"`
+	Curve   string `json:"crv"`
+	X       string `json:"x"`
+	Y       string `json:"y"`
+}
+
+func (v *TokenVerifier) fetch(ctx context.Context) (map[string]crypto.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	rsp, err := v.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close() //nolint:errcheck // Nothing useful to do with this error.
+	if rsp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf(errFmtFetchKeysStatus, rsp.StatusCode)
+	}
+
+	set := struct {
+		Keys []jsonWebKey `json:"keys"`
+	}{}
+	if err := json.NewDecoder(rsp.Body).Decode(&set); err != nil {
+		return nil, errors.Wrap(err, errDecodeKeys)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		// Keys for encryption can't verify signatures.
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		k, err := getPublicKey(jwk)
+		if err != nil {
+			return nil, errors.Wrap(err, errInvalidKey)
+		}
+		keys[jwk.KeyID] = k
+	}
+	return keys, nil
+}
+
+func getPublicKey(jwk jsonWebKey) (crypto.PublicKey, error) {
+	switch jwk.KeyType {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		var c elliptic.Curve
+		var ec ecdh.Curve
+		switch jwk.Curve {
+		case "P-256":
+			c, ec = elliptic.P256(), ecdh.P256()
+		case "P-384":
+			c, ec = elliptic.P384(), ecdh.P384()
+		case "P-521":
+			c, ec = elliptic.P521(), ecdh.P521()
+		default:
+			return nil, errors.Errorf(errFmtUnsupportedCrv, jwk.Curve)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		// Parsing the key as an uncompressed point ensures it's on the curve.
+		size := (c.Params().BitSize + 7) / 8
+		point := make([]byte, 1+2*size)
+		point[0] = 4
+		new(big.Int).SetBytes(x).FillBytes(point[1 : 1+size])
+		new(big.Int).SetBytes(y).FillBytes(point[1+size:])
+		if _, err := ec.NewPublicKey(point); err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: c, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	default:
+		return nil, errors.Errorf(errFmtUnsupportedKey, jwk.KeyType)
+	}
+}
+
+func verifySignature(alg string, key crypto.PublicKey, signed, sig []byte) error {
+	var h crypto.Hash
+	switch alg {
+	case "RS256", "PS256", "ES256":
+		h = crypto.SHA256
+	case "RS384", "PS384", "ES384":
+		h = crypto.SHA384
+	case "RS512", "PS512", "ES512":
+		h = crypto.SHA512
+	default:
+		// Notably we don't support "none", or symmetric HMAC algorithms.
+		return errors.Errorf(errFmtUnsupportedAlg, alg)
+	}
+	hh := h.New()
+	hh.Write(signed)
+	digest := hh.Sum(nil)
+
+	switch alg[0] {
+	case 'R':
+		k, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return errors.Errorf(errFmtWrongKeyType, alg)
+		}
+		return errors.Wrap(rsa.VerifyPKCS1v15(k, h, digest, sig), errInvalidSignature)
+	case 'P':
+		k, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return errors.Errorf(errFmtWrongKeyType, alg)
+		}
+		return errors.Wrap(rsa.VerifyPSS(k, h, digest, sig, nil), errInvalidSignature)
+	default:
+		k, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return errors.Errorf(errFmtWrongKeyType, alg)
+		}
+		// JWS ECDSA signatures are the concatenation of R and S.
+		size := (k.Curve.Params().BitSize + 7) / 8
+		if len(sig) != 2*size {
+			return errors.New(errInvalidSignature)
+		}
+		r, s := new(big.Int).SetBytes(sig[:size]), new(big.Int).SetBytes(sig[size:])
+		if !ecdsa.Verify(k, digest, r, s) {
+			return errors.New(errInvalidSignature)
+		}
+		return nil
+	}
+}
+
+func decodeSegment(seg string, v any) error {
+	b, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+func unixTime(secs float64) time.Time {
+	return time.Unix(0, int64(secs*float64(time.Second)))
+}
+
+func contains(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
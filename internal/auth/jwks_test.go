@@ -0,0 +1,315 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func b64(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+
+// sign returns a JWT with the supplied header and claims, signed using the
+// supplied key.
+func sign(t *testing.T, key crypto.Signer, header, claims map[string]any) string {
+	t.Helper()
+	hb, _ := json.Marshal(header)
+	cb, _ := json.Marshal(claims)
+	signed := b64(hb) + "." + b64(cb)
+
+	digest := crypto.SHA256.New()
+	digest.Write([]byte(signed))
+
+	var sig []byte
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		s, err := rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest.Sum(nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig = s
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, k, digest.Sum(nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig = make([]byte, 64)
+		r.FillBytes(sig[:32])
+		s.FillBytes(sig[32:])
+	}
+	return signed + "." + b64(sig)
+}
+
+func TestTokenVerifierVerify(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jwks := map[string]any{"keys": []map[string]any{
+		{"kty": "RSA", "kid": "rsa", "use": "sig", "n": b64(rsaKey.N.Bytes()), "e": b64(big.NewInt(int64(rsaKey.E)).Bytes())},
+		{"kty": "EC", "kid": "ec", "crv": "P-256", "x": b64(ecKey.X.Bytes()), "y": b64(ecKey.Y.Bytes())},
+	}}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(jwks)
+	}))
+	defer srv.Close()
+
+	now := time.Unix(1700000000, 0)
+	valid := map[string]any{
+		"iss": "https://issuer.example.org",
+		"aud": "xgql",
+		"exp": now.Add(time.Hour).Unix(),
+		"nbf": now.Add(-time.Hour).Unix(),
+	}
+	with := func(k string, v any) map[string]any {
+		out := map[string]any{}
+		for k, v := range valid {
+			out[k] = v
+		}
+		out[k] = v
+		return out
+	}
+
+	cases := map[string]struct {
+		reason string
+		opts   []TokenVerifierOption
+		token  string
+		want   error
+	}{
+		"ValidRSA": {
+			reason: "A token signed by a known RSA key should be valid.",
+			opts:   []TokenVerifierOption{WithIssuer("https://issuer.example.org"), WithAudience("xgql")},
+			token:  sign(t, rsaKey, map[string]any{"alg": "RS256", "kid": "rsa"}, valid),
+		},
+		"ValidEC": {
+			reason: "A token signed by a known ECDSA key should be valid.",
+			token:  sign(t, ecKey, map[string]any{"alg": "ES256", "kid": "ec"}, valid),
+		},
+		"AudienceArray": {
+			reason: "A token whose audience array includes the configured audience should be valid.",
+			opts:   []TokenVerifierOption{WithAudience("xgql")},
+			token:  sign(t, rsaKey, map[string]any{"alg": "RS256", "kid": "rsa"}, with("aud", []string{"kubernetes", "xgql"})),
+		},
+		"Malformed": {
+			reason: "A token that isn't a JWT should be invalid.",
+			token:  "coolToken",
+			want:   errors.New(errMalformedToken),
+		},
+		"UnknownKey": {
+			reason: "A token signed by an unknown key should be invalid.",
+			token:  sign(t, otherKey, map[string]any{"alg": "RS256", "kid": "other"}, valid),
+			want:   errors.Errorf(errFmtUnknownKey, "other"),
+		},
+		"WrongKey": {
+			reason: "A token whose signature doesn't match its key should be invalid.",
+			token:  sign(t, otherKey, map[string]any{"alg": "RS256", "kid": "rsa"}, valid),
+			want:   errors.Wrap(rsa.ErrVerification, errInvalidSignature),
+		},
+		"UnsupportedAlgorithm": {
+			reason: "A token that isn't signed using a supported algorithm should be invalid.",
+			token:  b64([]byte(`{"alg":"none","kid":"rsa"}`)) + "." + b64([]byte(`{}`)) + ".",
+			want:   errors.Errorf(errFmtUnsupportedAlg, "none"),
+		},
+		"Expired": {
+			reason: "An expired token should be invalid.",
+			token:  sign(t, rsaKey, map[string]any{"alg": "RS256", "kid": "rsa"}, with("exp", now.Add(-time.Hour).Unix())),
+			want:   errors.New(errExpired),
+		},
+		"NotYetValid": {
+			reason: "A token that isn't yet valid should be invalid.",
+			token:  sign(t, rsaKey, map[string]any{"alg": "RS256", "kid": "rsa"}, with("nbf", now.Add(time.Hour).Unix())),
+			want:   errors.New(errNotYetValid),
+		},
+		"WrongIssuer": {
+			reason: "A token that wasn't issued by the configured issuer should be invalid.",
+			opts:   []TokenVerifierOption{WithIssuer("https://other.example.org")},
+			token:  sign(t, rsaKey, map[string]any{"alg": "RS256", "kid": "rsa"}, valid),
+			want:   errors.Errorf(errFmtWrongIssuer, "https://issuer.example.org", "https://other.example.org"),
+		},
+		"WrongAudience": {
+			reason: "A token whose audience doesn't include the configured audience should be invalid.",
+			opts:   []TokenVerifierOption{WithAudience("other")},
+			token:  sign(t, rsaKey, map[string]any{"alg": "RS256", "kid": "rsa"}, valid),
+			want:   errors.Errorf(errFmtWrongAudience, "other"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			v := NewTokenVerifier(srv.URL, tc.opts...)
+			v.now = func() time.Time { return now }
+
+			err := v.Verify(context.Background(), tc.token)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nv.Verify(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestTokenVerifierFetch(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := sign(t, key, map[string]any{"alg": "RS256", "kid": "rotated"}, map[string]any{})
+
+	fetches := 0
+	rotated := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fetches++
+		keys := []map[string]any{}
+		if rotated {
+			keys = append(keys, map[string]any{"kty": "RSA", "kid": "rotated", "n": b64(key.N.Bytes()), "e": b64(big.NewInt(int64(key.E)).Bytes())})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": keys})
+	}))
+	defer srv.Close()
+
+	now := time.Unix(1700000000, 0)
+	v := NewTokenVerifier(srv.URL)
+	v.now = func() time.Time { return now }
+
+	// The key is unknown, so we fetch keys.
+	if err := v.Verify(context.Background(), token); err == nil {
+		t.Errorf("v.Verify(...): want error before rotation, got nil")
+	}
+
+	// The key is still unknown, but we fetched keys recently.
+	rotated = true
+	if err := v.Verify(context.Background(), token); err == nil {
+		t.Errorf("v.Verify(...): want error within the minimum key refresh interval, got nil")
+	}
+
+	// The key is still unknown, and we haven't fetched keys recently.
+	now = now.Add(minKeyRefresh + time.Second)
+	if err := v.Verify(context.Background(), token); err != nil {
+		t.Errorf("v.Verify(...): want no error after rotation, got %v", err)
+	}
+
+	if diff := cmp.Diff(2, fetches); diff != "" {
+		t.Errorf("v.Verify(...): -want fetches, +got fetches:\n%s", diff)
+	}
+}
+
+func TestTokenVerifierConcurrentFetch(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	known := sign(t, key, map[string]any{"alg": "RS256", "kid": "known"}, map[string]any{})
+	rotated := sign(t, key, map[string]any{"alg": "RS256", "kid": "rotated"}, map[string]any{})
+	jwk := func(kid string) map[string]any {
+		return map[string]any{"kty": "RSA", "kid": kid, "n": b64(key.N.Bytes()), "e": b64(big.NewInt(int64(key.E)).Bytes())}
+	}
+
+	var fetches atomic.Int32
+	unblock := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		// The first fetch returns only the known key. Later fetches block
+		// until we unblock them, then return the rotated key too.
+		if fetches.Add(1) > 1 {
+			<-unblock
+			_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]any{jwk("known"), jwk("rotated")}})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]any{jwk("known")}})
+	}))
+	defer srv.Close()
+
+	now := time.Unix(1700000000, 0)
+	v := NewTokenVerifier(srv.URL)
+	v.now = func() time.Time { return now }
+
+	if err := v.Verify(context.Background(), known); err != nil {
+		t.Fatalf("v.Verify(...): %v", err)
+	}
+
+	// Many callers see a token signed by an unknown key at once.
+	now = now.Add(minKeyRefresh + time.Second)
+	wg := sync.WaitGroup{}
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- v.Verify(context.Background(), rotated)
+		}()
+	}
+
+	// Wait for the fetch to start.
+	for fetches.Load() < 2 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// Tokens signed by known keys shouldn't wait for the fetch.
+	done := make(chan error)
+	go func() { done <- v.Verify(context.Background(), known) }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("v.Verify(...): want no error verifying a known key during a fetch, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Errorf("v.Verify(...): verifying a known key waited for a fetch")
+	}
+
+	// A caller shouldn't wait for the fetch after its context is done.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := v.Verify(ctx, rotated); !errors.Is(err, context.Canceled) {
+		t.Errorf("v.Verify(...): want context canceled error, got %v", err)
+	}
+
+	close(unblock)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Errorf("v.Verify(...): want no error after rotation, got %v", err)
+		}
+	}
+
+	if diff := cmp.Diff(int32(2), fetches.Load()); diff != "" {
+		t.Errorf("v.Verify(...): -want fetches, +got fetches:\n%s", diff)
+	}
+}
@@ -19,13 +19,20 @@ import (
 	"context"
 	"crypto/rand"
 	"io"
+	mrand "math/rand/v2"
 	"net/http"
+	"os"
+	"path/filepath"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"k8s.io/apimachinery/pkg/api/meta"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/rest"
+	certutil "k8s.io/client-go/util/cert"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -44,6 +51,19 @@ const (
 	errNewHTTPClient    = "cannot create new HTTP client"
 	errDelegClient      = "cannot create cache-backed client"
 	errWaitForCacheSync = "cannot sync client cache"
+	errRequestDone      = "request finished before client was created"
+	errReadCAFile       = "cannot read API server CA file"
+	errParseCAFile      = "cannot parse API server CA file"
+
+	errFmtIndexField = "cannot index client cache by field %q"
+)
+
+const (
+	// The default fraction by which each client's expiry may vary.
+	defaultExpiryJitter = 0.1
+
+	// The maximum fraction by which each client's expiry may vary.
+	maxExpiryJitter = 0.5
 )
 
 // A NewCacheFn creates a new controller-runtime cache.
@@ -62,8 +82,53 @@ var (
 	DefaultNewClientFn NewClientFn = client.New
 )
 
+// A ConfigOption configures a REST config.
+type ConfigOption func(cfg *rest.Config) error
+
+// WithCAFile configures a REST config to trust the CA bundle at the supplied
+// path when connecting to the API server, for example because the API server
+// uses a self-signed certificate. The bundle must contain at least one PEM
+// encoded certificate.
+func WithCAFile(path string) ConfigOption {
+	return func(cfg *rest.Config) error {
+		data, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return errors.Wrap(err, errReadCAFile)
+		}
+		if _, err := certutil.ParseCertsPEM(data); err != nil {
+			return errors.Wrap(err, errParseCAFile)
+		}
+		cfg.CAFile = path
+		cfg.CAData = data
+		return nil
+	}
+}
+
+// WithInsecureSkipTLSVerify configures a REST config not to verify the API
+// server's certificate. This is insecure, and intended only for development
+// against a local API server with a self-signed certificate.
+func WithInsecureSkipTLSVerify() ConfigOption {
+	return func(cfg *rest.Config) error {
+		// The API server's certificate can't be verified against a CA when
+		// verification is skipped; client-go rejects configs that do both.
+		cfg.Insecure = true
+		cfg.CAFile = ""
+		cfg.CAData = nil
+		return nil
+	}
+}
+
+// WithUserAgent configures a REST config to identify itself to the API server
+// using the supplied user-agent, rather than xgql/<version>.
+func WithUserAgent(ua string) ConfigOption {
+	return func(cfg *rest.Config) error {
+		cfg.UserAgent = ua
+		return nil
+	}
+}
+
 // Config returns a REST config.
-func Config() (*rest.Config, error) {
+func Config(o ...ConfigOption) (*rest.Config, error) {
 	cfg, err := ctrl.GetConfig()
 	if err != nil {
 		return nil, errors.Wrap(err, "cannot create in-cluster configuration")
@@ -72,8 +137,15 @@ func Config() (*rest.Config, error) {
 	cfg.QPS = 50
 	cfg.Burst = 300
 
+	// Identify xgql's requests in API server audit logs.
 	cfg.UserAgent = "xgql/" + version.Version
 
+	for _, fn := range o {
+		if err := fn(cfg); err != nil {
+			return nil, err
+		}
+	}
+
 	return cfg, nil
 }
 
@@ -93,7 +165,8 @@ func RESTMapper(cfg *rest.Config, httpClient *http.Client) (meta.RESTMapper, err
 }
 
 // Anonymize the supplied config by returning a copy with all authentication
-// details and credentials removed.
+// details and credentials removed. TLS details that aren't credentials, like
+// the CA used to verify the API server, are preserved.
 func Anonymize(cfg *rest.Config) *rest.Config {
 	return rest.AnonymousClientConfig(cfg)
 }
@@ -114,24 +187,74 @@ func Anonymize(cfg *rest.Config) *rest.Config {
 // bearer token, which is used to authenticate to an API server. Each client is
 // backed by its own cache, which is populated by automatically watching any
 // type the client is asked to get or list. Clients (and their caches) expire
-// and are garbage collected if they are unused for five minutes.
+// and are garbage collected if they are unused for about five minutes.
 type Cache struct {
 	// a context that will be valid for the lifetime of Cache.
 	ctx    context.Context
 	active map[string]*session
 	mx     sync.RWMutex
 
-	cfg     *rest.Config
-	scheme  *runtime.Scheme
-	mapper  meta.RESTMapper
-	nocache []client.Object
-	expiry  time.Duration
+	cfg      *rest.Config
+	scheme   *runtime.Scheme
+	mapper   meta.RESTMapper
+	nocache  []client.Object
+	ttls     map[schema.GroupVersionKind]time.Duration
+	uncached bool
+	mfields  bool
+	nowrites bool
+	expiry   time.Duration
+	jitter   float64
+	resync   *time.Duration
+	pageSize int64
+	indexes  []Index
+
+	// fallback is how long a cached client waits for its cache to sync
+	// before it reads directly from the API server instead. Clients never
+	// fall back if it is zero.
+	fallback time.Duration
+
+	// exchanger exchanges callers' bearer tokens, if configured.
+	exchanger TokenExchanger
+
+	// creates limits the number of clients that may be created concurrently.
+	// Creation is unlimited if it is nil.
+	creates chan struct{}
+
+	// creating serializes creation of clients with the same ID, so that
+	// concurrent calls with the same credentials share one client.
+	creating map[string]*createLock
+	cmx      sync.Mutex
+
+	// Statistics about calls that needed a new client. See CreateStats.
+	independent atomic.Uint64
+	coalesced   atomic.Uint64
+	waiting     atomic.Int64
+	stampede    int64
+
+	// discovered API resources, by client ID, or by sharedDiscoveryID if
+	// discovery is shared. See ServerPreferredResources.
+	discovered     map[string]discovered
+	discoveryTTL   time.Duration
+	shareDiscovery bool
+	dmx            sync.Mutex
+
+	// kinds defined by XRDs, by client ID. See DefinedKinds.
+	defined        map[string]defined
+	definedRefresh time.Duration
+	kmx            sync.Mutex
 
-	newCache  NewCacheFn
-	newClient NewClientFn
+	// xrdGeneration is incremented whenever an XRD changes.
+	xrdGeneration atomic.Uint64
+
+	newCache     NewCacheFn
+	newClient    NewClientFn
+	newDiscovery NewDiscoveryClientFn
 
 	salt []byte
 	log  logging.Logger
+
+	// health is the result of the most recent health check, if any.
+	health atomic.Pointer[HealthReport]
 }
 
 // A CacheOption configures the client cache.
@@ -162,6 +285,135 @@ func WithExpiry(d time.Duration) CacheOption {
 	}
 }
 
+// WithExpiryJitter configures how much each client's expiry may vary from the
+// configured expiry, as a fraction of it. Each client's expiry is chosen at
+// random from [expiry*(1-f), expiry*(1+f)] when the client is created, so that
+// clients created together, for example after a restart, don't all expire and
+// rebuild their caches together. Jitter is disabled if f is not positive. f is
+// capped at 0.5. The default is 0.1 (i.e. ±10%).
+func WithExpiryJitter(f float64) CacheOption {
+	return func(c *Cache) {
+		c.jitter = min(max(f, 0), maxExpiryJitter)
+	}
+}
+
+// WithResyncPeriod configures the minimum frequency at which each client's
+// cache resyncs the resources it watches. Shorter periods correct drift more
+// quickly, for example on clusters with flaky watch connections, but each
+// active client's informers do more work as the period shrinks, and more
+// clients mean more load on the API server. controller-runtime's default
+// period (10 hours, with jitter) is used if this option is not supplied.
+func WithResyncPeriod(d time.Duration) CacheOption {
+	return func(c *Cache) {
+		c.resync = &d
+	}
+}
+
+// WithListPageSize configures how many resources each client's cache requests
+// per page when it lists the resources it watches. Smaller pages reduce the
+// memory used to sync a cache, and the chance that the API server times out
+// listing a large set of resources, at the cost of more requests. Note that the
+// API server may serve an initial list from its own watch cache, in which case
+// it ignores the page size. client-go's default page size (500) is used if n
+// is not positive.
+func WithListPageSize(n int64) CacheOption {
+	return func(c *Cache) {
+		if n <= 0 {
+			c.pageSize = 0
+			return
+		}
+		c.pageSize = n
+	}
+}
+
+// WithTokenExchanger configures the client cache to exchange each caller's
+// bearer token for another token, and to authenticate to the API server using
+// the exchanged token. The caller's token is never sent to the API server.
+// Clients are still keyed by the caller's token. Tokens are exchanged for each
+// request, because exchanged tokens may expire while a client is in use.
+func WithTokenExchanger(x TokenExchanger) CacheOption {
+	return func(c *Cache) {
+		c.exchanger = x
+	}
+}
+
+// WithMaxConcurrentCreates limits the number of clients that may be created
+// concurrently. Creating a client involves discovery and an initial list of
+// each watched type, so many clients created at once (for example when xgql
+// starts cold) can overwhelm the API server. Calls that need a new client wait
+// until fewer than n clients are being created; calls that can use an already
+// cached client never wait. Creation is unlimited if n is not positive.
+func WithMaxConcurrentCreates(n int) CacheOption {
+	return func(c *Cache) {
+		if n <= 0 {
+			c.creates = nil
+			return
+		}
+		c.creates = make(chan struct{}, n)
+	}
+}
+
+// WithDiscoveryTTL configures how long the API resources discovered by
+// ServerPreferredResources are cached for each set of credentials, or for all
+// credentials if discovery is shared. Shorter
+// TTLs pick up newly served resources, such as those defined by a newly
+// installed provider, more quickly. The default TTL is 30 seconds.
+func WithDiscoveryTTL(d time.Duration) CacheOption {
+	return func(c *Cache) {
+		c.discoveryTTL = d
+	}
+}
+
+// ShareDiscovery configures ServerPreferredResources to cache the API
+// resources it discovers once for all credentials, rather than once per set of
+// credentials. Discovery is largely identity independent, so this avoids
+// rediscovering resources for every user who asks.
+//
+// Resources discovered using one caller's credentials are returned to all
+// callers until the discovery TTL passes, including callers who couldn't have
+// discovered them. Discovered resources describe only which kinds the API
+// server serves, not which of them a caller may get or list; RBAC is still
+// enforced when a caller reads a resource. Don't share discovery if the kinds
+// the API server serves are sensitive.
+func ShareDiscovery() CacheOption {
+	return func(c *Cache) {
+		c.shareDiscovery = true
+	}
+}
+
+// WithDefinedKindsRefresh configures how long the kinds returned by
+// DefinedKinds are cached for each set of credentials. Cached kinds are
+// discarded sooner if an XRD changes, unless clients are uncached. The default
+// refresh interval is 30 seconds.
+func WithDefinedKindsRefresh(d time.Duration) CacheOption {
+	return func(c *Cache) {
+		c.definedRefresh = d
+	}
+}
+
+// WithStampedeThreshold configures the number of calls waiting for a
+// concurrent call to create their client at or above which CreateStats reports
+// a stampede. Stampedes are never reported if n is not positive. The default
+// threshold is 10.
+func WithStampedeThreshold(n int) CacheOption {
+	return func(c *Cache) {
+		c.stampede = int64(n)
+	}
+}
+
+// WithIndexes configures the indexes registered with each client's cache. A
+// cached client may list objects of an indexed type using client.MatchingFields
+// to efficiently find those with a particular field value, for example to find
+// the resources a particular resource owns. Each index adds work to the
+// informer for its type, so only register indexes that are used. Indexes are
+// not registered if caching is disabled, or for types the API server doesn't
+// serve.
+func WithIndexes(idx ...Index) CacheOption {
+	return func(c *Cache) {
+		c.indexes = idx
+	}
+}
+
 // DoNotCache configures clients not to cache objects of the supplied types.
 // Note that the cache machinery extracts a GVK from these objects, so they can
 // either be types known to the scheme or *unstructured.Unstructured with their
@@ -172,6 +424,75 @@ func DoNotCache(o []client.Object) CacheOption {
 	}
 }
 
+// WithKindTTL configures clients not to cache objects of the supplied kinds,
+// but instead to read them from the API server at most once per the supplied
+// TTL. This trades freshness for fewer API server requests, and is useful for
+// kinds that are rarely watched for long enough to justify a cache but are
+// read often, or that change rarely.
+//
+// Each client remembers the result of each distinct get or list of these kinds
+// for their TTL. Unlike cached objects, they may be up to their TTL out of date
+// and reading them does not trigger live queries. A write of one of these
+// kinds using the same client causes it to forget all results of that kind.
+// Kinds that aren't supplied are cached (or not) as before. TTLs are ignored
+// if caching is disabled.
+func WithKindTTL(ttls map[schema.GroupVersionKind]time.Duration) CacheOption {
+	return func(c *Cache) {
+		c.ttls = make(map[schema.GroupVersionKind]time.Duration, len(ttls))
+		for gvk, ttl := range ttls {
+			if ttl > 0 {
+				c.ttls[gvk] = ttl
+			}
+		}
+	}
+}
+
+// FallBackToLiveReads configures clients whose caches don't sync within the
+// supplied timeout to stop their cache and read directly from the API server
+// for the rest of their session, rather than failing. This allows callers who
+// may get resources but not list and watch them, and whose caches therefore
+// can't sync, to read them. Clients wait up to the supplied timeout for their
+// cache to sync when they're created, and for each cached read until they fall
+// back. Clients never fall back if the timeout is zero, which is the default.
+func FallBackToLiveReads(timeout time.Duration) CacheOption {
+	return func(c *Cache) {
+		c.fallback = timeout
+	}
+}
+
+// DisableCache configures clients not to cache any objects. Every read is sent
+// to the API server, which is useful when debugging issues that caching can
+// mask - for example when a caller lacks RBAC access to watch a type of
+// resource the cache will report that no resources exist, rather than that the
+// caller is forbidden from reading them. Clients still expire when unused.
+func DisableCache() CacheOption {
+	return func(c *Cache) {
+		c.uncached = true
+	}
+}
+
+// DisableWrites configures clients to only read. Every write returns
+// ErrReadOnly without reaching the API server, so no caller can write
+// regardless of the RBAC permissions of their credentials.
+func DisableWrites() CacheOption {
+	return func(c *Cache) {
+		c.nowrites = true
+	}
+}
+
+// IncludeManagedFields configures clients to return the managed fields of the
+// objects they read. Managed fields are populated by server-side apply, are
+// rarely useful to callers, and can make up much of an object's size, so they
+// are stripped (from cached objects, from reads, and from the objects writes
+// echo back) by default. Objects echoed back by writes are also stripped of
+// the kubectl last-applied-configuration annotation unless this option is
+// supplied.
+func IncludeManagedFields() CacheOption {
+	return func(c *Cache) {
+		c.mfields = true
+	}
+}
+
 // UseNewCacheMiddleware configures the cache to use the supplied middleware
 // functions when creating new caches. This can be used to wrap the cache's
 // default new cache function with additional functionality.
@@ -191,15 +512,24 @@ func NewCache(s *runtime.Scheme, c *rest.Config, o ...CacheOption) *Cache {
 	_, _ = io.ReadFull(rand.Reader, salt)
 
 	ch := &Cache{
-		ctx:    context.Background(),
-		active: make(map[string]*session),
+		ctx:        context.Background(),
+		active:     make(map[string]*session),
+		creating:   make(map[string]*createLock),
+		discovered: make(map[string]discovered),
+		defined:    make(map[string]defined),
 
-		cfg:    c,
-		scheme: s,
-		expiry: 5 * time.Minute,
+		cfg:      c,
+		scheme:   s,
+		expiry:   5 * time.Minute,
+		jitter:   defaultExpiryJitter,
+		stampede: defaultStampedeThreshold,
 
-		newCache:  DefaultNewCacheFn,
-		newClient: DefaultNewClientFn,
+		discoveryTTL:   defaultDiscoveryTTL,
+		definedRefresh: defaultDefinedKindsRefresh,
+
+		newCache:     DefaultNewCacheFn,
+		newClient:    DefaultNewClientFn,
+		newDiscovery: DefaultNewDiscoveryClientFn,
 
 		salt: salt,
 		log:  logging.NewNopLogger(),
@@ -219,10 +549,17 @@ type getOptions struct {
 type GetOption func(o *getOptions)
 
 // Get a client that uses the specified bearer token.
-func (c *Cache) Get(cr auth.Credentials, o ...GetOption) (client.Client, error) { //nolint:gocyclo
-	extra := bytes.Buffer{}
-	extra.Write(c.salt)
-	id := cr.Hash(extra.Bytes())
+func (c *Cache) Get(cr auth.Credentials, o ...GetOption) (client.Client, error) {
+	return c.GetWithContext(context.Background(), cr, o...)
+}
+
+// GetWithContext gets a client that uses the specified bearer token. Creating
+// a new client is abandoned if the supplied context is done before the client
+// is cached, so that a request that is about to time out doesn't cache a client
+// nobody will use. The context does not govern the lifetime of the client once
+// it is cached; clients expire only when they go unused.
+func (c *Cache) GetWithContext(ctx context.Context, cr auth.Credentials, o ...GetOption) (client.Client, error) { //nolint:gocyclo
+	id := c.id(cr)
 
 	log := c.log.WithValues("client-id", id)
 
@@ -232,58 +569,181 @@ func (c *Cache) Get(cr auth.Credentials, o ...GetOption) (client.Client, error)
 
 	if ok {
 		log.Debug("Used existing cached client",
-			"new-expiry", time.Now().Add(c.expiry),
+			"new-expiry", time.Now().Add(sn.expiry),
 		)
-		sn.expiration.Reset(c.expiry)
+		sn.touch(sn.expiry)
 		return sn.client, nil
 	}
 
+	if err := ctx.Err(); err != nil {
+		return nil, errors.Wrap(err, errRequestDone)
+	}
+
+	// Only one call at a time may create the client for a particular ID.
+	// Any others wait, then use the client it created.
+	unlock, err := c.lockCreate(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, errRequestDone)
+	}
+	defer unlock()
+
+	c.mx.RLock()
+	sn, ok = c.active[id]
+	c.mx.RUnlock()
+	if ok {
+		// A concurrent call created the client while we waited.
+		c.recordCreate(ctx, true)
+		log.Debug("Used existing cached client",
+			"new-expiry", time.Now().Add(sn.expiry),
+		)
+		sn.touch(sn.expiry)
+		return sn.client, nil
+	}
+
+	if c.creates != nil {
+		select {
+		case c.creates <- struct{}{}:
+		case <-ctx.Done():
+			return nil, errors.Wrap(ctx.Err(), errRequestDone)
+		}
+		defer func() { <-c.creates }()
+	}
+
 	started := time.Now()
 	cfg := cr.Inject(c.cfg)
+	exchange := c.exchanger != nil && cr.BearerToken != ""
+	if exchange {
+		// Exchange the token up front, so that we don't create a client that
+		// can't authenticate.
+		if _, err := c.exchanger.Exchange(ctx, cr.BearerToken); err != nil {
+			return nil, errors.Wrap(err, errExchangeToken)
+		}
+		cfg.BearerToken = ""
+	}
 	hc, err := rest.HTTPClientFor(cfg)
 	if err != nil {
 		return nil, errors.Wrap(err, errNewHTTPClient)
 	}
-	ca, err := c.newCache(cfg, cache.Options{
+	if exchange {
+		hc = withTokenExchange(hc, c.exchanger, cr.BearerToken)
+	}
+	copts := client.Options{
 		HTTPClient: hc,
 		Scheme:     c.scheme,
 		Mapper:     c.mapper,
-	})
-	if err != nil {
-		return nil, errors.Wrap(err, errNewCache)
 	}
 
-	wc, err := c.newClient(cfg, client.Options{
-		HTTPClient: hc,
-		Scheme:     c.scheme,
-		Mapper:     c.mapper,
-		Cache: &client.CacheOptions{
+	var ca cache.Cache
+	if !c.uncached {
+		co := cache.Options{
+			HTTPClient: hc,
+			Scheme:     c.scheme,
+			Mapper:     c.mapper,
+			SyncPeriod: c.resync,
+		}
+		if !c.mfields {
+			co.DefaultTransform = cache.TransformStripManagedFields()
+		}
+		if c.pageSize > 0 {
+			co.HTTPClient = withListPageSize(hc, c.pageSize)
+		}
+		ca, err = c.newCache(cfg, co)
+		if err != nil {
+			return nil, errors.Wrap(err, errNewCache)
+		}
+		// Indexes must be registered before the cache starts.
+		for _, idx := range c.indexes {
+			err := ca.IndexField(ctx, idx.Object, idx.Field, idx.Extract)
+			if meta.IsNoMatchError(err) {
+				log.Debug("Not indexing unserved type", "field", idx.Field, "error", err)
+				continue
+			}
+			if err != nil {
+				return nil, errors.Wrapf(err, errFmtIndexField, idx.Field)
+			}
+		}
+		copts.Cache = &client.CacheOptions{
 			Reader:     ca,
-			DisableFor: c.nocache,
+			DisableFor: c.uncachedObjects(),
 			// TODO(negz): Don't cache unstructured objects? Doing so allows us to
 			// cache object types that aren't known at build time, like managed
 			// resources and composite resources. On the other hand it could lead to
 			// the cache starting a watch on any kind of resource it encounters,
 			// e.g. arbitrary owner references.
 			Unstructured: true,
-		},
-	})
+		}
+	}
+
+	wc, err := c.newClient(cfg, copts)
 	if err != nil {
 		return nil, errors.Wrap(err, errNewClient)
 	}
+	if c.nowrites {
+		wc = &readOnlyClient{Client: wc}
+	}
+	if !c.mfields {
+		// Not all reads are served by the cache, so we strip managed fields
+		// from objects read directly from the API server too.
+		wc = &managedFieldsStripper{Client: wc}
+	}
+	if !c.uncached && len(c.ttls) > 0 {
+		wc = newTTLClient(wc, c.ttls)
+	}
+	if !c.uncached {
+		// Batching reads is only worthwhile when they're served by the
+		// cache, because a batch of reads is served by listing objects.
+		wc = &loadingClient{Client: wc, uncached: c.uncachedGVKs()}
+	}
+
+	// The session's context is derived from the Cache's context, not the
+	// request's, so that the client outlives the request that created it.
+	// The cache runs until the session ends, unless it's stopped early because
+	// the client fell back to reading from the API server.
+	lctx, cancel := context.WithCancel(c.ctx)
+	cctx := lctx
+
+	var fc *fallbackClient
+	if ca != nil && c.fallback > 0 {
+		var stopCache context.CancelFunc
+		cctx, stopCache = context.WithCancel(lctx)
+		lc, err := c.newClient(cfg, client.Options{HTTPClient: hc, Scheme: c.scheme, Mapper: c.mapper})
+		if err != nil {
+			stopCache()
+			cancel()
+			return nil, errors.Wrap(err, errNewClient)
+		}
+		if !c.mfields {
+			lc = &managedFieldsStripper{Client: lc}
+		}
+		fc = &fallbackClient{Client: wc, live: lc, timeout: c.fallback, stopCache: stopCache, log: log}
+		wc = fc
+	}
+
+	// Building the client may have taken a while. Don't cache it if the
+	// request that wanted it is gone.
+	if err := ctx.Err(); err != nil {
+		cancel()
+		return nil, errors.Wrap(err, errRequestDone)
+	}
 
 	// We use a distinct s.expiry ticker rather than a context deadline or timeout
 	// because it's not possible to extend a context's deadline or timeout, but it
 	// is possible to 'reset' (i.e. extend) a ticker.
-	expiration := &tickerExpiration{t: time.NewTicker(c.expiry)}
-	newExpiry := time.Now().Add(c.expiry)
-	ctx, cancel := context.WithCancel(c.ctx)
-	sn = &session{client: wc, cancel: cancel, expiration: expiration}
+	expiry := c.jitteredExpiry()
+	expiration := &tickerExpiration{t: time.NewTicker(expiry)}
+	newExpiry := time.Now().Add(expiry)
+	sn = newSession(wc, cancel, expiration, started)
+	sn.tokenHash = cr.TokenHash()
+	sn.cache = ca
+	sn.fallback = fc
+	sn.expiry = expiry
 
 	c.mx.Lock()
 	// another gorouting might have set the session.
 	if sn, ok := c.active[id]; ok {
 		c.mx.Unlock()
+		c.recordCreate(ctx, true)
+		sn.touch(sn.expiry)
 		log.Debug("Used existing cached client",
 			"duration", time.Since(started),
 			"new-expiry", newExpiry,
@@ -292,16 +752,7 @@ func (c *Cache) Get(cr auth.Credentials, o ...GetOption) (client.Client, error)
 	}
 	c.active[id] = sn
 	c.mx.Unlock()
-
-	go func() {
-		err := ca.Start(ctx)
-		log.Debug("Cache stopped", "error", err)
-
-		// Start blocks until ctx is closed, or it encounters an error. If we make
-		// it here either the cache crashed, or the context was cancelled (e.g.
-		// because our session expired).
-		c.remove(id)
-	}()
+	c.recordCreate(ctx, false)
 
 	// Stop our cache when we expire.
 	go func() {
@@ -309,17 +760,64 @@ func (c *Cache) Get(cr auth.Credentials, o ...GetOption) (client.Client, error)
 		case <-expiration.C():
 			// We expired, and should remove ourself from the session cache.
 			log.Debug("Client expired")
-		case <-ctx.Done():
+		case <-lctx.Done():
 			log.Debug("Client stopped")
 			// We're done for some other reason (e.g. the cache crashed).
 		}
 		c.remove(id)
 	}()
 
-	if !ca.WaitForCacheSync(ctx) {
+	if ca == nil {
+		sn.synced.Store(true)
+		log.Debug("Created uncached client",
+			"duration", time.Since(started),
+			"new-expiry", newExpiry,
+		)
+		return sn.client, nil
+	}
+
+	go func() {
+		err := ca.Start(cctx)
+		log.Debug("Cache stopped", "error", err)
+
+		// The session continues without its cache if the client fell back
+		// to reading from the API server.
+		if sn.readsLive() {
+			return
+		}
+
+		// Start blocks until cctx is closed, or it encounters an error. If we make
+		// it here either the cache crashed, or the context was cancelled (e.g.
+		// because our session expired).
 		c.remove(id)
+	}()
+
+	// The cache runs until the session ends, but we only wait for it to sync
+	// for as long as the request wants the client. If the request is done
+	// before the cache syncs we remove the session, which stops the cache.
+	sctx, scancel := context.WithCancel(lctx)
+	defer scancel()
+	stop := context.AfterFunc(ctx, scancel)
+	defer stop()
+
+	if fc != nil {
+		// Don't wait longer than the fallback timeout for the cache to sync.
+		t := time.AfterFunc(c.fallback, scancel)
+		defer t.Stop()
+	}
+
+	if !ca.WaitForCacheSync(sctx) {
+		if ctx.Err() == nil && lctx.Err() == nil && fc != nil {
+			fc.fallBack()
+			return sn.client, nil
+		}
+		c.remove(id)
+		if err := ctx.Err(); err != nil {
+			return nil, errors.Wrap(err, errRequestDone)
+		}
 		return nil, errors.New(errWaitForCacheSync)
 	}
+	sn.synced.Store(true)
 
 	log.Debug("Created cached client",
 		"duration", time.Since(started),
@@ -329,6 +827,87 @@ func (c *Cache) Get(cr auth.Credentials, o ...GetOption) (client.Client, error)
 	return sn.client, nil
 }
 
+// Invalidate the client associated with the supplied credentials, if any. The
+// client's cache is stopped and it is removed from the Cache immediately rather
+// than when it expires, for example because the RBAC permissions of the subject
+// of the credentials have changed or their bearer token has been revoked. The
+// next call to Get with the same credentials will create a new client. Any API
+// resources discovered, and kinds defined by XRDs, using the credentials are
+// forgotten too. Invalidate is a no-op if there is no active client for the
+// credentials.
+func (c *Cache) Invalidate(cr auth.Credentials) {
+	id := c.id(cr)
+	c.remove(id)
+
+	c.dmx.Lock()
+	delete(c.discovered, id)
+	c.dmx.Unlock()
+
+	c.kmx.Lock()
+	delete(c.defined, id)
+	c.kmx.Unlock()
+}
+
+// id returns the identifier of the client associated with the supplied
+// credentials.
+func (c *Cache) id(cr auth.Credentials) string {
+	extra := bytes.Buffer{}
+	extra.Write(c.salt)
+	return cr.Hash(extra.Bytes())
+}
+
+// A createLock serializes creation of a client.
+type createLock struct {
+	ch   chan struct{}
+	refs int
+}
+
+// lockCreate blocks until the caller may create the client with the supplied
+// ID, or the supplied context is done. The returned function must be called
+// to release the lock.
+func (c *Cache) lockCreate(ctx context.Context, id string) (func(), error) {
+	c.cmx.Lock()
+	l, ok := c.creating[id]
+	if !ok {
+		l = &createLock{ch: make(chan struct{}, 1)}
+		c.creating[id] = l
+	}
+	l.refs++
+	c.cmx.Unlock()
+
+	release := func() {
+		c.cmx.Lock()
+		defer c.cmx.Unlock()
+		l.refs--
+		if l.refs == 0 {
+			delete(c.creating, id)
+		}
+	}
+
+	unlock := func() {
+		<-l.ch
+		release()
+	}
+
+	// Don't count calls that can take the lock immediately as waiting.
+	select {
+	case l.ch <- struct{}{}:
+		return unlock, nil
+	default:
+	}
+
+	c.recordWaiting(ctx, 1)
+	defer c.recordWaiting(ctx, -1)
+
+	select {
+	case l.ch <- struct{}{}:
+		return unlock, nil
+	case <-ctx.Done():
+		release()
+		return nil, ctx.Err()
+	}
+}
+
 func (c *Cache) remove(id string) {
 	c.mx.Lock()
 	defer c.mx.Unlock()
@@ -341,12 +920,51 @@ func (c *Cache) remove(id string) {
 	}
 }
 
+// uncachedObjects returns objects of the kinds that clients should not cache.
+func (c *Cache) uncachedObjects() []client.Object {
+	out := make([]client.Object, 0, len(c.nocache)+len(c.ttls))
+	out = append(out, c.nocache...)
+	for gvk := range c.ttls {
+		u := &kunstructured.Unstructured{}
+		u.SetGroupVersionKind(gvk)
+		out = append(out, u)
+	}
+	return out
+}
+
+// uncachedGVKs returns the kinds of object that clients should not cache.
+func (c *Cache) uncachedGVKs() map[schema.GroupVersionKind]bool {
+	out := make(map[schema.GroupVersionKind]bool, len(c.nocache)+len(c.ttls))
+	for _, o := range c.nocache {
+		gvk, err := apiutil.GVKForObject(o, c.scheme)
+		if err != nil {
+			continue
+		}
+		out[gvk] = true
+	}
+	for gvk := range c.ttls {
+		out[gvk] = true
+	}
+	return out
+}
+
 type expiration interface {
 	Reset(d time.Duration)
 	Stop()
 	C() <-chan time.Time
 }
 
+// jitteredExpiry returns the configured expiry, varied at random by up to the
+// configured jitter. See WithExpiryJitter.
+func (c *Cache) jitteredExpiry() time.Duration {
+	if c.jitter <= 0 {
+		return c.expiry
+	}
+	// A random factor in [1-jitter, 1+jitter).
+	f := 1 + c.jitter*(2*mrand.Float64()-1) //nolint:gosec // Jitter needn't be cryptographically secure.
+	return time.Duration(float64(c.expiry) * f)
+}
+
 type tickerExpiration struct{ t *time.Ticker }
 
 func (e *tickerExpiration) Reset(d time.Duration) { e.t.Reset(d) }
@@ -355,6 +973,58 @@ func (e *tickerExpiration) C() <-chan time.Time   { return e.t.C }
 
 type session struct {
 	client     client.Client
+	cache      cache.Cache // Nil if the client is uncached.
 	cancel     context.CancelFunc
 	expiration expiration
+
+	// expiry is how long the session may go unused before it expires. It's
+	// chosen when the session is created, and never changes.
+	expiry time.Duration
+
+	// created is when the session was created. It never changes.
+	created time.Time
+
+	// tokenHash is the TokenHash of the credentials the session was created
+	// with. It never changes.
+	tokenHash string
+
+	// lastUsed is when the session was last used, in nanoseconds since the
+	// Unix epoch. It's updated concurrently by callers of Get, so it must only
+	// be accessed via touch and lastUsedAt.
+	lastUsed atomic.Int64
+
+	// synced is true once the session's cache has synced.
+	synced atomic.Bool
+
+	// fallback is the session's client if it may fall back to reading from
+	// the API server when its cache doesn't sync. Nil otherwise.
+	fallback *fallbackClient
+
+	// watchingXRDs is true once the session's cache is watching XRDs. See
+	// DefinedKinds.
+	watchingXRDs atomic.Bool
+}
+
+func newSession(c client.Client, cancel context.CancelFunc, e expiration, created time.Time) *session {
+	sn := &session{client: c, cancel: cancel, expiration: e, created: created}
+	sn.lastUsed.Store(created.UnixNano())
+	return sn
+}
+
+// readsLive returns true if the session's client fell back to reading from
+// the API server, having stopped its cache.
+func (s *session) readsLive() bool {
+	return s.fallback != nil && s.fallback.fellBack.Load()
+}
+
+// touch records that the session was used, and extends its expiry by the
+// supplied duration.
+func (s *session) touch(d time.Duration) {
+	s.expiration.Reset(d)
+	s.lastUsed.Store(time.Now().UnixNano())
+}
+
+// lastUsedAt returns when the session was last used.
+func (s *session) lastUsedAt() time.Time {
+	return time.Unix(0, s.lastUsed.Load())
 }
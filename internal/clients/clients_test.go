@@ -16,12 +16,21 @@ package clients
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/rest"
+	certutil "k8s.io/client-go/util/cert"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -48,6 +57,16 @@ type MockCache struct {
 
 	MockStart            func(stop context.Context) error
 	MockWaitForCacheSync func(ctx context.Context) bool
+	MockIndexField       func(ctx context.Context, obj client.Object, field string, extractValue client.IndexerFunc) error
+	MockGetInformer      func(ctx context.Context, obj client.Object, opts ...cache.InformerGetOption) (cache.Informer, error)
+}
+
+func (c *MockCache) GetInformer(ctx context.Context, obj client.Object, opts ...cache.InformerGetOption) (cache.Informer, error) {
+	return c.MockGetInformer(ctx, obj, opts...)
+}
+
+func (c *MockCache) IndexField(ctx context.Context, obj client.Object, field string, extractValue client.IndexerFunc) error {
+	return c.MockIndexField(ctx, obj, field, extractValue)
 }
 
 func (c *MockCache) Start(stop context.Context) error {
@@ -91,6 +110,20 @@ func TestGet(t *testing.T) {
 				err: errors.Wrap(errBoom, errNewClient),
 			},
 		},
+		"ExchangeTokenError": {
+			reason: "Errors exchanging the caller's bearer token should be returned.",
+			copts: []CacheOption{
+				WithTokenExchanger(TokenExchangerFn(func(_ context.Context, _ string) (string, error) {
+					return "", errBoom
+				})),
+			},
+			args: args{
+				creds: auth.Credentials{BearerToken: "coolToken"},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errExchangeToken),
+			},
+		},
 		"NewCacheError": {
 			reason: "Errors creating a new controller-runtime cache should be returned.",
 			copts: []CacheOption{
@@ -140,6 +173,92 @@ func TestGet(t *testing.T) {
 				active: 0,
 			},
 		},
+		"CacheDidNotSyncFellBack": {
+			reason: "Clients should fall back to reading from the API server, rather than being removed, if their caches don't sync in time and fallback is enabled.",
+			copts: []CacheOption{
+				FallBackToLiveReads(10 * time.Millisecond),
+				WithNewClientFn(NewClientFn(func(cfg *rest.Config, o client.Options) (client.Client, error) {
+					return test.NewMockClient(), nil
+				})),
+				WithNewCacheFn(NewCacheFn(func(cfg *rest.Config, o cache.Options) (cache.Cache, error) {
+					ca := &MockCache{
+						MockStart: func(stop context.Context) error {
+							<-stop.Done()
+							return nil
+						},
+						// Our cache never syncs, for example because the
+						// caller may not list what it watches.
+						MockWaitForCacheSync: func(ctx context.Context) bool {
+							<-ctx.Done()
+							return false
+						},
+					}
+					return ca, nil
+				})),
+			},
+			want: want{
+				err:    nil,
+				active: 1,
+			},
+		},
+		"IndexFieldError": {
+			reason: "Errors registering a cache index should be returned.",
+			copts: []CacheOption{
+				WithIndexes(OwnerUIDsIndex(&unstructured.Unstructured{})),
+				WithNewCacheFn(NewCacheFn(func(cfg *rest.Config, o cache.Options) (cache.Cache, error) {
+					ca := &MockCache{
+						MockIndexField: func(_ context.Context, _ client.Object, _ string, _ client.IndexerFunc) error { return errBoom },
+					}
+					return ca, nil
+				})),
+			},
+			want: want{
+				err: errors.Wrapf(errBoom, errFmtIndexField, IndexFieldOwnerUIDs),
+			},
+		},
+		"IndexFieldNoMatch": {
+			reason: "Indexes for types the API server doesn't serve should be skipped.",
+			copts: []CacheOption{
+				WithIndexes(OwnerUIDsIndex(&unstructured.Unstructured{})),
+				WithNewClientFn(NewClientFn(func(cfg *rest.Config, o client.Options) (client.Client, error) {
+					return test.NewMockClient(), nil
+				})),
+				WithNewCacheFn(NewCacheFn(func(cfg *rest.Config, o cache.Options) (cache.Cache, error) {
+					ca := &MockCache{
+						MockIndexField: func(_ context.Context, _ client.Object, _ string, _ client.IndexerFunc) error {
+							return &meta.NoKindMatchError{GroupKind: schema.GroupKind{Group: "example.org", Kind: "Example"}}
+						},
+						MockStart: func(stop context.Context) error {
+							<-stop.Done()
+							return nil
+						},
+						MockWaitForCacheSync: func(_ context.Context) bool { return true },
+					}
+					return ca, nil
+				})),
+			},
+			want: want{
+				active: 1,
+			},
+		},
+		"Uncached": {
+			reason: "Clients should read directly from the API server, without a cache, if caching is disabled.",
+			copts: []CacheOption{
+				DisableCache(),
+				WithNewClientFn(NewClientFn(func(cfg *rest.Config, o client.Options) (client.Client, error) {
+					if o.Cache != nil {
+						return nil, errBoom
+					}
+					return test.NewMockClient(), nil
+				})),
+				WithNewCacheFn(NewCacheFn(func(cfg *rest.Config, o cache.Options) (cache.Cache, error) {
+					return nil, errBoom
+				})),
+			},
+			want: want{
+				active: 1,
+			},
+		},
 		"Success": {
 			reason: "Caches should be removed from the active map if they don't sync.",
 			copts: []CacheOption{
@@ -185,8 +304,711 @@ func TestGet(t *testing.T) {
 	}
 }
 
+func TestGetWithContext(t *testing.T) {
+	done, cancelDone := context.WithCancel(context.Background())
+	cancelDone()
+
+	creating, cancelCreating := context.WithCancel(context.Background())
+	defer cancelCreating()
+
+	type want struct {
+		err    error
+		active int
+	}
+
+	cases := map[string]struct {
+		reason string
+		copts  []CacheOption
+		ctx    context.Context
+		want   want
+	}{
+		"RequestDone": {
+			reason: "A client should not be created if the request context is already done.",
+			copts: []CacheOption{
+				WithNewCacheFn(NewCacheFn(func(cfg *rest.Config, o cache.Options) (cache.Cache, error) {
+					t.Error("unexpected call to NewCacheFn")
+					return nil, nil
+				})),
+			},
+			ctx: done,
+			want: want{
+				err:    errors.Wrap(context.Canceled, errRequestDone),
+				active: 0,
+			},
+		},
+		"RequestDoneDuringCreation": {
+			reason: "A client should not be cached if the request context is done while it is being created.",
+			copts: []CacheOption{
+				WithNewClientFn(NewClientFn(func(cfg *rest.Config, o client.Options) (client.Client, error) {
+					cancelCreating()
+					return test.NewMockClient(), nil
+				})),
+				WithNewCacheFn(NewCacheFn(func(cfg *rest.Config, o cache.Options) (cache.Cache, error) {
+					return &MockCache{}, nil
+				})),
+			},
+			ctx: creating,
+			want: want{
+				err:    errors.Wrap(context.Canceled, errRequestDone),
+				active: 0,
+			},
+		},
+		"Success": {
+			reason: "A client should be cached if the request context is not done.",
+			copts: []CacheOption{
+				WithNewClientFn(NewClientFn(func(cfg *rest.Config, o client.Options) (client.Client, error) {
+					return test.NewMockClient(), nil
+				})),
+				WithNewCacheFn(NewCacheFn(func(cfg *rest.Config, o cache.Options) (cache.Cache, error) {
+					ca := &MockCache{
+						MockStart: func(stop context.Context) error {
+							<-stop.Done()
+							return nil
+						},
+						MockWaitForCacheSync: func(ctx context.Context) bool { return true },
+					}
+					return ca, nil
+				})),
+			},
+			ctx: context.Background(),
+			want: want{
+				active: 1,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			copts := append([]CacheOption{WithContext(ctx)}, tc.copts...)
+			c := NewCache(runtime.NewScheme(), &rest.Config{}, copts...)
+			_, err := c.GetWithContext(tc.ctx, auth.Credentials{})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nc.GetWithContext(...): -want error, +got:\n%s", tc.reason, diff)
+			}
+
+			c.mx.RLock()
+			active := len(c.active)
+			c.mx.RUnlock()
+			if diff := cmp.Diff(tc.want.active, active); diff != "" {
+				t.Errorf("\n%s\nc.GetWithContext(...): -want active clients, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGetWithContextCancelledDuringSync(t *testing.T) {
+	started := make(chan struct{})
+	stopped := make(chan struct{})
+
+	newClient := WithNewClientFn(NewClientFn(func(cfg *rest.Config, o client.Options) (client.Client, error) {
+		return test.NewMockClient(), nil
+	}))
+	newCache := WithNewCacheFn(NewCacheFn(func(cfg *rest.Config, o cache.Options) (cache.Cache, error) {
+		ca := &MockCache{
+			MockStart: func(stop context.Context) error {
+				close(started)
+				<-stop.Done()
+				close(stopped)
+				return nil
+			},
+			// Our cache never syncs; it only gives up when told to stop.
+			MockWaitForCacheSync: func(ctx context.Context) bool {
+				<-ctx.Done()
+				return false
+			},
+		}
+		return ca, nil
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c := NewCache(runtime.NewScheme(), &rest.Config{}, WithContext(ctx), newClient, newCache)
+
+	rctx, rcancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		rcancel()
+	}()
+
+	errs := make(chan error)
+	go func() {
+		_, err := c.GetWithContext(rctx, auth.Credentials{})
+		errs <- err
+	}()
+
+	select {
+	case err := <-errs:
+		want := errors.Wrap(context.Canceled, errRequestDone)
+		if diff := cmp.Diff(want, err, test.EquateErrors()); diff != "" {
+			t.Errorf("\nc.GetWithContext(...): -want error, +got:\n%s", diff)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("c.GetWithContext(...): did not return promptly after the request context was cancelled")
+	}
+
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("c.GetWithContext(...): did not stop the cache after the request context was cancelled")
+	}
+
+	c.mx.RLock()
+	active := len(c.active)
+	c.mx.RUnlock()
+	if diff := cmp.Diff(0, active); diff != "" {
+		t.Errorf("\nc.GetWithContext(...): -want active clients, +got:\n%s", diff)
+	}
+}
+
+func TestWithMaxConcurrentCreates(t *testing.T) {
+	const (
+		limit   = 2
+		callers = 6
+	)
+
+	var (
+		mu       sync.Mutex
+		inflight int
+		peak     int
+	)
+
+	newClient := WithNewClientFn(NewClientFn(func(cfg *rest.Config, o client.Options) (client.Client, error) {
+		return test.NewMockClient(), nil
+	}))
+	newCache := WithNewCacheFn(NewCacheFn(func(cfg *rest.Config, o cache.Options) (cache.Cache, error) {
+		ca := &MockCache{
+			MockStart: func(stop context.Context) error {
+				<-stop.Done()
+				return nil
+			},
+			// Syncing takes a little while, so that concurrent creates
+			// overlap if they are not limited.
+			MockWaitForCacheSync: func(ctx context.Context) bool {
+				mu.Lock()
+				inflight++
+				if inflight > peak {
+					peak = inflight
+				}
+				mu.Unlock()
+
+				time.Sleep(50 * time.Millisecond)
+
+				mu.Lock()
+				inflight--
+				mu.Unlock()
+				return true
+			},
+		}
+		return ca, nil
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c := NewCache(runtime.NewScheme(), &rest.Config{}, WithContext(ctx), WithMaxConcurrentCreates(limit), newClient, newCache)
+
+	wg := sync.WaitGroup{}
+	errs := make(chan error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			cr := auth.Credentials{Impersonate: auth.Impersonation{Username: fmt.Sprintf("user-%d", i)}}
+			if _, err := c.GetWithContext(context.Background(), cr); err != nil {
+				errs <- err
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("\nc.GetWithContext(...): unexpected error: %s", err)
+	}
+	if peak > limit {
+		t.Errorf("\nc.GetWithContext(...): %d clients were created concurrently, want at most %d", peak, limit)
+	}
+
+	// Fill the semaphore. Calls that can use an existing client should not
+	// need to wait for it.
+	for i := 0; i < limit; i++ {
+		c.creates <- struct{}{}
+	}
+
+	done := make(chan error)
+	go func() {
+		_, err := c.GetWithContext(context.Background(), auth.Credentials{Impersonate: auth.Impersonation{Username: "user-0"}})
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("\nc.GetWithContext(...): unexpected error: %s", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("c.GetWithContext(...): a cached client waited for a client to be created")
+	}
+}
+
+func TestGetWithContextConcurrentSameCredentials(t *testing.T) {
+	const callers = 10
+
+	var (
+		caches   atomic.Int32
+		c        *Cache
+		creating CreateStats
+	)
+
+	// Creating the client doesn't finish until the other calls are waiting
+	// for it to be cached, or a while has passed.
+	newClient := WithNewClientFn(NewClientFn(func(cfg *rest.Config, o client.Options) (client.Client, error) {
+		deadline := time.Now().Add(5 * time.Second)
+		for c.CreateStats().Waiting < callers-1 && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+		creating = c.CreateStats()
+		return test.NewMockClient(), nil
+	}))
+	newCache := WithNewCacheFn(NewCacheFn(func(cfg *rest.Config, o cache.Options) (cache.Cache, error) {
+		caches.Add(1)
+		ca := &MockCache{
+			MockStart: func(stop context.Context) error {
+				<-stop.Done()
+				return nil
+			},
+			MockWaitForCacheSync: func(ctx context.Context) bool {
+				return true
+			},
+		}
+		return ca, nil
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c = NewCache(runtime.NewScheme(), &rest.Config{}, WithContext(ctx), WithStampedeThreshold(callers-1), newClient, newCache)
+
+	wg := sync.WaitGroup{}
+	got := make([]client.Client, callers)
+	errs := make([]error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			got[i], errs[i] = c.GetWithContext(context.Background(), auth.Credentials{BearerToken: "cool-token"})
+		}(i)
+	}
+	wg.Wait()
+
+	for i := range errs {
+		if errs[i] != nil {
+			t.Errorf("\nc.GetWithContext(...): unexpected error: %s", errs[i])
+		}
+		if got[i] != got[0] {
+			t.Errorf("\nc.GetWithContext(...): call %d returned a different client than call 0", i)
+		}
+	}
+	if diff := cmp.Diff(int32(1), caches.Load()); diff != "" {
+		t.Errorf("\nc.GetWithContext(...): -want caches created, +got:\n%s", diff)
+	}
+
+	c.cmx.Lock()
+	locks := len(c.creating)
+	c.cmx.Unlock()
+	if diff := cmp.Diff(0, locks); diff != "" {
+		t.Errorf("\nc.GetWithContext(...): -want creation locks, +got:\n%s", diff)
+	}
+
+	wantCreating := CreateStats{Waiting: callers - 1, Stampede: true}
+	if diff := cmp.Diff(wantCreating, creating); diff != "" {
+		t.Errorf("\nc.CreateStats(): -want stats while creating, +got:\n%s", diff)
+	}
+	wantDone := CreateStats{Independent: 1, Coalesced: callers - 1}
+	if diff := cmp.Diff(wantDone, c.CreateStats()); diff != "" {
+		t.Errorf("\nc.CreateStats(): -want stats when done, +got:\n%s", diff)
+	}
+}
+
+func TestWithResyncPeriod(t *testing.T) {
+	errBoom := errors.New("boom")
+	hour := time.Hour
+
+	cases := map[string]struct {
+		reason string
+		copts  []CacheOption
+		want   *time.Duration
+	}{
+		"Default": {
+			reason: "Caches should use controller-runtime's default sync period if no resync period is configured.",
+			want:   nil,
+		},
+		"Configured": {
+			reason: "Caches should use the configured resync period.",
+			copts:  []CacheOption{WithResyncPeriod(hour)},
+			want:   &hour,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got *time.Duration
+			copts := append(tc.copts, WithNewCacheFn(NewCacheFn(func(cfg *rest.Config, o cache.Options) (cache.Cache, error) {
+				got = o.SyncPeriod
+				return nil, errBoom
+			})))
+			c := NewCache(runtime.NewScheme(), &rest.Config{}, copts...)
+			_, _ = c.Get(auth.Credentials{})
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nc.Get(...): -want sync period, +got sync period:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestWithKindTTL(t *testing.T) {
+	errBoom := errors.New("boom")
+	gvk := schema.GroupVersionKind{Group: "example.org", Version: "v1", Kind: "Slow"}
+
+	cases := map[string]struct {
+		reason string
+		copts  []CacheOption
+		want   []schema.GroupVersionKind
+	}{
+		"Default": {
+			reason: "Clients should cache all kinds if no TTLs are configured.",
+			want:   []schema.GroupVersionKind{},
+		},
+		"Configured": {
+			reason: "Clients should not cache kinds with a TTL.",
+			copts:  []CacheOption{WithKindTTL(map[schema.GroupVersionKind]time.Duration{gvk: time.Minute})},
+			want:   []schema.GroupVersionKind{gvk},
+		},
+		"NotPositive": {
+			reason: "Clients should ignore TTLs that are not positive.",
+			copts:  []CacheOption{WithKindTTL(map[schema.GroupVersionKind]time.Duration{gvk: 0})},
+			want:   []schema.GroupVersionKind{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := []schema.GroupVersionKind{}
+			copts := append(tc.copts,
+				WithNewCacheFn(NewCacheFn(func(cfg *rest.Config, o cache.Options) (cache.Cache, error) {
+					return &MockCache{}, nil
+				})),
+				WithNewClientFn(NewClientFn(func(cfg *rest.Config, o client.Options) (client.Client, error) {
+					for _, obj := range o.Cache.DisableFor {
+						got = append(got, obj.GetObjectKind().GroupVersionKind())
+					}
+					return nil, errBoom
+				})),
+			)
+			c := NewCache(runtime.NewScheme(), &rest.Config{}, copts...)
+			_, _ = c.Get(auth.Credentials{})
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nc.Get(...): -want uncached kinds, +got uncached kinds:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestWithExpiryJitter(t *testing.T) {
+	expiry := 10 * time.Minute
+	sessions := 50
+
+	cases := map[string]struct {
+		reason string
+		copts  []CacheOption
+		jitter float64
+	}{
+		"Default": {
+			reason: "Clients should expire within 10% of the configured expiry by default.",
+			jitter: defaultExpiryJitter,
+		},
+		"Configured": {
+			reason: "Clients should expire within the configured jitter of the configured expiry.",
+			copts:  []CacheOption{WithExpiryJitter(0.2)},
+			jitter: 0.2,
+		},
+		"Capped": {
+			reason: "Clients should expire within the maximum jitter of the configured expiry.",
+			copts:  []CacheOption{WithExpiryJitter(2)},
+			jitter: maxExpiryJitter,
+		},
+		"Disabled": {
+			reason: "Clients should expire after exactly the configured expiry if jitter is disabled.",
+			copts:  []CacheOption{WithExpiryJitter(0)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			copts := append([]CacheOption{
+				WithContext(ctx),
+				WithExpiry(expiry),
+				WithNewClientFn(NewClientFn(func(cfg *rest.Config, o client.Options) (client.Client, error) {
+					return test.NewMockClient(), nil
+				})),
+				WithNewCacheFn(NewCacheFn(func(cfg *rest.Config, o cache.Options) (cache.Cache, error) {
+					ca := &MockCache{
+						MockStart: func(stop context.Context) error {
+							<-stop.Done()
+							return nil
+						},
+						MockWaitForCacheSync: func(ctx context.Context) bool { return true },
+					}
+					return ca, nil
+				})),
+			}, tc.copts...)
+			c := NewCache(runtime.NewScheme(), &rest.Config{}, copts...)
+
+			for i := 0; i < sessions; i++ {
+				cr := auth.Credentials{Impersonate: auth.Impersonation{Username: fmt.Sprintf("user-%d", i)}}
+				if _, err := c.Get(cr); err != nil {
+					t.Fatalf("\n%s\nc.Get(...): %s", tc.reason, err)
+				}
+			}
+
+			lo := time.Duration(float64(expiry) * (1 - tc.jitter))
+			hi := time.Duration(float64(expiry) * (1 + tc.jitter))
+			distinct := map[time.Duration]bool{}
+
+			c.mx.RLock()
+			for id, sn := range c.active {
+				if sn.expiry < lo || sn.expiry > hi {
+					t.Errorf("\n%s\nc.Get(...): session %s: want expiry in [%s, %s], got %s", tc.reason, id, lo, hi, sn.expiry)
+				}
+				distinct[sn.expiry] = true
+			}
+			c.mx.RUnlock()
+
+			// Jittered expiries are very unlikely to collide.
+			want := sessions
+			if tc.jitter == 0 {
+				want = 1
+			}
+			if diff := cmp.Diff(want, len(distinct)); diff != "" {
+				t.Errorf("\n%s\nc.Get(...): -want distinct expiries, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestInvalidate(t *testing.T) {
+	cool := auth.Credentials{Impersonate: auth.Impersonation{Username: "cool"}}
+	lame := auth.Credentials{Impersonate: auth.Impersonation{Username: "lame"}}
+
+	type args struct {
+		active     []auth.Credentials
+		invalidate auth.Credentials
+	}
+
+	type want struct {
+		active int
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NoActiveClient": {
+			reason: "Invalidating credentials without an active client should be a no-op.",
+			args: args{
+				invalidate: cool,
+			},
+			want: want{
+				active: 0,
+			},
+		},
+		"ActiveClient": {
+			reason: "Invalidating credentials with an active client should remove that client.",
+			args: args{
+				active:     []auth.Credentials{cool},
+				invalidate: cool,
+			},
+			want: want{
+				active: 0,
+			},
+		},
+		"OtherActiveClient": {
+			reason: "Invalidating credentials should not remove clients associated with other credentials.",
+			args: args{
+				active:     []auth.Credentials{cool, lame},
+				invalidate: cool,
+			},
+			want: want{
+				active: 1,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			c := NewCache(runtime.NewScheme(), &rest.Config{},
+				WithContext(ctx),
+				WithNewClientFn(NewClientFn(func(cfg *rest.Config, o client.Options) (client.Client, error) {
+					return test.NewMockClient(), nil
+				})),
+				WithNewCacheFn(NewCacheFn(func(cfg *rest.Config, o cache.Options) (cache.Cache, error) {
+					ca := &MockCache{
+						MockStart: func(stop context.Context) error {
+							<-stop.Done()
+							return nil
+						},
+						MockWaitForCacheSync: func(ctx context.Context) bool { return true },
+					}
+					return ca, nil
+				})),
+			)
+
+			for _, cr := range tc.args.active {
+				if _, err := c.Get(cr); err != nil {
+					t.Fatalf("\n%s\nc.Get(...): %s", tc.reason, err)
+				}
+			}
+
+			c.Invalidate(tc.args.invalidate)
+
+			c.mx.RLock()
+			active := len(c.active)
+			c.mx.RUnlock()
+			if diff := cmp.Diff(tc.want.active, active); diff != "" {
+				t.Errorf("\n%s\nc.Invalidate(...): -want active clients, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+type MockExpiration struct {
+	reset []time.Duration
+}
+
+func (e *MockExpiration) Reset(d time.Duration) { e.reset = append(e.reset, d) }
+func (e *MockExpiration) Stop()                 {}
+func (e *MockExpiration) C() <-chan time.Time   { return nil }
+
+func TestSessionTouch(t *testing.T) {
+	created := time.Now().Add(-1 * time.Hour)
+	e := &MockExpiration{}
+	sn := newSession(test.NewMockClient(), func() {}, e, created)
+
+	if diff := cmp.Diff(created.UnixNano(), sn.lastUsedAt().UnixNano()); diff != "" {
+		t.Errorf("sn.lastUsedAt(): -want last used at creation time, +got:\n%s", diff)
+	}
+
+	before := time.Now()
+	sn.touch(5 * time.Minute)
+
+	if diff := cmp.Diff([]time.Duration{5 * time.Minute}, e.reset); diff != "" {
+		t.Errorf("sn.touch(...): -want expiration resets, +got:\n%s", diff)
+	}
+	if lu := sn.lastUsedAt(); lu.Before(before) {
+		t.Errorf("sn.touch(...): want last used at or after %s, got %s", before, lu)
+	}
+	if diff := cmp.Diff(created, sn.created); diff != "" {
+		t.Errorf("sn.touch(...): -want unchanged creation time, +got:\n%s", diff)
+	}
+}
+
 func WithContext(ctx context.Context) CacheOption {
 	return func(c *Cache) {
 		c.ctx = ctx
 	}
 }
+
+func TestWithCAFile(t *testing.T) {
+	dir := t.TempDir()
+
+	ca, _, err := certutil.GenerateSelfSignedCertKey("example.org", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	valid := filepath.Join(dir, "valid.crt")
+	if err := os.WriteFile(valid, ca, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	invalid := filepath.Join(dir, "invalid.crt")
+	if err := os.WriteFile(invalid, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	missing := filepath.Join(dir, "missing.crt")
+
+	_, errRead := os.ReadFile(missing)
+	_, errParse := certutil.ParseCertsPEM([]byte("not a certificate"))
+
+	type want struct {
+		cfg *rest.Config
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		path   string
+		want   want
+	}{
+		"Valid": {
+			reason: "A valid CA bundle should be set on the REST config.",
+			path:   valid,
+			want: want{
+				cfg: &rest.Config{TLSClientConfig: rest.TLSClientConfig{CAFile: valid, CAData: ca}},
+			},
+		},
+		"Missing": {
+			reason: "We should return an error if the CA bundle can't be read.",
+			path:   missing,
+			want: want{
+				cfg: &rest.Config{},
+				err: errors.Wrap(errRead, errReadCAFile),
+			},
+		},
+		"Invalid": {
+			reason: "We should return an error if the CA bundle can't be parsed.",
+			path:   invalid,
+			want: want{
+				cfg: &rest.Config{},
+				err: errors.Wrap(errParse, errParseCAFile),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cfg := &rest.Config{}
+			err := WithCAFile(tc.path)(cfg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nWithCAFile(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cfg, cfg); diff != "" {
+				t.Errorf("\n%s\nWithCAFile(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			// The CA must survive anonymization, which all clients use.
+			if diff := cmp.Diff(tc.want.cfg.TLSClientConfig, Anonymize(cfg).TLSClientConfig); diff != "" {
+				t.Errorf("\n%s\nAnonymize(...): -want TLS config, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestWithInsecureSkipTLSVerify(t *testing.T) {
+	cfg := &rest.Config{TLSClientConfig: rest.TLSClientConfig{CAFile: "ca.crt", CAData: []byte("ca"), ServerName: "example.org"}}
+	if err := WithInsecureSkipTLSVerify()(cfg); err != nil {
+		t.Fatalf("WithInsecureSkipTLSVerify(...): %s", err)
+	}
+	want := &rest.Config{TLSClientConfig: rest.TLSClientConfig{Insecure: true, ServerName: "example.org"}}
+	if diff := cmp.Diff(want, cfg); diff != "" {
+		t.Errorf("\nWithInsecureSkipTLSVerify(...): -want, +got:\n%s", diff)
+	}
+	if _, err := rest.TransportFor(cfg); err != nil {
+		t.Errorf("\nrest.TransportFor(...): the insecure config should be usable: %s", err)
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// SessionInfo describes an active client session. It never includes the
+// credentials used by the session's client, only hashes of them.
+type SessionInfo struct {
+	// ID identifies the session. It's the salted hash of the impersonation
+	// config the Cache uses to key sessions, so every caller impersonating the
+	// same user and groups shares a session.
+	ID string `json:"id"`
+
+	// TokenHash is a SHA-256 hash of the bearer token the session was created
+	// with, as logged when a caller reveals secret values or removes a
+	// finalizer. It's empty if the session was created without a bearer token.
+	TokenHash string `json:"tokenHash,omitempty"`
+
+	// Created is when the session was created.
+	Created time.Time `json:"created"`
+
+	// LastUsed is when the session's client was last returned by Get.
+	LastUsed time.Time `json:"lastUsed"`
+
+	// Expires is when the session will expire unless it is used again.
+	Expires time.Time `json:"expires"`
+
+	// Synced is true if the session's cache has synced.
+	Synced bool `json:"synced"`
+}
+
+// Sessions returns information about all active client sessions, ordered by
+// creation time.
+func (c *Cache) Sessions() []SessionInfo {
+	c.mx.RLock()
+	out := make([]SessionInfo, 0, len(c.active))
+	for id, sn := range c.active {
+		lu := sn.lastUsedAt()
+		out = append(out, SessionInfo{
+			ID:        id,
+			TokenHash: sn.tokenHash,
+			Created:   sn.created,
+			LastUsed:  lu,
+			Expires:   lu.Add(sn.expiry),
+			Synced:    sn.synced.Load(),
+		})
+	}
+	c.mx.RUnlock()
+
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Created.Before(out[j].Created) })
+	return out
+}
+
+// SessionsHandler returns the Cache's active client sessions as JSON.
+func SessionsHandler(c *Cache) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(c.Sessions())
+	})
+}
@@ -0,0 +1,89 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/upbound/xgql/internal/auth"
+)
+
+func TestSessions(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c := NewCache(runtime.NewScheme(), &rest.Config{},
+		WithContext(ctx),
+		WithExpiry(1*time.Hour),
+		WithExpiryJitter(0),
+		WithNewClientFn(NewClientFn(func(cfg *rest.Config, o client.Options) (client.Client, error) {
+			return test.NewMockClient(), nil
+		})),
+		WithNewCacheFn(NewCacheFn(func(cfg *rest.Config, o cache.Options) (cache.Cache, error) {
+			ca := &MockCache{
+				MockStart: func(stop context.Context) error {
+					<-stop.Done()
+					return nil
+				},
+				MockWaitForCacheSync: func(ctx context.Context) bool { return true },
+			}
+			return ca, nil
+		})),
+	)
+
+	cr := auth.Credentials{BearerToken: "supersecret", Impersonate: auth.Impersonation{Username: "cool"}}
+	if _, err := c.Get(cr); err != nil {
+		t.Fatalf("c.Get(...): %s", err)
+	}
+
+	got := c.Sessions()
+	if diff := cmp.Diff(1, len(got)); diff != "" {
+		t.Fatalf("c.Sessions(): -want sessions, +got:\n%s", diff)
+	}
+
+	s := got[0]
+	if diff := cmp.Diff(c.id(cr), s.ID); diff != "" {
+		t.Errorf("c.Sessions(): -want ID, +got:\n%s", diff)
+	}
+	if strings.Contains(s.ID, cr.BearerToken) {
+		t.Errorf("c.Sessions(): ID %q must not contain the bearer token", s.ID)
+	}
+	if diff := cmp.Diff(cr.TokenHash(), s.TokenHash); diff != "" {
+		t.Errorf("c.Sessions(): -want token hash, +got:\n%s", diff)
+	}
+	if strings.Contains(s.TokenHash, cr.BearerToken) {
+		t.Errorf("c.Sessions(): token hash %q must not contain the bearer token", s.TokenHash)
+	}
+	if !s.Synced {
+		t.Errorf("c.Sessions(): want synced session")
+	}
+	if s.Created.IsZero() || s.LastUsed.Before(s.Created) {
+		t.Errorf("c.Sessions(): want created %s at or before last used %s", s.Created, s.LastUsed)
+	}
+	if diff := cmp.Diff(s.LastUsed.Add(1*time.Hour), s.Expires); diff != "" {
+		t.Errorf("c.Sessions(): -want expires, +got:\n%s", diff)
+	}
+}
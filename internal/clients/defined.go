@@ -0,0 +1,222 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	extv1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+
+	"github.com/upbound/xgql/internal/auth"
+)
+
+const (
+	errListXRDs       = "cannot list composite resource definitions"
+	errWatchXRDs      = "cannot watch composite resource definitions"
+	errGetXRDInformer = "cannot get composite resource definition informer"
+)
+
+// The default duration for which the kinds defined by XRDs are cached.
+const defaultDefinedKindsRefresh = 30 * time.Second
+
+// A DefinedKind is a kind of composite resource or claim defined by an XRD.
+type DefinedKind struct {
+	schema.GroupVersionKind
+
+	// ListKind is the kind of a list of this kind.
+	ListKind string
+}
+
+// ListGroupVersionKind returns the GroupVersionKind of a list of this kind.
+func (k DefinedKind) ListGroupVersionKind() schema.GroupVersionKind {
+	return k.GroupVersion().WithKind(k.ListKind)
+}
+
+// DefinedKinds are the kinds of composite resource and claim defined by XRDs.
+type DefinedKinds struct {
+	CompositeResources []DefinedKind
+	Claims             []DefinedKind
+}
+
+// GetDefinedKinds returns the kinds defined by the supplied XRDs, at their
+// referenceable version. XRDs that don't offer a claim define only a composite
+// resource kind.
+func GetDefinedKinds(xrds []extv1.CompositeResourceDefinition) DefinedKinds {
+	out := DefinedKinds{
+		CompositeResources: make([]DefinedKind, 0, len(xrds)),
+		Claims:             make([]DefinedKind, 0, len(xrds)),
+	}
+
+	for i := range xrds {
+		spec := xrds[i].Spec
+		gv := schema.GroupVersion{Group: spec.Group, Version: pickVersion(spec.Versions)}
+
+		out.CompositeResources = append(out.CompositeResources, getDefinedKind(gv, spec.Names.Kind, spec.Names.ListKind))
+		if spec.ClaimNames != nil {
+			out.Claims = append(out.Claims, getDefinedKind(gv, spec.ClaimNames.Kind, spec.ClaimNames.ListKind))
+		}
+	}
+
+	return out
+}
+
+func getDefinedKind(gv schema.GroupVersion, kind, listKind string) DefinedKind {
+	// We infer the list kind when it's not set, like the API server does.
+	if listKind == "" {
+		listKind = kind + "List"
+	}
+	return DefinedKind{GroupVersionKind: gv.WithKind(kind), ListKind: listKind}
+}
+
+// pickVersion picks the referenceable version of an XRD. Exactly one version
+// should be referenceable, but nothing enforces that, so we fall back to the
+// first served version.
+func pickVersion(vs []extv1.CompositeResourceDefinitionVersion) string {
+	for _, v := range vs {
+		if v.Referenceable {
+			return v.Name
+		}
+	}
+	for _, v := range vs {
+		if v.Served {
+			return v.Name
+		}
+	}
+	return ""
+}
+
+type defined struct {
+	kinds   DefinedKinds
+	expires time.Time
+
+	// generation is the generation of XRDs the kinds were derived from. See
+	// definitionsHandler.
+	generation uint64
+}
+
+// DefinedKinds returns the kinds of composite resource and claim defined by the
+// XRDs that can be listed using the supplied credentials. Results are cached
+// per credentials, so that dashboards that poll for every claim or composite
+// resource don't list XRDs on every request. Cached results are discarded
+// after the refresh interval, or as soon as an XRD is created, updated, or
+// deleted, whichever happens first.
+func (c *Cache) DefinedKinds(ctx context.Context, cr auth.Credentials) (DefinedKinds, error) {
+	id := c.id(cr)
+	now := time.Now()
+
+	c.kmx.Lock()
+	d, ok := c.defined[id]
+	c.kmx.Unlock()
+	if ok && now.Before(d.expires) && d.generation == c.xrdGeneration.Load() {
+		return d.kinds, nil
+	}
+
+	cl, err := c.GetWithContext(ctx, cr)
+	if err != nil {
+		return DefinedKinds{}, err
+	}
+
+	// Cached results are still discarded after the refresh interval if we
+	// can't watch XRDs, for example because the client is uncached.
+	if err := c.watchDefinitions(ctx, id); err != nil {
+		c.log.Debug("Cannot watch composite resource definitions", "client-id", id, "error", err)
+	}
+
+	// We must load the generation before we list XRDs, so that we don't
+	// cache kinds derived from XRDs that changed while we listed them.
+	gen := c.xrdGeneration.Load()
+
+	in := &extv1.CompositeResourceDefinitionList{}
+	if err := cl.List(ctx, in); err != nil {
+		return DefinedKinds{}, errors.Wrap(err, errListXRDs)
+	}
+	kinds := GetDefinedKinds(in.Items)
+
+	c.kmx.Lock()
+	defer c.kmx.Unlock()
+	for k, d := range c.defined {
+		if now.After(d.expires) {
+			delete(c.defined, k)
+		}
+	}
+	c.defined[id] = defined{kinds: kinds, expires: now.Add(c.definedRefresh), generation: gen}
+	return kinds, nil
+}
+
+// watchDefinitions watches XRDs using the cache of the session with the
+// supplied ID, if it isn't already.
+func (c *Cache) watchDefinitions(ctx context.Context, id string) error {
+	c.mx.RLock()
+	sn, ok := c.active[id]
+	c.mx.RUnlock()
+	if !ok || sn.cache == nil || sn.readsLive() {
+		return nil
+	}
+	if !sn.watchingXRDs.CompareAndSwap(false, true) {
+		return nil
+	}
+
+	i, err := sn.cache.GetInformer(ctx, &extv1.CompositeResourceDefinition{})
+	if err != nil {
+		sn.watchingXRDs.Store(false)
+		return errors.Wrap(err, errGetXRDInformer)
+	}
+	if _, err := i.AddEventHandler(&definitionsHandler{cache: c}); err != nil {
+		sn.watchingXRDs.Store(false)
+		return errors.Wrap(err, errWatchXRDs)
+	}
+	return nil
+}
+
+// A definitionsHandler increments the Cache's XRD generation whenever an XRD is
+// created, updated, or deleted, discarding the defined kinds cached for all
+// credentials. XRDs are cluster scoped, so a change observed using any
+// credentials may affect the kinds defined for all credentials.
+type definitionsHandler struct {
+	cache *Cache
+}
+
+var _ toolscache.ResourceEventHandler = &definitionsHandler{}
+
+// OnAdd is called when an XRD is created, or observed during the initial list.
+func (h *definitionsHandler) OnAdd(_ interface{}, isInInitialList bool) {
+	// XRDs in the initial list existed before we started watching.
+	if isInInitialList {
+		return
+	}
+	h.cache.xrdGeneration.Add(1)
+}
+
+// OnUpdate is called when an XRD is updated, or resynced.
+func (h *definitionsHandler) OnUpdate(oldObj, newObj interface{}) {
+	// Periodic resyncs deliver updates for XRDs that haven't changed.
+	o, oldOK := oldObj.(client.Object)
+	n, newOK := newObj.(client.Object)
+	if oldOK && newOK && o.GetResourceVersion() == n.GetResourceVersion() {
+		return
+	}
+	h.cache.xrdGeneration.Add(1)
+}
+
+// OnDelete is called when an XRD is deleted.
+func (h *definitionsHandler) OnDelete(_ interface{}) {
+	h.cache.xrdGeneration.Add(1)
+}
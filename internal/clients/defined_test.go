@@ -0,0 +1,234 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	kextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	extv1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+
+	"github.com/upbound/xgql/internal/auth"
+)
+
+type MockInformer struct {
+	cache.Informer
+
+	MockAddEventHandler func(handler toolscache.ResourceEventHandler) (toolscache.ResourceEventHandlerRegistration, error)
+}
+
+func (i *MockInformer) AddEventHandler(handler toolscache.ResourceEventHandler) (toolscache.ResourceEventHandlerRegistration, error) {
+	return i.MockAddEventHandler(handler)
+}
+
+func TestGetDefinedKinds(t *testing.T) {
+	xrds := []extv1.CompositeResourceDefinition{
+		{Spec: extv1.CompositeResourceDefinitionSpec{
+			Group:      "example.org",
+			Names:      kextv1.CustomResourceDefinitionNames{Kind: "XAlpha"},
+			ClaimNames: &kextv1.CustomResourceDefinitionNames{Kind: "Alpha", ListKind: "Alphas"},
+			Versions: []extv1.CompositeResourceDefinitionVersion{
+				{Name: "v1", Served: true},
+				{Name: "v2", Served: true, Referenceable: true},
+			},
+		}},
+		{Spec: extv1.CompositeResourceDefinitionSpec{
+			Group:    "example.org",
+			Names:    kextv1.CustomResourceDefinitionNames{Kind: "XBeta"},
+			Versions: []extv1.CompositeResourceDefinitionVersion{{Name: "v1", Served: true}},
+		}},
+	}
+
+	want := DefinedKinds{
+		CompositeResources: []DefinedKind{
+			{GroupVersionKind: schema.GroupVersionKind{Group: "example.org", Version: "v2", Kind: "XAlpha"}, ListKind: "XAlphaList"},
+			{GroupVersionKind: schema.GroupVersionKind{Group: "example.org", Version: "v1", Kind: "XBeta"}, ListKind: "XBetaList"},
+		},
+		Claims: []DefinedKind{
+			{GroupVersionKind: schema.GroupVersionKind{Group: "example.org", Version: "v2", Kind: "Alpha"}, ListKind: "Alphas"},
+		},
+	}
+
+	got := GetDefinedKinds(xrds)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("\nGetDefinedKinds(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestDefinedKinds(t *testing.T) {
+	newXRD := func(kind, rv string) extv1.CompositeResourceDefinition {
+		return extv1.CompositeResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{ResourceVersion: rv},
+			Spec: extv1.CompositeResourceDefinitionSpec{
+				Group:    "example.org",
+				Names:    kextv1.CustomResourceDefinitionNames{Kind: kind},
+				Versions: []extv1.CompositeResourceDefinitionVersion{{Name: "v1", Served: true, Referenceable: true}},
+			},
+		}
+	}
+	alpha, beta := newXRD("XAlpha", "1"), newXRD("XBeta", "1")
+	alphaKind := DefinedKind{GroupVersionKind: schema.GroupVersionKind{Group: "example.org", Version: "v1", Kind: "XAlpha"}, ListKind: "XAlphaList"}
+	betaKind := DefinedKind{GroupVersionKind: schema.GroupVersionKind{Group: "example.org", Version: "v1", Kind: "XBeta"}, ListKind: "XBetaList"}
+
+	type want struct {
+		kinds DefinedKinds
+		lists int
+	}
+
+	// Each case gets defined kinds, adds the beta XRD, delivers an event to
+	// the XRD event handler, then gets defined kinds again.
+	cases := map[string]struct {
+		reason string
+		copts  []CacheOption
+		event  func(h toolscache.ResourceEventHandler)
+		want   want
+	}{
+		"Cached": {
+			reason: "Defined kinds should be derived from XRDs once within the refresh interval if no XRD events are observed.",
+			want: want{
+				kinds: DefinedKinds{CompositeResources: []DefinedKind{alphaKind}, Claims: []DefinedKind{}},
+				lists: 1,
+			},
+		},
+		"Expired": {
+			reason: "Defined kinds should be derived from XRDs again once the refresh interval has passed.",
+			copts:  []CacheOption{WithDefinedKindsRefresh(-time.Second)},
+			want: want{
+				kinds: DefinedKinds{CompositeResources: []DefinedKind{alphaKind, betaKind}, Claims: []DefinedKind{}},
+				lists: 2,
+			},
+		},
+		"XRDAdded": {
+			reason: "Defined kinds should be derived from XRDs again once an XRD is added.",
+			event:  func(h toolscache.ResourceEventHandler) { h.OnAdd(&beta, false) },
+			want: want{
+				kinds: DefinedKinds{CompositeResources: []DefinedKind{alphaKind, betaKind}, Claims: []DefinedKind{}},
+				lists: 2,
+			},
+		},
+		"XRDInInitialList": {
+			reason: "XRDs observed in the initial list of the XRD watch should not invalidate defined kinds.",
+			event:  func(h toolscache.ResourceEventHandler) { h.OnAdd(&beta, true) },
+			want: want{
+				kinds: DefinedKinds{CompositeResources: []DefinedKind{alphaKind}, Claims: []DefinedKind{}},
+				lists: 1,
+			},
+		},
+		"XRDUpdated": {
+			reason: "Defined kinds should be derived from XRDs again once an XRD is updated.",
+			event: func(h toolscache.ResourceEventHandler) {
+				updated := alpha.DeepCopy()
+				updated.SetResourceVersion("2")
+				h.OnUpdate(&alpha, updated)
+			},
+			want: want{
+				kinds: DefinedKinds{CompositeResources: []DefinedKind{alphaKind, betaKind}, Claims: []DefinedKind{}},
+				lists: 2,
+			},
+		},
+		"XRDResynced": {
+			reason: "Resyncs of XRDs that haven't changed should not invalidate defined kinds.",
+			event:  func(h toolscache.ResourceEventHandler) { h.OnUpdate(&alpha, alpha.DeepCopy()) },
+			want: want{
+				kinds: DefinedKinds{CompositeResources: []DefinedKind{alphaKind}, Claims: []DefinedKind{}},
+				lists: 1,
+			},
+		},
+		"XRDDeleted": {
+			reason: "Defined kinds should be derived from XRDs again once an XRD is deleted.",
+			event:  func(h toolscache.ResourceEventHandler) { h.OnDelete(&alpha) },
+			want: want{
+				kinds: DefinedKinds{CompositeResources: []DefinedKind{alphaKind, betaKind}, Claims: []DefinedKind{}},
+				lists: 2,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			xrds := []extv1.CompositeResourceDefinition{alpha}
+			lists := 0
+			var handler toolscache.ResourceEventHandler
+
+			copts := append(tc.copts,
+				WithContext(ctx),
+				WithNewClientFn(NewClientFn(func(cfg *rest.Config, o client.Options) (client.Client, error) {
+					return &test.MockClient{
+						MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+							lists++
+							obj.(*extv1.CompositeResourceDefinitionList).Items = xrds
+							return nil
+						}),
+					}, nil
+				})),
+				WithNewCacheFn(NewCacheFn(func(cfg *rest.Config, o cache.Options) (cache.Cache, error) {
+					return &MockCache{
+						MockStart: func(stop context.Context) error {
+							<-stop.Done()
+							return nil
+						},
+						MockWaitForCacheSync: func(ctx context.Context) bool { return true },
+						MockGetInformer: func(_ context.Context, _ client.Object, _ ...cache.InformerGetOption) (cache.Informer, error) {
+							return &MockInformer{MockAddEventHandler: func(h toolscache.ResourceEventHandler) (toolscache.ResourceEventHandlerRegistration, error) {
+								handler = h
+								return nil, nil
+							}}, nil
+						},
+					}, nil
+				})),
+			)
+			c := NewCache(runtime.NewScheme(), &rest.Config{}, copts...)
+			cr := auth.Credentials{BearerToken: "cool-token"}
+
+			if _, err := c.DefinedKinds(ctx, cr); err != nil {
+				t.Fatalf("\n%s\nc.DefinedKinds(...): %s", tc.reason, err)
+			}
+
+			xrds = []extv1.CompositeResourceDefinition{alpha, beta}
+			if tc.event != nil {
+				if handler == nil {
+					t.Fatalf("\n%s\nc.DefinedKinds(...): did not watch XRDs", tc.reason)
+				}
+				tc.event(handler)
+			}
+
+			got, err := c.DefinedKinds(ctx, cr)
+			if err != nil {
+				t.Fatalf("\n%s\nc.DefinedKinds(...): %s", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.kinds, got); diff != "" {
+				t.Errorf("\n%s\nc.DefinedKinds(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.lists, lists); diff != "" {
+				t.Errorf("\n%s\nc.DefinedKinds(...): -want XRD lists, +got XRD lists:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
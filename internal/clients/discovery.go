@@ -0,0 +1,102 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import (
+	"context"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+
+	"github.com/upbound/xgql/internal/auth"
+)
+
+const (
+	errNewDiscoveryClient = "cannot create discovery client"
+	errDiscoverResources  = "cannot discover API resources"
+)
+
+// The default duration for which discovered API resources are cached.
+const defaultDiscoveryTTL = 30 * time.Second
+
+// The ID under which discovered API resources are cached when discovery is
+// shared. Client IDs are hashes, so they're never empty.
+const sharedDiscoveryID = ""
+
+// A NewDiscoveryClientFn creates a new discovery client.
+type NewDiscoveryClientFn func(cfg *rest.Config) (discovery.ServerResourcesInterface, error)
+
+// DefaultNewDiscoveryClientFn creates a new discovery client.
+func DefaultNewDiscoveryClientFn(cfg *rest.Config) (discovery.ServerResourcesInterface, error) {
+	return discovery.NewDiscoveryClientForConfig(cfg)
+}
+
+type discovered struct {
+	resources []*metav1.APIResourceList
+	expires   time.Time
+}
+
+// ServerPreferredResources returns the preferred version of each API resource
+// served by the API server, discovered using the supplied credentials. Results
+// are cached per credentials, or for all credentials if discovery is shared,
+// for a short time, so that callers such as a UI building a resource picker
+// don't rediscover resources on every request. If some API groups can't be
+// discovered, for example because an aggregated API server is unavailable, the
+// resources that could be discovered are returned.
+func (c *Cache) ServerPreferredResources(ctx context.Context, cr auth.Credentials) ([]*metav1.APIResourceList, error) {
+	id := c.id(cr)
+	key := id
+	if c.shareDiscovery {
+		key = sharedDiscoveryID
+	}
+	now := time.Now()
+
+	c.dmx.Lock()
+	d, ok := c.discovered[key]
+	c.dmx.Unlock()
+	if ok && now.Before(d.expires) {
+		return d.resources, nil
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, errors.Wrap(err, errRequestDone)
+	}
+
+	dc, err := c.newDiscovery(cr.Inject(c.cfg))
+	if err != nil {
+		return nil, errors.Wrap(err, errNewDiscoveryClient)
+	}
+	rs, err := dc.ServerPreferredResources()
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+		return nil, errors.Wrap(err, errDiscoverResources)
+	}
+	if err != nil {
+		c.log.Debug("Cannot discover some API groups", "client-id", id, "error", err)
+	}
+
+	c.dmx.Lock()
+	defer c.dmx.Unlock()
+	for k, d := range c.discovered {
+		if now.After(d.expires) {
+			delete(c.discovered, k)
+		}
+	}
+	c.discovered[key] = discovered{resources: rs, expires: now.Add(c.discoveryTTL)}
+	return rs, nil
+}
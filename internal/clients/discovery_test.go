@@ -0,0 +1,155 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/upbound/xgql/internal/auth"
+)
+
+func WithNewDiscoveryClientFn(fn NewDiscoveryClientFn) CacheOption {
+	return func(c *Cache) {
+		c.newDiscovery = fn
+	}
+}
+
+type MockDiscoveryClient struct {
+	discovery.ServerResourcesInterface
+
+	MockServerPreferredResources func() ([]*metav1.APIResourceList, error)
+}
+
+func (m *MockDiscoveryClient) ServerPreferredResources() ([]*metav1.APIResourceList, error) {
+	return m.MockServerPreferredResources()
+}
+
+func TestServerPreferredResources(t *testing.T) {
+	errBoom := errors.New("boom")
+	pods := []*metav1.APIResourceList{{GroupVersion: "v1", APIResources: []metav1.APIResource{{Name: "pods", Kind: "Pod"}}}}
+
+	type want struct {
+		resources   []*metav1.APIResourceList
+		err         error
+		discoveries int
+	}
+
+	cases := map[string]struct {
+		reason string
+		copts  []CacheOption
+		calls  int
+		// users makes each call using different credentials.
+		users  bool
+		result func() ([]*metav1.APIResourceList, error)
+		want   want
+	}{
+		"Success": {
+			reason: "Discovered resources should be returned.",
+			calls:  1,
+			result: func() ([]*metav1.APIResourceList, error) { return pods, nil },
+			want:   want{resources: pods, discoveries: 1},
+		},
+		"Cached": {
+			reason: "Resources should be discovered once per credentials within the discovery TTL.",
+			calls:  3,
+			result: func() ([]*metav1.APIResourceList, error) { return pods, nil },
+			want:   want{resources: pods, discoveries: 1},
+		},
+		"Expired": {
+			reason: "Resources should be rediscovered once the discovery TTL has passed.",
+			copts:  []CacheOption{WithDiscoveryTTL(-time.Second)},
+			calls:  2,
+			result: func() ([]*metav1.APIResourceList, error) { return pods, nil },
+			want:   want{resources: pods, discoveries: 2},
+		},
+		"PartialDiscovery": {
+			reason: "Resources should be returned if only some API groups can't be discovered.",
+			calls:  1,
+			result: func() ([]*metav1.APIResourceList, error) {
+				return pods, &discovery.ErrGroupDiscoveryFailed{Groups: map[schema.GroupVersion]error{{Group: "example.org", Version: "v1"}: errBoom}}
+			},
+			want: want{resources: pods, discoveries: 1},
+		},
+		"PerCredentials": {
+			reason: "Resources should be discovered once for each set of credentials if discovery isn't shared.",
+			calls:  3,
+			users:  true,
+			result: func() ([]*metav1.APIResourceList, error) { return pods, nil },
+			want:   want{resources: pods, discoveries: 3},
+		},
+		"Shared": {
+			reason: "Resources should be discovered once for all credentials within the discovery TTL if discovery is shared.",
+			copts:  []CacheOption{ShareDiscovery()},
+			calls:  3,
+			users:  true,
+			result: func() ([]*metav1.APIResourceList, error) { return pods, nil },
+			want:   want{resources: pods, discoveries: 1},
+		},
+		"DiscoveryError": {
+			reason: "Errors discovering resources should be returned, and not cached.",
+			calls:  2,
+			result: func() ([]*metav1.APIResourceList, error) { return nil, errBoom },
+			want:   want{err: errors.Wrap(errBoom, errDiscoverResources), discoveries: 2},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			discoveries := 0
+			copts := append(tc.copts, WithNewDiscoveryClientFn(NewDiscoveryClientFn(func(_ *rest.Config) (discovery.ServerResourcesInterface, error) {
+				return &MockDiscoveryClient{MockServerPreferredResources: func() ([]*metav1.APIResourceList, error) {
+					discoveries++
+					return tc.result()
+				}}, nil
+			})))
+			c := NewCache(runtime.NewScheme(), &rest.Config{}, copts...)
+
+			var (
+				got []*metav1.APIResourceList
+				err error
+			)
+			for i := 0; i < tc.calls; i++ {
+				cr := auth.Credentials{BearerToken: "cool-token"}
+				if tc.users {
+					cr.Impersonate.Username = fmt.Sprintf("cool-user-%d", i)
+				}
+				got, err = c.ServerPreferredResources(context.Background(), cr)
+			}
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nc.ServerPreferredResources(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.resources, got); diff != "" {
+				t.Errorf("\n%s\nc.ServerPreferredResources(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.discoveries, discoveries); diff != "" {
+				t.Errorf("\n%s\nc.ServerPreferredResources(...): -want discoveries, +got discoveries:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+)
+
+const errExchangeToken = "cannot exchange bearer token"
+
+// A TokenExchanger exchanges a caller's bearer token for the token used to
+// authenticate to the API server.
+type TokenExchanger interface {
+	Exchange(ctx context.Context, token string) (string, error)
+}
+
+// A tokenExchanger is an HTTP transport that authenticates each request using
+// the token a caller's bearer token was exchanged for. Exchanged tokens expire,
+// typically well before the client that uses them, so we exchange the caller's
+// token for every request. The TokenExchanger is expected to cache exchanged
+// tokens until they're about to expire.
+type tokenExchanger struct {
+	transport http.RoundTripper
+	exchanger TokenExchanger
+	token     string
+}
+
+// RoundTrip the supplied request, authenticating it using an exchanged token.
+func (e *tokenExchanger) RoundTrip(req *http.Request) (*http.Response, error) {
+	t, err := e.exchanger.Exchange(req.Context(), e.token)
+	if err != nil {
+		return nil, errors.Wrap(err, errExchangeToken)
+	}
+
+	// A RoundTripper mustn't modify the request it's passed.
+	r := req.Clone(req.Context())
+	r.Header.Set("Authorization", "Bearer "+t)
+	return e.transport.RoundTrip(r)
+}
+
+// withTokenExchange returns a copy of the supplied HTTP client that
+// authenticates each request using the token the supplied token is exchanged
+// for.
+func withTokenExchange(hc *http.Client, x TokenExchanger, token string) *http.Client {
+	t := hc.Transport
+	if t == nil {
+		t = http.DefaultTransport
+	}
+	out := *hc
+	out.Transport = &tokenExchanger{transport: t, exchanger: x, token: token}
+	return &out
+}
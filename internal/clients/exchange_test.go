@@ -0,0 +1,85 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+)
+
+type TokenExchangerFn func(ctx context.Context, token string) (string, error)
+
+func (fn TokenExchangerFn) Exchange(ctx context.Context, token string) (string, error) {
+	return fn(ctx, token)
+}
+
+func TestTokenExchanger(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	cases := map[string]struct {
+		reason   string
+		x        TokenExchanger
+		wantAuth string
+		wantErr  bool
+	}{
+		"Exchanged": {
+			reason: "We should authenticate requests using the exchanged token.",
+			x: TokenExchangerFn(func(_ context.Context, token string) (string, error) {
+				return "exchanged-" + token, nil
+			}),
+			wantAuth: "Bearer exchanged-cool",
+		},
+		"ExchangeError": {
+			reason: "We should not send requests if we can't exchange the token.",
+			x: TokenExchangerFn(func(_ context.Context, _ string) (string, error) {
+				return "", errBoom
+			}),
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ""
+			srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				got = r.Header.Get("Authorization")
+			}))
+			defer srv.Close()
+
+			hc := withTokenExchange(srv.Client(), tc.x, "cool")
+			req, _ := http.NewRequest(http.MethodGet, srv.URL+"/api/v1/namespaces", nil)
+			rsp, err := hc.Do(req)
+			if rsp != nil {
+				_ = rsp.Body.Close()
+			}
+
+			if diff := cmp.Diff(tc.wantErr, err != nil); diff != "" {
+				t.Errorf("\n%s\ntokenExchanger.RoundTrip(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.wantAuth, got); diff != "" {
+				t.Errorf("\n%s\ntokenExchanger.RoundTrip(...): -want authorization, +got authorization:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff("", req.Header.Get("Authorization")); diff != "" {
+				t.Errorf("\n%s\ntokenExchanger.RoundTrip(...): -want unmodified request, +got request:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
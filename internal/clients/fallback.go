@@ -0,0 +1,94 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+)
+
+// A fallbackClient reads from its cache until the cache fails to sync, then
+// reads directly from the API server for the rest of its life.
+//
+// A cache syncs by listing and watching the resources it reads, so a caller
+// who may get a resource but not list or watch it can't read it from a cache.
+// Reads wait for the cache to sync, so in that case they'd wait until they
+// time out. A fallbackClient only waits up to its timeout for a cached read.
+// If the read doesn't complete in time, it stops the cache and retries the
+// read directly from the API server, where the caller's permission to get the
+// resource is sufficient.
+type fallbackClient struct {
+	// Client reads from the cache, and writes to the API server.
+	client.Client
+
+	// live reads from the API server.
+	live client.Client
+
+	timeout   time.Duration
+	stopCache context.CancelFunc
+	log       logging.Logger
+
+	// fellBack is true once the client reads from the API server.
+	fellBack atomic.Bool
+}
+
+// Get the object with the supplied key.
+func (c *fallbackClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	return c.read(ctx,
+		func(ctx context.Context) error { return c.Client.Get(ctx, key, obj, opts...) },
+		func(ctx context.Context) error { return c.live.Get(ctx, key, obj, opts...) },
+	)
+}
+
+// List objects.
+func (c *fallbackClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return c.read(ctx,
+		func(ctx context.Context) error { return c.Client.List(ctx, list, opts...) },
+		func(ctx context.Context) error { return c.live.List(ctx, list, opts...) },
+	)
+}
+
+func (c *fallbackClient) read(ctx context.Context, cached, live func(ctx context.Context) error) error {
+	if c.fellBack.Load() {
+		return live(ctx)
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	err := cached(tctx)
+
+	// The read failed because our timeout expired, not the caller's. We
+	// assume that's because the cache couldn't sync.
+	if err == nil || tctx.Err() == nil || ctx.Err() != nil {
+		return err
+	}
+	c.fallBack()
+	return live(ctx)
+}
+
+// fallBack stops the client's cache, and reads directly from the API server
+// from now on.
+func (c *fallbackClient) fallBack() {
+	if !c.fellBack.CompareAndSwap(false, true) {
+		return
+	}
+	c.log.Info("Client cache did not sync in time. Reading directly from the API server instead.", "timeout", c.timeout)
+	c.stopCache()
+}
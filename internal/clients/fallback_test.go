@@ -0,0 +1,140 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestFallbackClientGet(t *testing.T) {
+	gr := schema.GroupResource{Group: "example.org", Resource: "examples"}
+	errForbidden := kerrors.NewForbidden(gr, "", errors.New("cannot list"))
+
+	// A cache can't sync if the caller may not list what it watches. Reads
+	// from an unsynced cache wait until they time out.
+	unsynced := &test.MockClient{
+		MockGet: func(ctx context.Context, _ client.ObjectKey, _ client.Object) error {
+			<-ctx.Done()
+			return kerrors.NewTimeoutError("failed waiting for Informer to sync", 0)
+		},
+	}
+	synced := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			obj.SetName("cached")
+			return nil
+		}),
+	}
+
+	// The caller may get, but not list.
+	live := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			obj.SetName("live")
+			return nil
+		}),
+		MockList: test.NewMockListFn(errForbidden),
+	}
+
+	type want struct {
+		name     string
+		err      error
+		fellBack bool
+		stopped  bool
+	}
+
+	cases := map[string]struct {
+		reason   string
+		cached   client.Client
+		ctx      func() context.Context
+		fellBack bool
+		want     want
+	}{
+		"CacheSynced": {
+			reason: "We should read from the cache if it's synced.",
+			cached: synced,
+			ctx:    context.Background,
+			want:   want{name: "cached"},
+		},
+		"ListForbiddenGetAllowed": {
+			reason: "We should stop the cache and read from the API server if a cached read times out because the cache can't sync.",
+			cached: unsynced,
+			ctx:    context.Background,
+			want:   want{name: "live", fellBack: true, stopped: true},
+		},
+		"AlreadyFellBack": {
+			reason:   "We should read from the API server without trying the cache if we already fell back.",
+			cached:   &test.MockClient{MockGet: test.NewMockGetFn(errors.New("unexpected cached read"))},
+			ctx:      context.Background,
+			fellBack: true,
+			want:     want{name: "live", fellBack: true},
+		},
+		"CallerTimedOut": {
+			reason: "We should not fall back if the caller's context is done rather than ours.",
+			cached: unsynced,
+			ctx: func() context.Context {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx
+			},
+			want: want{err: kerrors.NewTimeoutError("failed waiting for Informer to sync", 0)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			stopped := false
+			c := &fallbackClient{Client: tc.cached, live: live, timeout: 10 * time.Millisecond, stopCache: func() { stopped = true }, log: logging.NewNopLogger()}
+			c.fellBack.Store(tc.fellBack)
+
+			u := &unstructured.Unstructured{}
+			err := c.Get(tc.ctx(), types.NamespacedName{Name: "example"}, u)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nc.Get(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.name, u.GetName()); diff != "" {
+				t.Errorf("\n%s\nc.Get(...): -want name, +got name:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.fellBack, c.fellBack.Load()); diff != "" {
+				t.Errorf("\n%s\nc.Get(...): -want fell back, +got fell back:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.stopped, stopped); diff != "" {
+				t.Errorf("\n%s\nc.Get(...): -want cache stopped, +got cache stopped:\n%s", tc.reason, diff)
+			}
+		})
+	}
+
+	// Once we've fallen back, lists go to the API server too. The caller may
+	// not list, so they get an error rather than waiting for a cache that
+	// will never sync.
+	c := &fallbackClient{Client: unsynced, live: live, timeout: 10 * time.Millisecond, stopCache: func() {}, log: logging.NewNopLogger()}
+	c.fellBack.Store(true)
+	err := c.List(context.Background(), &unstructured.UnstructuredList{})
+	if diff := cmp.Diff(errForbidden, err, test.EquateErrors()); diff != "" {
+		t.Errorf("c.List(...): -want error, +got error:\n%s", diff)
+	}
+}
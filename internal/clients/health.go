@@ -0,0 +1,113 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+)
+
+// A HealthReport summarizes a health check of the Cache's client caches.
+type HealthReport struct {
+	// Checked is when the health check ran.
+	Checked time.Time
+
+	// Healthy is the number of caches that were still synced.
+	Healthy int
+
+	// Unhealthy is the number of caches that were not synced in time. Their
+	// sessions were removed, and will be recreated the next time a client is
+	// needed.
+	Unhealthy int
+}
+
+// CheckHealth verifies that the cache of each active client is still synced,
+// waiting up to the supplied timeout for each cache. Clients with caches that
+// are not synced in time are removed from the Cache; a new client will be
+// created the next time one is needed. Clients that have not finished their
+// initial sync, clients without caches, and clients that fell back to reading
+// from the API server are not checked.
+func (c *Cache) CheckHealth(ctx context.Context, timeout time.Duration) HealthReport {
+	c.mx.RLock()
+	check := make(map[string]*session, len(c.active))
+	for id, sn := range c.active {
+		if sn.cache == nil || !sn.synced.Load() || sn.readsLive() {
+			continue
+		}
+		check[id] = sn
+	}
+	c.mx.RUnlock()
+
+	var (
+		wg sync.WaitGroup
+		mx sync.Mutex
+	)
+	r := HealthReport{Checked: time.Now()}
+	for id, sn := range check {
+		id, sn := id, sn // So we don't capture the loop variables.
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			ok := sn.cache.WaitForCacheSync(tctx)
+
+			mx.Lock()
+			defer mx.Unlock()
+			if ok {
+				r.Healthy++
+				return
+			}
+			r.Unhealthy++
+			c.log.Debug("Removing client with unhealthy cache", "client-id", id)
+			c.remove(id)
+		}()
+	}
+	wg.Wait()
+
+	c.health.Store(&r)
+	return r
+}
+
+// MonitorHealth calls CheckHealth at the supplied interval until the supplied
+// context is done.
+func (c *Cache) MonitorHealth(ctx context.Context, interval, timeout time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			r := c.CheckHealth(ctx, timeout)
+			c.log.Debug("Checked client cache health", "healthy", r.Healthy, "unhealthy", r.Unhealthy)
+		}
+	}
+}
+
+// Ready returns an error if most of the client caches checked by the most
+// recent health check were unhealthy. A few wedged caches are expected from
+// time to time and are recreated, but many suggest the process is in a bad
+// state. The Cache is ready if its health has never been checked.
+func (c *Cache) Ready() error {
+	r := c.health.Load()
+	if r == nil || r.Unhealthy == 0 || r.Unhealthy < r.Healthy {
+		return nil
+	}
+	return errors.Errorf("%d of %d client caches were unhealthy at %s", r.Unhealthy, r.Healthy+r.Unhealthy, r.Checked.Format(time.RFC3339))
+}
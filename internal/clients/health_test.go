@@ -0,0 +1,140 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestCheckHealth(t *testing.T) {
+	synced := &MockCache{MockWaitForCacheSync: func(ctx context.Context) bool { return true }}
+	wedged := &MockCache{MockWaitForCacheSync: func(ctx context.Context) bool {
+		<-ctx.Done()
+		return false
+	}}
+
+	newSn := func(ca *MockCache, isSynced bool) *session {
+		sn := newSession(test.NewMockClient(), func() {}, &MockExpiration{}, time.Now())
+		if ca != nil {
+			sn.cache = ca
+		}
+		sn.synced.Store(isSynced)
+		return sn
+	}
+
+	type want struct {
+		report HealthReport
+		active []string
+		ready  bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		active map[string]*session
+		want   want
+	}{
+		"NoSessions": {
+			reason: "A Cache with no active sessions should be healthy.",
+			want: want{
+				active: []string{},
+				ready:  true,
+			},
+		},
+		"Healthy": {
+			reason: "Sessions with synced caches should be kept.",
+			active: map[string]*session{
+				"a": newSn(synced, true),
+				"b": newSn(synced, true),
+			},
+			want: want{
+				report: HealthReport{Healthy: 2},
+				active: []string{"a", "b"},
+				ready:  true,
+			},
+		},
+		"SomeUnhealthy": {
+			reason: "Sessions with wedged caches should be removed, but a few should not make the Cache unready.",
+			active: map[string]*session{
+				"a": newSn(synced, true),
+				"b": newSn(synced, true),
+				"c": newSn(wedged, true),
+			},
+			want: want{
+				report: HealthReport{Healthy: 2, Unhealthy: 1},
+				active: []string{"a", "b"},
+				ready:  true,
+			},
+		},
+		"MostlyUnhealthy": {
+			reason: "A Cache whose sessions were mostly unhealthy should be unready.",
+			active: map[string]*session{
+				"a": newSn(synced, true),
+				"b": newSn(wedged, true),
+				"c": newSn(wedged, true),
+			},
+			want: want{
+				report: HealthReport{Healthy: 1, Unhealthy: 2},
+				active: []string{"a"},
+				ready:  false,
+			},
+		},
+		"NotChecked": {
+			reason: "Sessions that haven't finished their initial sync, or that have no cache, should not be checked.",
+			active: map[string]*session{
+				"a": newSn(wedged, false),
+				"b": newSn(nil, true),
+			},
+			want: want{
+				active: []string{"a", "b"},
+				ready:  true,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := NewCache(runtime.NewScheme(), &rest.Config{})
+			for id, sn := range tc.active {
+				c.active[id] = sn
+			}
+
+			got := c.CheckHealth(context.Background(), 100*time.Millisecond)
+			if diff := cmp.Diff(tc.want.report, got, cmpopts.IgnoreFields(HealthReport{}, "Checked")); diff != "" {
+				t.Errorf("\n%s\nc.CheckHealth(...): -want, +got:\n%s", tc.reason, diff)
+			}
+
+			active := []string{}
+			for id := range c.active {
+				active = append(active, id)
+			}
+			if diff := cmp.Diff(tc.want.active, active, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+				t.Errorf("\n%s\nc.CheckHealth(...): -want active clients, +got:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.ready, c.Ready() == nil); diff != "" {
+				t.Errorf("\n%s\nc.Ready(): -want ready, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
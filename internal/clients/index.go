@@ -0,0 +1,57 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Fields by which objects may be indexed.
+const (
+	// IndexFieldOwnerUIDs indexes objects by the UIDs of their owners.
+	IndexFieldOwnerUIDs = "metadata.ownerReferences.uid"
+)
+
+// An Index of objects of a particular type by a particular field. Indexes let
+// a cached client efficiently find the objects with a particular field value
+// by listing them using client.MatchingFields, rather than listing all objects
+// of the type and filtering them.
+type Index struct {
+	// Object is the type of object to index. It may be a type known to the
+	// scheme or *unstructured.Unstructured with its APIVersion and Kind set.
+	// Typed and unstructured objects are cached, and thus indexed, separately.
+	Object client.Object
+
+	// Field is the name of the index.
+	Field string
+
+	// Extract the values by which the supplied object is indexed.
+	Extract client.IndexerFunc
+}
+
+// OwnerUIDsIndex indexes objects of the supplied type by the UIDs of their
+// owners.
+func OwnerUIDsIndex(o client.Object) Index {
+	return Index{Object: o, Field: IndexFieldOwnerUIDs, Extract: ownerUIDs}
+}
+
+func ownerUIDs(o client.Object) []string {
+	refs := o.GetOwnerReferences()
+	out := make([]string, len(refs))
+	for i, ref := range refs {
+		out[i] = string(ref.UID)
+	}
+	return out
+}
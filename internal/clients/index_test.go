@@ -0,0 +1,117 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestIndexExtract(t *testing.T) {
+	owned := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{OwnerReferences: []metav1.OwnerReference{{UID: types.UID("a")}, {UID: types.UID("b")}}}}
+
+	cases := map[string]struct {
+		reason string
+		idx    Index
+		o      client.Object
+		want   []string
+	}{
+		"OwnerUIDs": {
+			reason: "An object should be indexed by the UIDs of its owners.",
+			idx:    OwnerUIDsIndex(&corev1.Secret{}),
+			o:      owned,
+			want:   []string{"a", "b"},
+		},
+		"NoOwnerUIDs": {
+			reason: "An object without owners should not be indexed.",
+			idx:    OwnerUIDsIndex(&corev1.Secret{}),
+			o:      &corev1.Secret{},
+			want:   []string{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := tc.idx.Extract(tc.o)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nidx.Extract(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+// BenchmarkOwnedLookup finds the objects owned by a particular owner, with and
+// without an OwnerUIDsIndex. Client caches are backed by the same indexer used
+// here.
+func BenchmarkOwnedLookup(b *testing.B) {
+	const (
+		owners = 1000
+		owned  = 10
+	)
+
+	idx := OwnerUIDsIndex(&kunstructured.Unstructured{})
+	indexer := toolscache.NewIndexer(toolscache.MetaNamespaceKeyFunc, toolscache.Indexers{
+		idx.Field: func(obj any) ([]string, error) { return idx.Extract(obj.(client.Object)), nil },
+	})
+	for i := 0; i < owners; i++ {
+		for j := 0; j < owned; j++ {
+			u := &kunstructured.Unstructured{Object: map[string]any{}}
+			u.SetName(fmt.Sprintf("owned-%d-%d", i, j))
+			u.SetOwnerReferences([]metav1.OwnerReference{{UID: types.UID(fmt.Sprintf("owner-%d", i))}})
+			if err := indexer.Add(u); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+
+	// The last owner.
+	uid := fmt.Sprintf("owner-%d", owners-1)
+
+	b.Run("Unindexed", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			found := 0
+			for _, obj := range indexer.List() {
+				for _, k := range idx.Extract(obj.(client.Object)) {
+					if k == uid {
+						found++
+					}
+				}
+			}
+			if found != owned {
+				b.Fatalf("found %d owned objects, want %d", found, owned)
+			}
+		}
+	})
+
+	b.Run("Indexed", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			objs, err := indexer.ByIndex(idx.Field, uid)
+			if err != nil {
+				b.Fatal(err)
+			}
+			if len(objs) != owned {
+				b.Fatalf("found %d owned objects, want %d", len(objs), owned)
+			}
+		}
+	})
+}
@@ -0,0 +1,274 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/upbound/xgql/internal/live_query"
+)
+
+// loaderWait is how long a loader collects reads before it flushes them.
+const loaderWait = 2 * time.Millisecond
+
+// The default duration for which a loader remembers that an object was not
+// found.
+const defaultNotFoundTTL = 2 * time.Second
+
+type loaderCtxKeyType int
+
+const loaderCtxKey loaderCtxKeyType = iota
+
+// LoaderMiddleware adds a set of loaders to the request context. Reads made
+// by clients in the context of the request are batched and deduplicated by
+// the loaders. Reads are scoped to the credentials of the client that makes
+// them, so loaders are never shared between requests. Loaders remember that an
+// object was not found for a short time; see NewLoaderMiddleware.
+func LoaderMiddleware(next http.Handler) http.Handler {
+	return NewLoaderMiddleware(defaultNotFoundTTL)(next)
+}
+
+// NewLoaderMiddleware returns middleware that adds a set of loaders to the
+// request context, like LoaderMiddleware. Each loader remembers that an object
+// was not found for the supplied duration, so that an operation that reads a
+// missing object several times, for example a tree with several references to
+// a deleted resource, reads it only once. Loaders are scoped to a request and
+// to the credentials of a client, so an object that wasn't found using one
+// caller's credentials is never reported as not found to another. Objects that
+// weren't found are read again each time if the duration is not positive.
+func NewLoaderMiddleware(notFoundTTL time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(withLoaders(r.Context(), loaderWait, notFoundTTL)))
+		})
+	}
+}
+
+func withLoaders(ctx context.Context, wait, notFoundTTL time.Duration) context.Context {
+	return context.WithValue(ctx, loaderCtxKey, &loaders{ctx: ctx, wait: wait, notFoundTTL: notFoundTTL, m: make(map[*loadingClient]*loader)})
+}
+
+// loaders are the loaders of a single request, one per client.
+type loaders struct {
+	ctx         context.Context
+	wait        time.Duration
+	notFoundTTL time.Duration
+
+	mx sync.Mutex
+	m  map[*loadingClient]*loader
+}
+
+func (ls *loaders) For(c *loadingClient) *loader {
+	ls.mx.Lock()
+	defer ls.mx.Unlock()
+	l, ok := ls.m[c]
+	if !ok {
+		l = &loader{ctx: ls.ctx, client: c.Client, wait: ls.wait, notFoundTTL: ls.notFoundTTL, notFound: make(map[loadKey]notFoundResult)}
+		ls.m[c] = l
+	}
+	return l
+}
+
+// A loadingClient is a client that batches and deduplicates the unstructured
+// objects it reads using the loader in the request context, if any.
+type loadingClient struct {
+	client.Client
+
+	// uncached types are read directly from the API server. We don't batch
+	// them, because batching them would list them from the API server.
+	uncached map[schema.GroupVersionKind]bool
+}
+
+// Get the object with the supplied key.
+func (c *loadingClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok || len(opts) > 0 || c.uncached[u.GroupVersionKind()] {
+		return c.Client.Get(ctx, key, obj, opts...)
+	}
+	ls, ok := ctx.Value(loaderCtxKey).(*loaders)
+	if !ok {
+		return c.Client.Get(ctx, key, obj, opts...)
+	}
+	// Live queries track the objects read in their context in order to
+	// determine when they need to be re-run. A batch is read in the context
+	// of the request, not the live query.
+	if _, live := live_query.IsLive(ctx); live {
+		return c.Client.Get(ctx, key, obj, opts...)
+	}
+	return ls.For(c).Get(ctx, key, u)
+}
+
+type loadKey struct {
+	gvk schema.GroupVersionKind
+	key types.NamespacedName
+}
+
+type loadGroup struct {
+	gvk       schema.GroupVersionKind
+	namespace string
+}
+
+type loadResult struct {
+	obj *unstructured.Unstructured
+	err error
+}
+
+type notFoundResult struct {
+	err     error
+	expires time.Time
+}
+
+type loadBatch struct {
+	results map[loadKey]*loadResult
+	done    chan struct{}
+}
+
+// A loader collects the reads made within a short window then flushes them
+// as a batch. Identical reads within a batch are made only once. Reads of
+// several objects of the same type in the same namespace are made using a
+// single list. Objects that weren't found aren't read again until their
+// not found result expires.
+type loader struct {
+	ctx         context.Context
+	client      client.Reader
+	wait        time.Duration
+	notFoundTTL time.Duration
+
+	mx       sync.Mutex
+	batch    *loadBatch
+	notFound map[loadKey]notFoundResult
+}
+
+// Get the object with the supplied key.
+func (l *loader) Get(ctx context.Context, key client.ObjectKey, u *unstructured.Unstructured) error {
+	lk := loadKey{gvk: u.GroupVersionKind(), key: key}
+
+	l.mx.Lock()
+	if nf, ok := l.notFound[lk]; ok {
+		if time.Now().Before(nf.expires) {
+			l.mx.Unlock()
+			return nf.err
+		}
+		delete(l.notFound, lk)
+	}
+	b := l.batch
+	if b == nil {
+		b = &loadBatch{results: make(map[loadKey]*loadResult), done: make(chan struct{})}
+		l.batch = b
+		time.AfterFunc(l.wait, func() { l.flush(b) })
+	}
+	r, ok := b.results[lk]
+	if !ok {
+		r = &loadResult{}
+		b.results[lk] = r
+	}
+	l.mx.Unlock()
+
+	select {
+	case <-b.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	if r.err != nil {
+		return r.err
+	}
+	r.obj.DeepCopyInto(u)
+	return nil
+}
+
+func (l *loader) flush(b *loadBatch) {
+	// No more reads may join the batch once we start flushing it.
+	l.mx.Lock()
+	l.batch = nil
+	l.mx.Unlock()
+
+	groups := make(map[loadGroup][]loadKey)
+	for lk := range b.results {
+		g := loadGroup{gvk: lk.gvk, namespace: lk.key.Namespace}
+		groups[g] = append(groups[g], lk)
+	}
+
+	wg := sync.WaitGroup{}
+	for g, keys := range groups {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			l.load(g, keys, b.results)
+		}()
+	}
+	wg.Wait()
+
+	if l.notFoundTTL > 0 {
+		expires := time.Now().Add(l.notFoundTTL)
+		l.mx.Lock()
+		for lk, r := range b.results {
+			if kerrors.IsNotFound(r.err) {
+				l.notFound[lk] = notFoundResult{err: r.err, expires: expires}
+			}
+		}
+		l.mx.Unlock()
+	}
+	close(b.done)
+}
+
+func (l *loader) load(g loadGroup, keys []loadKey, results map[loadKey]*loadResult) {
+	if len(keys) > 1 && l.list(g, keys, results) == nil {
+		return
+	}
+
+	// Fall back to reading objects individually if we can't list them, for
+	// example because we're allowed to get but not list them.
+	for _, lk := range keys {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(lk.gvk)
+		r := results[lk]
+		r.obj, r.err = u, l.client.Get(l.ctx, lk.key, u)
+	}
+}
+
+func (l *loader) list(g loadGroup, keys []loadKey, results map[loadKey]*loadResult) error {
+	ul := &unstructured.UnstructuredList{}
+	ul.SetGroupVersionKind(g.gvk.GroupVersion().WithKind(g.gvk.Kind + "List"))
+	if err := l.client.List(l.ctx, ul, client.InNamespace(g.namespace)); err != nil {
+		return err
+	}
+
+	byName := make(map[string]*unstructured.Unstructured, len(ul.Items))
+	for i := range ul.Items {
+		byName[ul.Items[i].GetName()] = &ul.Items[i]
+	}
+
+	for _, lk := range keys {
+		r := results[lk]
+		u, ok := byName[lk.key.Name]
+		if !ok {
+			// This mirrors the error returned by the cache when it can't
+			// find an object.
+			r.err = kerrors.NewNotFound(schema.GroupResource{Group: lk.gvk.Group, Resource: lk.gvk.Kind}, lk.key.Name)
+			continue
+		}
+		r.obj = u
+	}
+	return nil
+}
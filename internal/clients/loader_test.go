@@ -0,0 +1,294 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+var loaderGVK = schema.GroupVersionKind{Group: "example.org", Version: "v1", Kind: "Example"}
+
+// A countingClient serves the objects with the supplied names, counting the
+// calls made to it.
+type countingClient struct {
+	test.MockClient
+
+	gets  atomic.Int64
+	lists atomic.Int64
+}
+
+func newCountingClient(listErr error, names ...string) *countingClient {
+	objs := make(map[string]unstructured.Unstructured, len(names))
+	for _, n := range names {
+		u := unstructured.Unstructured{}
+		u.SetGroupVersionKind(loaderGVK)
+		u.SetName(n)
+		objs[n] = u
+	}
+
+	c := &countingClient{}
+	c.MockGet = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+		c.gets.Add(1)
+		u, ok := objs[key.Name]
+		if !ok {
+			return kerrors.NewNotFound(schema.GroupResource{Group: loaderGVK.Group, Resource: loaderGVK.Kind}, key.Name)
+		}
+		u.DeepCopyInto(obj.(*unstructured.Unstructured))
+		return nil
+	}
+	c.MockList = func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+		c.lists.Add(1)
+		if listErr != nil {
+			return listErr
+		}
+		l := obj.(*unstructured.UnstructuredList)
+		for _, u := range objs {
+			l.Items = append(l.Items, *u.DeepCopy())
+		}
+		return nil
+	}
+	return c
+}
+
+// readAll concurrently reads the objects with the supplied names. It returns
+// the name of each object read, or the reason it could not be read.
+func readAll(ctx context.Context, c client.Client, names ...string) []string {
+	got := make([]string, len(names))
+	wg := sync.WaitGroup{}
+	for i, n := range names {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			u := &unstructured.Unstructured{}
+			u.SetGroupVersionKind(loaderGVK)
+			if err := c.Get(ctx, client.ObjectKey{Name: n}, u); err != nil {
+				got[i] = string(kerrors.ReasonForError(err))
+				return
+			}
+			got[i] = u.GetName()
+		}()
+	}
+	wg.Wait()
+	return got
+}
+
+func TestLoadingClient(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type args struct {
+		loaders  bool
+		uncached bool
+		listErr  error
+		names    []string
+	}
+	type want struct {
+		got   []string
+		gets  int64
+		lists int64
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NoLoaders": {
+			reason: "Reads should not be batched outside the context of a request with loaders.",
+			args: args{
+				names: []string{"a", "b", "a"},
+			},
+			want: want{
+				got:  []string{"a", "b", "a"},
+				gets: 3,
+			},
+		},
+		"Deduplicate": {
+			reason: "Identical reads within a batch should be made only once.",
+			args: args{
+				loaders: true,
+				names:   []string{"a", "a", "a"},
+			},
+			want: want{
+				got:  []string{"a", "a", "a"},
+				gets: 1,
+			},
+		},
+		"Batch": {
+			reason: "Reads of several objects of the same kind in the same namespace should be made using a single list.",
+			args: args{
+				loaders: true,
+				names:   []string{"a", "b", "c", "missing"},
+			},
+			want: want{
+				got:   []string{"a", "b", "c", string(metav1.StatusReasonNotFound)},
+				lists: 1,
+			},
+		},
+		"ListError": {
+			reason: "We should fall back to reading objects individually if we can't list them.",
+			args: args{
+				loaders: true,
+				listErr: errBoom,
+				names:   []string{"a", "b", "c"},
+			},
+			want: want{
+				got:   []string{"a", "b", "c"},
+				gets:  3,
+				lists: 1,
+			},
+		},
+		"Uncached": {
+			reason: "Reads of objects that aren't cached should not be batched.",
+			args: args{
+				loaders:  true,
+				uncached: true,
+				names:    []string{"a", "b", "a"},
+			},
+			want: want{
+				got:  []string{"a", "b", "a"},
+				gets: 3,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cc := newCountingClient(tc.args.listErr, "a", "b", "c")
+			c := &loadingClient{Client: cc, uncached: map[schema.GroupVersionKind]bool{loaderGVK: tc.args.uncached}}
+
+			ctx := context.Background()
+			if tc.args.loaders {
+				// Use a generous wait so all reads join the same batch.
+				ctx = withLoaders(ctx, 100*time.Millisecond, 0)
+			}
+
+			got := readAll(ctx, c, tc.args.names...)
+			if diff := cmp.Diff(tc.want.got, got); diff != "" {
+				t.Errorf("\n%s\nc.Get(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.gets, cc.gets.Load()); diff != "" {
+				t.Errorf("\n%s\nc.Get(...): -want gets, +got gets:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.lists, cc.lists.Load()); diff != "" {
+				t.Errorf("\n%s\nc.Get(...): -want lists, +got lists:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestLoadingClientNotFound(t *testing.T) {
+	cases := map[string]struct {
+		reason      string
+		notFoundTTL time.Duration
+		want        int64
+	}{
+		"Remembered": {
+			reason:      "An object that wasn't found should not be read again until its not found result expires.",
+			notFoundTTL: time.Hour,
+			want:        1,
+		},
+		"Expired": {
+			reason:      "An object that wasn't found should be read again once its not found result expires.",
+			notFoundTTL: time.Nanosecond,
+			want:        2,
+		},
+		"Disabled": {
+			reason: "An object that wasn't found should be read again if not found results aren't remembered.",
+			want:   2,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cc := newCountingClient(nil, "a")
+			c := &loadingClient{Client: cc}
+			ctx := withLoaders(context.Background(), time.Millisecond, tc.notFoundTTL)
+
+			// Each read is made in its own batch.
+			for i := 0; i < 2; i++ {
+				got := readAll(ctx, c, "missing")
+				if diff := cmp.Diff([]string{string(metav1.StatusReasonNotFound)}, got); diff != "" {
+					t.Errorf("\n%s\nc.Get(...): -want, +got:\n%s", tc.reason, diff)
+				}
+			}
+			if diff := cmp.Diff(tc.want, cc.gets.Load()); diff != "" {
+				t.Errorf("\n%s\nc.Get(...): -want gets, +got gets:\n%s", tc.reason, diff)
+			}
+
+			// Loaders are scoped to a client, and thus to credentials.
+			other := newCountingClient(nil, "missing")
+			got := readAll(ctx, &loadingClient{Client: other}, "missing")
+			if diff := cmp.Diff([]string{"missing"}, got); diff != "" {
+				t.Errorf("\n%s\nc.Get(...): -want another client's read, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+// BenchmarkLoadingClient reads the children of a wide tree, in which some
+// children are referenced more than once, and reports the calls made to the
+// underlying client.
+func BenchmarkLoadingClient(b *testing.B) {
+	const width = 100
+
+	names := make([]string, width)
+	refs := make([]string, 0, width+width/10)
+	for i := range names {
+		names[i] = fmt.Sprintf("child-%d", i)
+		refs = append(refs, names[i])
+	}
+	for i := 0; i < width/10; i++ {
+		refs = append(refs, names[i])
+	}
+
+	for _, bc := range []struct {
+		name    string
+		loaders bool
+	}{
+		{name: "Unbatched"},
+		{name: "Batched", loaders: true},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			cc := newCountingClient(nil, names...)
+			c := &loadingClient{Client: cc}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				// Loaders are created per request.
+				ctx := context.Background()
+				if bc.loaders {
+					ctx = withLoaders(ctx, loaderWait, defaultNotFoundTTL)
+				}
+				readAll(ctx, c, refs...)
+			}
+			b.ReportMetric(float64(cc.gets.Load()+cc.lists.Load())/float64(b.N), "calls/op")
+		})
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// A managedFieldsStripper is a client that strips the managed fields of the
+// objects it reads. It also strips the objects that writes echo back from the
+// API server, for example the result of a server-side apply patch, so that the
+// results of writes are consistent with reads. Written objects are echoed back
+// without the kubectl last-applied-configuration annotation too; it's noise to
+// a caller that just wrote the object.
+type managedFieldsStripper struct {
+	client.Client
+}
+
+// Get the object with the supplied key, without its managed fields.
+func (c *managedFieldsStripper) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if err := c.Client.Get(ctx, key, obj, opts...); err != nil {
+		return err
+	}
+	stripManagedFields(obj)
+	return nil
+}
+
+// List objects, without their managed fields.
+func (c *managedFieldsStripper) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if err := c.Client.List(ctx, list, opts...); err != nil {
+		return err
+	}
+	return meta.EachListItem(list, func(o runtime.Object) error {
+		stripManagedFields(o)
+		return nil
+	})
+}
+
+// Create the supplied object, then strip the created object.
+func (c *managedFieldsStripper) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if err := c.Client.Create(ctx, obj, opts...); err != nil {
+		return err
+	}
+	stripWritten(obj)
+	return nil
+}
+
+// Update the supplied object, then strip the updated object.
+func (c *managedFieldsStripper) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if err := c.Client.Update(ctx, obj, opts...); err != nil {
+		return err
+	}
+	stripWritten(obj)
+	return nil
+}
+
+// Patch the supplied object, then strip the patched object.
+func (c *managedFieldsStripper) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if err := c.Client.Patch(ctx, obj, patch, opts...); err != nil {
+		return err
+	}
+	stripWritten(obj)
+	return nil
+}
+
+// stripWritten strips the managed fields and the kubectl last-applied
+// annotation of an object echoed back by a write. We don't strip the annotation
+// from objects we read, because it's used to compute a managed resource's diff.
+func stripWritten(o client.Object) {
+	stripManagedFields(o)
+	a := o.GetAnnotations()
+	if _, ok := a[corev1.LastAppliedConfigAnnotation]; !ok {
+		return
+	}
+	delete(a, corev1.LastAppliedConfigAnnotation)
+	o.SetAnnotations(a)
+}
+
+func stripManagedFields(o runtime.Object) {
+	a, err := meta.Accessor(o)
+	if err != nil {
+		return
+	}
+	// Lists may be served directly from the cache without a deep copy, so we
+	// avoid writing to objects that have no managed fields to strip.
+	if len(a.GetManagedFields()) == 0 {
+		return
+	}
+	a.SetManagedFields(nil)
+}
@@ -0,0 +1,169 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/upbound/xgql/internal/auth"
+)
+
+func TestManagedFieldsStripper(t *testing.T) {
+	mf := []metav1.ManagedFieldsEntry{{Manager: "crossplane"}}
+
+	withManagedFields := func(name string) unstructured.Unstructured {
+		u := unstructured.Unstructured{}
+		u.SetAPIVersion("example.org/v1")
+		u.SetKind("Example")
+		u.SetName(name)
+		u.SetManagedFields(mf)
+		return u
+	}
+
+	c := &managedFieldsStripper{Client: &test.MockClient{
+		MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			obj.SetManagedFields(mf)
+			return nil
+		}),
+		MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+			switch l := obj.(type) {
+			case *unstructured.UnstructuredList:
+				l.Items = []unstructured.Unstructured{withManagedFields("a"), withManagedFields("b")}
+			case *corev1.SecretList:
+				l.Items = []corev1.Secret{{ObjectMeta: metav1.ObjectMeta{Name: "a", ManagedFields: mf}}}
+			}
+			return nil
+		}),
+		MockPatch: test.NewMockPatchFn(nil, func(obj client.Object) error {
+			obj.SetManagedFields(mf)
+			obj.SetAnnotations(map[string]string{corev1.LastAppliedConfigAnnotation: "{}", "cool": "very"})
+			return nil
+		}),
+		MockCreate: test.NewMockCreateFn(nil, func(obj client.Object) error {
+			obj.SetManagedFields(mf)
+			obj.SetAnnotations(map[string]string{corev1.LastAppliedConfigAnnotation: "{}"})
+			return nil
+		}),
+	}}
+
+	t.Run("Get", func(t *testing.T) {
+		u := &unstructured.Unstructured{}
+		if err := c.Get(context.Background(), client.ObjectKey{Name: "a"}, u); err != nil {
+			t.Fatalf("c.Get(...): %s", err)
+		}
+		if diff := cmp.Diff(0, len(u.GetManagedFields())); diff != "" {
+			t.Errorf("\nc.Get(...): -want managed fields, +got:\n%s", diff)
+		}
+	})
+
+	t.Run("Apply", func(t *testing.T) {
+		u := withManagedFields("a")
+		if err := c.Patch(context.Background(), &u, client.Apply, client.FieldOwner("xgql")); err != nil {
+			t.Fatalf("c.Patch(...): %s", err)
+		}
+		if diff := cmp.Diff(0, len(u.GetManagedFields())); diff != "" {
+			t.Errorf("\nc.Patch(...): -want managed fields, +got:\n%s", diff)
+		}
+		if diff := cmp.Diff(map[string]string{"cool": "very"}, u.GetAnnotations()); diff != "" {
+			t.Errorf("\nc.Patch(...): -want annotations, +got:\n%s", diff)
+		}
+	})
+
+	t.Run("Create", func(t *testing.T) {
+		s := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "a"}}
+		if err := c.Create(context.Background(), s); err != nil {
+			t.Fatalf("c.Create(...): %s", err)
+		}
+		if diff := cmp.Diff(0, len(s.GetManagedFields())); diff != "" {
+			t.Errorf("\nc.Create(...): -want managed fields, +got:\n%s", diff)
+		}
+		if diff := cmp.Diff(map[string]string{}, s.GetAnnotations()); diff != "" {
+			t.Errorf("\nc.Create(...): -want annotations, +got:\n%s", diff)
+		}
+	})
+
+	t.Run("ListUnstructured", func(t *testing.T) {
+		l := &unstructured.UnstructuredList{}
+		if err := c.List(context.Background(), l); err != nil {
+			t.Fatalf("c.List(...): %s", err)
+		}
+		for _, u := range l.Items {
+			if diff := cmp.Diff(0, len(u.GetManagedFields())); diff != "" {
+				t.Errorf("\nc.List(...): %s: -want managed fields, +got:\n%s", u.GetName(), diff)
+			}
+		}
+	})
+
+	t.Run("ListTyped", func(t *testing.T) {
+		l := &corev1.SecretList{}
+		if err := c.List(context.Background(), l); err != nil {
+			t.Fatalf("c.List(...): %s", err)
+		}
+		for _, s := range l.Items {
+			if diff := cmp.Diff(0, len(s.GetManagedFields())); diff != "" {
+				t.Errorf("\nc.List(...): %s: -want managed fields, +got:\n%s", s.GetName(), diff)
+			}
+		}
+	})
+}
+
+func TestIncludeManagedFields(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	cases := map[string]struct {
+		reason string
+		copts  []CacheOption
+		want   bool
+	}{
+		"Default": {
+			reason: "Caches should strip managed fields by default.",
+			want:   true,
+		},
+		"IncludeManagedFields": {
+			reason: "Caches should not strip managed fields when they're included.",
+			copts:  []CacheOption{IncludeManagedFields()},
+			want:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got bool
+			copts := append(tc.copts, WithNewCacheFn(NewCacheFn(func(cfg *rest.Config, o cache.Options) (cache.Cache, error) {
+				got = o.DefaultTransform != nil
+				return nil, errBoom
+			})))
+			c := NewCache(runtime.NewScheme(), &rest.Config{}, copts...)
+			_, _ = c.Get(auth.Credentials{})
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nc.Get(...): -want transform, +got transform:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// A listPager is an HTTP transport that sets the page size of paged list
+// requests. Informers page the initial list of each type they watch, but
+// neither client-go nor controller-runtime let us configure the size of those
+// pages when an informer is created by a cache. Instead we rewrite the limit
+// of every request that sets one. Watches and requests that aren't paged are
+// passed through unchanged.
+type listPager struct {
+	transport http.RoundTripper
+	pageSize  int64
+}
+
+// RoundTrip the supplied request, setting its page size if it's paged.
+func (p *listPager) RoundTrip(req *http.Request) (*http.Response, error) {
+	q := req.URL.Query()
+	if req.Method != http.MethodGet || !q.Has("limit") || q.Get("watch") == "true" {
+		return p.transport.RoundTrip(req)
+	}
+
+	// A RoundTripper mustn't modify the request it's passed.
+	r := req.Clone(req.Context())
+	q.Set("limit", strconv.FormatInt(p.pageSize, 10))
+	r.URL.RawQuery = q.Encode()
+	return p.transport.RoundTrip(r)
+}
+
+// withListPageSize returns a copy of the supplied HTTP client that sets the
+// page size of paged list requests.
+func withListPageSize(hc *http.Client, pageSize int64) *http.Client {
+	t := hc.Transport
+	if t == nil {
+		t = http.DefaultTransport
+	}
+	out := *hc
+	out.Transport = &listPager{transport: t, pageSize: pageSize}
+	return &out
+}
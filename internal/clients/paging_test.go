@@ -0,0 +1,82 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestListPager(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		method string
+		query  string
+		want   string
+	}{
+		"PagedList": {
+			reason: "We should set the page size of a paged list.",
+			method: http.MethodGet,
+			query:  "limit=500&resourceVersion=0",
+			want:   "limit=42&resourceVersion=0",
+		},
+		"UnpagedList": {
+			reason: "We should not page a list that isn't paged.",
+			method: http.MethodGet,
+			query:  "resourceVersion=0",
+			want:   "resourceVersion=0",
+		},
+		"Watch": {
+			reason: "We should not modify a watch.",
+			method: http.MethodGet,
+			query:  "limit=500&watch=true",
+			want:   "limit=500&watch=true",
+		},
+		"Write": {
+			reason: "We should not modify a request that isn't a read.",
+			method: http.MethodPost,
+			query:  "limit=500",
+			want:   "limit=500",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ""
+			srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				got = r.URL.RawQuery
+			}))
+			defer srv.Close()
+
+			hc := withListPageSize(srv.Client(), 42)
+			req, _ := http.NewRequest(tc.method, srv.URL+"/apis/example.org/v1/examples?"+tc.query, nil)
+			rsp, err := hc.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_ = rsp.Body.Close()
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nlistPager.RoundTrip(...): -want query, +got query:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.query, req.URL.RawQuery); diff != "" {
+				t.Errorf("\n%s\nlistPager.RoundTrip(...): -want unmodified request, +got request:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
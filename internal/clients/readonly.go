@@ -0,0 +1,123 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import (
+	"context"
+
+	authnv1 "k8s.io/api/authentication/v1"
+	authzv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+)
+
+// ErrReadOnly is returned by every write made using a client produced by a
+// Cache configured with DisableWrites.
+var ErrReadOnly = errors.New("writes are disabled; xgql is read-only")
+
+// A readOnlyClient is a client that can only read. Every write returns
+// ErrReadOnly without reaching the API server.
+//
+// Reviews, like a SelfSubjectAccessReview, are created but never persisted.
+// Creating a review is how a caller reads whether they're authenticated, or
+// what they're authorized to do, so creates of reviews are allowed.
+type readOnlyClient struct {
+	client.Client
+}
+
+// Create the supplied object if it's a review. Otherwise return ErrReadOnly.
+func (c *readOnlyClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		return ErrReadOnly
+	}
+	switch gvk.Group {
+	case authnv1.GroupName, authzv1.GroupName:
+		return c.Client.Create(ctx, obj, opts...)
+	}
+	return ErrReadOnly
+}
+
+// Update returns ErrReadOnly.
+func (c *readOnlyClient) Update(_ context.Context, _ client.Object, _ ...client.UpdateOption) error {
+	return ErrReadOnly
+}
+
+// Patch returns ErrReadOnly.
+func (c *readOnlyClient) Patch(_ context.Context, _ client.Object, _ client.Patch, _ ...client.PatchOption) error {
+	return ErrReadOnly
+}
+
+// Delete returns ErrReadOnly.
+func (c *readOnlyClient) Delete(_ context.Context, _ client.Object, _ ...client.DeleteOption) error {
+	return ErrReadOnly
+}
+
+// DeleteAllOf returns ErrReadOnly.
+func (c *readOnlyClient) DeleteAllOf(_ context.Context, _ client.Object, _ ...client.DeleteAllOfOption) error {
+	return ErrReadOnly
+}
+
+// Status returns a writer for the status subresource that can't write.
+func (c *readOnlyClient) Status() client.SubResourceWriter {
+	return readOnlySubResourceWriter{}
+}
+
+// SubResource returns a client for the named subresource that can only read.
+func (c *readOnlyClient) SubResource(subResource string) client.SubResourceClient {
+	return &readOnlySubResourceClient{SubResourceClient: c.Client.SubResource(subResource)}
+}
+
+// A readOnlySubResourceWriter is a subresource writer that can't write. Every
+// write returns ErrReadOnly.
+type readOnlySubResourceWriter struct{}
+
+// Create returns ErrReadOnly.
+func (readOnlySubResourceWriter) Create(_ context.Context, _ client.Object, _ client.Object, _ ...client.SubResourceCreateOption) error {
+	return ErrReadOnly
+}
+
+// Update returns ErrReadOnly.
+func (readOnlySubResourceWriter) Update(_ context.Context, _ client.Object, _ ...client.SubResourceUpdateOption) error {
+	return ErrReadOnly
+}
+
+// Patch returns ErrReadOnly.
+func (readOnlySubResourceWriter) Patch(_ context.Context, _ client.Object, _ client.Patch, _ ...client.SubResourcePatchOption) error {
+	return ErrReadOnly
+}
+
+// A readOnlySubResourceClient is a subresource client that can only read.
+type readOnlySubResourceClient struct {
+	client.SubResourceClient
+	readOnlySubResourceWriter
+}
+
+// Create returns ErrReadOnly.
+func (c *readOnlySubResourceClient) Create(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+	return c.readOnlySubResourceWriter.Create(ctx, obj, subResource, opts...)
+}
+
+// Update returns ErrReadOnly.
+func (c *readOnlySubResourceClient) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	return c.readOnlySubResourceWriter.Update(ctx, obj, opts...)
+}
+
+// Patch returns ErrReadOnly.
+func (c *readOnlySubResourceClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	return c.readOnlySubResourceWriter.Patch(ctx, obj, patch, opts...)
+}
@@ -0,0 +1,151 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	authzv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+type MockSubResourceClient struct {
+	client.SubResourceClient
+
+	MockGet func(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceGetOption) error
+}
+
+func (c *MockSubResourceClient) Get(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceGetOption) error {
+	return c.MockGet(ctx, obj, subResource, opts...)
+}
+
+func TestReadOnlyClient(t *testing.T) {
+	errUnexpectedWrite := errors.New("unexpected write to underlying client")
+
+	s := runtime.NewScheme()
+	_ = corev1.AddToScheme(s)
+	_ = authzv1.AddToScheme(s)
+
+	cm := &corev1.ConfigMap{}
+	patch := client.RawPatch(types.MergePatchType, []byte("{}"))
+
+	cases := map[string]struct {
+		reason string
+		call   func(ctx context.Context, c client.Client) error
+		want   error
+	}{
+		"Get": {
+			reason: "Reads should be passed through to the underlying client.",
+			call: func(ctx context.Context, c client.Client) error {
+				return c.Get(ctx, types.NamespacedName{Name: "cm"}, cm)
+			},
+		},
+		"List": {
+			reason: "Reads should be passed through to the underlying client.",
+			call:   func(ctx context.Context, c client.Client) error { return c.List(ctx, &corev1.ConfigMapList{}) },
+		},
+		"CreateReview": {
+			reason: "Creates of reviews should be passed through to the underlying client, because they read authorization.",
+			call: func(ctx context.Context, c client.Client) error {
+				return c.Create(ctx, &authzv1.SelfSubjectAccessReview{})
+			},
+		},
+		"Create": {
+			reason: "Creates should return ErrReadOnly.",
+			call:   func(ctx context.Context, c client.Client) error { return c.Create(ctx, cm) },
+			want:   ErrReadOnly,
+		},
+		"Update": {
+			reason: "Updates should return ErrReadOnly.",
+			call:   func(ctx context.Context, c client.Client) error { return c.Update(ctx, cm) },
+			want:   ErrReadOnly,
+		},
+		"Patch": {
+			reason: "Patches should return ErrReadOnly.",
+			call:   func(ctx context.Context, c client.Client) error { return c.Patch(ctx, cm, patch) },
+			want:   ErrReadOnly,
+		},
+		"Delete": {
+			reason: "Deletes should return ErrReadOnly.",
+			call:   func(ctx context.Context, c client.Client) error { return c.Delete(ctx, cm) },
+			want:   ErrReadOnly,
+		},
+		"DeleteAllOf": {
+			reason: "Deletes should return ErrReadOnly.",
+			call:   func(ctx context.Context, c client.Client) error { return c.DeleteAllOf(ctx, cm) },
+			want:   ErrReadOnly,
+		},
+		"StatusUpdate": {
+			reason: "Status updates should return ErrReadOnly.",
+			call:   func(ctx context.Context, c client.Client) error { return c.Status().Update(ctx, cm) },
+			want:   ErrReadOnly,
+		},
+		"SubResourceGet": {
+			reason: "Subresource reads should be passed through to the underlying client.",
+			call: func(ctx context.Context, c client.Client) error {
+				return c.SubResource("scale").Get(ctx, cm, &corev1.ConfigMap{})
+			},
+		},
+		"SubResourcePatch": {
+			reason: "Subresource patches should return ErrReadOnly.",
+			call:   func(ctx context.Context, c client.Client) error { return c.SubResource("scale").Patch(ctx, cm, patch) },
+			want:   ErrReadOnly,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			// Writes that reach the underlying client return an error other
+			// than ErrReadOnly.
+			mc := &readOnlyMockClient{
+				MockClient: test.MockClient{
+					MockGet:         test.NewMockGetFn(nil),
+					MockList:        test.NewMockListFn(nil),
+					MockCreate:      test.NewMockCreateFn(nil),
+					MockUpdate:      test.NewMockUpdateFn(errUnexpectedWrite),
+					MockPatch:       test.NewMockPatchFn(errUnexpectedWrite),
+					MockDelete:      test.NewMockDeleteFn(errUnexpectedWrite),
+					MockDeleteAllOf: test.NewMockDeleteAllOfFn(errUnexpectedWrite),
+					MockScheme:      test.NewMockSchemeFn(s),
+				},
+				sub: &MockSubResourceClient{MockGet: func(_ context.Context, _ client.Object, _ client.Object, _ ...client.SubResourceGetOption) error {
+					return nil
+				}},
+			}
+
+			err := tc.call(context.Background(), &readOnlyClient{Client: mc})
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nreadOnlyClient: -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+// readOnlyMockClient is a MockClient that returns the supplied subresource
+// client.
+type readOnlyMockClient struct {
+	test.MockClient
+	sub client.SubResourceClient
+}
+
+func (c *readOnlyMockClient) SubResource(_ string) client.SubResourceClient { return c.sub }
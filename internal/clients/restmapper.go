@@ -0,0 +1,239 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery/cached/disk"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/upbound/xgql/internal/opentelemetry"
+)
+
+// DiskCachedRESTMapper returns a REST mapper that, like RESTMapper, discovers
+// an API server's available REST API endpoints, but persists what it discovers
+// in the supplied directory so that it may be reused across restarts, for
+// example by mounting a volume. Discovery data older than the supplied TTL is
+// discovered again. The first time a client asks for a kind of resource that
+// is unknown to the cached discovery data, for example because its CRD was
+// installed while xgql wasn't running, the REST mapper discards the cache and
+// discovers again. Once discovery data is fresh further unknown kinds aren't
+// rediscovered until the REST mapper is refreshed.
+func DiskCachedRESTMapper(cfg *rest.Config, dir string, ttl time.Duration) (meta.RESTMapper, error) {
+	dcfg := rest.CopyConfig(cfg)
+	dcfg.QPS = 50
+	dcfg.Burst = 300
+
+	dc, err := disk.NewCachedDiscoveryClientForConfig(dcfg, filepath.Join(dir, "discovery"), filepath.Join(dir, "http"), ttl)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewDiscoveryClient)
+	}
+	return restmapper.NewDeferredDiscoveryRESTMapper(dc), nil
+}
+
+// A NewRESTMapperFn returns a new REST mapper.
+type NewRESTMapperFn func() (meta.RESTMapper, error)
+
+// A RefreshingRESTMapper is a REST mapper that can replace its underlying REST
+// mapper with a new one, discarding any stale discovery data. The underlying
+// REST mapper is swapped atomically, so a refresh never blocks a request that
+// is using the REST mapper.
+//
+// Resolutions that fail are exported as OpenTelemetry metrics by API group,
+// as are the first successful resolutions in each API group after a failure.
+// A spike in failures typically means a CRD was installed that the REST mapper
+// hasn't yet discovered. Callers choose which API groups they resolve, so to
+// bound the cardinality of these metrics failures in API groups the REST
+// mapper has never resolved are exported as failures in the "unknown" group.
+type RefreshingRESTMapper struct {
+	newMapper NewRESTMapperFn
+	current   atomic.Pointer[restMapper]
+
+	mx sync.RWMutex
+
+	// The API groups in which a resolution has succeeded.
+	known map[string]bool
+
+	// The API groups in which the most recent resolution failed. At most
+	// maxFailingGroups are tracked.
+	failing map[string]bool
+}
+
+const (
+	// unknownGroup is the API group failures are recorded in when they're
+	// in an API group the REST mapper has never resolved.
+	unknownGroup = "unknown"
+
+	// maxFailingGroups is the maximum number of failing API groups tracked.
+	maxFailingGroups = 100
+)
+
+// restMapper wraps a meta.RESTMapper so that it may be stored in an
+// atomic.Pointer.
+type restMapper struct{ meta.RESTMapper }
+
+// NewRefreshingRESTMapper returns a REST mapper that is refreshed by replacing
+// it with a new REST mapper returned by the supplied function.
+func NewRefreshingRESTMapper(fn NewRESTMapperFn) (*RefreshingRESTMapper, error) {
+	m := &RefreshingRESTMapper{newMapper: fn, known: make(map[string]bool), failing: make(map[string]bool)}
+	if err := m.Refresh(); err != nil {
+		return nil, errors.Wrap(err, "cannot create REST mapper")
+	}
+	return m, nil
+}
+
+// Refresh replaces the underlying REST mapper with a new one. The existing
+// REST mapper continues to be used if a new one can't be created.
+func (m *RefreshingRESTMapper) Refresh() error {
+	rm, err := m.newMapper()
+	if err != nil {
+		return err
+	}
+	m.current.Store(&restMapper{RESTMapper: rm})
+	return nil
+}
+
+// RefreshEvery calls Refresh at the supplied interval until the supplied
+// context is done.
+func (m *RefreshingRESTMapper) RefreshEvery(ctx context.Context, interval time.Duration, log logging.Logger) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			if err := m.Refresh(); err != nil {
+				log.Info("Cannot refresh REST mapper", "error", err)
+				continue
+			}
+			log.Debug("Refreshed REST mapper")
+		}
+	}
+}
+
+// FailingGroups returns the sorted API groups in which the most recent
+// resolution failed.
+func (m *RefreshingRESTMapper) FailingGroups() []string {
+	m.mx.RLock()
+	defer m.mx.RUnlock()
+	out := make([]string, 0, len(m.failing))
+	for g := range m.failing {
+		out = append(out, g)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// record the outcome of a resolution in the supplied API group.
+func (m *RefreshingRESTMapper) record(group string, err error) {
+	// Most resolutions succeed in a known group that isn't failing, and don't
+	// need to record anything.
+	m.mx.RLock()
+	ok := err == nil && m.known[group] && !m.failing[group]
+	m.mx.RUnlock()
+	if ok {
+		return
+	}
+
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	if err != nil {
+		label := group
+		if !m.known[group] {
+			label = unknownGroup
+		}
+		opentelemetry.RecordRESTMappingFailure(context.Background(), label)
+		if len(m.failing) < maxFailingGroups {
+			m.failing[group] = true
+		}
+		return
+	}
+
+	// Groups only become known by resolving successfully, so there can't be
+	// more of them than the API server serves.
+	m.known[group] = true
+	if m.failing[group] {
+		delete(m.failing, group)
+		opentelemetry.RecordRESTMappingRecovery(context.Background(), group)
+	}
+}
+
+// KindFor takes a partial resource and returns the single match. Returns an
+// error if there are multiple matches.
+func (m *RefreshingRESTMapper) KindFor(resource schema.GroupVersionResource) (schema.GroupVersionKind, error) {
+	gvk, err := m.current.Load().KindFor(resource)
+	m.record(resource.Group, err)
+	return gvk, err
+}
+
+// KindsFor takes a partial resource and returns the list of potential kinds in
+// priority order.
+func (m *RefreshingRESTMapper) KindsFor(resource schema.GroupVersionResource) ([]schema.GroupVersionKind, error) {
+	gvks, err := m.current.Load().KindsFor(resource)
+	m.record(resource.Group, err)
+	return gvks, err
+}
+
+// ResourceFor takes a partial resource and returns the single match. Returns
+// an error if there are multiple matches.
+func (m *RefreshingRESTMapper) ResourceFor(input schema.GroupVersionResource) (schema.GroupVersionResource, error) {
+	gvr, err := m.current.Load().ResourceFor(input)
+	m.record(input.Group, err)
+	return gvr, err
+}
+
+// ResourcesFor takes a partial resource and returns the list of potential
+// resource in priority order.
+func (m *RefreshingRESTMapper) ResourcesFor(input schema.GroupVersionResource) ([]schema.GroupVersionResource, error) {
+	gvrs, err := m.current.Load().ResourcesFor(input)
+	m.record(input.Group, err)
+	return gvrs, err
+}
+
+// RESTMapping identifies a preferred resource mapping for the provided group
+// kind.
+func (m *RefreshingRESTMapper) RESTMapping(gk schema.GroupKind, versions ...string) (*meta.RESTMapping, error) {
+	rm, err := m.current.Load().RESTMapping(gk, versions...)
+	m.record(gk.Group, err)
+	return rm, err
+}
+
+// RESTMappings returns all resource mappings for the provided group kind if no
+// version search is provided. Otherwise identifies a preferred resource
+// mapping for the provided version(s).
+func (m *RefreshingRESTMapper) RESTMappings(gk schema.GroupKind, versions ...string) ([]*meta.RESTMapping, error) {
+	rms, err := m.current.Load().RESTMappings(gk, versions...)
+	m.record(gk.Group, err)
+	return rms, err
+}
+
+// ResourceSingularizer returns the singular form of the supplied resource.
+func (m *RefreshingRESTMapper) ResourceSingularizer(resource string) (string, error) {
+	return m.current.Load().ResourceSingularizer(resource)
+}
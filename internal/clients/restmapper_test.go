@@ -0,0 +1,166 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestRefreshingRESTMapper(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	gvk := schema.GroupVersionKind{Group: "example.org", Version: "v1", Kind: "Example"}
+	gvr := schema.GroupVersionResource{Group: "example.org", Version: "v1", Resource: "examples"}
+
+	empty := meta.NewDefaultRESTMapper(nil)
+	known := meta.NewDefaultRESTMapper(nil)
+	known.Add(gvk, meta.RESTScopeRoot)
+
+	var (
+		next meta.RESTMapper = empty
+		err  error
+	)
+	m, nerr := NewRefreshingRESTMapper(func() (meta.RESTMapper, error) { return next, err })
+	if nerr != nil {
+		t.Fatalf("NewRefreshingRESTMapper(...): %s", nerr)
+	}
+
+	if _, err := m.KindFor(gvr); !meta.IsNoMatchError(err) {
+		t.Errorf("m.KindFor(...): want no match error before refresh, got %v", err)
+	}
+	if diff := cmp.Diff([]string{"example.org"}, m.FailingGroups()); diff != "" {
+		t.Errorf("m.FailingGroups(): want group to be failing before refresh: -want, +got:\n%s", diff)
+	}
+
+	next = known
+	if err := m.Refresh(); err != nil {
+		t.Fatalf("m.Refresh(): %s", err)
+	}
+	got, kerr := m.KindFor(gvr)
+	if kerr != nil {
+		t.Fatalf("m.KindFor(...): %s", kerr)
+	}
+	if diff := cmp.Diff(gvk, got); diff != "" {
+		t.Errorf("m.KindFor(...): -want, +got:\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{}, m.FailingGroups()); diff != "" {
+		t.Errorf("m.FailingGroups(): want group to have recovered after refresh: -want, +got:\n%s", diff)
+	}
+
+	// A failed refresh should keep the existing mapper.
+	next, err = empty, errBoom
+	if diff := cmp.Diff(errBoom, m.Refresh(), test.EquateErrors()); diff != "" {
+		t.Errorf("m.Refresh(): -want error, +got error:\n%s", diff)
+	}
+	if _, err := m.KindFor(gvr); err != nil {
+		t.Errorf("m.KindFor(...): want existing mapper to be kept after failed refresh, got %v", err)
+	}
+}
+
+func TestRefreshingRESTMapperFailingGroups(t *testing.T) {
+	m, err := NewRefreshingRESTMapper(func() (meta.RESTMapper, error) { return meta.NewDefaultRESTMapper(nil), nil })
+	if err != nil {
+		t.Fatalf("NewRefreshingRESTMapper(...): %s", err)
+	}
+
+	// Callers choose which groups they resolve.
+	for i := 0; i < maxFailingGroups*2; i++ {
+		_, _ = m.KindFor(schema.GroupVersionResource{Group: fmt.Sprintf("%d.example.org", i), Version: "v1", Resource: "examples"})
+	}
+
+	if diff := cmp.Diff(maxFailingGroups, len(m.FailingGroups())); diff != "" {
+		t.Errorf("m.FailingGroups(): want at most %d failing groups: -want, +got:\n%s", maxFailingGroups, diff)
+	}
+}
+
+func TestDiskCachedRESTMapper(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "example.org", Version: "v1", Kind: "Example"}
+	gvr := schema.GroupVersionResource{Group: "example.org", Version: "v1", Resource: "examples"}
+
+	discovery := map[string]any{
+		"/api": &metav1.APIVersions{},
+		"/apis": &metav1.APIGroupList{Groups: []metav1.APIGroup{{
+			Name:             gvk.Group,
+			Versions:         []metav1.GroupVersionForDiscovery{{GroupVersion: gvk.GroupVersion().String(), Version: gvk.Version}},
+			PreferredVersion: metav1.GroupVersionForDiscovery{GroupVersion: gvk.GroupVersion().String(), Version: gvk.Version},
+		}}},
+		"/apis/example.org/v1": &metav1.APIResourceList{
+			GroupVersion: gvk.GroupVersion().String(),
+			APIResources: []metav1.APIResource{{Name: gvr.Resource, Kind: gvk.Kind, Verbs: metav1.Verbs{"get", "list"}}},
+		},
+	}
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		body, ok := discovery[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	cfg := &rest.Config{Host: srv.URL}
+
+	m, err := DiskCachedRESTMapper(cfg, dir, time.Hour)
+	if err != nil {
+		t.Fatalf("DiskCachedRESTMapper(...): %s", err)
+	}
+	got, err := m.KindFor(gvr)
+	if err != nil {
+		t.Fatalf("m.KindFor(...): %s", err)
+	}
+	if diff := cmp.Diff(gvk, got); diff != "" {
+		t.Errorf("m.KindFor(...): -want, +got:\n%s", diff)
+	}
+	if requests == 0 {
+		t.Errorf("m.KindFor(...): want discovery requests to the API server, got none")
+	}
+
+	// A new REST mapper, for example after a restart, should use the
+	// discovery data cached on disk rather than asking the API server.
+	requests = 0
+	m, err = DiskCachedRESTMapper(cfg, dir, time.Hour)
+	if err != nil {
+		t.Fatalf("DiskCachedRESTMapper(...): %s", err)
+	}
+	got, err = m.KindFor(gvr)
+	if err != nil {
+		t.Fatalf("m.KindFor(...): %s", err)
+	}
+	if diff := cmp.Diff(gvk, got); diff != "" {
+		t.Errorf("m.KindFor(...): -want, +got:\n%s", diff)
+	}
+	if diff := cmp.Diff(0, requests); diff != "" {
+		t.Errorf("m.KindFor(...): -want API server requests, +got API server requests:\n%s", diff)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import (
+	"context"
+
+	"github.com/upbound/xgql/internal/opentelemetry"
+)
+
+// The default number of calls waiting for a concurrent call to create their
+// client at or above which a stampede is occurring.
+const defaultStampedeThreshold = 10
+
+// CreateStats summarize the calls to the Cache that needed a new client.
+type CreateStats struct {
+	// Independent is the number of calls that created a new client.
+	Independent uint64
+
+	// Coalesced is the number of calls that used a client created by a
+	// concurrent call with the same credentials, rather than creating one.
+	Coalesced uint64
+
+	// Waiting is the number of calls that are currently waiting for a
+	// concurrent call with the same credentials to create a client.
+	Waiting int64
+
+	// Stampede is true if at least the Cache's stampede threshold of calls
+	// are waiting, for example because many requests that share credentials
+	// arrived while xgql was starting.
+	Stampede bool
+}
+
+// CreateStats returns statistics about the calls to the Cache that needed a
+// new client. Creations are also exported as OpenTelemetry metrics.
+func (c *Cache) CreateStats() CreateStats {
+	w := c.waiting.Load()
+	return CreateStats{
+		Independent: c.independent.Load(),
+		Coalesced:   c.coalesced.Load(),
+		Waiting:     w,
+		Stampede:    c.stampede > 0 && w >= c.stampede,
+	}
+}
+
+// recordCreate records that a call needed a new client, and whether it used a
+// client created by a concurrent call.
+func (c *Cache) recordCreate(ctx context.Context, coalesced bool) {
+	if coalesced {
+		c.coalesced.Add(1)
+	} else {
+		c.independent.Add(1)
+	}
+	opentelemetry.RecordClientCreate(ctx, coalesced)
+}
+
+// recordWaiting records a change in the number of calls waiting for a
+// concurrent call to create their client.
+func (c *Cache) recordWaiting(ctx context.Context, delta int64) {
+	w := c.waiting.Add(delta)
+	opentelemetry.RecordClientCreateWaiting(ctx, delta)
+	if delta > 0 && w == c.stampede {
+		c.log.Debug("Client creation stampede", "waiting", w)
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// A ttlClient is a client that reads objects of some kinds from the API server
+// at most once per kind-specific TTL. Reads of these kinds made within the TTL
+// of a previous identical read are served from a copy of its result. Reads of
+// other kinds are passed through to the underlying client.
+//
+// Objects of these kinds are not watched, so unlike objects in the client's
+// cache they may be up to their TTL out of date, and reading them does not
+// trigger live queries. A write of any object of one of these kinds discards
+// the results of previous reads of that kind, so that a caller can read its
+// own writes. Results are scoped to the client, and thus to the credentials it
+// uses. Results that are never read again are kept until the client expires.
+type ttlClient struct {
+	client.Client
+
+	ttls map[schema.GroupVersionKind]time.Duration
+	now  func() time.Time
+
+	mx      sync.Mutex
+	results map[ttlKey]ttlResult
+}
+
+type ttlKey struct {
+	gvk schema.GroupVersionKind
+
+	// Key is the namespaced name of a get, or the options of a list.
+	key string
+}
+
+type ttlResult struct {
+	obj     runtime.Object
+	expires time.Time
+}
+
+func newTTLClient(c client.Client, ttls map[schema.GroupVersionKind]time.Duration) *ttlClient {
+	return &ttlClient{Client: c, ttls: ttls, now: time.Now, results: make(map[ttlKey]ttlResult)}
+}
+
+// Get the object with the supplied key.
+func (c *ttlClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil || len(opts) > 0 {
+		return c.Client.Get(ctx, key, obj, opts...)
+	}
+	return c.read(gvk, key.String(), obj, func() error { return c.Client.Get(ctx, key, obj, opts...) })
+}
+
+// List objects.
+func (c *ttlClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	gvk, err := apiutil.GVKForObject(list, c.Scheme())
+	if err != nil {
+		return c.Client.List(ctx, list, opts...)
+	}
+	lo := &client.ListOptions{}
+	lo.ApplyOptions(opts)
+
+	// Paginated lists are never reused; each page depends on the last.
+	if lo.Limit > 0 || lo.Continue != "" {
+		return c.Client.List(ctx, list, opts...)
+	}
+	key := fmt.Sprintf("namespace=%s,labels=%s,fields=%s", lo.Namespace, lo.LabelSelector, lo.FieldSelector)
+	gvk.Kind = strings.TrimSuffix(gvk.Kind, "List")
+	return c.read(gvk, key, list, func() error { return c.Client.List(ctx, list, opts...) })
+}
+
+// Create the supplied object.
+func (c *ttlClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	defer c.forget(obj)
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+// Update the supplied object.
+func (c *ttlClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	defer c.forget(obj)
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+// Patch the supplied object.
+func (c *ttlClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	defer c.forget(obj)
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+// Delete the supplied object.
+func (c *ttlClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	defer c.forget(obj)
+	return c.Client.Delete(ctx, obj, opts...)
+}
+
+// DeleteAllOf deletes all objects of the supplied type.
+func (c *ttlClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	defer c.forget(obj)
+	return c.Client.DeleteAllOf(ctx, obj, opts...)
+}
+
+// read serves the supplied object from the result of a previous read with the
+// supplied key if that result has not expired. Otherwise it calls fn to read
+// the object and, if the read succeeds, stores a copy of the result.
+func (c *ttlClient) read(gvk schema.GroupVersionKind, key string, obj runtime.Object, fn func() error) error {
+	ttl, ok := c.ttls[gvk]
+	if !ok {
+		return fn()
+	}
+
+	tk := ttlKey{gvk: gvk, key: key}
+	now := c.now()
+
+	c.mx.Lock()
+	r, ok := c.results[tk]
+	if ok && now.After(r.expires) {
+		delete(c.results, tk)
+		ok = false
+	}
+	c.mx.Unlock()
+
+	if ok && copyInto(r.obj, obj) {
+		return nil
+	}
+
+	if err := fn(); err != nil {
+		return err
+	}
+
+	c.mx.Lock()
+	c.results[tk] = ttlResult{obj: obj.DeepCopyObject(), expires: now.Add(ttl)}
+	c.mx.Unlock()
+	return nil
+}
+
+// forget the results of all previous reads of the supplied object's kind.
+func (c *ttlClient) forget(obj runtime.Object) {
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		return
+	}
+	if _, ok := c.ttls[gvk]; !ok {
+		return
+	}
+
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	for tk := range c.results {
+		if tk.gvk == gvk {
+			delete(c.results, tk)
+		}
+	}
+}
+
+// copyInto copies a deep copy of from into to, if they are the same type.
+func copyInto(from, to runtime.Object) bool {
+	fv, tv := reflect.ValueOf(from), reflect.ValueOf(to)
+	if fv.Type() != tv.Type() || fv.Kind() != reflect.Pointer {
+		return false
+	}
+	tv.Elem().Set(reflect.ValueOf(from.DeepCopyObject()).Elem())
+	return true
+}
@@ -0,0 +1,167 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestTTLClient(t *testing.T) {
+	ttl := schema.GroupVersionKind{Group: "example.org", Version: "v1", Kind: "Slow"}
+	other := schema.GroupVersionKind{Group: "example.org", Version: "v1", Kind: "Fast"}
+
+	// An action is a call to the client, made a duration after it was created.
+	type action struct {
+		after time.Duration
+		call  func(ctx context.Context, c client.Client) error
+	}
+
+	get := func(gvk schema.GroupVersionKind, name string) func(ctx context.Context, c client.Client) error {
+		return func(ctx context.Context, c client.Client) error {
+			u := &unstructured.Unstructured{}
+			u.SetGroupVersionKind(gvk)
+			return c.Get(ctx, types.NamespacedName{Name: name}, u)
+		}
+	}
+	list := func(gvk schema.GroupVersionKind, opts ...client.ListOption) func(ctx context.Context, c client.Client) error {
+		return func(ctx context.Context, c client.Client) error {
+			ul := &unstructured.UnstructuredList{}
+			ul.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+			return c.List(ctx, ul, opts...)
+		}
+	}
+	patch := func(gvk schema.GroupVersionKind, name string) func(ctx context.Context, c client.Client) error {
+		return func(ctx context.Context, c client.Client) error {
+			u := &unstructured.Unstructured{}
+			u.SetGroupVersionKind(gvk)
+			u.SetName(name)
+			return c.Patch(ctx, u, client.RawPatch(types.MergePatchType, []byte("{}")))
+		}
+	}
+
+	type want struct {
+		gets  int
+		lists int
+	}
+
+	cases := map[string]struct {
+		reason  string
+		actions []action
+		want    want
+	}{
+		"GetWithinTTL": {
+			reason: "Identical gets of a kind with a TTL within the TTL should be read from the API server once.",
+			actions: []action{
+				{call: get(ttl, "a")},
+				{after: 30 * time.Second, call: get(ttl, "a")},
+			},
+			want: want{gets: 1},
+		},
+		"GetDifferentNames": {
+			reason: "Gets of different objects should each be read from the API server.",
+			actions: []action{
+				{call: get(ttl, "a")},
+				{call: get(ttl, "b")},
+			},
+			want: want{gets: 2},
+		},
+		"GetAfterTTL": {
+			reason: "A get of a kind with a TTL after the TTL should be read from the API server again.",
+			actions: []action{
+				{call: get(ttl, "a")},
+				{after: 2 * time.Minute, call: get(ttl, "a")},
+			},
+			want: want{gets: 2},
+		},
+		"GetWithoutTTL": {
+			reason: "Gets of a kind without a TTL should always be read from the underlying client.",
+			actions: []action{
+				{call: get(other, "a")},
+				{call: get(other, "a")},
+			},
+			want: want{gets: 2},
+		},
+		"ListWithinTTL": {
+			reason: "Identical lists of a kind with a TTL within the TTL should be read from the API server once.",
+			actions: []action{
+				{call: list(ttl, client.InNamespace("default"))},
+				{call: list(ttl, client.InNamespace("default"))},
+				{call: list(ttl, client.InNamespace("other"))},
+			},
+			want: want{lists: 2},
+		},
+		"ListPaginated": {
+			reason: "Paginated lists should always be read from the API server.",
+			actions: []action{
+				{call: list(ttl, client.Limit(10))},
+				{call: list(ttl, client.Limit(10))},
+			},
+			want: want{lists: 2},
+		},
+		"WriteForgets": {
+			reason: "A write of a kind with a TTL should cause subsequent reads of that kind to be read from the API server.",
+			actions: []action{
+				{call: get(ttl, "a")},
+				{call: list(ttl)},
+				{call: patch(ttl, "b")},
+				{call: get(ttl, "a")},
+				{call: list(ttl)},
+			},
+			want: want{gets: 2, lists: 2},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := want{}
+			mc := &test.MockClient{
+				MockGet: func(_ context.Context, _ client.ObjectKey, _ client.Object) error {
+					got.gets++
+					return nil
+				},
+				MockList: func(_ context.Context, _ client.ObjectList, _ ...client.ListOption) error {
+					got.lists++
+					return nil
+				},
+				MockPatch:  test.NewMockPatchFn(nil),
+				MockScheme: test.NewMockSchemeFn(runtime.NewScheme()),
+			}
+
+			created := time.Now()
+			c := newTTLClient(mc, map[schema.GroupVersionKind]time.Duration{ttl: time.Minute})
+			for _, a := range tc.actions {
+				c.now = func() time.Time { return created.Add(a.after) }
+				if err := a.call(context.Background(), c); err != nil {
+					t.Fatalf("\n%s\nttlClient: unexpected error: %s", tc.reason, err)
+				}
+			}
+
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nttlClient: -want reads, +got reads:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -17,21 +17,49 @@ package model
 import (
 	"encoding/json"
 
+	corev1 "k8s.io/api/core/v1"
 	kextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/utils/ptr"
 
 	"github.com/google/go-cmp/cmp"
 
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+
 	extv1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
 )
 
+// AnnotationKeyCompositionResourceName is the annotation Crossplane uses to
+// record which of a composition's resource templates a resource was composed
+// from.
+const AnnotationKeyCompositionResourceName = "crossplane.io/composition-resource-name"
+
+const (
+	errFmtUnknownReadinessCheck = "unknown readiness check type %q"
+	errMissingMatchCondition    = "matchCondition is required by MatchCondition readiness checks"
+)
+
+// defaultReadinessChecks are used for resource templates that don't specify
+// any readiness checks, per Crossplane.
+var defaultReadinessChecks = []extv1.ReadinessCheck{{
+	Type: extv1.ReadinessCheckTypeMatchCondition,
+	MatchCondition: &extv1.MatchConditionReadinessCheck{
+		Type:   xpv1.TypeReady,
+		Status: corev1.ConditionTrue,
+	},
+}}
+
 // A CompositeResourceDefinitionSpec represents the desired state of a
 // CompositeResourceDefinition.
 type CompositeResourceDefinitionSpec struct {
-	Group                string                               `json:"group"`
-	Names                CompositeResourceDefinitionNames     `json:"names"`
-	ClaimNames           *CompositeResourceDefinitionNames    `json:"claimNames"`
-	ConnectionSecretKeys []string                             `json:"connectionSecretKeys"`
-	Versions             []CompositeResourceDefinitionVersion `json:"versions"`
+	Group                  string                               `json:"group"`
+	Names                  CompositeResourceDefinitionNames     `json:"names"`
+	ClaimNames             *CompositeResourceDefinitionNames    `json:"claimNames"`
+	ConnectionSecretKeys   []string                             `json:"connectionSecretKeys"`
+	DefaultCompositionRef  *LocalObjectReference                `json:"defaultCompositionRef"`
+	EnforcedCompositionRef *LocalObjectReference                `json:"enforcedCompositionRef"`
+	Versions               []CompositeResourceDefinitionVersion `json:"versions"`
 
 	DefaultCompositionReference  *extv1.CompositionReference
 	EnforcedCompositionReference *extv1.CompositionReference
@@ -133,7 +161,10 @@ func GetCompositeResourceDefinition(xrd *extv1.CompositeResourceDefinition) Comp
 			Group:                        xrd.Spec.Group,
 			Names:                        *GetCompositeResourceDefinitionNames(&xrd.Spec.Names),
 			ClaimNames:                   GetCompositeResourceDefinitionNames(xrd.Spec.ClaimNames),
+			ConnectionSecretKeys:         xrd.Spec.ConnectionSecretKeys,
 			Versions:                     GetCompositeResourceDefinitionVersions(xrd.Spec.Versions),
+			DefaultCompositionRef:        getCompositionReference(xrd.Spec.DefaultCompositionRef),
+			EnforcedCompositionRef:       getCompositionReference(xrd.Spec.EnforcedCompositionRef),
 			DefaultCompositionReference:  xrd.Spec.DefaultCompositionRef,
 			EnforcedCompositionReference: xrd.Spec.EnforcedCompositionRef,
 		},
@@ -144,6 +175,13 @@ func GetCompositeResourceDefinition(xrd *extv1.CompositeResourceDefinition) Comp
 	}
 }
 
+func getCompositionReference(ref *extv1.CompositionReference) *LocalObjectReference {
+	if ref == nil {
+		return nil
+	}
+	return &LocalObjectReference{Name: ref.Name}
+}
+
 // GetComposition from the supplied Crossplane Composition.
 func GetComposition(cmp *extv1.Composition) Composition {
 	return Composition{
@@ -161,6 +199,9 @@ func GetComposition(cmp *extv1.Composition) Composition {
 				Kind:       cmp.Spec.CompositeTypeRef.Kind,
 			},
 			WriteConnectionSecretsToNamespace: cmp.Spec.WriteConnectionSecretsToNamespace,
+			Pipeline:                          GetPipeline(cmp.Spec),
+			PatchSets:                         GetPatchSets(cmp.Spec),
+			Resources:                         GetComposedTemplates(cmp.Spec),
 		},
 		PavedAccess: PavedAccess{
 			Paved: paveObject(cmp),
@@ -168,6 +209,271 @@ func GetComposition(cmp *extv1.Composition) Composition {
 	}
 }
 
+// GetPipeline from the supplied Crossplane Composition spec. Compositions that
+// don't use Pipeline mode have no pipeline.
+func GetPipeline(in extv1.CompositionSpec) []PipelineStep {
+	if in.Mode == nil || *in.Mode != extv1.CompositionModePipeline {
+		return nil
+	}
+
+	out := make([]PipelineStep, len(in.Pipeline))
+	for i, s := range in.Pipeline {
+		out[i] = PipelineStep{
+			Step:        s.Step,
+			FunctionRef: FunctionReference{Name: s.FunctionRef.Name},
+		}
+		if s.Input != nil {
+			out[i].Input = s.Input.Raw
+		}
+	}
+	return out
+}
+
+// GetPatchSets from the supplied Crossplane Composition spec. Compositions
+// that use Pipeline mode have no patch sets.
+func GetPatchSets(in extv1.CompositionSpec) []PatchSet {
+	if in.Mode != nil && *in.Mode == extv1.CompositionModePipeline || len(in.PatchSets) == 0 {
+		return nil
+	}
+
+	out := make([]PatchSet, len(in.PatchSets))
+	for i, ps := range in.PatchSets {
+		out[i] = PatchSet{Name: ps.Name, Patches: GetCompositionPatches(ps.Patches)}
+	}
+	return out
+}
+
+// GetComposedTemplates from the supplied Crossplane Composition spec.
+// Compositions that use Pipeline mode have no resource templates.
+func GetComposedTemplates(in extv1.CompositionSpec) []ComposedTemplate {
+	if in.Mode != nil && *in.Mode == extv1.CompositionModePipeline || len(in.Resources) == 0 {
+		return nil
+	}
+
+	out := make([]ComposedTemplate, len(in.Resources))
+	for i, t := range in.Resources {
+		out[i] = ComposedTemplate{
+			Name:    t.Name,
+			Base:    t.Base.Raw,
+			Patches: GetCompositionPatches(t.Patches),
+		}
+		if out[i].Base == nil {
+			out[i].Base = []byte("{}")
+		}
+	}
+	return out
+}
+
+// GetCompositionPatches from the supplied Crossplane patches.
+func GetCompositionPatches(in []extv1.Patch) []CompositionPatch {
+	out := make([]CompositionPatch, len(in))
+	for i, p := range in {
+		out[i] = CompositionPatch{
+			Type:          string(p.Type),
+			FromFieldPath: p.FromFieldPath,
+			ToFieldPath:   p.ToFieldPath,
+			PatchSetName:  p.PatchSetName,
+			Transforms:    make([]Transform, 0, len(p.Transforms)),
+		}
+		// Patches default to patching from the composite resource.
+		if p.Type == "" {
+			out[i].Type = string(extv1.PatchTypeFromCompositeFieldPath)
+		}
+		if p.Combine != nil {
+			out[i].CombineFromFieldPaths = make([]string, len(p.Combine.Variables))
+			for j, v := range p.Combine.Variables {
+				out[i].CombineFromFieldPaths[j] = v.FromFieldPath
+			}
+		}
+		for _, t := range p.Transforms {
+			if tf := GetTransform(t); tf != nil {
+				out[i].Transforms = append(out[i].Transforms, tf)
+			}
+		}
+	}
+	return out
+}
+
+// GetTransform from the supplied Crossplane transform. It returns nil if the
+// transform is of an unknown type, or lacks the configuration its type needs.
+func GetTransform(in extv1.Transform) Transform { //nolint:gocyclo // One case per transform type.
+	switch in.Type {
+	case extv1.TransformTypeMath:
+		if in.Math == nil {
+			return nil
+		}
+		return MathTransform{
+			Type:     string(in.Math.GetType()),
+			Multiply: getIntPtr(in.Math.Multiply),
+			ClampMin: getIntPtr(in.Math.ClampMin),
+			ClampMax: getIntPtr(in.Math.ClampMax),
+		}
+	case extv1.TransformTypeMap:
+		if in.Map == nil {
+			return nil
+		}
+		raw, err := json.Marshal(in.Map.Pairs)
+		if err != nil {
+			return nil
+		}
+		return MapTransform{Pairs: raw}
+	case extv1.TransformTypeMatch:
+		if in.Match == nil {
+			return nil
+		}
+		out := MatchTransform{
+			Patterns:      make([]MatchTransformPattern, len(in.Match.Patterns)),
+			FallbackValue: in.Match.FallbackValue.Raw,
+		}
+		if in.Match.FallbackTo != "" {
+			out.FallbackTo = ptr.To(string(in.Match.FallbackTo))
+		}
+		for i, p := range in.Match.Patterns {
+			out.Patterns[i] = MatchTransformPattern{
+				Type:    string(p.Type),
+				Literal: p.Literal,
+				Regexp:  p.Regexp,
+				Result:  p.Result.Raw,
+			}
+			// Patterns default to matching a literal.
+			if p.Type == "" {
+				out.Patterns[i].Type = string(extv1.MatchTransformPatternTypeLiteral)
+			}
+		}
+		return out
+	case extv1.TransformTypeString:
+		if in.String == nil {
+			return nil
+		}
+		out := StringTransform{
+			Type:   string(in.String.Type),
+			Format: in.String.Format,
+			Trim:   in.String.Trim,
+		}
+		// String transforms default to formatting.
+		if in.String.Type == "" {
+			out.Type = string(extv1.StringTransformTypeFormat)
+		}
+		if in.String.Convert != nil {
+			out.Convert = ptr.To(string(*in.String.Convert))
+		}
+		if in.String.Regexp != nil {
+			out.Regexp = ptr.To(in.String.Regexp.Match)
+			out.RegexpGroup = in.String.Regexp.Group
+		}
+		if in.String.Join != nil {
+			out.Separator = ptr.To(in.String.Join.Separator)
+		}
+		return out
+	case extv1.TransformTypeConvert:
+		if in.Convert == nil {
+			return nil
+		}
+		out := ConvertTransform{ToType: string(in.Convert.ToType)}
+		if in.Convert.Format != nil {
+			out.Format = ptr.To(string(*in.Convert.Format))
+		}
+		return out
+	}
+	return nil
+}
+
+// GetReadinessCheckResults evaluates the readiness checks of the supplied
+// composition's resource template against the supplied resource, which must
+// have been composed from that template. It returns nil if the resource wasn't
+// composed from one of the composition's resource templates.
+func GetReadinessCheckResults(cmp *extv1.Composition, p *fieldpath.Paved) []ReadinessCheckResult {
+	name, err := p.GetString("metadata.annotations[" + AnnotationKeyCompositionResourceName + "]")
+	if err != nil {
+		return nil
+	}
+
+	// Compositions that use a function pipeline have no resource templates.
+	for _, t := range cmp.Spec.Resources {
+		if ptr.Deref(t.Name, "") != name {
+			continue
+		}
+		checks := t.ReadinessChecks
+		if len(checks) == 0 {
+			checks = defaultReadinessChecks
+		}
+		out := make([]ReadinessCheckResult, len(checks))
+		for i := range checks {
+			out[i] = GetReadinessCheckResult(checks[i], p)
+		}
+		return out
+	}
+	return nil
+}
+
+// GetReadinessCheckResult evaluates the supplied readiness check against the
+// supplied resource.
+func GetReadinessCheckResult(rc extv1.ReadinessCheck, p *fieldpath.Paved) ReadinessCheckResult {
+	out := ReadinessCheckResult{Type: string(rc.Type)}
+	switch rc.Type {
+	case extv1.ReadinessCheckTypeMatchString:
+		out.MatchString = ptr.To(rc.MatchString)
+	case extv1.ReadinessCheckTypeMatchInteger:
+		out.MatchInteger = ptr.To(int(rc.MatchInteger))
+	case extv1.ReadinessCheckTypeMatchCondition:
+		if rc.MatchCondition != nil {
+			out.MatchCondition = &ReadinessCheckCondition{
+				Type:   string(rc.MatchCondition.Type),
+				Status: GetConditionStatus(rc.MatchCondition.Status),
+			}
+		}
+	}
+	if rc.FieldPath != "" {
+		out.FieldPath = ptr.To(rc.FieldPath)
+	}
+
+	passed, err := readinessCheckPassed(rc, p)
+	if err != nil {
+		out.Error = ptr.To(err.Error())
+	}
+	out.Passed = passed
+	return out
+}
+
+// readinessCheckPassed mirrors how Crossplane evaluates readiness checks. A
+// check of a field that doesn't exist doesn't pass, but isn't an error.
+func readinessCheckPassed(rc extv1.ReadinessCheck, p *fieldpath.Paved) (bool, error) {
+	var passed bool
+	var err error
+	switch rc.Type {
+	case extv1.ReadinessCheckTypeNone:
+		return true, nil
+	case extv1.ReadinessCheckTypeNonEmpty:
+		_, err = p.GetValue(rc.FieldPath)
+		passed = err == nil
+	case extv1.ReadinessCheckTypeMatchString:
+		var v string
+		v, err = p.GetString(rc.FieldPath)
+		passed = err == nil && v == rc.MatchString
+	case extv1.ReadinessCheckTypeMatchInteger:
+		var v int64
+		v, err = p.GetInteger(rc.FieldPath)
+		passed = err == nil && v == rc.MatchInteger
+	case extv1.ReadinessCheckTypeMatchTrue, extv1.ReadinessCheckTypeMatchFalse:
+		var v bool
+		v, err = p.GetBool(rc.FieldPath)
+		passed = err == nil && v == (rc.Type == extv1.ReadinessCheckTypeMatchTrue)
+	case extv1.ReadinessCheckTypeMatchCondition:
+		if rc.MatchCondition == nil {
+			return false, errors.New(errMissingMatchCondition)
+		}
+		s := xpv1.ConditionedStatus{}
+		_ = p.GetValueInto("status", &s)
+		return s.GetCondition(rc.MatchCondition.Type).Status == rc.MatchCondition.Status, nil
+	default:
+		return false, errors.Errorf(errFmtUnknownReadinessCheck, rc.Type)
+	}
+	if fieldpath.IsNotFound(err) {
+		return false, nil
+	}
+	return passed, err
+}
+
 /* Handle deprecated items preferring non-deprecated */
 func (options *DefinedCompositeResourceOptionsInput) DeprecationPatch(version *string) {
 	if version != nil && options.Version == nil {
@@ -26,6 +26,7 @@ import (
 	"k8s.io/utils/ptr"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
 	extv1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
 )
 
@@ -76,6 +77,7 @@ func TestGetCompositeResourceDefinition(t *testing.T) {
 							OpenAPIV3Schema: rschema,
 						},
 					}},
+					ConnectionSecretKeys:   []string{"username", "password"},
 					DefaultCompositionRef:  &extv1.CompositionReference{Name: "default"},
 					EnforcedCompositionRef: &extv1.CompositionReference{Name: "enforced"},
 				},
@@ -130,6 +132,9 @@ func TestGetCompositeResourceDefinition(t *testing.T) {
 						Served:        true,
 						Schema:        &CompositeResourceValidation{OpenAPIV3Schema: schema},
 					}},
+					ConnectionSecretKeys:         []string{"username", "password"},
+					DefaultCompositionRef:        &LocalObjectReference{Name: "default"},
+					EnforcedCompositionRef:       &LocalObjectReference{Name: "enforced"},
 					DefaultCompositionReference:  &extv1.CompositionReference{Name: "default"},
 					EnforcedCompositionReference: &extv1.CompositionReference{Name: "enforced"},
 				},
@@ -219,6 +224,167 @@ func TestGetComposition(t *testing.T) {
 				},
 			},
 		},
+		"Pipeline": {
+			reason: "The pipeline of a Pipeline mode composition should be converted to our model",
+			xrd: &extv1.Composition{
+				Spec: extv1.CompositionSpec{
+					Mode: ptr.To(extv1.CompositionModePipeline),
+					Pipeline: []extv1.PipelineStep{
+						{
+							Step:        "cool-step",
+							FunctionRef: extv1.FunctionReference{Name: "cool-function"},
+							Input:       &rschema,
+						},
+						{
+							Step:        "no-input",
+							FunctionRef: extv1.FunctionReference{Name: "cool-function"},
+						},
+					},
+				},
+			},
+			want: Composition{
+				Metadata: ObjectMeta{},
+				Spec: CompositionSpec{
+					CompositeTypeRef: TypeReference{},
+					Pipeline: []PipelineStep{
+						{
+							Step:        "cool-step",
+							FunctionRef: FunctionReference{Name: "cool-function"},
+							Input:       []byte(schema),
+						},
+						{
+							Step:        "no-input",
+							FunctionRef: FunctionReference{Name: "cool-function"},
+						},
+					},
+				},
+			},
+		},
+		"Resources": {
+			reason: "The patch sets and resource templates of a Resources mode composition should be converted to our model",
+			xrd: &extv1.Composition{
+				Spec: extv1.CompositionSpec{
+					PatchSets: []extv1.PatchSet{
+						{
+							Name: "common",
+							Patches: []extv1.Patch{
+								{FromFieldPath: ptr.To("spec.region"), ToFieldPath: ptr.To("spec.forProvider.region")},
+							},
+						},
+					},
+					Resources: []extv1.ComposedTemplate{
+						{
+							Name: ptr.To("bucket"),
+							Base: rschema,
+							Patches: []extv1.Patch{
+								{Type: extv1.PatchTypePatchSet, PatchSetName: ptr.To("common")},
+								{
+									Type:          extv1.PatchTypeFromCompositeFieldPath,
+									FromFieldPath: ptr.To("spec.size"),
+									ToFieldPath:   ptr.To("spec.forProvider.size"),
+									Transforms: []extv1.Transform{
+										{Type: extv1.TransformTypeMath, Math: &extv1.MathTransform{Multiply: ptr.To[int64](2)}},
+										{Type: extv1.TransformTypeMap, Map: &extv1.MapTransform{Pairs: map[string]v1.JSON{"small": {Raw: []byte(`"t3.small"`)}}}},
+										{Type: extv1.TransformTypeMatch, Match: &extv1.MatchTransform{
+											Patterns:   []extv1.MatchTransformPattern{{Literal: ptr.To("a"), Result: v1.JSON{Raw: []byte(`"b"`)}}},
+											FallbackTo: extv1.MatchFallbackToTypeInput,
+										}},
+										{Type: extv1.TransformTypeString, String: &extv1.StringTransform{Format: ptr.To("%s-cool")}},
+										{Type: extv1.TransformTypeString, String: &extv1.StringTransform{
+											Type:   extv1.StringTransformTypeRegexp,
+											Regexp: &extv1.StringTransformRegexp{Match: "^(.*)$", Group: ptr.To(1)},
+										}},
+										{Type: extv1.TransformTypeConvert, Convert: &extv1.ConvertTransform{ToType: extv1.TransformIOTypeInt64}},
+										{Type: extv1.TransformTypeMath},
+									},
+								},
+								{
+									Type: extv1.PatchTypeCombineFromComposite,
+									Combine: &extv1.Combine{
+										Variables: []extv1.CombineVariable{{FromFieldPath: "spec.a"}, {FromFieldPath: "spec.b"}},
+									},
+									ToFieldPath: ptr.To("metadata.name"),
+								},
+							},
+						},
+					},
+				},
+			},
+			want: Composition{
+				Metadata: ObjectMeta{},
+				Spec: CompositionSpec{
+					CompositeTypeRef: TypeReference{},
+					PatchSets: []PatchSet{
+						{
+							Name: "common",
+							Patches: []CompositionPatch{
+								{
+									Type:          string(extv1.PatchTypeFromCompositeFieldPath),
+									FromFieldPath: ptr.To("spec.region"),
+									ToFieldPath:   ptr.To("spec.forProvider.region"),
+									Transforms:    []Transform{},
+								},
+							},
+						},
+					},
+					Resources: []ComposedTemplate{
+						{
+							Name: ptr.To("bucket"),
+							Base: []byte(schema),
+							Patches: []CompositionPatch{
+								{
+									Type:         string(extv1.PatchTypePatchSet),
+									PatchSetName: ptr.To("common"),
+									Transforms:   []Transform{},
+								},
+								{
+									Type:          string(extv1.PatchTypeFromCompositeFieldPath),
+									FromFieldPath: ptr.To("spec.size"),
+									ToFieldPath:   ptr.To("spec.forProvider.size"),
+									Transforms: []Transform{
+										MathTransform{Type: string(extv1.MathTransformTypeMultiply), Multiply: ptr.To(2)},
+										MapTransform{Pairs: []byte(`{"small":"t3.small"}`)},
+										MatchTransform{
+											Patterns:   []MatchTransformPattern{{Type: string(extv1.MatchTransformPatternTypeLiteral), Literal: ptr.To("a"), Result: []byte(`"b"`)}},
+											FallbackTo: ptr.To(string(extv1.MatchFallbackToTypeInput)),
+										},
+										StringTransform{Type: string(extv1.StringTransformTypeFormat), Format: ptr.To("%s-cool")},
+										StringTransform{Type: string(extv1.StringTransformTypeRegexp), Regexp: ptr.To("^(.*)$"), RegexpGroup: ptr.To(1)},
+										ConvertTransform{ToType: string(extv1.TransformIOTypeInt64)},
+									},
+								},
+								{
+									Type:                  string(extv1.PatchTypeCombineFromComposite),
+									CombineFromFieldPaths: []string{"spec.a", "spec.b"},
+									ToFieldPath:           ptr.To("metadata.name"),
+									Transforms:            []Transform{},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"ResourcesMode": {
+			reason: "A Resources mode composition should have no pipeline",
+			xrd: &extv1.Composition{
+				Spec: extv1.CompositionSpec{
+					Mode: ptr.To(extv1.CompositionModeResources),
+					Pipeline: []extv1.PipelineStep{
+						{
+							Step:        "ignored",
+							FunctionRef: extv1.FunctionReference{Name: "cool-function"},
+						},
+					},
+				},
+			},
+			want: Composition{
+				Metadata: ObjectMeta{},
+				Spec: CompositionSpec{
+					CompositeTypeRef: TypeReference{},
+				},
+			},
+		},
 		"Empty": {
 			reason: "Absent optional fields should be absent in our model",
 			xrd:    &extv1.Composition{},
@@ -241,6 +407,97 @@ func TestGetComposition(t *testing.T) {
 	}
 }
 
+func TestGetReadinessCheckResults(t *testing.T) {
+	composed := fieldpath.Pave(map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]any{AnnotationKeyCompositionResourceName: "bucket"},
+		},
+		"status": map[string]any{
+			"atProvider": map[string]any{"state": "Available", "replicas": int64(3), "public": false},
+			"conditions": []any{map[string]any{"type": "Ready", "status": "True"}},
+		},
+	})
+	uncomposed := fieldpath.Pave(map[string]any{})
+
+	composition := func(checks ...extv1.ReadinessCheck) *extv1.Composition {
+		return &extv1.Composition{Spec: extv1.CompositionSpec{
+			Resources: []extv1.ComposedTemplate{
+				{Name: ptr.To("other")},
+				{Name: ptr.To("bucket"), ReadinessChecks: checks},
+			},
+		}}
+	}
+
+	cases := map[string]struct {
+		reason string
+		cmp    *extv1.Composition
+		p      *fieldpath.Paved
+		want   []ReadinessCheckResult
+	}{
+		"NotComposed": {
+			reason: "A resource without a composition resource name annotation has no readiness checks.",
+			cmp:    composition(),
+			p:      uncomposed,
+			want:   nil,
+		},
+		"NoTemplate": {
+			reason: "A resource that names a template the composition doesn't have has no readiness checks.",
+			cmp:    &extv1.Composition{},
+			p:      composed,
+			want:   nil,
+		},
+		"DefaultChecks": {
+			reason: "A template without readiness checks should be checked for a true Ready condition.",
+			cmp:    composition(),
+			p:      composed,
+			want: []ReadinessCheckResult{{
+				Type:           "MatchCondition",
+				MatchCondition: &ReadinessCheckCondition{Type: "Ready", Status: ConditionStatusTrue},
+				Passed:         true,
+			}},
+		},
+		"Checks": {
+			reason: "Each of a template's readiness checks should be evaluated against the resource.",
+			cmp: composition(
+				extv1.ReadinessCheck{Type: extv1.ReadinessCheckTypeMatchString, FieldPath: "status.atProvider.state", MatchString: "Available"},
+				extv1.ReadinessCheck{Type: extv1.ReadinessCheckTypeMatchInteger, FieldPath: "status.atProvider.replicas", MatchInteger: 2},
+				extv1.ReadinessCheck{Type: extv1.ReadinessCheckTypeNonEmpty, FieldPath: "status.atProvider.missing"},
+				extv1.ReadinessCheck{Type: extv1.ReadinessCheckTypeMatchFalse, FieldPath: "status.atProvider.public"},
+				extv1.ReadinessCheck{Type: extv1.ReadinessCheckTypeMatchTrue, FieldPath: "status.atProvider.state"},
+				extv1.ReadinessCheck{Type: extv1.ReadinessCheckTypeMatchCondition, MatchCondition: &extv1.MatchConditionReadinessCheck{Type: "Synced", Status: "True"}},
+				extv1.ReadinessCheck{Type: extv1.ReadinessCheckTypeNone},
+			),
+			p: composed,
+			want: []ReadinessCheckResult{
+				{Type: "MatchString", FieldPath: ptr.To("status.atProvider.state"), MatchString: ptr.To("Available"), Passed: true},
+				{Type: "MatchInteger", FieldPath: ptr.To("status.atProvider.replicas"), MatchInteger: ptr.To(2), Passed: false},
+				{Type: "NonEmpty", FieldPath: ptr.To("status.atProvider.missing"), Passed: false},
+				{Type: "MatchFalse", FieldPath: ptr.To("status.atProvider.public"), Passed: true},
+				{Type: "MatchTrue", FieldPath: ptr.To("status.atProvider.state"), Passed: false, Error: ptr.To("status.atProvider.state: not a bool")},
+				{Type: "MatchCondition", MatchCondition: &ReadinessCheckCondition{Type: "Synced", Status: ConditionStatusTrue}, Passed: false},
+				{Type: "None", Passed: true},
+			},
+		},
+		"UnknownCheck": {
+			reason: "A readiness check of an unknown type should not pass.",
+			cmp:    composition(extv1.ReadinessCheck{Type: "Cool"}),
+			p:      composed,
+			want: []ReadinessCheckResult{
+				{Type: "Cool", Error: ptr.To(`unknown readiness check type "Cool"`)},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GetReadinessCheckResults(tc.cmp, tc.p)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGetReadinessCheckResults(...): -want, +got\n:%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
 func TestDefinedCompositeResourceOptionsInputDeprecation(t *testing.T) {
 	version1 := "v1"
 	version2 := "v2"
@@ -0,0 +1,96 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	authv1 "k8s.io/api/authorization/v1"
+	"k8s.io/utils/ptr"
+)
+
+// GetKubernetesResourceAttributes from the supplied input.
+func GetKubernetesResourceAttributes(in ResourceAttributesInput) *authv1.ResourceAttributes {
+	return &authv1.ResourceAttributes{
+		Verb:        in.Verb,
+		Group:       ptr.Deref(in.Group, ""),
+		Resource:    in.Resource,
+		Subresource: ptr.Deref(in.Subresource, ""),
+		Name:        ptr.Deref(in.Name, ""),
+		Namespace:   ptr.Deref(in.Namespace, ""),
+	}
+}
+
+// GetResourceAttributes from the supplied Kubernetes resource attributes.
+func GetResourceAttributes(in *authv1.ResourceAttributes) ResourceAttributes {
+	if in == nil {
+		return ResourceAttributes{}
+	}
+	out := ResourceAttributes{
+		Verb:     in.Verb,
+		Resource: in.Resource,
+	}
+	if in.Group != "" {
+		out.Group = ptr.To(in.Group)
+	}
+	if in.Subresource != "" {
+		out.Subresource = ptr.To(in.Subresource)
+	}
+	if in.Name != "" {
+		out.Name = ptr.To(in.Name)
+	}
+	if in.Namespace != "" {
+		out.Namespace = ptr.To(in.Namespace)
+	}
+	return out
+}
+
+// GetAccessReview from the supplied Kubernetes self subject access review.
+func GetAccessReview(in *authv1.SelfSubjectAccessReview) AccessReview {
+	out := AccessReview{
+		ResourceAttributes: GetResourceAttributes(in.Spec.ResourceAttributes),
+		Allowed:            in.Status.Allowed,
+		Denied:             in.Status.Denied,
+	}
+	if in.Status.Reason != "" {
+		out.Reason = ptr.To(in.Status.Reason)
+	}
+	return out
+}
+
+// GetSubjectRules from the supplied Kubernetes self subject rules review.
+func GetSubjectRules(in *authv1.SelfSubjectRulesReview) SubjectRules {
+	out := SubjectRules{
+		ResourceRules:    make([]ResourceRule, len(in.Status.ResourceRules)),
+		NonResourceRules: make([]NonResourceRule, len(in.Status.NonResourceRules)),
+		Incomplete:       in.Status.Incomplete,
+	}
+	for i, rr := range in.Status.ResourceRules {
+		out.ResourceRules[i] = ResourceRule{
+			Verbs:         rr.Verbs,
+			APIGroups:     rr.APIGroups,
+			Resources:     rr.Resources,
+			ResourceNames: rr.ResourceNames,
+		}
+	}
+	for i, nr := range in.Status.NonResourceRules {
+		out.NonResourceRules[i] = NonResourceRule{
+			Verbs:           nr.Verbs,
+			NonResourceURLs: nr.NonResourceURLs,
+		}
+	}
+	if in.Status.EvaluationError != "" {
+		out.EvaluationError = ptr.To(in.Status.EvaluationError)
+	}
+	return out
+}
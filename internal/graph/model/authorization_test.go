@@ -0,0 +1,179 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	authv1 "k8s.io/api/authorization/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestGetAccessReview(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		in     *authv1.SelfSubjectAccessReview
+		want   AccessReview
+	}{
+		"Full": {
+			reason: "All supported fields should be converted to our model",
+			in: &authv1.SelfSubjectAccessReview{
+				Spec: authv1.SelfSubjectAccessReviewSpec{
+					ResourceAttributes: &authv1.ResourceAttributes{
+						Verb:        "delete",
+						Group:       "example.org",
+						Resource:    "examples",
+						Subresource: "status",
+						Name:        "cool",
+						Namespace:   "default",
+					},
+				},
+				Status: authv1.SubjectAccessReviewStatus{
+					Allowed: false,
+					Denied:  true,
+					Reason:  "nope",
+				},
+			},
+			want: AccessReview{
+				ResourceAttributes: ResourceAttributes{
+					Verb:        "delete",
+					Group:       ptr.To("example.org"),
+					Resource:    "examples",
+					Subresource: ptr.To("status"),
+					Name:        ptr.To("cool"),
+					Namespace:   ptr.To("default"),
+				},
+				Denied: true,
+				Reason: ptr.To("nope"),
+			},
+		},
+		"Empty": {
+			reason: "Absent optional fields should be absent in our model",
+			in:     &authv1.SelfSubjectAccessReview{},
+			want:   AccessReview{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GetAccessReview(tc.in)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGetAccessReview(...): -want, +got\n:%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGetKubernetesResourceAttributes(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		in     ResourceAttributesInput
+		want   *authv1.ResourceAttributes
+	}{
+		"Full": {
+			reason: "All supported fields should be converted to Kubernetes resource attributes",
+			in: ResourceAttributesInput{
+				Verb:        "create",
+				Group:       ptr.To("example.org"),
+				Resource:    "examples",
+				Subresource: ptr.To("status"),
+				Name:        ptr.To("cool"),
+				Namespace:   ptr.To("default"),
+			},
+			want: &authv1.ResourceAttributes{
+				Verb:        "create",
+				Group:       "example.org",
+				Resource:    "examples",
+				Subresource: "status",
+				Name:        "cool",
+				Namespace:   "default",
+			},
+		},
+		"Empty": {
+			reason: "Absent optional fields should be empty",
+			in:     ResourceAttributesInput{Verb: "list", Resource: "pods"},
+			want:   &authv1.ResourceAttributes{Verb: "list", Resource: "pods"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GetKubernetesResourceAttributes(tc.in)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGetKubernetesResourceAttributes(...): -want, +got\n:%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGetSubjectRules(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		in     *authv1.SelfSubjectRulesReview
+		want   SubjectRules
+	}{
+		"Full": {
+			reason: "All supported fields should be converted to our model",
+			in: &authv1.SelfSubjectRulesReview{
+				Status: authv1.SubjectRulesReviewStatus{
+					ResourceRules: []authv1.ResourceRule{{
+						Verbs:         []string{"get", "list"},
+						APIGroups:     []string{"example.org"},
+						Resources:     []string{"examples"},
+						ResourceNames: []string{"cool"},
+					}},
+					NonResourceRules: []authv1.NonResourceRule{{
+						Verbs:           []string{"get"},
+						NonResourceURLs: []string{"/healthz"},
+					}},
+					Incomplete:      true,
+					EvaluationError: "webhook authorizer does not support rules",
+				},
+			},
+			want: SubjectRules{
+				ResourceRules: []ResourceRule{{
+					Verbs:         []string{"get", "list"},
+					APIGroups:     []string{"example.org"},
+					Resources:     []string{"examples"},
+					ResourceNames: []string{"cool"},
+				}},
+				NonResourceRules: []NonResourceRule{{
+					Verbs:           []string{"get"},
+					NonResourceURLs: []string{"/healthz"},
+				}},
+				Incomplete:      true,
+				EvaluationError: ptr.To("webhook authorizer does not support rules"),
+			},
+		},
+		"Empty": {
+			reason: "An empty review should produce empty, non-nil rules",
+			in:     &authv1.SelfSubjectRulesReview{},
+			want: SubjectRules{
+				ResourceRules:    []ResourceRule{},
+				NonResourceRules: []NonResourceRule{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GetSubjectRules(tc.in)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGetSubjectRules(...): -want, +got\n:%s", tc.reason, diff)
+			}
+		})
+	}
+}
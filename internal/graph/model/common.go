@@ -17,6 +17,7 @@ package model
 import (
 	"encoding/json"
 	"io"
+	"sort"
 
 	"github.com/99designs/gqlgen/graphql"
 	corev1 "k8s.io/api/core/v1"
@@ -30,7 +31,9 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
 	extv1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+	extv1alpha1 "github.com/crossplane/crossplane/apis/apiextensions/v1alpha1"
 	pkgv1 "github.com/crossplane/crossplane/apis/pkg/v1"
+	pkgv1beta1 "github.com/crossplane/crossplane/apis/pkg/v1beta1"
 
 	"github.com/upbound/xgql/internal/unstructured"
 )
@@ -100,13 +103,36 @@ func GetConditions(in []xpv1.Condition) []Condition {
 	return out
 }
 
+// GetProblems returns the supplied conditions that aren't True, or nil if all
+// are True.
+func GetProblems(in []Condition) []Condition {
+	var out []Condition
+	for _, c := range in {
+		if c.Status != ConditionStatusTrue {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
 // GetLabelSelector from the supplied Kubernetes label selector
 func GetLabelSelector(s *metav1.LabelSelector) *LabelSelector {
 	if s == nil {
 		return nil
 	}
 
-	return &LabelSelector{MatchLabels: s.MatchLabels}
+	out := &LabelSelector{MatchLabels: s.MatchLabels}
+	if len(s.MatchExpressions) > 0 {
+		out.MatchExpressions = make([]LabelSelectorRequirement, len(s.MatchExpressions))
+		for i, r := range s.MatchExpressions {
+			out.MatchExpressions[i] = LabelSelectorRequirement{
+				Key:      r.Key,
+				Operator: string(r.Operator),
+				Values:   r.Values,
+			}
+		}
+	}
+	return out
 }
 
 // GetGenericResource from the suppled Kubernetes resource.
@@ -155,8 +181,24 @@ func (cm ConfigMap) Data(keys []string) map[string]string {
 	return out
 }
 
-// GetSecret from the suppled Kubernetes Secret
+// GetSecret from the suppled Kubernetes Secret. The secret's values are
+// omitted from its unstructured representation, so that they may only be read
+// using its data field. So is its kubectl last-applied-configuration
+// annotation, which contains its values if it was applied using kubectl, and
+// its managed fields.
 func GetSecret(s *corev1.Secret) Secret {
+	keys := make([]string, 0, len(s.Data))
+	for k := range s.Data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	redacted := s.DeepCopy()
+	redacted.Data = nil
+	redacted.StringData = nil
+	redacted.ManagedFields = nil
+	delete(redacted.Annotations, corev1.LastAppliedConfigAnnotation)
+
 	out := Secret{
 		ID: ReferenceID{
 			APIVersion: corev1.SchemeGroupVersion.String(),
@@ -166,10 +208,11 @@ func GetSecret(s *corev1.Secret) Secret {
 		},
 		APIVersion: corev1.SchemeGroupVersion.String(),
 		Kind:       "Secret",
-		Metadata:   GetObjectMeta(s),
+		Metadata:   GetObjectMeta(redacted),
 		PavedAccess: PavedAccess{
-			Paved: paveObject(s),
+			Paved: paveObject(redacted),
 		},
+		DataKeys: keys,
 	}
 
 	if s.Data != nil {
@@ -186,8 +229,23 @@ func GetSecret(s *corev1.Secret) Secret {
 	return out
 }
 
-// GetConfigMap from the supplied Kubernetes ConfigMap.
+// GetConfigMap from the supplied Kubernetes ConfigMap. The config map's values
+// are omitted from its unstructured representation, so that they may only be
+// read using its data field.
 func GetConfigMap(cm *corev1.ConfigMap) ConfigMap {
+	keys := make([]string, 0, len(cm.Data)+len(cm.BinaryData))
+	for k := range cm.Data {
+		keys = append(keys, k)
+	}
+	for k := range cm.BinaryData {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	redacted := cm.DeepCopy()
+	redacted.Data = nil
+	redacted.BinaryData = nil
+
 	return ConfigMap{
 		ID: ReferenceID{
 			APIVersion: corev1.SchemeGroupVersion.String(),
@@ -199,10 +257,55 @@ func GetConfigMap(cm *corev1.ConfigMap) ConfigMap {
 		Kind:       "ConfigMap",
 		Metadata:   GetObjectMeta(cm),
 		PavedAccess: PavedAccess{
-			Paved: paveObject(cm),
+			Paved: paveObject(redacted),
+		},
+		data:     cm.Data,
+		DataKeys: keys,
+	}
+}
+
+// GetResourceQuota from the supplied Kubernetes ResourceQuota.
+func GetResourceQuota(rq *corev1.ResourceQuota) ResourceQuota {
+	out := ResourceQuota{
+		ID: ReferenceID{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "ResourceQuota",
+			Namespace:  rq.GetNamespace(),
+			Name:       rq.GetName(),
+		},
+		APIVersion: corev1.SchemeGroupVersion.String(),
+		Kind:       "ResourceQuota",
+		Metadata:   GetObjectMeta(rq),
+		Spec: ResourceQuotaSpec{
+			Hard: getResourceList(rq.Spec.Hard),
+		},
+		PavedAccess: PavedAccess{
+			Paved: paveObject(rq),
 		},
-		data: cm.Data,
 	}
+	for _, s := range rq.Spec.Scopes {
+		out.Spec.Scopes = append(out.Spec.Scopes, string(s))
+	}
+	if len(rq.Status.Hard) > 0 || len(rq.Status.Used) > 0 {
+		out.Status = &ResourceQuotaStatus{
+			Hard: getResourceList(rq.Status.Hard),
+			Used: getResourceList(rq.Status.Used),
+		}
+	}
+	return out
+}
+
+// getResourceList returns the supplied resource list as a map of resource
+// names to quantities.
+func getResourceList(in corev1.ResourceList) map[string]string {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(in))
+	for name, q := range in {
+		out[string(name)] = q.String()
+	}
+	return out
 }
 
 // GetCustomResourceDefinitionNames from the supplied Kubernetes names.
@@ -356,6 +459,21 @@ func GetKubernetesResource(u *kunstructured.Unstructured) (KubernetesResource, e
 		}
 		return GetProviderRevision(pr), nil
 
+	// Crossplane serves functions at v1beta1 before v1.17.
+	case u.GroupVersionKind() == pkgv1.FunctionGroupVersionKind, u.GroupVersionKind() == pkgv1beta1.FunctionGroupVersionKind:
+		fn := &pkgv1.Function{}
+		if err := convert(u, fn); err != nil {
+			return nil, errors.Wrap(err, "cannot convert function")
+		}
+		return GetFunction(fn), nil
+
+	case u.GroupVersionKind() == pkgv1.FunctionRevisionGroupVersionKind, u.GroupVersionKind() == pkgv1beta1.FunctionRevisionGroupVersionKind:
+		fr := &pkgv1.FunctionRevision{}
+		if err := convert(u, fr); err != nil {
+			return nil, errors.Wrap(err, "cannot convert function revision")
+		}
+		return GetFunctionRevision(fr), nil
+
 	case u.GroupVersionKind() == pkgv1.ConfigurationGroupVersionKind:
 		c := &pkgv1.Configuration{}
 		if err := convert(u, c); err != nil {
@@ -370,6 +488,13 @@ func GetKubernetesResource(u *kunstructured.Unstructured) (KubernetesResource, e
 		}
 		return GetConfigurationRevision(cr), nil
 
+	case u.GroupVersionKind() == pkgv1beta1.DeploymentRuntimeConfigGroupVersionKind:
+		rc := &pkgv1beta1.DeploymentRuntimeConfig{}
+		if err := convert(u, rc); err != nil {
+			return nil, errors.Wrap(err, "cannot convert deployment runtime config")
+		}
+		return GetDeploymentRuntimeConfig(rc), nil
+
 	case u.GroupVersionKind() == extv1.CompositeResourceDefinitionGroupVersionKind:
 		xrd := &extv1.CompositeResourceDefinition{}
 		if err := convert(u, xrd); err != nil {
@@ -377,6 +502,20 @@ func GetKubernetesResource(u *kunstructured.Unstructured) (KubernetesResource, e
 		}
 		return GetCompositeResourceDefinition(xrd), nil
 
+	case u.GroupVersionKind() == extv1alpha1.EnvironmentConfigGroupVersionKind:
+		ec := &extv1alpha1.EnvironmentConfig{}
+		if err := convert(u, ec); err != nil {
+			return nil, errors.Wrap(err, "cannot convert environment config")
+		}
+		return GetEnvironmentConfig(ec), nil
+
+	case u.GroupVersionKind() == extv1alpha1.UsageGroupVersionKind:
+		us := &extv1alpha1.Usage{}
+		if err := convert(u, us); err != nil {
+			return nil, errors.Wrap(err, "cannot convert usage")
+		}
+		return GetUsage(us), nil
+
 	case u.GroupVersionKind() == extv1.CompositionGroupVersionKind:
 		cmp := &extv1.Composition{}
 		if err := convert(u, cmp); err != nil {
@@ -407,6 +546,13 @@ func GetKubernetesResource(u *kunstructured.Unstructured) (KubernetesResource, e
 		}
 		return GetConfigMap(cm), nil
 
+	case u.GroupVersionKind() == schema.GroupVersionKind{Group: corev1.GroupName, Version: "v1", Kind: "ResourceQuota"}:
+		rq := &corev1.ResourceQuota{}
+		if err := convert(u, rq); err != nil {
+			return nil, errors.Wrap(err, "cannot convert resource quota")
+		}
+		return GetResourceQuota(rq), nil
+
 	default:
 		return GetGenericResource(u), nil
 	}
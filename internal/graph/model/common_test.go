@@ -15,6 +15,7 @@
 package model
 
 import (
+	"bytes"
 	"encoding/json"
 	"testing"
 	"time"
@@ -23,6 +24,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "k8s.io/api/core/v1"
 	kextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	kschema "k8s.io/apimachinery/pkg/runtime/schema"
@@ -71,6 +73,40 @@ func TestGetConditions(t *testing.T) {
 	}
 }
 
+func TestGetProblems(t *testing.T) {
+	ready := Condition{Type: string(xpv1.TypeReady), Status: ConditionStatusTrue}
+	unhealthy := Condition{Type: "Healthy", Status: ConditionStatusFalse, Reason: "UnhealthyPackageRevision", Message: ptr.To("cannot pull package")}
+	unknown := Condition{Type: "Installed", Status: ConditionStatusUnknown}
+
+	cases := map[string]struct {
+		reason string
+		in     []Condition
+		want   []Condition
+	}{
+		"NoConditions": {
+			reason: "A resource without conditions has no problems.",
+		},
+		"AllTrue": {
+			reason: "A resource whose conditions are all True has no problems.",
+			in:     []Condition{ready},
+		},
+		"Problems": {
+			reason: "Conditions that aren't True should be returned, in order.",
+			in:     []Condition{unhealthy, ready, unknown},
+			want:   []Condition{unhealthy, unknown},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GetProblems(tc.in)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGetProblems(...): -want, +got\n:%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
 func TestGetGenericResource(t *testing.T) {
 	cases := map[string]struct {
 		reason string
@@ -190,8 +226,38 @@ func TestGetSecret(t *testing.T) {
 				Metadata: ObjectMeta{
 					Name: "cool",
 				},
-				Type: ptr.To("cool"),
-				data: map[string]string{"cool": "secret"},
+				Type:     ptr.To("cool"),
+				data:     map[string]string{"cool": "secret"},
+				DataKeys: []string{"cool"},
+			},
+		},
+		"KubectlApplied": {
+			reason: "The kubectl last-applied-configuration annotation and managed fields of a secret should be omitted from our model",
+			s: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cool",
+					Annotations: map[string]string{
+						corev1.LastAppliedConfigAnnotation: `{"apiVersion":"v1","kind":"Secret","data":{"cool":"c2VjcmV0"}}`,
+						"cool":                             "very",
+					},
+					ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubectl"}},
+				},
+				Data: map[string][]byte{"cool": []byte("secret")},
+			},
+			want: Secret{
+				ID: ReferenceID{
+					APIVersion: corev1.SchemeGroupVersion.String(),
+					Kind:       "Secret",
+					Name:       "cool",
+				},
+				APIVersion: corev1.SchemeGroupVersion.String(),
+				Kind:       "Secret",
+				Metadata: ObjectMeta{
+					Name:        "cool",
+					annotations: map[string]string{"cool": "very"},
+				},
+				data:     map[string]string{"cool": "secret"},
+				DataKeys: []string{"cool"},
 			},
 		},
 		"Empty": {
@@ -205,6 +271,7 @@ func TestGetSecret(t *testing.T) {
 				APIVersion: corev1.SchemeGroupVersion.String(),
 				Kind:       "Secret",
 				Metadata:   ObjectMeta{},
+				DataKeys:   []string{},
 			},
 		},
 	}
@@ -215,6 +282,15 @@ func TestGetSecret(t *testing.T) {
 			if diff := cmp.Diff(tc.want, got, cmpopts.IgnoreFields(Secret{}, "PavedAccess"), cmp.AllowUnexported(Secret{}, ObjectMeta{})); diff != "" {
 				t.Errorf("\n%s\nGetSecret(...): -want, +got\n:%s", tc.reason, diff)
 			}
+			if v, err := got.GetValue("data"); err == nil {
+				t.Errorf("\n%s\nGetSecret(...): want data omitted from the unstructured secret, got %v", tc.reason, v)
+			}
+			if v, err := got.GetValue("metadata.managedFields"); err == nil {
+				t.Errorf("\n%s\nGetSecret(...): want managed fields omitted from the unstructured secret, got %v", tc.reason, v)
+			}
+			if j, _ := json.Marshal(got.Paved.UnstructuredContent()); bytes.Contains(j, []byte("c2VjcmV0")) {
+				t.Errorf("\n%s\nGetSecret(...): want values omitted from the unstructured secret, got %s", tc.reason, j)
+			}
 		})
 	}
 }
@@ -276,7 +352,8 @@ func TestGetConfigMap(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "cool",
 				},
-				Data: map[string]string{"cool": "secret"},
+				Data:       map[string]string{"cool": "secret"},
+				BinaryData: map[string][]byte{"binary": []byte("secret")},
 			},
 			want: ConfigMap{
 				ID: ReferenceID{
@@ -289,7 +366,8 @@ func TestGetConfigMap(t *testing.T) {
 				Metadata: ObjectMeta{
 					Name: "cool",
 				},
-				data: map[string]string{"cool": "secret"},
+				data:     map[string]string{"cool": "secret"},
+				DataKeys: []string{"binary", "cool"},
 			},
 		},
 		"Empty": {
@@ -303,6 +381,7 @@ func TestGetConfigMap(t *testing.T) {
 				APIVersion: corev1.SchemeGroupVersion.String(),
 				Kind:       "ConfigMap",
 				Metadata:   ObjectMeta{},
+				DataKeys:   []string{},
 			},
 		},
 	}
@@ -311,7 +390,82 @@ func TestGetConfigMap(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			got := GetConfigMap(tc.cm)
 			if diff := cmp.Diff(tc.want, got, cmpopts.IgnoreFields(ConfigMap{}, "PavedAccess"), cmp.AllowUnexported(ConfigMap{}, ObjectMeta{})); diff != "" {
-				t.Errorf("\n%s\nGetSecret(...): -want, +got\n:%s", tc.reason, diff)
+				t.Errorf("\n%s\nGetConfigMap(...): -want, +got\n:%s", tc.reason, diff)
+			}
+			for _, f := range []string{"data", "binaryData"} {
+				if v, err := got.GetValue(f); err == nil {
+					t.Errorf("\n%s\nGetConfigMap(...): want %s omitted from the unstructured config map, got %v", tc.reason, f, v)
+				}
+			}
+		})
+	}
+}
+
+func TestGetResourceQuota(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		rq     *corev1.ResourceQuota
+		want   ResourceQuota
+	}{
+		"Full": {
+			reason: "All supported fields should be converted to our model",
+			rq: &corev1.ResourceQuota{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "cool",
+				},
+				Spec: corev1.ResourceQuotaSpec{
+					Hard:   corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("2"), corev1.ResourcePods: resource.MustParse("10")},
+					Scopes: []corev1.ResourceQuotaScope{corev1.ResourceQuotaScopeNotTerminating},
+				},
+				Status: corev1.ResourceQuotaStatus{
+					Hard: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("2"), corev1.ResourcePods: resource.MustParse("10")},
+					Used: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("1500m"), corev1.ResourcePods: resource.MustParse("3")},
+				},
+			},
+			want: ResourceQuota{
+				ID: ReferenceID{
+					APIVersion: corev1.SchemeGroupVersion.String(),
+					Kind:       "ResourceQuota",
+					Namespace:  "default",
+					Name:       "cool",
+				},
+				APIVersion: corev1.SchemeGroupVersion.String(),
+				Kind:       "ResourceQuota",
+				Metadata: ObjectMeta{
+					Namespace: ptr.To("default"),
+					Name:      "cool",
+				},
+				Spec: ResourceQuotaSpec{
+					Hard:   map[string]string{"requests.cpu": "2", "pods": "10"},
+					Scopes: []string{"NotTerminating"},
+				},
+				Status: &ResourceQuotaStatus{
+					Hard: map[string]string{"requests.cpu": "2", "pods": "10"},
+					Used: map[string]string{"requests.cpu": "1500m", "pods": "3"},
+				},
+			},
+		},
+		"Empty": {
+			reason: "Absent optional fields should be absent in our model",
+			rq:     &corev1.ResourceQuota{},
+			want: ResourceQuota{
+				ID: ReferenceID{
+					APIVersion: corev1.SchemeGroupVersion.String(),
+					Kind:       "ResourceQuota",
+				},
+				APIVersion: corev1.SchemeGroupVersion.String(),
+				Kind:       "ResourceQuota",
+				Metadata:   ObjectMeta{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GetResourceQuota(tc.rq)
+			if diff := cmp.Diff(tc.want, got, cmpopts.IgnoreFields(ResourceQuota{}, "PavedAccess"), cmp.AllowUnexported(ObjectMeta{})); diff != "" {
+				t.Errorf("\n%s\nGetResourceQuota(...): -want, +got\n:%s", tc.reason, diff)
 			}
 		})
 	}
@@ -471,8 +625,9 @@ func TestGetKubernetesResource(t *testing.T) {
 					ID:       ReferenceID{Name: "cool"},
 					Metadata: ObjectMeta{Name: "cool"},
 					Spec: ManagedResourceSpec{
-						ProviderConfigRef: &ProviderConfigReference{Name: "pr"},
-						DeletionPolicy:    &dp,
+						ProviderConfigRef:  &ProviderConfigReference{Name: "pr"},
+						DeletionPolicy:     &dp,
+						ManagementPolicies: []ManagementAction{ManagementActionAll},
 					},
 				},
 			},
@@ -701,6 +856,7 @@ func TestGetKubernetesResource(t *testing.T) {
 					APIVersion: corev1.SchemeGroupVersion.String(),
 					Kind:       "Secret",
 					Metadata:   ObjectMeta{},
+					DataKeys:   []string{},
 				},
 			},
 		},
@@ -720,6 +876,7 @@ func TestGetKubernetesResource(t *testing.T) {
 					APIVersion: corev1.SchemeGroupVersion.String(),
 					Kind:       "ConfigMap",
 					Metadata:   ObjectMeta{},
+					DataKeys:   []string{},
 				},
 			},
 		},
@@ -29,10 +29,12 @@ import (
 // A CompositeResourceSpec defines the desired state of a composite resource.
 type CompositeResourceSpec struct {
 	CompositionSelector *LabelSelector `json:"compositionSelector"`
+	CompositionPending  bool           `json:"compositionPending"`
 
 	CompositionReference             *corev1.ObjectReference
 	ClaimReference                   *claim.Reference
 	ResourceReferences               []corev1.ObjectReference
+	EnvironmentConfigReferences      []corev1.ObjectReference
 	WriteConnectionSecretToReference *xpv1.SecretReference
 }
 
@@ -40,13 +42,17 @@ type CompositeResourceSpec struct {
 func GetCompositeResourceStatus(xr *unstructured.Composite) *CompositeResourceStatus {
 	c := xr.GetConditions()
 	t := xr.GetConnectionDetailsLastPublishedTime()
+	ref := xr.GetWriteConnectionSecretToReference()
 
 	out := &CompositeResourceStatus{}
 	if len(c) > 0 {
 		out.Conditions = GetConditions(c)
 	}
+	if t != nil || ref != nil {
+		out.ConnectionDetails = &CompositeResourceConnectionDetails{SecretRef: GetSecretReference(ref)}
+	}
 	if t != nil {
-		out.ConnectionDetails = &CompositeResourceConnectionDetails{LastPublishedTime: &t.Time}
+		out.ConnectionDetails.LastPublishedTime = &t.Time
 	}
 
 	if cmp.Equal(out, &CompositeResourceStatus{}) {
@@ -59,7 +65,7 @@ func GetCompositeResourceStatus(xr *unstructured.Composite) *CompositeResourceSt
 // GetCompositeResource from the supplied Crossplane resource.
 func GetCompositeResource(u *kunstructured.Unstructured) CompositeResource {
 	xr := &unstructured.Composite{Unstructured: *u}
-	return CompositeResource{
+	out := CompositeResource{
 		ID: ReferenceID{
 			APIVersion: xr.GetAPIVersion(),
 			Kind:       xr.GetKind(),
@@ -81,6 +87,28 @@ func GetCompositeResource(u *kunstructured.Unstructured) CompositeResource {
 			Paved: fieldpath.Pave(u.Object),
 		},
 	}
+	// Crossplane sets the composition reference once it has used the selector
+	// to select a composition.
+	out.Spec.CompositionPending = out.Spec.CompositionSelector != nil && out.Spec.CompositionReference == nil
+	if refs := xr.GetEnvironmentConfigReferences(); len(refs) > 0 {
+		out.Spec.EnvironmentConfigReferences = refs
+	}
+	return out
+}
+
+// A ResourceTreeNode is a node in the tree of resources rooted at a composite
+// resource or claim.
+type ResourceTreeNode struct {
+	ID       ReferenceID        `json:"id"`
+	Resource KubernetesResource `json:"resource,omitempty"`
+	Ready    bool               `json:"ready"`
+	Errors   []string           `json:"errors,omitempty"`
+	Children []ResourceTreeNode `json:"children"`
+
+	// The composition used by the composite resource that is the parent of
+	// this node, if any. Used to resolve the readiness checks of the resource
+	// at this node.
+	CompositionReference *corev1.ObjectReference
 }
 
 func delocalize(ref *xpv1.LocalSecretReference, namespace string) *xpv1.SecretReference {
@@ -108,13 +136,17 @@ type CompositeResourceClaimSpec struct {
 func GetCompositeResourceClaimStatus(xrc *unstructured.Claim) *CompositeResourceClaimStatus {
 	c := xrc.GetConditions()
 	t := xrc.GetConnectionDetailsLastPublishedTime()
+	ref := delocalize(xrc.GetWriteConnectionSecretToReference(), xrc.GetNamespace())
 
 	out := &CompositeResourceClaimStatus{}
 	if len(c) > 0 {
 		out.Conditions = GetConditions(c)
 	}
+	if t != nil || ref != nil {
+		out.ConnectionDetails = &CompositeResourceClaimConnectionDetails{SecretRef: GetSecretReference(ref)}
+	}
 	if t != nil {
-		out.ConnectionDetails = &CompositeResourceClaimConnectionDetails{LastPublishedTime: &t.Time}
+		out.ConnectionDetails.LastPublishedTime = &t.Time
 	}
 
 	if cmp.Equal(out, &CompositeResourceClaimStatus{}) {
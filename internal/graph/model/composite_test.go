@@ -52,6 +52,7 @@ func TestGetCompositeResource(t *testing.T) {
 				xr.SetCompositionReference(&corev1.ObjectReference{Name: "coolcmp"})
 				xr.SetClaimReference(&claim.Reference{Name: "coolclaim"})
 				xr.SetResourceReferences([]corev1.ObjectReference{{Name: "coolmanaged"}})
+				xr.SetEnvironmentConfigReferences([]corev1.ObjectReference{{Name: "coolenv"}})
 				xr.SetWriteConnectionSecretToReference(&xpv1.SecretReference{Name: "coolsecret"})
 				xr.SetConnectionDetailsLastPublishedTime(&mp)
 				xr.SetConditions(xpv1.Condition{})
@@ -74,12 +75,60 @@ func TestGetCompositeResource(t *testing.T) {
 					CompositionReference:             &corev1.ObjectReference{Name: "coolcmp"},
 					ClaimReference:                   &claim.Reference{Name: "coolclaim"},
 					ResourceReferences:               []corev1.ObjectReference{{Name: "coolmanaged"}},
+					EnvironmentConfigReferences:      []corev1.ObjectReference{{Name: "coolenv"}},
 					WriteConnectionSecretToReference: &xpv1.SecretReference{Name: "coolsecret"},
 				},
 				Status: &CompositeResourceStatus{
 					Conditions: []Condition{{}},
 					ConnectionDetails: &CompositeResourceConnectionDetails{
 						LastPublishedTime: &pub,
+						SecretRef:         &SecretReference{Name: "coolsecret"},
+					},
+				},
+			},
+		},
+		"CompositionPending": {
+			reason: "A composite resource that selects a composition that hasn't been selected yet should be pending",
+			u: func() *kunstructured.Unstructured {
+				xr := &unstructured.Composite{Unstructured: kunstructured.Unstructured{Object: make(map[string]interface{})}}
+				xr.SetCompositionSelector(&metav1.LabelSelector{
+					MatchLabels: map[string]string{"cool": "very"},
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: "provider", Operator: metav1.LabelSelectorOpIn, Values: []string{"aws", "gcp"}},
+						{Key: "deprecated", Operator: metav1.LabelSelectorOpDoesNotExist},
+					},
+				})
+				return xr.GetUnstructured()
+			}(),
+			want: CompositeResource{
+				Spec: CompositeResourceSpec{
+					CompositionSelector: &LabelSelector{
+						MatchLabels: map[string]string{"cool": "very"},
+						MatchExpressions: []LabelSelectorRequirement{
+							{Key: "provider", Operator: "In", Values: []string{"aws", "gcp"}},
+							{Key: "deprecated", Operator: "DoesNotExist"},
+						},
+					},
+					CompositionPending: true,
+					ResourceReferences: []corev1.ObjectReference{},
+				},
+			},
+		},
+		"Unpublished": {
+			reason: "The connection secret should be reported even if connection details haven't been published to it yet",
+			u: func() *kunstructured.Unstructured {
+				xr := &unstructured.Composite{Unstructured: kunstructured.Unstructured{Object: make(map[string]interface{})}}
+				xr.SetWriteConnectionSecretToReference(&xpv1.SecretReference{Namespace: "default", Name: "coolsecret"})
+				return xr.GetUnstructured()
+			}(),
+			want: CompositeResource{
+				Spec: CompositeResourceSpec{
+					ResourceReferences:               []corev1.ObjectReference{},
+					WriteConnectionSecretToReference: &xpv1.SecretReference{Namespace: "default", Name: "coolsecret"},
+				},
+				Status: &CompositeResourceStatus{
+					ConnectionDetails: &CompositeResourceConnectionDetails{
+						SecretRef: &SecretReference{Namespace: "default", Name: "coolsecret"},
 					},
 				},
 			},
@@ -165,6 +214,7 @@ func TestGetCompositeResourceClaim(t *testing.T) {
 					Conditions: []Condition{{}},
 					ConnectionDetails: &CompositeResourceClaimConnectionDetails{
 						LastPublishedTime: &pub,
+						SecretRef:         &SecretReference{Namespace: "default", Name: "coolsecret"},
 					},
 				},
 			},
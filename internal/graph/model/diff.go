@@ -0,0 +1,116 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/json"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+)
+
+const (
+	errUnmarshalLastApplied = "cannot unmarshal last applied configuration"
+	errMarshalSpecValue     = "cannot marshal spec value"
+)
+
+// GetSpecDifferences returns the differences between the spec of the supplied
+// object, as it was last applied by kubectl, and its current spec. It returns
+// nil if the object was not last applied by kubectl.
+//
+// Only fields that were last applied are compared. Fields that were set by
+// something else, for example those late-initialized by a provider or
+// defaulted by the API server, are not differences.
+func GetSpecDifferences(p *fieldpath.Paved) ([]SpecDifference, error) {
+	if p == nil {
+		return nil, nil
+	}
+	a := map[string]string{}
+	_ = p.GetValueInto("metadata.annotations", &a)
+	raw, ok := a[corev1.LastAppliedConfigAnnotation]
+	if !ok {
+		return nil, nil
+	}
+
+	applied := map[string]any{}
+	if err := json.Unmarshal([]byte(raw), &applied); err != nil {
+		return nil, errors.Wrap(err, errUnmarshalLastApplied)
+	}
+
+	// GetValueInto round trips the current spec through the same JSON package
+	// we used to unmarshal the last applied configuration, so that values of
+	// both have the same types; e.g. int64 for whole numbers. The current spec
+	// is nil if the object has no spec.
+	var current any
+	_ = p.GetValueInto("spec", &current)
+
+	out := []SpecDifference{}
+	if err := diff("spec", applied["spec"], current, &out); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Path < out[j].Path })
+	return out, nil
+}
+
+// diff appends the differences between the applied and current values at the
+// supplied path. Objects are compared field by field. Other values, including
+// arrays, are compared as a whole.
+func diff(path string, applied, current any, out *[]SpecDifference) error {
+	if applied == nil {
+		return nil
+	}
+
+	am, aok := applied.(map[string]any)
+	cm, cok := current.(map[string]any)
+	if aok && cok {
+		for k, v := range am {
+			if err := diff(joinFieldPath(path, k), v, cm[k], out); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if reflect.DeepEqual(applied, current) {
+		return nil
+	}
+
+	d := SpecDifference{Path: path}
+	var err error
+	if d.LastApplied, err = json.Marshal(applied); err != nil {
+		return errors.Wrap(err, errMarshalSpecValue)
+	}
+	if current != nil {
+		if d.Current, err = json.Marshal(current); err != nil {
+			return errors.Wrap(err, errMarshalSpecValue)
+		}
+	}
+	*out = append(*out, d)
+	return nil
+}
+
+// joinFieldPath joins the supplied field to the supplied field path, using
+// bracket notation for fields that can't be expressed using dot notation.
+func joinFieldPath(path, field string) string {
+	if strings.ContainsAny(field, ".[]") {
+		return path + "[" + field + "]"
+	}
+	return path + "." + field
+}
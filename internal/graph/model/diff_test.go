@@ -0,0 +1,111 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/json"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestGetSpecDifferences(t *testing.T) {
+	errUnmarshal := json.Unmarshal([]byte("{"), &map[string]any{})
+
+	paved := func(lastApplied string, spec map[string]any) *fieldpath.Paved {
+		obj := map[string]any{"spec": spec}
+		if lastApplied != "" {
+			obj["metadata"] = map[string]any{
+				"annotations": map[string]any{corev1.LastAppliedConfigAnnotation: lastApplied},
+			}
+		}
+		return fieldpath.Pave(obj)
+	}
+
+	type want struct {
+		d   []SpecDifference
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		p      *fieldpath.Paved
+		want   want
+	}{
+		"NoAnnotation": {
+			reason: "An object that was not last applied by kubectl should have no differences",
+			p:      paved("", map[string]any{"region": "us-east-1"}),
+			want:   want{},
+		},
+		"MalformedAnnotation": {
+			reason: "A last applied configuration that isn't JSON should result in an error",
+			p:      paved("{", map[string]any{"region": "us-east-1"}),
+			want: want{
+				err: errors.Wrap(errUnmarshal, errUnmarshalLastApplied),
+			},
+		},
+		"NoDifferences": {
+			reason: "Fields that are not last applied, e.g. late-initialized fields, should not be differences",
+			p: paved(`{"spec":{"forProvider":{"region":"us-east-1","size":2}}}`, map[string]any{
+				"forProvider": map[string]any{"region": "us-east-1", "size": int64(2), "zone": "a"},
+			}),
+			want: want{
+				d: []SpecDifference{},
+			},
+		},
+		"Differences": {
+			reason: "Changed and removed fields should be differences, sorted by path",
+			p: paved(`{"spec":{"forProvider":{"region":"us-east-1","tags":["a"],"example.org/key":"v"}}}`, map[string]any{
+				"forProvider": map[string]any{"region": "us-west-2", "tags": []any{"a", "b"}},
+			}),
+			want: want{
+				d: []SpecDifference{
+					{
+						Path:        "spec.forProvider.region",
+						LastApplied: []byte(`"us-east-1"`),
+						Current:     []byte(`"us-west-2"`),
+					},
+					{
+						Path:        "spec.forProvider.tags",
+						LastApplied: []byte(`["a"]`),
+						Current:     []byte(`["a","b"]`),
+					},
+					{
+						Path:        "spec.forProvider[example.org/key]",
+						LastApplied: []byte(`"v"`),
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := GetSpecDifferences(tc.p)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nGetSpecDifferences(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.d, got); diff != "" {
+				t.Errorf("\n%s\nGetSpecDifferences(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// GetAPIResources from the supplied Kubernetes discovery data. Subresources
+// are omitted. If group is not nil only resources in that API group are
+// returned. Resources are sorted by group, then name.
+func GetAPIResources(in []*metav1.APIResourceList, group *string) []APIResource {
+	out := make([]APIResource, 0)
+	for _, l := range in {
+		if l == nil {
+			continue
+		}
+		gv, err := schema.ParseGroupVersion(l.GroupVersion)
+		if err != nil {
+			continue
+		}
+		if group != nil && gv.Group != *group {
+			continue
+		}
+		for _, r := range l.APIResources {
+			if strings.Contains(r.Name, "/") {
+				continue
+			}
+			out = append(out, APIResource{
+				Group:      gv.Group,
+				Version:    gv.Version,
+				Kind:       r.Kind,
+				Name:       r.Name,
+				Namespaced: r.Namespaced,
+				Verbs:      r.Verbs,
+				ShortNames: r.ShortNames,
+				Categories: r.Categories,
+			})
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Group != out[j].Group {
+			return out[i].Group < out[j].Group
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
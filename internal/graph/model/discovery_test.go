@@ -0,0 +1,82 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestGetAPIResources(t *testing.T) {
+	lists := []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				{Name: "pods", Kind: "Pod", Namespaced: true, Verbs: []string{"get", "list"}, ShortNames: []string{"po"}, Categories: []string{"all"}},
+				{Name: "pods/status", Kind: "Pod", Namespaced: true, Verbs: []string{"get"}},
+				{Name: "namespaces", Kind: "Namespace", Verbs: []string{"get", "list"}},
+			},
+		},
+		{
+			GroupVersion: "apiextensions.crossplane.io/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "compositions", Kind: "Composition", Verbs: []string{"get"}, Categories: []string{"crossplane"}},
+			},
+		},
+		nil,
+	}
+
+	namespaces := APIResource{Version: "v1", Kind: "Namespace", Name: "namespaces", Verbs: []string{"get", "list"}}
+	pods := APIResource{Version: "v1", Kind: "Pod", Name: "pods", Namespaced: true, Verbs: []string{"get", "list"}, ShortNames: []string{"po"}, Categories: []string{"all"}}
+	compositions := APIResource{Group: "apiextensions.crossplane.io", Version: "v1", Kind: "Composition", Name: "compositions", Verbs: []string{"get"}, Categories: []string{"crossplane"}}
+
+	cases := map[string]struct {
+		reason string
+		group  *string
+		want   []APIResource
+	}{
+		"AllGroups": {
+			reason: "All resources except subresources should be returned, sorted by group then name.",
+			want:   []APIResource{namespaces, pods, compositions},
+		},
+		"CoreGroup": {
+			reason: "Only resources in the core group should be returned when the group is empty.",
+			group:  ptr.To(""),
+			want:   []APIResource{namespaces, pods},
+		},
+		"NamedGroup": {
+			reason: "Only resources in the supplied group should be returned.",
+			group:  ptr.To("apiextensions.crossplane.io"),
+			want:   []APIResource{compositions},
+		},
+		"UnknownGroup": {
+			reason: "No resources should be returned for a group that isn't served.",
+			group:  ptr.To("example.org"),
+			want:   []APIResource{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GetAPIResources(lists, tc.group)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGetAPIResources(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	extv1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+	extv1alpha1 "github.com/crossplane/crossplane/apis/apiextensions/v1alpha1"
+)
+
+const (
+	errUnmarshalDefaultData = "cannot unmarshal default environment data"
+	errFmtUnmarshalData     = "cannot unmarshal data of environment config %q"
+	errMarshalEnvironment   = "cannot marshal environment"
+)
+
+// GetEnvironmentConfig from the supplied Crossplane environment config.
+func GetEnvironmentConfig(ec *extv1alpha1.EnvironmentConfig) EnvironmentConfig {
+	out := EnvironmentConfig{
+		ID: ReferenceID{
+			APIVersion: ec.APIVersion,
+			Kind:       ec.Kind,
+			Name:       ec.GetName(),
+		},
+
+		APIVersion: ec.APIVersion,
+		Kind:       ec.Kind,
+		Metadata:   GetObjectMeta(ec),
+		PavedAccess: PavedAccess{
+			Paved: paveObject(ec),
+		},
+	}
+	if len(ec.Data) > 0 {
+		if raw, err := json.Marshal(ec.Data); err == nil {
+			out.Data = raw
+		}
+	}
+	return out
+}
+
+// GetCompositeResourceEnvironment previews the environment the supplied
+// composition configures, given the environment configs a composite resource
+// selects, in the order it selects them. It returns nil if the composition
+// doesn't configure an environment.
+func GetCompositeResourceEnvironment(cmp *extv1.Composition, ecs []*extv1alpha1.EnvironmentConfig) (*CompositeResourceEnvironment, error) {
+	if cmp.Spec.Environment == nil {
+		return nil, nil
+	}
+
+	// Like Crossplane, we merge environment configs over the default data in
+	// order, such that later environment configs take precedence.
+	env := map[string]any{}
+	if err := unmarshalData(cmp.Spec.Environment.DefaultData, &env); err != nil {
+		return nil, errors.Wrap(err, errUnmarshalDefaultData)
+	}
+	for _, ec := range ecs {
+		data := map[string]any{}
+		if err := unmarshalData(ec.Data, &data); err != nil {
+			return nil, errors.Wrapf(err, errFmtUnmarshalData, ec.GetName())
+		}
+		mergeEnvironment(env, data)
+	}
+
+	raw, err := json.Marshal(env)
+	if err != nil {
+		return nil, errors.Wrap(err, errMarshalEnvironment)
+	}
+
+	return &CompositeResourceEnvironment{
+		Data:    raw,
+		Patches: getEnvironmentPatches(cmp, fieldpath.Pave(env)),
+	}, nil
+}
+
+func unmarshalData(in any, out *map[string]any) error {
+	raw, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, out)
+}
+
+// mergeEnvironment deeply merges src into dst. Objects are merged, while any
+// other value in src replaces the value in dst.
+func mergeEnvironment(dst, src map[string]any) {
+	for k, sv := range src {
+		sm, sok := sv.(map[string]any)
+		dm, dok := dst[k].(map[string]any)
+		if sok && dok {
+			mergeEnvironment(dm, sm)
+			continue
+		}
+		dst[k] = sv
+	}
+}
+
+// getEnvironmentPatches returns the supplied composition's patches that read
+// from or write to the supplied environment. Patch sets are expanded.
+func getEnvironmentPatches(cmp *extv1.Composition, env *fieldpath.Paved) []EnvironmentPatch {
+	out := make([]EnvironmentPatch, 0)
+
+	for _, p := range cmp.Spec.Environment.Patches {
+		// Environment patches that patch to the composite resource read
+		// from the environment.
+		reads := p.Type == extv1.PatchTypeToCompositeFieldPath || p.Type == extv1.PatchTypeCombineToComposite
+		out = append(out, getEnvironmentPatch(nil, p.Type, p.FromFieldPath, p.Combine, p.ToFieldPath, reads, env))
+	}
+
+	sets := make(map[string][]extv1.Patch, len(cmp.Spec.PatchSets))
+	for _, ps := range cmp.Spec.PatchSets {
+		sets[ps.Name] = ps.Patches
+	}
+
+	for i, t := range cmp.Spec.Resources {
+		name := ptr.Deref(t.Name, fmt.Sprintf("resources[%d]", i))
+
+		patches := make([]extv1.Patch, 0, len(t.Patches))
+		for _, p := range t.Patches {
+			if p.Type == extv1.PatchTypePatchSet && p.PatchSetName != nil {
+				patches = append(patches, sets[*p.PatchSetName]...)
+				continue
+			}
+			patches = append(patches, p)
+		}
+
+		for _, p := range patches {
+			switch p.Type {
+			case extv1.PatchTypeFromEnvironmentFieldPath, extv1.PatchTypeCombineFromEnvironment:
+				out = append(out, getEnvironmentPatch(ptr.To(name), p.Type, p.FromFieldPath, p.Combine, p.ToFieldPath, true, env))
+			case extv1.PatchTypeToEnvironmentFieldPath, extv1.PatchTypeCombineToEnvironment:
+				out = append(out, getEnvironmentPatch(ptr.To(name), p.Type, p.FromFieldPath, p.Combine, p.ToFieldPath, false, env))
+			}
+		}
+	}
+
+	return out
+}
+
+func getEnvironmentPatch(resource *string, t extv1.PatchType, from *string, c *extv1.Combine, to *string, reads bool, env *fieldpath.Paved) EnvironmentPatch {
+	// Patches default to patching from the composite resource.
+	if t == "" {
+		t = extv1.PatchTypeFromCompositeFieldPath
+	}
+
+	out := EnvironmentPatch{
+		Resource:       resource,
+		Type:           string(t),
+		FromFieldPaths: make([]string, 0),
+		ToFieldPath:    to,
+	}
+	if from != nil {
+		out.FromFieldPaths = append(out.FromFieldPaths, *from)
+	}
+	if c != nil {
+		for _, v := range c.Variables {
+			out.FromFieldPaths = append(out.FromFieldPaths, v.FromFieldPath)
+		}
+	}
+
+	if !reads {
+		return out
+	}
+
+	out.Values = make([][]byte, len(out.FromFieldPaths))
+	for i, path := range out.FromFieldPaths {
+		v, err := env.GetValue(path)
+		if err != nil {
+			// The environment doesn't contain this field path.
+			continue
+		}
+		if raw, err := json.Marshal(v); err == nil {
+			out.Values[i] = raw
+		}
+	}
+	return out
+}
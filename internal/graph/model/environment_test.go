@@ -0,0 +1,193 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	kextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	extv1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+	extv1alpha1 "github.com/crossplane/crossplane/apis/apiextensions/v1alpha1"
+)
+
+func TestGetEnvironmentConfig(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		ec     *extv1alpha1.EnvironmentConfig
+		want   EnvironmentConfig
+	}{
+		"Full": {
+			reason: "All supported fields should be converted to our model",
+			ec: &extv1alpha1.EnvironmentConfig{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: extv1alpha1.EnvironmentConfigGroupVersionKind.GroupVersion().String(),
+					Kind:       extv1alpha1.EnvironmentConfigKind,
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cool",
+				},
+				Data: map[string]kextv1.JSON{
+					"region": {Raw: []byte(`"us-east-1"`)},
+				},
+			},
+			want: EnvironmentConfig{
+				ID: ReferenceID{
+					APIVersion: extv1alpha1.EnvironmentConfigGroupVersionKind.GroupVersion().String(),
+					Kind:       extv1alpha1.EnvironmentConfigKind,
+					Name:       "cool",
+				},
+				APIVersion: extv1alpha1.EnvironmentConfigGroupVersionKind.GroupVersion().String(),
+				Kind:       extv1alpha1.EnvironmentConfigKind,
+				Metadata: ObjectMeta{
+					Name: "cool",
+				},
+				Data: []byte(`{"region":"us-east-1"}`),
+			},
+		},
+		"Empty": {
+			reason: "Absent optional fields should be absent in our model",
+			ec:     &extv1alpha1.EnvironmentConfig{},
+			want: EnvironmentConfig{
+				Metadata: ObjectMeta{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GetEnvironmentConfig(tc.ec)
+			if diff := cmp.Diff(tc.want, got, cmpopts.IgnoreFields(EnvironmentConfig{}, "PavedAccess"), cmp.AllowUnexported(ObjectMeta{})); diff != "" {
+				t.Errorf("\n%s\nGetEnvironmentConfig(...): -want, +got\n:%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGetCompositeResourceEnvironment(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		cmp    *extv1.Composition
+		ecs    []*extv1alpha1.EnvironmentConfig
+		want   *CompositeResourceEnvironment
+	}{
+		"NoEnvironment": {
+			reason: "A composition that doesn't configure an environment should have no environment.",
+			cmp:    &extv1.Composition{},
+		},
+		"Merged": {
+			reason: "Environment configs should be merged over the default data in order, and patches that use the environment should be returned.",
+			cmp: &extv1.Composition{
+				Spec: extv1.CompositionSpec{
+					Environment: &extv1.EnvironmentConfiguration{
+						DefaultData: map[string]kextv1.JSON{
+							"region": {Raw: []byte(`"us-west-2"`)},
+							"tags":   {Raw: []byte(`{"team":"platform","env":"dev"}`)},
+						},
+						Patches: []extv1.EnvironmentPatch{
+							{Type: extv1.PatchTypeToCompositeFieldPath, FromFieldPath: ptr.To("region"), ToFieldPath: ptr.To("status.region")},
+							{FromFieldPath: ptr.To("spec.size"), ToFieldPath: ptr.To("size")},
+						},
+					},
+					PatchSets: []extv1.PatchSet{
+						{
+							Name: "common",
+							Patches: []extv1.Patch{
+								{Type: extv1.PatchTypeFromEnvironmentFieldPath, FromFieldPath: ptr.To("tags"), ToFieldPath: ptr.To("spec.forProvider.tags")},
+							},
+						},
+					},
+					Resources: []extv1.ComposedTemplate{
+						{
+							Name: ptr.To("bucket"),
+							Patches: []extv1.Patch{
+								{Type: extv1.PatchTypePatchSet, PatchSetName: ptr.To("common")},
+								{FromFieldPath: ptr.To("spec.name"), ToFieldPath: ptr.To("metadata.name")},
+								{
+									Type: extv1.PatchTypeCombineFromEnvironment,
+									Combine: &extv1.Combine{
+										Variables: []extv1.CombineVariable{{FromFieldPath: "region"}, {FromFieldPath: "zone"}},
+									},
+									ToFieldPath: ptr.To("spec.forProvider.location"),
+								},
+							},
+						},
+						{
+							Patches: []extv1.Patch{
+								{Type: extv1.PatchTypeToEnvironmentFieldPath, FromFieldPath: ptr.To("status.atProvider.arn"), ToFieldPath: ptr.To("arn")},
+							},
+						},
+					},
+				},
+			},
+			ecs: []*extv1alpha1.EnvironmentConfig{
+				{Data: map[string]kextv1.JSON{"tags": {Raw: []byte(`{"env":"staging"}`)}}},
+				{Data: map[string]kextv1.JSON{"region": {Raw: []byte(`"eu-west-1"`)}}},
+			},
+			want: &CompositeResourceEnvironment{
+				Data: []byte(`{"region":"eu-west-1","tags":{"env":"staging","team":"platform"}}`),
+				Patches: []EnvironmentPatch{
+					{
+						Type:           string(extv1.PatchTypeToCompositeFieldPath),
+						FromFieldPaths: []string{"region"},
+						ToFieldPath:    ptr.To("status.region"),
+						Values:         [][]byte{[]byte(`"eu-west-1"`)},
+					},
+					{
+						Type:           string(extv1.PatchTypeFromCompositeFieldPath),
+						FromFieldPaths: []string{"spec.size"},
+						ToFieldPath:    ptr.To("size"),
+					},
+					{
+						Resource:       ptr.To("bucket"),
+						Type:           string(extv1.PatchTypeFromEnvironmentFieldPath),
+						FromFieldPaths: []string{"tags"},
+						ToFieldPath:    ptr.To("spec.forProvider.tags"),
+						Values:         [][]byte{[]byte(`{"env":"staging","team":"platform"}`)},
+					},
+					{
+						Resource:       ptr.To("bucket"),
+						Type:           string(extv1.PatchTypeCombineFromEnvironment),
+						FromFieldPaths: []string{"region", "zone"},
+						ToFieldPath:    ptr.To("spec.forProvider.location"),
+						Values:         [][]byte{[]byte(`"eu-west-1"`), nil},
+					},
+					{
+						Resource:       ptr.To("resources[1]"),
+						Type:           string(extv1.PatchTypeToEnvironmentFieldPath),
+						FromFieldPaths: []string{"status.atProvider.arn"},
+						ToFieldPath:    ptr.To("arn"),
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := GetCompositeResourceEnvironment(tc.cmp, tc.ecs)
+			if err != nil {
+				t.Fatalf("\n%s\nGetCompositeResourceEnvironment(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGetCompositeResourceEnvironment(...): -want, +got\n:%s", tc.reason, diff)
+			}
+		})
+	}
+}
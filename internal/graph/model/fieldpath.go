@@ -19,7 +19,10 @@ import (
 	"strings"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
 
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
 )
@@ -30,6 +33,26 @@ import (
 // resolutions to PavedAccess, which is also embedded via the `@goType` directive.
 type SkipUnstructured interface{}
 
+// SkipConditions is a marker type. Like SkipUnstructured it is used in the
+// schema via a `@goType` directive to delegate resolution of all "conditions"
+// fields to PavedAccess.
+type SkipConditions interface{}
+
+// SkipReady, SkipSynced, and SkipUpToDate are marker types. Like
+// SkipUnstructured they are used in the schema via a `@goType` directive to
+// delegate resolution of all "ready", "synced", and "upToDate" fields to
+// PavedAccess.
+type (
+	SkipReady    interface{}
+	SkipSynced   interface{}
+	SkipUpToDate interface{}
+)
+
+// SkipManifest is a marker type. Like SkipUnstructured it is used in the
+// schema via a `@goType` directive to delegate resolution of all "manifest"
+// fields to PavedAccess.
+type SkipManifest interface{}
+
 // PavedAccess is an embedded resolver for "unstructured" and "fieldPath" fields.
 // It is embedded in generated types via a `@goType` directive.
 type PavedAccess struct {
@@ -51,6 +74,107 @@ func (f PavedAccess) FieldPath(path *string) ([]byte, error) {
 	return fieldPath(f.Paved, *path)
 }
 
+// Conditions implements the "conditions" field and returns the conditions at
+// status.conditions, if any. Conditions that can't be read as Crossplane style
+// conditions are ignored.
+func (f PavedAccess) Conditions() []Condition {
+	if f.Paved == nil {
+		return []Condition{}
+	}
+	c := []xpv1.Condition{}
+	if err := f.GetValueInto("status.conditions", &c); err != nil || len(c) == 0 {
+		return []Condition{}
+	}
+	return GetConditions(c)
+}
+
+// Ready implements the "ready" field and returns the status of the Ready
+// condition, if any.
+func (f PavedAccess) Ready() *bool {
+	return f.conditionStatus(xpv1.TypeReady)
+}
+
+// Synced implements the "synced" field and returns the status of the Synced
+// condition, if any.
+func (f PavedAccess) Synced() *bool {
+	return f.conditionStatus(xpv1.TypeSynced)
+}
+
+// UpToDate implements the "upToDate" field and returns whether the resource's
+// status.observedGeneration is at least its metadata.generation, or nil if it
+// has no observed generation.
+func (f PavedAccess) UpToDate() *bool {
+	if f.Paved == nil {
+		return nil
+	}
+	og, ok := f.integer("status.observedGeneration")
+	if !ok {
+		return nil
+	}
+	g, _ := f.integer("metadata.generation")
+	return ptr.To(og >= g)
+}
+
+// integer returns the integer at the supplied path. Unstructured objects
+// decoded from JSON may represent integers as floats.
+func (f PavedAccess) integer(path string) (int64, bool) {
+	v, err := f.GetValue(path)
+	if err != nil {
+		return 0, false
+	}
+	switch n := v.(type) {
+	case int64:
+		return n, true
+	case float64:
+		return int64(n), true
+	default:
+		return 0, false
+	}
+}
+
+// conditionStatus returns true if the supplied condition is true, false if
+// it is false, and nil if it is absent or its status is unknown.
+func (f PavedAccess) conditionStatus(ct xpv1.ConditionType) *bool {
+	for _, c := range f.Conditions() {
+		if c.Type != string(ct) {
+			continue
+		}
+		switch c.Status {
+		case ConditionStatusTrue:
+			return ptr.To(true)
+		case ConditionStatusFalse:
+			return ptr.To(false)
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+// Manifest implements the "manifest" field and returns the serialized object
+// in the supplied format, which defaults to YAML. The object's managed fields
+// are omitted unless includeManagedFields is true.
+func (f PavedAccess) Manifest(format *ManifestFormat, includeManagedFields *bool) (string, error) {
+	if f.Paved == nil {
+		return "", nil
+	}
+
+	obj := f.UnstructuredContent()
+	if includeManagedFields == nil || !*includeManagedFields {
+		obj = runtime.DeepCopyJSON(obj)
+		if md, ok := obj["metadata"].(map[string]interface{}); ok {
+			delete(md, "managedFields")
+		}
+	}
+
+	if format != nil && *format == ManifestFormatJSON {
+		out, err := json.MarshalIndent(obj, "", "  ")
+		return string(out), errors.Wrap(err, "cannot marshal manifest as JSON")
+	}
+	out, err := yaml.Marshal(obj)
+	return string(out), errors.Wrap(err, "cannot marshal manifest as YAML")
+}
+
 // raw returns the supplied object as unstructured JSON bytes. It panics if
 // the object cannot be marshalled as JSON, which _should_ only happen if this
 // program is fundamentally broken - e.g. trying to use a weird runtime.Object.
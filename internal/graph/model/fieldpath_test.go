@@ -27,6 +27,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/ptr"
 
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+
 	"github.com/upbound/xgql/internal/unstructured"
 )
 
@@ -206,3 +208,255 @@ func TestPavedAccess_FieldPath(t *testing.T) {
 		})
 	}
 }
+
+func TestPavedAccess_Conditions(t *testing.T) {
+	transition := metav1.NewTime(time.Unix(42, 0))
+
+	cases := map[string]struct {
+		reason string
+		object map[string]any
+		want   []Condition
+	}{
+		"Conditions": {
+			reason: "Conditions at status.conditions should be converted to our model.",
+			object: map[string]any{
+				"status": map[string]any{
+					"conditions": []any{
+						map[string]any{
+							"type":               "Ready",
+							"status":             "True",
+							"reason":             "Available",
+							"message":            "So cool",
+							"lastTransitionTime": transition.Format(time.RFC3339),
+						},
+					},
+				},
+			},
+			want: []Condition{{
+				Type:               "Ready",
+				Status:             ConditionStatusTrue,
+				Reason:             "Available",
+				Message:            ptr.To("So cool"),
+				LastTransitionTime: transition.Time,
+			}},
+		},
+		"NoStatus": {
+			reason: "An object without a status should have no conditions.",
+			object: map[string]any{},
+			want:   []Condition{},
+		},
+		"WeirdConditions": {
+			reason: "Conditions that aren't an array should be ignored.",
+			object: map[string]any{
+				"status": map[string]any{
+					"conditions": "wat",
+				},
+			},
+			want: []Condition{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			f := PavedAccess{Paved: fieldpath.Pave(tc.object)}
+			got := f.Conditions()
+			if diff := cmp.Diff(tc.want, got, cmpopts.EquateApproxTime(0)); diff != "" {
+				t.Errorf("\n%s\nPavedAccess.Conditions(): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestPavedAccess_ReadySynced(t *testing.T) {
+	object := func(conditions ...map[string]any) map[string]any {
+		c := make([]any, len(conditions))
+		for i := range conditions {
+			c[i] = conditions[i]
+		}
+		return map[string]any{"status": map[string]any{"conditions": c}}
+	}
+
+	type want struct {
+		ready  *bool
+		synced *bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		object map[string]any
+		want   want
+	}{
+		"True": {
+			reason: "A resource with true Ready and Synced conditions should be ready and synced.",
+			object: object(
+				map[string]any{"type": "Ready", "status": "True"},
+				map[string]any{"type": "Synced", "status": "True"},
+			),
+			want: want{ready: ptr.To(true), synced: ptr.To(true)},
+		},
+		"False": {
+			reason: "A resource with false Ready and Synced conditions should be neither ready nor synced.",
+			object: object(
+				map[string]any{"type": "Ready", "status": "False"},
+				map[string]any{"type": "Synced", "status": "False"},
+			),
+			want: want{ready: ptr.To(false), synced: ptr.To(false)},
+		},
+		"Unknown": {
+			reason: "A resource whose Ready condition has an unknown status should not report whether it is ready.",
+			object: object(
+				map[string]any{"type": "Ready", "status": "Unknown"},
+				map[string]any{"type": "Synced", "status": "True"},
+			),
+			want: want{synced: ptr.To(true)},
+		},
+		"Absent": {
+			reason: "A resource without Ready and Synced conditions should not report whether it is ready or synced.",
+			object: map[string]any{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			f := PavedAccess{Paved: fieldpath.Pave(tc.object)}
+			if diff := cmp.Diff(tc.want.ready, f.Ready()); diff != "" {
+				t.Errorf("\n%s\nPavedAccess.Ready(): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.synced, f.Synced()); diff != "" {
+				t.Errorf("\n%s\nPavedAccess.Synced(): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestPavedAccess_UpToDate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		object map[string]any
+		want   *bool
+	}{
+		"Observed": {
+			reason: "A resource whose observed generation matches its generation should be up to date.",
+			object: map[string]any{
+				"metadata": map[string]any{"generation": int64(3)},
+				"status":   map[string]any{"observedGeneration": int64(3)},
+			},
+			want: ptr.To(true),
+		},
+		"Behind": {
+			reason: "A resource whose observed generation is older than its generation should not be up to date.",
+			object: map[string]any{
+				"metadata": map[string]any{"generation": int64(4)},
+				"status":   map[string]any{"observedGeneration": int64(3)},
+			},
+			want: ptr.To(false),
+		},
+		"Float": {
+			reason: "Generations decoded from JSON as floats should be compared.",
+			object: map[string]any{
+				"metadata": map[string]any{"generation": float64(2)},
+				"status":   map[string]any{"observedGeneration": float64(2)},
+			},
+			want: ptr.To(true),
+		},
+		"NoObservedGeneration": {
+			reason: "A resource without an observed generation should not report whether it is up to date.",
+			object: map[string]any{
+				"metadata": map[string]any{"generation": int64(1)},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			f := PavedAccess{Paved: fieldpath.Pave(tc.object)}
+			if diff := cmp.Diff(tc.want, f.UpToDate()); diff != "" {
+				t.Errorf("\n%s\nPavedAccess.UpToDate(): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestPavedAccess_Manifest(t *testing.T) {
+	object := func() map[string]any {
+		return map[string]any{
+			"apiVersion": "example.org/v1",
+			"kind":       "Example",
+			"metadata": map[string]any{
+				"name": "cool",
+				"managedFields": []any{
+					map[string]any{"manager": "crossplane"},
+				},
+			},
+		}
+	}
+
+	type args struct {
+		format               *ManifestFormat
+		includeManagedFields *bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		object map[string]any
+		args   args
+		want   string
+	}{
+		"DefaultYAML": {
+			reason: "The manifest should be YAML without managed fields by default.",
+			object: object(),
+			want: `apiVersion: example.org/v1
+kind: Example
+metadata:
+  name: cool
+`,
+		},
+		"JSON": {
+			reason: "The manifest should be indented JSON when requested.",
+			object: object(),
+			args: args{
+				format: ptr.To(ManifestFormatJSON),
+			},
+			want: `{
+  "apiVersion": "example.org/v1",
+  "kind": "Example",
+  "metadata": {
+    "name": "cool"
+  }
+}`,
+		},
+		"IncludeManagedFields": {
+			reason: "The manifest should include managed fields when requested.",
+			object: object(),
+			args: args{
+				format:               ptr.To(ManifestFormatYaml),
+				includeManagedFields: ptr.To(true),
+			},
+			want: `apiVersion: example.org/v1
+kind: Example
+metadata:
+  managedFields:
+  - manager: crossplane
+  name: cool
+`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			f := PavedAccess{Paved: fieldpath.Pave(tc.object)}
+			got, err := f.Manifest(tc.args.format, tc.args.includeManagedFields)
+			if err != nil {
+				t.Fatalf("\n%s\nPavedAccess.Manifest(...): unexpected error: %s", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nPavedAccess.Manifest(...): -want, +got:\n%s", tc.reason, diff)
+			}
+
+			// Stripping managed fields must not modify the underlying object.
+			if _, err := f.GetValue("metadata.managedFields"); err != nil {
+				t.Errorf("\n%s\nPavedAccess.Manifest(...): modified the underlying object: %s", tc.reason, err)
+			}
+		})
+	}
+}
@@ -46,6 +46,62 @@ type ProviderConfigDefinition interface {
 	IsProviderConfigDefinition()
 }
 
+// A Transform transforms the value read by a patch.
+type Transform interface {
+	IsTransform()
+}
+
+// An APIResource is a kind of resource served by the API server.
+type APIResource struct {
+	// The API group of the resource. Empty for the core API group.
+	Group string `json:"group"`
+	// The preferred API version of the resource.
+	Version string `json:"version"`
+	// The kind of the resource.
+	Kind string `json:"kind"`
+	// The name of the resource, i.e. the lowercase plural form of its kind.
+	Name string `json:"name"`
+	// Whether the resource is namespaced, or cluster scoped.
+	Namespaced bool `json:"namespaced"`
+	// The verbs the API server supports for the resource, e.g. get and list.
+	Verbs []string `json:"verbs"`
+	// Short names for the resource, e.g. `deploy` for `deployments`.
+	ShortNames []string `json:"shortNames,omitempty"`
+	// The categories the resource belongs to, e.g. `all` or `crossplane`.
+	Categories []string `json:"categories,omitempty"`
+}
+
+// An AccessReview indicates whether the caller may perform an action.
+type AccessReview struct {
+	// The action that was reviewed.
+	ResourceAttributes ResourceAttributes `json:"resourceAttributes"`
+	// Whether the caller may perform the action.
+	Allowed bool `json:"allowed"`
+	// Whether the caller is explicitly denied the action. An action may be neither
+	// allowed nor denied, in which case it is not allowed.
+	Denied bool `json:"denied"`
+	// Why the action was allowed or denied, if known.
+	Reason *string `json:"reason,omitempty"`
+}
+
+// ActivateRevisionPayload is the result of activating a package revision.
+type ActivateRevisionPayload struct {
+	// The updated package. Null if the mutation failed.
+	Package KubernetesResource `json:"package,omitempty"`
+	// The activated revision. Null if the mutation failed.
+	ActiveRevision KubernetesResource `json:"activeRevision,omitempty"`
+}
+
+// A ComposedTemplate is a template from which a composition composes a resource.
+type ComposedTemplate struct {
+	// The name of this resource template, if any.
+	Name *string `json:"name,omitempty"`
+	// The base resource that this template's patches are applied to.
+	Base []byte `json:"base"`
+	// The patches this template applies, in order.
+	Patches []CompositionPatch `json:"patches"`
+}
+
 // A CompositeResource is a resource this is reconciled by composing other
 // composite or managed resources. Composite resources use a Composition to
 // determine which resources to compose, and how.
@@ -134,10 +190,38 @@ type CompositeResource struct {
 	//
 	// https://github.com/kubernetes/community/blob/61f3d0/contributors/devel/sig-architecture/api-conventions.md#selecting-fields
 	PavedAccess `json:"fieldPath"`
+	// The conditions of this resource, read from its `status.conditions` field.
+	// Resources that don't report conditions have none.
+	SkipConditions `json:"conditions"`
+	// Whether this resource is ready, read from its `Ready` condition. Null if the
+	// resource doesn't report whether it is ready.
+	SkipReady `json:"ready,omitempty"`
+	// Whether this resource is synced, read from its `Synced` condition. Null if the
+	// resource doesn't report whether it is synced.
+	SkipSynced `json:"synced,omitempty"`
+	// Whether this resource's controller has observed its latest generation, read
+	// by comparing its `metadata.generation` with its `status.observedGeneration`.
+	// Null if the resource doesn't report an observed generation.
+	SkipUpToDate `json:"upToDate,omitempty"`
+	// The serialized manifest of this resource, for example to copy and paste.
+	// Its `metadata.managedFields` are omitted unless included explicitly.
+	SkipManifest `json:"manifest"`
 	// Events pertaining to this resource.
 	Events EventConnection `json:"events"`
 	// The definition of this resource.
 	Definition *CompositeResourceDefinition `json:"definition,omitempty"`
+	// The OpenAPI v3 schema of this resource's version, per its definition. Null if
+	// the schema can't be determined.
+	Schema *OpenAPISchema `json:"schema,omitempty"`
+	// The tree of resources rooted at this composite resource, i.e. the resources
+	// it composes and, recursively, the resources they compose. The depth of the
+	// tree is limited by the server. Walking the tree is expensive, so only callers
+	// who may list composite resource definitions may do so.
+	Tree ResourceTreeNode `json:"tree"`
+	// Usages that protect this resource from deletion.
+	UsedBy UsageConnection `json:"usedBy"`
+	// Usages in which this resource uses other resources.
+	Uses UsageConnection `json:"uses"`
 }
 
 func (CompositeResource) IsNode() {}
@@ -230,10 +314,34 @@ type CompositeResourceClaim struct {
 	//
 	// https://github.com/kubernetes/community/blob/61f3d0/contributors/devel/sig-architecture/api-conventions.md#selecting-fields
 	PavedAccess `json:"fieldPath"`
+	// The conditions of this resource, read from its `status.conditions` field.
+	// Resources that don't report conditions have none.
+	SkipConditions `json:"conditions"`
+	// Whether this resource is ready, read from its `Ready` condition. Null if the
+	// resource doesn't report whether it is ready.
+	SkipReady `json:"ready,omitempty"`
+	// Whether this resource is synced, read from its `Synced` condition. Null if the
+	// resource doesn't report whether it is synced.
+	SkipSynced `json:"synced,omitempty"`
+	// Whether this resource's controller has observed its latest generation, read
+	// by comparing its `metadata.generation` with its `status.observedGeneration`.
+	// Null if the resource doesn't report an observed generation.
+	SkipUpToDate `json:"upToDate,omitempty"`
+	// The serialized manifest of this resource, for example to copy and paste.
+	// Its `metadata.managedFields` are omitted unless included explicitly.
+	SkipManifest `json:"manifest"`
 	// Events pertaining to this resource.
 	Events EventConnection `json:"events"`
 	// The definition of this resource.
 	Definition *CompositeResourceDefinition `json:"definition,omitempty"`
+	// The OpenAPI v3 schema of this resource's version, per its definition. Null if
+	// the schema can't be determined.
+	Schema *OpenAPISchema `json:"schema,omitempty"`
+	// The tree of resources rooted at this claim, i.e. the composite resource it
+	// references and, recursively, the resources that composes. The depth of the
+	// tree is limited by the server. Walking the tree is expensive, so only callers
+	// who may list composite resource definitions may do so.
+	Tree ResourceTreeNode `json:"tree"`
 }
 
 func (CompositeResourceClaim) IsNode() {}
@@ -255,6 +363,9 @@ type CompositeResourceClaimConnectionDetails struct {
 	// The time at which the composite resource claim's connection details were last
 	// published.
 	LastPublishedTime *time.Time `json:"lastPublishedTime,omitempty"`
+	// A reference to the secret the composite resource claim's connection details are
+	// published to. The secret's values are never exposed.
+	SecretRef *SecretReference `json:"secretRef,omitempty"`
 }
 
 // A CompositeResourceClaimStatus represents the observed status of a composite
@@ -282,6 +393,9 @@ type CompositeResourceConnectionDetails struct {
 	// The time at which the composite resource's connection details were last
 	// published.
 	LastPublishedTime *time.Time `json:"lastPublishedTime,omitempty"`
+	// A reference to the secret the composite resource's connection details are
+	// published to. The secret's values are never exposed.
+	SecretRef *SecretReference `json:"secretRef,omitempty"`
 }
 
 // A CompositeResourceDefinition (or XRD) defines a new kind of resource. The new
@@ -371,12 +485,32 @@ type CompositeResourceDefinition struct {
 	//
 	// https://github.com/kubernetes/community/blob/61f3d0/contributors/devel/sig-architecture/api-conventions.md#selecting-fields
 	PavedAccess `json:"fieldPath"`
+	// The conditions of this resource, read from its `status.conditions` field.
+	// Resources that don't report conditions have none.
+	SkipConditions `json:"conditions"`
+	// Whether this resource is ready, read from its `Ready` condition. Null if the
+	// resource doesn't report whether it is ready.
+	SkipReady `json:"ready,omitempty"`
+	// Whether this resource is synced, read from its `Synced` condition. Null if the
+	// resource doesn't report whether it is synced.
+	SkipSynced `json:"synced,omitempty"`
+	// Whether this resource's controller has observed its latest generation, read
+	// by comparing its `metadata.generation` with its `status.observedGeneration`.
+	// Null if the resource doesn't report an observed generation.
+	SkipUpToDate `json:"upToDate,omitempty"`
+	// The serialized manifest of this resource, for example to copy and paste.
+	// Its `metadata.managedFields` are omitted unless included explicitly.
+	SkipManifest `json:"manifest"`
 	// Events pertaining to this resource.
 	Events EventConnection `json:"events"`
 	// The generated `CustomResourceDefinition` for this XRD
 	CompositeResourceCrd *CustomResourceDefinition `json:"compositeResourceCRD,omitempty"`
 	// The generated `CustomResourceDefinition` of this XRDs `CompositeClaim` if defined
 	CompositeResourceClaimCrd *CustomResourceDefinition `json:"compositeResourceClaimCRD,omitempty"`
+	// The OpenAPI v3 schema of a served version of the composite resource defined
+	// by this XRD, for example to generate a form for the resource. Null if the
+	// version isn't served or has no schema.
+	Schema *OpenAPISchema `json:"schema,omitempty"`
 	// Composite resources (XRs) defined by this XRD.
 	DefinedCompositeResources CompositeResourceConnection `json:"definedCompositeResources"`
 	// Composite resource claims (XRCs) defined by this XRD.
@@ -467,6 +601,18 @@ type CompositeResourceDefinitionVersion struct {
 	Schema *CompositeResourceValidation `json:"schema,omitempty"`
 }
 
+// A CompositeResourceEnvironment is a preview of the environment in which a
+// composite resource is composed.
+type CompositeResourceEnvironment struct {
+	// The environment, resolved by merging the data of the environment configs the
+	// composite resource selects, in order, over the composition's default
+	// environment data. Changes made by the composition's environment patches are
+	// not included.
+	Data []byte `json:"data,omitempty"`
+	// The composition's patches that read from or write to the environment.
+	Patches []EnvironmentPatch `json:"patches"`
+}
+
 // A CompositeResourceClaimStatus represents the observed state of a composite
 // resource.
 type CompositeResourceStatus struct {
@@ -572,6 +718,22 @@ type Composition struct {
 	//
 	// https://github.com/kubernetes/community/blob/61f3d0/contributors/devel/sig-architecture/api-conventions.md#selecting-fields
 	PavedAccess `json:"fieldPath"`
+	// The conditions of this resource, read from its `status.conditions` field.
+	// Resources that don't report conditions have none.
+	SkipConditions `json:"conditions"`
+	// Whether this resource is ready, read from its `Ready` condition. Null if the
+	// resource doesn't report whether it is ready.
+	SkipReady `json:"ready,omitempty"`
+	// Whether this resource is synced, read from its `Synced` condition. Null if the
+	// resource doesn't report whether it is synced.
+	SkipSynced `json:"synced,omitempty"`
+	// Whether this resource's controller has observed its latest generation, read
+	// by comparing its `metadata.generation` with its `status.observedGeneration`.
+	// Null if the resource doesn't report an observed generation.
+	SkipUpToDate `json:"upToDate,omitempty"`
+	// The serialized manifest of this resource, for example to copy and paste.
+	// Its `metadata.managedFields` are omitted unless included explicitly.
+	SkipManifest `json:"manifest"`
 	// Events pertaining to this resource.
 	Events EventConnection `json:"events"`
 }
@@ -588,6 +750,23 @@ type CompositionConnection struct {
 	TotalCount int `json:"totalCount"`
 }
 
+// A CompositionPatch patches a field of a composed resource, a composite resource,
+// or the environment using a field of another.
+type CompositionPatch struct {
+	// The type of this patch, for example `FromCompositeFieldPath`.
+	Type string `json:"type"`
+	// The field path this patch reads from, if any.
+	FromFieldPath *string `json:"fromFieldPath,omitempty"`
+	// The field paths this patch combines, if any.
+	CombineFromFieldPaths []string `json:"combineFromFieldPaths,omitempty"`
+	// The field path this patch writes to, if any.
+	ToFieldPath *string `json:"toFieldPath,omitempty"`
+	// The name of the patch set this patch includes, for `PatchSet` patches.
+	PatchSetName *string `json:"patchSetName,omitempty"`
+	// The transforms this patch applies to the value it reads, in order.
+	Transforms []Transform `json:"transforms"`
+}
+
 // A CompositionSpec represents the desired state of a composition.
 type CompositionSpec struct {
 	// CompositeTypeRef specifies the type of composite resource that this
@@ -597,6 +776,15 @@ type CompositionSpec struct {
 	// connection secrets of composite resource dynamically provisioned using this
 	// composition will be created.
 	WriteConnectionSecretsToNamespace *string `json:"writeConnectionSecretsToNamespace,omitempty"`
+	// The pipeline of composition functions this composition runs. Compositions
+	// that don't use Pipeline mode have no pipeline.
+	Pipeline []PipelineStep `json:"pipeline,omitempty"`
+	// The named sets of patches this composition's resource templates may include.
+	// Compositions that don't use Resources mode have no patch sets.
+	PatchSets []PatchSet `json:"patchSets,omitempty"`
+	// The templates of the resources this composition composes. Compositions that
+	// don't use Resources mode have no resource templates.
+	Resources []ComposedTemplate `json:"resources,omitempty"`
 }
 
 // A CompositionStatus represents the observed state of a composition.
@@ -637,8 +825,14 @@ type ConfigMap struct {
 	Kind string `json:"kind"`
 	// Metadata that is common to all Kubernetes API resources.
 	Metadata ObjectMeta `json:"metadata"`
-	// The data stored in this config map.
+	// The data stored in this config map. Values are only returned if xgql was
+	// started with `--expose-config-map-values`; this field is null otherwise. Use
+	// `dataKeys` to read which keys a config map contains.
 	data map[string]string `json:"-"`
+	// The keys of the data stored in this config map, including its binary data,
+	// sorted by name. Use this rather than `data` to read which keys a config map
+	// contains without reading their values.
+	DataKeys []string `json:"dataKeys"`
 	// An unstructured JSON representation of the underlying Kubernetes resource.
 	SkipUnstructured `json:"unstructured"`
 	// A JSON representation of a field within the underlying Kubernetes resource.
@@ -711,6 +905,22 @@ type ConfigMap struct {
 	//
 	// https://github.com/kubernetes/community/blob/61f3d0/contributors/devel/sig-architecture/api-conventions.md#selecting-fields
 	PavedAccess `json:"fieldPath"`
+	// The conditions of this resource, read from its `status.conditions` field.
+	// Resources that don't report conditions have none.
+	SkipConditions `json:"conditions"`
+	// Whether this resource is ready, read from its `Ready` condition. Null if the
+	// resource doesn't report whether it is ready.
+	SkipReady `json:"ready,omitempty"`
+	// Whether this resource is synced, read from its `Synced` condition. Null if the
+	// resource doesn't report whether it is synced.
+	SkipSynced `json:"synced,omitempty"`
+	// Whether this resource's controller has observed its latest generation, read
+	// by comparing its `metadata.generation` with its `status.observedGeneration`.
+	// Null if the resource doesn't report an observed generation.
+	SkipUpToDate `json:"upToDate,omitempty"`
+	// The serialized manifest of this resource, for example to copy and paste.
+	// Its `metadata.managedFields` are omitted unless included explicitly.
+	SkipManifest `json:"manifest"`
 	// Events pertaining to this resource.
 	Events EventConnection `json:"events"`
 }
@@ -805,6 +1015,22 @@ type Configuration struct {
 	//
 	// https://github.com/kubernetes/community/blob/61f3d0/contributors/devel/sig-architecture/api-conventions.md#selecting-fields
 	PavedAccess `json:"fieldPath"`
+	// The conditions of this resource, read from its `status.conditions` field.
+	// Resources that don't report conditions have none.
+	SkipConditions `json:"conditions"`
+	// Whether this resource is ready, read from its `Ready` condition. Null if the
+	// resource doesn't report whether it is ready.
+	SkipReady `json:"ready,omitempty"`
+	// Whether this resource is synced, read from its `Synced` condition. Null if the
+	// resource doesn't report whether it is synced.
+	SkipSynced `json:"synced,omitempty"`
+	// Whether this resource's controller has observed its latest generation, read
+	// by comparing its `metadata.generation` with its `status.observedGeneration`.
+	// Null if the resource doesn't report an observed generation.
+	SkipUpToDate `json:"upToDate,omitempty"`
+	// The serialized manifest of this resource, for example to copy and paste.
+	// Its `metadata.managedFields` are omitted unless included explicitly.
+	SkipManifest `json:"manifest"`
 	// Events pertaining to this resource.
 	Events EventConnection `json:"events"`
 	// Revisions of this configuration.
@@ -911,6 +1137,22 @@ type ConfigurationRevision struct {
 	//
 	// https://github.com/kubernetes/community/blob/61f3d0/contributors/devel/sig-architecture/api-conventions.md#selecting-fields
 	PavedAccess `json:"fieldPath"`
+	// The conditions of this resource, read from its `status.conditions` field.
+	// Resources that don't report conditions have none.
+	SkipConditions `json:"conditions"`
+	// Whether this resource is ready, read from its `Ready` condition. Null if the
+	// resource doesn't report whether it is ready.
+	SkipReady `json:"ready,omitempty"`
+	// Whether this resource is synced, read from its `Synced` condition. Null if the
+	// resource doesn't report whether it is synced.
+	SkipSynced `json:"synced,omitempty"`
+	// Whether this resource's controller has observed its latest generation, read
+	// by comparing its `metadata.generation` with its `status.observedGeneration`.
+	// Null if the resource doesn't report an observed generation.
+	SkipUpToDate `json:"upToDate,omitempty"`
+	// The serialized manifest of this resource, for example to copy and paste.
+	// Its `metadata.managedFields` are omitted unless included explicitly.
+	SkipManifest `json:"manifest"`
 	// Events pertaining to this resource.
 	Events EventConnection `json:"events"`
 }
@@ -953,6 +1195,11 @@ type ConfigurationRevisionSpec struct {
 type ConfigurationRevisionStatus struct {
 	// The observed condition of this resource.
 	Conditions []Condition `json:"conditions,omitempty"`
+	// The conditions of this revision that aren't True, i.e. the reasons it isn't
+	// healthy or installed. For example a revision whose package can't be pulled
+	// has a Healthy condition with status False, whose reason and message describe
+	// the pull error.
+	Problems []Condition `json:"problems,omitempty"`
 	// The number of known dependencies.
 	FoundDependencies *int `json:"foundDependencies,omitempty"`
 	// The number of installed dependencies.
@@ -1013,6 +1260,16 @@ type ConfigurationStatus struct {
 
 func (ConfigurationStatus) IsConditionedStatus() {}
 
+// A ConvertTransform converts a value to another type.
+type ConvertTransform struct {
+	// The type to convert the value to, for example `int64`.
+	ToType string `json:"toType"`
+	// The format of the value, for example `quantity`.
+	Format *string `json:"format,omitempty"`
+}
+
+func (ConvertTransform) IsTransform() {}
+
 // CreateKubernetesResourceInput is the input required to create a Kubernetes
 // resource.
 type CreateKubernetesResourceInput struct {
@@ -1028,6 +1285,23 @@ type CreateKubernetesResourcePayload struct {
 	Resource KubernetesResource `json:"resource,omitempty"`
 }
 
+// A CrossplaneComponentStatus summarizes the health of one kind of Crossplane
+// component. A component with no instances, for example when no functions are
+// installed, is healthy.
+type CrossplaneComponentStatus struct {
+	// The name of the component, e.g. Providers.
+	Name string `json:"name"`
+	// The health of the component.
+	Health CrossplaneHealth `json:"health"`
+	// The number of instances of the component, e.g. the number of installed
+	// providers. For the Lock this is the number of locked packages.
+	Total int `json:"total"`
+	// The names of the unhealthy instances of the component. A package is
+	// unhealthy unless it's both installed and healthy. A locked package is
+	// unhealthy if any of its dependencies aren't locked.
+	Unhealthy []string `json:"unhealthy"`
+}
+
 // A `CrossplaneResourceTreeConnection` represents a connection to `CrossplaneResourceTreeNode`s
 type CrossplaneResourceTreeConnection struct {
 	// Connected nodes.
@@ -1047,6 +1321,15 @@ type CrossplaneResourceTreeNode struct {
 	Resource KubernetesResource `json:"resource"`
 }
 
+// A CrossplaneStatus summarizes the health of Crossplane's packages and their
+// package lock.
+type CrossplaneStatus struct {
+	// DEGRADED if any component is degraded, otherwise HEALTHY.
+	Health CrossplaneHealth `json:"health"`
+	// The health of each component - Providers, Configurations, Functions, and Lock.
+	Components []CrossplaneComponentStatus `json:"components"`
+}
+
 // A CustomResourceDefinition defines a type of custom resource that extends the
 // set of resources supported by the Kubernetes API.
 type CustomResourceDefinition struct {
@@ -1134,6 +1417,22 @@ type CustomResourceDefinition struct {
 	//
 	// https://github.com/kubernetes/community/blob/61f3d0/contributors/devel/sig-architecture/api-conventions.md#selecting-fields
 	PavedAccess `json:"fieldPath"`
+	// The conditions of this resource, read from its `status.conditions` field.
+	// Resources that don't report conditions have none.
+	SkipConditions `json:"conditions"`
+	// Whether this resource is ready, read from its `Ready` condition. Null if the
+	// resource doesn't report whether it is ready.
+	SkipReady `json:"ready,omitempty"`
+	// Whether this resource is synced, read from its `Synced` condition. Null if the
+	// resource doesn't report whether it is synced.
+	SkipSynced `json:"synced,omitempty"`
+	// Whether this resource's controller has observed its latest generation, read
+	// by comparing its `metadata.generation` with its `status.observedGeneration`.
+	// Null if the resource doesn't report an observed generation.
+	SkipUpToDate `json:"upToDate,omitempty"`
+	// The serialized manifest of this resource, for example to copy and paste.
+	// Its `metadata.managedFields` are omitted unless included explicitly.
+	SkipManifest `json:"manifest"`
 	// Events pertaining to this resource.
 	Events EventConnection `json:"events"`
 	// Custom resources defined by this CRD
@@ -1258,8 +1557,9 @@ type DeleteKubernetesResourcePayload struct {
 	Resource KubernetesResource `json:"resource,omitempty"`
 }
 
-// An event pertaining to a Kubernetes resource.
-type Event struct {
+// A DeploymentRuntimeConfig configures how Crossplane deploys the runtime of a
+// package, for example the Deployment and Service of a provider.
+type DeploymentRuntimeConfig struct {
 	// An opaque identifier that is unique across all types.
 	ID ReferenceID `json:"id"`
 	// The underlying Kubernetes API version of this resource.
@@ -1268,23 +1568,7 @@ type Event struct {
 	Kind string `json:"kind"`
 	// Metadata that is common to all Kubernetes API resources.
 	Metadata ObjectMeta `json:"metadata"`
-	// The Kubernetes resource this event pertains to.
-	InvolvedObject KubernetesResource `json:"involvedObject"`
-	// The type of event.
-	Type *EventType `json:"type,omitempty"`
-	// The reason the event was emitted.
-	Reason *string `json:"reason,omitempty"`
-	// Details about the event, if any.
-	Message *string `json:"message,omitempty"`
-	// The source of the event - e.g. a controller.
-	Source *EventSource `json:"source,omitempty"`
-	// The number of times this event has occurred.
-	Count *int `json:"count,omitempty"`
-	// The time at which this event was first recorded.
-	FirstTime *time.Time `json:"firstTime,omitempty"`
-	// The time at which this event was most recently recorded.
-	LastTime *time.Time `json:"lastTime,omitempty"`
-	// An unstructured JSON representation of the event.
+	// An unstructured JSON representation of the underlying Kubernetes resource.
 	SkipUnstructured `json:"unstructured"`
 	// A JSON representation of a field within the underlying Kubernetes resource.
 	//
@@ -1355,32 +1639,45 @@ type Event struct {
 	// ```
 	//
 	// https://github.com/kubernetes/community/blob/61f3d0/contributors/devel/sig-architecture/api-conventions.md#selecting-fields
-	PavedAccess       `json:"fieldPath"`
-	InvolvedObjectRef v11.ObjectReference `json:"-"`
+	PavedAccess `json:"fieldPath"`
+	// The conditions of this resource, read from its `status.conditions` field.
+	// Resources that don't report conditions have none.
+	SkipConditions `json:"conditions"`
+	// Whether this resource is ready, read from its `Ready` condition. Null if the
+	// resource doesn't report whether it is ready.
+	SkipReady `json:"ready,omitempty"`
+	// Whether this resource is synced, read from its `Synced` condition. Null if the
+	// resource doesn't report whether it is synced.
+	SkipSynced `json:"synced,omitempty"`
+	// Whether this resource's controller has observed its latest generation, read
+	// by comparing its `metadata.generation` with its `status.observedGeneration`.
+	// Null if the resource doesn't report an observed generation.
+	SkipUpToDate `json:"upToDate,omitempty"`
+	// The serialized manifest of this resource, for example to copy and paste.
+	// Its `metadata.managedFields` are omitted unless included explicitly.
+	SkipManifest `json:"manifest"`
+	// Events pertaining to this resource.
+	Events EventConnection `json:"events"`
+	// Providers that use this deployment runtime config.
+	Providers ProviderConnection `json:"providers"`
 }
 
-func (Event) IsNode() {}
+func (DeploymentRuntimeConfig) IsNode() {}
 
-// An EventConnection represents a connection to events.
-type EventConnection struct {
+func (DeploymentRuntimeConfig) IsKubernetesResource() {}
+
+// A DeploymentRuntimeConfigConnection represents a connection to deployment
+// runtime configs.
+type DeploymentRuntimeConfigConnection struct {
 	// Connected nodes.
-	Nodes []Event `json:"nodes,omitempty"`
+	Nodes []DeploymentRuntimeConfig `json:"nodes,omitempty"`
 	// The total number of connected nodes.
 	TotalCount int `json:"totalCount"`
 }
 
-// An EventSource is the source of an event. Note that in this context 'source'
-// indicates the software or system that emitted the event, not the Kubernetes
-// resource it pertains to.
-type EventSource struct {
-	// The software component that emitted the event.
-	Component *string `json:"component,omitempty"`
-}
-
-// A GenericResource represents a kind of Kubernetes resource that does not
-// correspond to a kind or class of resources that is more specifically modelled
-// by xgql.
-type GenericResource struct {
+// An EnvironmentConfig contains data that may be merged into the environment in
+// which a composite resource is composed.
+type EnvironmentConfig struct {
 	// An opaque identifier that is unique across all types.
 	ID ReferenceID `json:"id"`
 	// The underlying Kubernetes API version of this resource.
@@ -1389,6 +1686,9 @@ type GenericResource struct {
 	Kind string `json:"kind"`
 	// Metadata that is common to all Kubernetes API resources.
 	Metadata ObjectMeta `json:"metadata"`
+	// The data of this environment config, which is merged into the environment of
+	// the composite resources that select it.
+	Data []byte `json:"data,omitempty"`
 	// An unstructured JSON representation of the underlying Kubernetes resource.
 	SkipUnstructured `json:"unstructured"`
 	// A JSON representation of a field within the underlying Kubernetes resource.
@@ -1461,39 +1761,59 @@ type GenericResource struct {
 	//
 	// https://github.com/kubernetes/community/blob/61f3d0/contributors/devel/sig-architecture/api-conventions.md#selecting-fields
 	PavedAccess `json:"fieldPath"`
+	// The conditions of this resource, read from its `status.conditions` field.
+	// Resources that don't report conditions have none.
+	SkipConditions `json:"conditions"`
+	// Whether this resource is ready, read from its `Ready` condition. Null if the
+	// resource doesn't report whether it is ready.
+	SkipReady `json:"ready,omitempty"`
+	// Whether this resource is synced, read from its `Synced` condition. Null if the
+	// resource doesn't report whether it is synced.
+	SkipSynced `json:"synced,omitempty"`
+	// Whether this resource's controller has observed its latest generation, read
+	// by comparing its `metadata.generation` with its `status.observedGeneration`.
+	// Null if the resource doesn't report an observed generation.
+	SkipUpToDate `json:"upToDate,omitempty"`
+	// The serialized manifest of this resource, for example to copy and paste.
+	// Its `metadata.managedFields` are omitted unless included explicitly.
+	SkipManifest `json:"manifest"`
 	// Events pertaining to this resource.
 	Events EventConnection `json:"events"`
 }
 
-func (GenericResource) IsNode() {}
+func (EnvironmentConfig) IsNode() {}
 
-func (GenericResource) IsKubernetesResource() {}
+func (EnvironmentConfig) IsKubernetesResource() {}
 
-// A KubernetesResourceConnection represents a connection to Kubernetes resources.
-type KubernetesResourceConnection struct {
+// An EnvironmentConfigConnection represents a connection to environment configs.
+type EnvironmentConfigConnection struct {
 	// Connected nodes.
-	Nodes []KubernetesResource `json:"nodes,omitempty"`
+	Nodes []EnvironmentConfig `json:"nodes,omitempty"`
 	// The total number of connected nodes.
 	TotalCount int `json:"totalCount"`
 }
 
-// A LabelSelector matches a Kubernetes resource by labels.
-type LabelSelector struct {
-	// The labels to match on.
-	MatchLabels map[string]string `json:"matchLabels,omitempty"`
-}
-
-// `LocalObjectReference` contains a name to to let you inspect or modify the
-// locally referred object.
-type LocalObjectReference struct {
-	// Name of the referent.
-	Name string `json:"name"`
+// An EnvironmentPatch is a composition patch that reads from or writes to the
+// environment.
+type EnvironmentPatch struct {
+	// The name of the composed resource template this patch belongs to. Null for
+	// the composition's environment patches, which patch between the composite
+	// resource and the environment.
+	Resource *string `json:"resource,omitempty"`
+	// The type of this patch, for example `FromEnvironmentFieldPath`.
+	Type string `json:"type"`
+	// The field paths this patch reads from.
+	FromFieldPaths []string `json:"fromFieldPaths"`
+	// The field path this patch writes to.
+	ToFieldPath *string `json:"toFieldPath,omitempty"`
+	// The values this patch would read from the environment, in the order of its
+	// field paths. A value is null if the environment doesn't contain its field
+	// path. Null if this patch doesn't read from the environment.
+	Values [][]byte `json:"values,omitempty"`
 }
 
-// A ManagedResource is a Kubernetes API representation of a resource in an
-// external system, such as a cloud provider's API. Crossplane providers add
-// support for new kinds of managed resource.
-type ManagedResource struct {
+// An event pertaining to a Kubernetes resource.
+type Event struct {
 	// An opaque identifier that is unique across all types.
 	ID ReferenceID `json:"id"`
 	// The underlying Kubernetes API version of this resource.
@@ -1502,11 +1822,23 @@ type ManagedResource struct {
 	Kind string `json:"kind"`
 	// Metadata that is common to all Kubernetes API resources.
 	Metadata ObjectMeta `json:"metadata"`
-	// The desired state of this resource.
-	Spec ManagedResourceSpec `json:"spec"`
-	// The observed state of this resource.
-	Status *ManagedResourceStatus `json:"status,omitempty"`
-	// An unstructured JSON representation of the underlying Kubernetes resource.
+	// The Kubernetes resource this event pertains to.
+	InvolvedObject KubernetesResource `json:"involvedObject"`
+	// The type of event.
+	Type *EventType `json:"type,omitempty"`
+	// The reason the event was emitted.
+	Reason *string `json:"reason,omitempty"`
+	// Details about the event, if any.
+	Message *string `json:"message,omitempty"`
+	// The source of the event - e.g. a controller.
+	Source *EventSource `json:"source,omitempty"`
+	// The number of times this event has occurred.
+	Count *int `json:"count,omitempty"`
+	// The time at which this event was first recorded.
+	FirstTime *time.Time `json:"firstTime,omitempty"`
+	// The time at which this event was most recently recorded.
+	LastTime *time.Time `json:"lastTime,omitempty"`
+	// An unstructured JSON representation of the event.
 	SkipUnstructured `json:"unstructured"`
 	// A JSON representation of a field within the underlying Kubernetes resource.
 	//
@@ -1577,107 +1909,31 @@ type ManagedResource struct {
 	// ```
 	//
 	// https://github.com/kubernetes/community/blob/61f3d0/contributors/devel/sig-architecture/api-conventions.md#selecting-fields
-	PavedAccess `json:"fieldPath"`
-	// Events pertaining to this resource.
-	Events EventConnection `json:"events"`
-	// The definition of this resource.
-	Definition ManagedResourceDefinition `json:"definition,omitempty"`
-}
-
-func (ManagedResource) IsNode() {}
-
-func (ManagedResource) IsKubernetesResource() {}
-
-// A ManagedResourceStatus represents the observed state of a managed resource.
-type ManagedResourceStatus struct {
-	// The observed condition of this resource.
-	Conditions []Condition `json:"conditions,omitempty"`
-}
-
-func (ManagedResourceStatus) IsConditionedStatus() {}
-
-// `ObjectReference` contains enough information to let you inspect or modify the referred object.
-type ObjectReference struct {
-	// Kind of the referent.
-	Kind *string `json:"kind,omitempty"`
-	// Namespace of the referent.
-	Namespace *string `json:"namespace,omitempty"`
-	// Name of the referent.
-	Name *string `json:"name,omitempty"`
+	PavedAccess       `json:"fieldPath"`
+	InvolvedObjectRef v11.ObjectReference `json:"-"`
 }
 
-// An owner of a Kubernetes resource.
-type Owner struct {
-	// The owner.
-	Resource KubernetesResource `json:"resource"`
-	// Whether the owner is the controller of the owned Kubernetes resource.
-	Controller *bool `json:"controller,omitempty"`
-}
+func (Event) IsNode() {}
 
-// An OwnerConnection represents a connection to an owner.
-type OwnerConnection struct {
+// An EventConnection represents a connection to events.
+type EventConnection struct {
 	// Connected nodes.
-	Nodes []Owner `json:"nodes,omitempty"`
+	Nodes []Event `json:"nodes,omitempty"`
 	// The total number of connected nodes.
 	TotalCount int `json:"totalCount"`
 }
 
-// A Patch that should be applied to an unstructured input before it is submitted.
-type Patch struct {
-	// A field path references a field within a Kubernetes object via a simple
-	// string. API conventions describe the syntax as "standard JavaScript syntax for
-	// accessing that field, assuming the JSON object was transformed into a
-	// JavaScript object, without the leading dot, such as metadata.name".
-	//
-	// Valid examples:
-	//
-	// * metadata.name
-	// * spec.containers[0].name
-	// * data[.config.yml]
-	// * metadata.annotations['crossplane.io/external-name']
-	// * spec.items[0][8]
-	// * apiVersion
-	// * [42]
-	//
-	// Invalid examples:
-	//
-	// * .metadata.name - Leading period.
-	// * metadata..name - Double period.
-	// * metadata.name. - Trailing period.
-	// * spec.containers[] - Empty brackets.
-	// * spec.containers.[0].name - Period before open bracket.
-	//
-	// https://github.com/kubernetes/community/blob/61f3d0/contributors/devel/sig-architecture/api-conventions.md#selecting-fields
-	FieldPath string `json:"fieldPath"`
-	// Unstructured JSON to be patched in at the suppled field path. This could be a
-	// string, an object, or any other valid JSON.
-	Unstructured []byte `json:"unstructured"`
-}
-
-// A PolicyRule holds information that describes a KubernetesRBAC policy rule.
-type PolicyRule struct {
-	// Verbs is a list of verbs that apply to ALL the resources specified by this
-	// rule. '*' represents all verbs.
-	Verbs []string `json:"verbs"`
-	// APIGroups is the name of the APIGroup that contains the resources. If multiple
-	// API groups are specified, any action requested against one of the enumerated
-	// resources in any API group will be allowed.
-	APIGroups []string `json:"apiGroups,omitempty"`
-	// Resources is a list of resources this rule applies to. '*' represents all
-	// resources.
-	Resources []string `json:"resources,omitempty"`
-	// ResourceNames is a list of names that the rule applies to. An empty set means
-	// that everything is allowed.
-	ResourceNames []string `json:"resourceNames,omitempty"`
-	// NonResourceURLs is a set of partial urls that a user should have access to.
-	// '*' is allowed, but only as the full, final step in the path. Rules can either
-	// apply to API resources (such as "pods" or "secrets") or non-resource URL paths
-	// (such as "/api"),  but not both.
-	NonResourceURLs []string `json:"nonResourceURLs,omitempty"`
+// An EventSource is the source of an event. Note that in this context 'source'
+// indicates the software or system that emitted the event, not the Kubernetes
+// resource it pertains to.
+type EventSource struct {
+	// The software component that emitted the event.
+	Component *string `json:"component,omitempty"`
 }
 
-// A Provider extends Crossplane with support for new managed resources.
-type Provider struct {
+// A Function extends Crossplane with a composition function that may be run as a
+// step in a composition's pipeline.
+type Function struct {
 	// An opaque identifier that is unique across all types.
 	ID ReferenceID `json:"id"`
 	// The underlying Kubernetes API version of this resource.
@@ -1687,9 +1943,9 @@ type Provider struct {
 	// Metadata that is common to all Kubernetes API resources.
 	Metadata ObjectMeta `json:"metadata"`
 	// The desired state of this resource.
-	Spec ProviderSpec `json:"spec"`
+	Spec FunctionSpec `json:"spec"`
 	// The observed state of this resource.
-	Status *ProviderStatus `json:"status,omitempty"`
+	Status *FunctionStatus `json:"status,omitempty"`
 	// An unstructured JSON representation of the underlying Kubernetes resource.
 	SkipUnstructured `json:"unstructured"`
 	// A JSON representation of a field within the underlying Kubernetes resource.
@@ -1762,21 +2018,50 @@ type Provider struct {
 	//
 	// https://github.com/kubernetes/community/blob/61f3d0/contributors/devel/sig-architecture/api-conventions.md#selecting-fields
 	PavedAccess `json:"fieldPath"`
+	// The conditions of this resource, read from its `status.conditions` field.
+	// Resources that don't report conditions have none.
+	SkipConditions `json:"conditions"`
+	// Whether this resource is ready, read from its `Ready` condition. Null if the
+	// resource doesn't report whether it is ready.
+	SkipReady `json:"ready,omitempty"`
+	// Whether this resource is synced, read from its `Synced` condition. Null if the
+	// resource doesn't report whether it is synced.
+	SkipSynced `json:"synced,omitempty"`
+	// Whether this resource's controller has observed its latest generation, read
+	// by comparing its `metadata.generation` with its `status.observedGeneration`.
+	// Null if the resource doesn't report an observed generation.
+	SkipUpToDate `json:"upToDate,omitempty"`
+	// The serialized manifest of this resource, for example to copy and paste.
+	// Its `metadata.managedFields` are omitted unless included explicitly.
+	SkipManifest `json:"manifest"`
 	// Events pertaining to this resource.
 	Events EventConnection `json:"events"`
-	// Revisions of this provider.
-	Revisions ProviderRevisionConnection `json:"revisions"`
-	// The active revision of this provider.
-	ActiveRevision *ProviderRevision `json:"activeRevision,omitempty"`
+	// Revisions of this function.
+	Revisions FunctionRevisionConnection `json:"revisions"`
+	// The active revision of this function.
+	ActiveRevision *FunctionRevision `json:"activeRevision,omitempty"`
 }
 
-func (Provider) IsNode() {}
+func (Function) IsNode() {}
 
-func (Provider) IsKubernetesResource() {}
+func (Function) IsKubernetesResource() {}
 
-// A ProviderConfig configures a provider, in that it provides configuration that
-// is relevant to all managed resources installed by a provider.
-type ProviderConfig struct {
+// A FunctionConnection represents a connection to composition functions.
+type FunctionConnection struct {
+	// Connected nodes.
+	Nodes []Function `json:"nodes,omitempty"`
+	// The total number of connected nodes.
+	TotalCount int `json:"totalCount"`
+}
+
+// A FunctionReference references a composition function.
+type FunctionReference struct {
+	// Name of the referenced composition function.
+	Name string `json:"name"`
+}
+
+// A FunctionRevision represents a revision or 'version' of a function.
+type FunctionRevision struct {
 	// An opaque identifier that is unique across all types.
 	ID ReferenceID `json:"id"`
 	// The underlying Kubernetes API version of this resource.
@@ -1785,8 +2070,10 @@ type ProviderConfig struct {
 	Kind string `json:"kind"`
 	// Metadata that is common to all Kubernetes API resources.
 	Metadata ObjectMeta `json:"metadata"`
+	// The desired state of this resource.
+	Spec FunctionRevisionSpec `json:"spec"`
 	// The observed state of this resource.
-	Status *ProviderConfigStatus `json:"status,omitempty"`
+	Status *FunctionRevisionStatus `json:"status,omitempty"`
 	// An unstructured JSON representation of the underlying Kubernetes resource.
 	SkipUnstructured `json:"unstructured"`
 	// A JSON representation of a field within the underlying Kubernetes resource.
@@ -1859,25 +2146,917 @@ type ProviderConfig struct {
 	//
 	// https://github.com/kubernetes/community/blob/61f3d0/contributors/devel/sig-architecture/api-conventions.md#selecting-fields
 	PavedAccess `json:"fieldPath"`
+	// The conditions of this resource, read from its `status.conditions` field.
+	// Resources that don't report conditions have none.
+	SkipConditions `json:"conditions"`
+	// Whether this resource is ready, read from its `Ready` condition. Null if the
+	// resource doesn't report whether it is ready.
+	SkipReady `json:"ready,omitempty"`
+	// Whether this resource is synced, read from its `Synced` condition. Null if the
+	// resource doesn't report whether it is synced.
+	SkipSynced `json:"synced,omitempty"`
+	// Whether this resource's controller has observed its latest generation, read
+	// by comparing its `metadata.generation` with its `status.observedGeneration`.
+	// Null if the resource doesn't report an observed generation.
+	SkipUpToDate `json:"upToDate,omitempty"`
+	// The serialized manifest of this resource, for example to copy and paste.
+	// Its `metadata.managedFields` are omitted unless included explicitly.
+	SkipManifest `json:"manifest"`
 	// Events pertaining to this resource.
 	Events EventConnection `json:"events"`
-	// The definition of this resource.
-	Definition ProviderConfigDefinition `json:"definition,omitempty"`
 }
 
-func (ProviderConfig) IsNode() {}
+func (FunctionRevision) IsNode() {}
 
-func (ProviderConfig) IsKubernetesResource() {}
+func (FunctionRevision) IsKubernetesResource() {}
 
-// A reference to the ProviderConfig used by a particular managed resource.
-type ProviderConfigReference struct {
-	// Name of the provider config.
-	Name string `json:"name"`
+// A FunctionRevisionConnection represents a connection to function revisions.
+type FunctionRevisionConnection struct {
+	// Connected nodes.
+	Nodes []FunctionRevision `json:"nodes,omitempty"`
+	// The total number of connected nodes.
+	TotalCount int `json:"totalCount"`
 }
 
-// A ProviderConfigStatus represents the observed state of a provider config.
-type ProviderConfigStatus struct {
-	// The observed condition of this resource.
+// A FunctionRevisionSpec represents the desired state of a function revision.
+type FunctionRevisionSpec struct {
+	// Desired state of the function revision.
+	DesiredState PackageRevisionDesiredState `json:"desiredState"`
+	// Package image used by the install pod to extract package contents.
+	Package string `json:"package"`
+	// PackagePullPolicy defines the pull policy for the package. It is also applied
+	// to any images pulled for the package, such as a function's runtime image.
+	PackagePullPolicy *PackagePullPolicy `json:"packagePullPolicy,omitempty"`
+	// Revision number. Indicates when the revision will be garbage collected based
+	// on the function's RevisionHistoryLimit.
+	Revision int `json:"revision"`
+	// IgnoreCrossplaneConstraints indicates to the package manager whether to honor
+	// Crossplane version constrains specified by the package.
+	IgnoreCrossplaneConstraints *bool `json:"ignoreCrossplaneConstraints,omitempty"`
+	// SkipDependencyResolution indicates to the package manager whether to skip
+	// resolving dependencies for a package.
+	SkipDependencyResolution *bool `json:"skipDependencyResolution,omitempty"`
+}
+
+// A FunctionRevisionStatus represents the observed state of a function revision.
+type FunctionRevisionStatus struct {
+	// The observed condition of this resource.
+	Conditions []Condition `json:"conditions,omitempty"`
+	// The number of known dependencies.
+	FoundDependencies *int `json:"foundDependencies,omitempty"`
+	// The number of installed dependencies.
+	InstalledDependencies *int `json:"installedDependencies,omitempty"`
+	// The number of invalid dependencies.
+	InvalidDependencies *int `json:"invalidDependencies,omitempty"`
+	// Permissions requested by this function revision.
+	PermissionRequests []PolicyRule `json:"permissionRequests,omitempty"`
+	// The gRPC endpoint to which Crossplane sends requests to run this function
+	// revision.
+	Endpoint *string `json:"endpoint,omitempty"`
+}
+
+func (FunctionRevisionStatus) IsConditionedStatus() {}
+
+// A FunctionSpec represents the desired state of a function.
+type FunctionSpec struct {
+	// The name of the function package to pull from an OCI registry.
+	Package string `json:"package"`
+	// RevisionActivationPolicy specifies how the package controller should update
+	// from one revision to the next.
+	RevisionActivationPolicy *RevisionActivationPolicy `json:"revisionActivationPolicy,omitempty"`
+	// RevisionHistoryLimit dictates how the package controller cleans up old
+	// inactive package revisions. Defaults to 1. Can be disabled by explicitly
+	// setting to 0.
+	RevisionHistoryLimit *int `json:"revisionHistoryLimit,omitempty"`
+	// PackagePullPolicy defines the pull policy for the package.
+	PackagePullPolicy *PackagePullPolicy `json:"packagePullPolicy,omitempty"`
+	// IgnoreCrossplaneConstraints indicates to the package manager whether to honor
+	// Crossplane version constraints specified by the package.
+	IgnoreCrossplaneConstraints *bool `json:"ignoreCrossplaneConstraints,omitempty"`
+	// SkipDependencyResolution indicates to the package manager whether to skip
+	// resolving dependencies for a package.
+	SkipDependencyResolution *bool `json:"skipDependencyResolution,omitempty"`
+}
+
+// A FunctionStatus represents the observed state of a function.
+type FunctionStatus struct {
+	// The observed condition of this resource.
+	Conditions []Condition `json:"conditions,omitempty"`
+	// CurrentRevision is the name of the current package revision. It will reflect
+	// the most up to date revision, whether it has been activated or not.
+	CurrentRevision *string `json:"currentRevision,omitempty"`
+	// CurrentIdentifier is the most recent package source that was used to produce a
+	// revision. The package manager uses this field to determine whether to check
+	// for package updates for a given source when packagePullPolicy is set to
+	// IfNotPresent.
+	CurrentIdentifier *string `json:"currentIdentifier,omitempty"`
+}
+
+func (FunctionStatus) IsConditionedStatus() {}
+
+// A GenericResource represents a kind of Kubernetes resource that does not
+// correspond to a kind or class of resources that is more specifically modelled
+// by xgql.
+type GenericResource struct {
+	// An opaque identifier that is unique across all types.
+	ID ReferenceID `json:"id"`
+	// The underlying Kubernetes API version of this resource.
+	APIVersion string `json:"apiVersion"`
+	// The underlying Kubernetes API kind of this resource.
+	Kind string `json:"kind"`
+	// Metadata that is common to all Kubernetes API resources.
+	Metadata ObjectMeta `json:"metadata"`
+	// An unstructured JSON representation of the underlying Kubernetes resource.
+	SkipUnstructured `json:"unstructured"`
+	// A JSON representation of a field within the underlying Kubernetes resource.
+	//
+	// API conventions describe the syntax as:
+	// > standard JavaScript syntax for accessing that field, assuming the JSON
+	// > object was transformed into a JavaScript object, without the leading dot,
+	// > such as `metadata.name`.
+	//
+	// Valid examples:
+	//
+	// * `metadata.name`
+	// * `spec.containers[0].name`
+	// * `data[.config.yml]`
+	// * `metadata.annotations['crossplane.io/external-name']`
+	// * `spec.items[0][8]`
+	// * `apiVersion`
+	// * `[42]`
+	// * `spec.containers[*].args[*]` - Supports wildcard expansion.
+	//
+	// Invalid examples:
+	//
+	// * `.metadata.name` - Leading period.
+	// * `metadata..name` - Double period.
+	// * `metadata.name.` - Trailing period.
+	// * `spec.containers[]` - Empty brackets.
+	// * `spec.containers.[0].name` - Period before open bracket.
+	//
+	// Wildcards support:
+	//
+	// For an object with the following data:
+	//
+	// ```json
+	// {
+	//   "spec": {
+	//     "containers": [
+	//       {
+	//         "name": "cool",
+	//         "image": "latest",
+	//         "args": [
+	//           "start",
+	//           "now",
+	//           "debug"
+	//         ]
+	//       }
+	//     ]
+	//   }
+	// }
+	// ```
+	//
+	// The wildcard `spec.containers[*].args[*]` will be expanded to:
+	//
+	// ```json
+	// [
+	//   "spec.containers[0].args[0]",
+	//   "spec.containers[0].args[1]",
+	//   "spec.containers[0].args[2]",
+	// ]
+	// ```
+	//
+	// And the following result will be returned:
+	//
+	// ```json
+	// [
+	//   "start",
+	//   "now",
+	//   "debug"
+	// ]
+	// ```
+	//
+	// https://github.com/kubernetes/community/blob/61f3d0/contributors/devel/sig-architecture/api-conventions.md#selecting-fields
+	PavedAccess `json:"fieldPath"`
+	// The conditions of this resource, read from its `status.conditions` field.
+	// Resources that don't report conditions have none.
+	SkipConditions `json:"conditions"`
+	// Whether this resource is ready, read from its `Ready` condition. Null if the
+	// resource doesn't report whether it is ready.
+	SkipReady `json:"ready,omitempty"`
+	// Whether this resource is synced, read from its `Synced` condition. Null if the
+	// resource doesn't report whether it is synced.
+	SkipSynced `json:"synced,omitempty"`
+	// Whether this resource's controller has observed its latest generation, read
+	// by comparing its `metadata.generation` with its `status.observedGeneration`.
+	// Null if the resource doesn't report an observed generation.
+	SkipUpToDate `json:"upToDate,omitempty"`
+	// The serialized manifest of this resource, for example to copy and paste.
+	// Its `metadata.managedFields` are omitted unless included explicitly.
+	SkipManifest `json:"manifest"`
+	// Events pertaining to this resource.
+	Events EventConnection `json:"events"`
+}
+
+func (GenericResource) IsNode() {}
+
+func (GenericResource) IsKubernetesResource() {}
+
+// A `KubernetesResourceBatch` is a batch of Kubernetes resources fetched by
+// reference. Its resources and errors are in the order the resources were
+// referenced, such that `errors[i]` is the error fetching `resources[i]`.
+type KubernetesResourceBatch struct {
+	// The fetched resources. Null for resources that don't exist or can't be fetched.
+	Resources []KubernetesResource `json:"resources"`
+	// Errors fetching each resource. Null for resources that were fetched, or don't exist.
+	Errors []*string `json:"errors"`
+}
+
+// A KubernetesResourceConnection represents a connection to Kubernetes resources.
+type KubernetesResourceConnection struct {
+	// Connected nodes.
+	Nodes []KubernetesResource `json:"nodes,omitempty"`
+	// The total number of connected nodes.
+	TotalCount int `json:"totalCount"`
+}
+
+// A LabelSelector matches a Kubernetes resource by labels.
+type LabelSelector struct {
+	// The labels to match on.
+	MatchLabels map[string]string `json:"matchLabels,omitempty"`
+	// The label requirements to match on. All requirements must be met.
+	MatchExpressions []LabelSelectorRequirement `json:"matchExpressions,omitempty"`
+}
+
+// A LabelSelectorRequirement is a selector that relates a label's key to a set of
+// values.
+type LabelSelectorRequirement struct {
+	// The label key that the requirement applies to.
+	Key string `json:"key"`
+	// How the label's value relates to the requirement's values; one of In, NotIn,
+	// Exists, or DoesNotExist.
+	Operator string `json:"operator"`
+	// The values to match on. Must be non-empty for the In and NotIn operators, and
+	// empty for the Exists and DoesNotExist operators.
+	Values []string `json:"values,omitempty"`
+}
+
+// `LocalObjectReference` contains a name to to let you inspect or modify the
+// locally referred object.
+type LocalObjectReference struct {
+	// Name of the referent.
+	Name string `json:"name"`
+}
+
+// A ManagedResource is a Kubernetes API representation of a resource in an
+// external system, such as a cloud provider's API. Crossplane providers add
+// support for new kinds of managed resource.
+type ManagedResource struct {
+	// An opaque identifier that is unique across all types.
+	ID ReferenceID `json:"id"`
+	// The underlying Kubernetes API version of this resource.
+	APIVersion string `json:"apiVersion"`
+	// The underlying Kubernetes API kind of this resource.
+	Kind string `json:"kind"`
+	// Metadata that is common to all Kubernetes API resources.
+	Metadata ObjectMeta `json:"metadata"`
+	// The desired state of this resource.
+	Spec ManagedResourceSpec `json:"spec"`
+	// The observed state of this resource.
+	Status *ManagedResourceStatus `json:"status,omitempty"`
+	// An unstructured JSON representation of the underlying Kubernetes resource.
+	SkipUnstructured `json:"unstructured"`
+	// A JSON representation of a field within the underlying Kubernetes resource.
+	//
+	// API conventions describe the syntax as:
+	// > standard JavaScript syntax for accessing that field, assuming the JSON
+	// > object was transformed into a JavaScript object, without the leading dot,
+	// > such as `metadata.name`.
+	//
+	// Valid examples:
+	//
+	// * `metadata.name`
+	// * `spec.containers[0].name`
+	// * `data[.config.yml]`
+	// * `metadata.annotations['crossplane.io/external-name']`
+	// * `spec.items[0][8]`
+	// * `apiVersion`
+	// * `[42]`
+	// * `spec.containers[*].args[*]` - Supports wildcard expansion.
+	//
+	// Invalid examples:
+	//
+	// * `.metadata.name` - Leading period.
+	// * `metadata..name` - Double period.
+	// * `metadata.name.` - Trailing period.
+	// * `spec.containers[]` - Empty brackets.
+	// * `spec.containers.[0].name` - Period before open bracket.
+	//
+	// Wildcards support:
+	//
+	// For an object with the following data:
+	//
+	// ```json
+	// {
+	//   "spec": {
+	//     "containers": [
+	//       {
+	//         "name": "cool",
+	//         "image": "latest",
+	//         "args": [
+	//           "start",
+	//           "now",
+	//           "debug"
+	//         ]
+	//       }
+	//     ]
+	//   }
+	// }
+	// ```
+	//
+	// The wildcard `spec.containers[*].args[*]` will be expanded to:
+	//
+	// ```json
+	// [
+	//   "spec.containers[0].args[0]",
+	//   "spec.containers[0].args[1]",
+	//   "spec.containers[0].args[2]",
+	// ]
+	// ```
+	//
+	// And the following result will be returned:
+	//
+	// ```json
+	// [
+	//   "start",
+	//   "now",
+	//   "debug"
+	// ]
+	// ```
+	//
+	// https://github.com/kubernetes/community/blob/61f3d0/contributors/devel/sig-architecture/api-conventions.md#selecting-fields
+	PavedAccess `json:"fieldPath"`
+	// The conditions of this resource, read from its `status.conditions` field.
+	// Resources that don't report conditions have none.
+	SkipConditions `json:"conditions"`
+	// Whether this resource is ready, read from its `Ready` condition. Null if the
+	// resource doesn't report whether it is ready.
+	SkipReady `json:"ready,omitempty"`
+	// Whether this resource is synced, read from its `Synced` condition. Null if the
+	// resource doesn't report whether it is synced.
+	SkipSynced `json:"synced,omitempty"`
+	// Whether this resource's controller has observed its latest generation, read
+	// by comparing its `metadata.generation` with its `status.observedGeneration`.
+	// Null if the resource doesn't report an observed generation.
+	SkipUpToDate `json:"upToDate,omitempty"`
+	// The serialized manifest of this resource, for example to copy and paste.
+	// Its `metadata.managedFields` are omitted unless included explicitly.
+	SkipManifest `json:"manifest"`
+	// The name of this resource in the external system, read from its
+	// `crossplane.io/external-name` annotation.
+	ExternalName *string `json:"externalName,omitempty"`
+	// The progress of this resource's creation in the external system, read from
+	// its `crossplane.io/external-create-*` annotations.
+	ExternalCreate *ManagedResourceExternalCreate `json:"externalCreate,omitempty"`
+	// The differences between this resource's spec as it was last applied by
+	// kubectl, read from its `kubectl.kubernetes.io/last-applied-configuration`
+	// annotation, and its current spec. Only fields that were last applied are
+	// compared, so fields late-initialized by the provider are not differences.
+	// Null if the resource was not last applied by kubectl.
+	Diff []SpecDifference `json:"diff,omitempty"`
+	// Events pertaining to this resource.
+	Events EventConnection `json:"events"`
+	// The definition of this resource.
+	Definition ManagedResourceDefinition `json:"definition,omitempty"`
+	// The provider config this resource uses. Resources that don't reference a
+	// provider config use the provider config named 'default'.
+	ProviderConfig *ProviderConfig `json:"providerConfig,omitempty"`
+	// Usages that protect this resource from deletion.
+	UsedBy UsageConnection `json:"usedBy"`
+	// Usages in which this resource uses other resources.
+	Uses UsageConnection `json:"uses"`
+}
+
+func (ManagedResource) IsNode() {}
+
+func (ManagedResource) IsKubernetesResource() {}
+
+// A ManagedResourceExternalCreate records when a managed resource's provider
+// attempted to create it in the external system.
+type ManagedResourceExternalCreate struct {
+	// The time at which the provider was about to create the external resource.
+	Pending *time.Time `json:"pending,omitempty"`
+	// The time at which the provider successfully created the external resource.
+	Succeeded *time.Time `json:"succeeded,omitempty"`
+	// The time at which the provider failed to create the external resource.
+	Failed *time.Time `json:"failed,omitempty"`
+}
+
+// A ManagedResourceReference is a reference to a managed resource.
+type ManagedResourceReference struct {
+	// The API version of the managed resource.
+	APIVersion string `json:"apiVersion"`
+	// The kind of the managed resource.
+	Kind string `json:"kind"`
+	// The name of the managed resource.
+	Name string `json:"name"`
+}
+
+// A ManagedResourceStatus represents the observed state of a managed resource.
+type ManagedResourceStatus struct {
+	// The observed condition of this resource.
+	Conditions []Condition `json:"conditions,omitempty"`
+	// The time at which this resource was last reconciled with a different
+	// outcome, read from the last transition time of its `Synced` condition. Null
+	// if the resource has no `Synced` condition.
+	LastReconcileTime *time.Time `json:"lastReconcileTime,omitempty"`
+	// The error this resource's last reconcile returned, read from the message of
+	// its `Synced` condition when that condition is false. Null if the resource
+	// was last reconciled successfully, or has no `Synced` condition.
+	LastReconcileError *string `json:"lastReconcileError,omitempty"`
+}
+
+func (ManagedResourceStatus) IsConditionedStatus() {}
+
+// A MapTransform maps an input string to an output value.
+type MapTransform struct {
+	// The output value of each input string.
+	Pairs []byte `json:"pairs"`
+}
+
+func (MapTransform) IsTransform() {}
+
+// A MatchTransform returns the result of the first pattern matching its input.
+type MatchTransform struct {
+	// The patterns to match, in order.
+	Patterns []MatchTransformPattern `json:"patterns"`
+	// The value returned if no pattern matches, when falling back to a value.
+	FallbackValue []byte `json:"fallbackValue,omitempty"`
+	// What to return if no pattern matches: `Value` or `Input`.
+	FallbackTo *string `json:"fallbackTo,omitempty"`
+}
+
+func (MatchTransform) IsTransform() {}
+
+// A MatchTransformPattern is a pattern of a MatchTransform.
+type MatchTransformPattern struct {
+	// The type of this pattern: `literal` or `regexp`.
+	Type string `json:"type"`
+	// The literal to match, for `literal` patterns.
+	Literal *string `json:"literal,omitempty"`
+	// The regular expression to match, for `regexp` patterns.
+	Regexp *string `json:"regexp,omitempty"`
+	// The value returned if this pattern matches.
+	Result []byte `json:"result,omitempty"`
+}
+
+// A MathTransform transforms a number.
+type MathTransform struct {
+	// The type of this math transform, for example `Multiply`.
+	Type string `json:"type"`
+	// The number by which to multiply the input, for `Multiply` transforms.
+	Multiply *int `json:"multiply,omitempty"`
+	// The minimum of the output, for `ClampMin` transforms.
+	ClampMin *int `json:"clampMin,omitempty"`
+	// The maximum of the output, for `ClampMax` transforms.
+	ClampMax *int `json:"clampMax,omitempty"`
+}
+
+func (MathTransform) IsTransform() {}
+
+// A NonResourceRule describes actions the caller may perform upon non-resource
+// URLs.
+type NonResourceRule struct {
+	// The verbs the caller may use. '*' represents all verbs.
+	Verbs []string `json:"verbs"`
+	// The non-resource URLs the rule applies to. '*' represents all URLs, and may
+	// be used as a suffix to match URL prefixes.
+	NonResourceURLs []string `json:"nonResourceURLs,omitempty"`
+}
+
+// `ObjectReference` contains enough information to let you inspect or modify the referred object.
+type ObjectReference struct {
+	// Kind of the referent.
+	Kind *string `json:"kind,omitempty"`
+	// Namespace of the referent.
+	Namespace *string `json:"namespace,omitempty"`
+	// Name of the referent.
+	Name *string `json:"name,omitempty"`
+}
+
+// An `ObjectReferenceInput` references a Kubernetes resource by its API version,
+// kind, namespace, and name.
+type ObjectReferenceInput struct {
+	// API version of the referent.
+	APIVersion string `json:"apiVersion"`
+	// Kind of the referent.
+	Kind string `json:"kind"`
+	// Namespace of the referent. Leave unset for cluster scoped resources.
+	Namespace *string `json:"namespace,omitempty"`
+	// Name of the referent.
+	Name string `json:"name"`
+}
+
+// An OpenAPISchema describes a Kubernetes resource, or a field of a resource, per
+// an OpenAPI v3 schema. Nested schemas must be selected explicitly, so clients
+// that need the whole schema of a deeply nested resource may prefer to use the
+// `openAPIV3Schema` JSON of a `CompositeResourceDefinitionVersion`.
+type OpenAPISchema struct {
+	// The type of the value, e.g. `object`, `array`, `string`, or `integer`.
+	Type *string `json:"type,omitempty"`
+	// The format of the value, e.g. `date-time` or `int64`.
+	Format *string `json:"format,omitempty"`
+	// A description of the value.
+	Description *string `json:"description,omitempty"`
+	// Whether the value may be null.
+	Nullable bool `json:"nullable"`
+	// The names of the object's required properties.
+	Required []string `json:"required,omitempty"`
+	// The properties of the object, sorted by name.
+	Properties []OpenAPISchemaProperty `json:"properties,omitempty"`
+	// The schema of the array's items.
+	Items *OpenAPISchema `json:"items,omitempty"`
+	// The schema of the object's additional properties, i.e. the values of a map.
+	AdditionalProperties *OpenAPISchema `json:"additionalProperties,omitempty"`
+	// The allowed values.
+	Enum [][]byte `json:"enum,omitempty"`
+	// The default value.
+	Default []byte `json:"default,omitempty"`
+	// The minimum allowed value of a number.
+	Minimum *float64 `json:"minimum,omitempty"`
+	// The maximum allowed value of a number.
+	Maximum *float64 `json:"maximum,omitempty"`
+	// The minimum allowed length of a string.
+	MinLength *int `json:"minLength,omitempty"`
+	// The maximum allowed length of a string.
+	MaxLength *int `json:"maxLength,omitempty"`
+	// The minimum allowed number of items of an array.
+	MinItems *int `json:"minItems,omitempty"`
+	// The maximum allowed number of items of an array.
+	MaxItems *int `json:"maxItems,omitempty"`
+	// A regular expression that a string must match.
+	Pattern *string `json:"pattern,omitempty"`
+	// Whether fields that aren't specified by the schema are preserved, per
+	// `x-kubernetes-preserve-unknown-fields`.
+	PreserveUnknownFields bool `json:"preserveUnknownFields"`
+}
+
+// An OpenAPISchemaProperty is a property of an object described by an OpenAPI v3
+// schema.
+type OpenAPISchemaProperty struct {
+	// The name of the property.
+	Name string `json:"name"`
+	// Whether the property is required.
+	Required bool `json:"required"`
+	// The schema of the property.
+	Schema OpenAPISchema `json:"schema"`
+}
+
+// An owner of a Kubernetes resource.
+type Owner struct {
+	// The owner.
+	Resource KubernetesResource `json:"resource"`
+	// Whether the owner is the controller of the owned Kubernetes resource.
+	Controller *bool `json:"controller,omitempty"`
+}
+
+// An OwnerConnection represents a connection to an owner.
+type OwnerConnection struct {
+	// Connected nodes.
+	Nodes []Owner `json:"nodes,omitempty"`
+	// The total number of connected nodes.
+	TotalCount int `json:"totalCount"`
+}
+
+// A Patch that should be applied to an unstructured input before it is submitted.
+type Patch struct {
+	// A field path references a field within a Kubernetes object via a simple
+	// string. API conventions describe the syntax as "standard JavaScript syntax for
+	// accessing that field, assuming the JSON object was transformed into a
+	// JavaScript object, without the leading dot, such as metadata.name".
+	//
+	// Valid examples:
+	//
+	// * metadata.name
+	// * spec.containers[0].name
+	// * data[.config.yml]
+	// * metadata.annotations['crossplane.io/external-name']
+	// * spec.items[0][8]
+	// * apiVersion
+	// * [42]
+	//
+	// Invalid examples:
+	//
+	// * .metadata.name - Leading period.
+	// * metadata..name - Double period.
+	// * metadata.name. - Trailing period.
+	// * spec.containers[] - Empty brackets.
+	// * spec.containers.[0].name - Period before open bracket.
+	//
+	// https://github.com/kubernetes/community/blob/61f3d0/contributors/devel/sig-architecture/api-conventions.md#selecting-fields
+	FieldPath string `json:"fieldPath"`
+	// Unstructured JSON to be patched in at the suppled field path. This could be a
+	// string, an object, or any other valid JSON.
+	Unstructured []byte `json:"unstructured"`
+}
+
+// PatchResourcePayload is the result of patching a Kubernetes resource.
+type PatchResourcePayload struct {
+	// The patched Kubernetes resource. Null if the patch failed.
+	Resource KubernetesResource `json:"resource,omitempty"`
+}
+
+// A PatchSet is a named set of patches that resource templates may include.
+type PatchSet struct {
+	// The name of this patch set.
+	Name string `json:"name"`
+	// The patches in this patch set.
+	Patches []CompositionPatch `json:"patches"`
+}
+
+// PauseResourcePayload is the result of pausing or resuming a Kubernetes resource.
+type PauseResourcePayload struct {
+	// The paused or resumed Kubernetes resource. Null if the mutation failed.
+	Resource KubernetesResource `json:"resource,omitempty"`
+	// Whether reconciliation of the resource is paused.
+	Paused bool `json:"paused"`
+}
+
+// A PipelineStep is a step in a composition's pipeline of composition functions.
+type PipelineStep struct {
+	// The name of this step. Step names are unique within a pipeline.
+	Step string `json:"step"`
+	// The composition function this step runs.
+	FunctionRef FunctionReference `json:"functionRef"`
+	// The input this step passes to its composition function, if any.
+	Input []byte `json:"input,omitempty"`
+	// The installed composition function this step runs.
+	Function KubernetesResource `json:"function,omitempty"`
+}
+
+// A PolicyRule holds information that describes a KubernetesRBAC policy rule.
+type PolicyRule struct {
+	// Verbs is a list of verbs that apply to ALL the resources specified by this
+	// rule. '*' represents all verbs.
+	Verbs []string `json:"verbs"`
+	// APIGroups is the name of the APIGroup that contains the resources. If multiple
+	// API groups are specified, any action requested against one of the enumerated
+	// resources in any API group will be allowed.
+	APIGroups []string `json:"apiGroups,omitempty"`
+	// Resources is a list of resources this rule applies to. '*' represents all
+	// resources.
+	Resources []string `json:"resources,omitempty"`
+	// ResourceNames is a list of names that the rule applies to. An empty set means
+	// that everything is allowed.
+	ResourceNames []string `json:"resourceNames,omitempty"`
+	// NonResourceURLs is a set of partial urls that a user should have access to.
+	// '*' is allowed, but only as the full, final step in the path. Rules can either
+	// apply to API resources (such as "pods" or "secrets") or non-resource URL paths
+	// (such as "/api"),  but not both.
+	NonResourceURLs []string `json:"nonResourceURLs,omitempty"`
+}
+
+// A Provider extends Crossplane with support for new managed resources.
+type Provider struct {
+	// An opaque identifier that is unique across all types.
+	ID ReferenceID `json:"id"`
+	// The underlying Kubernetes API version of this resource.
+	APIVersion string `json:"apiVersion"`
+	// The underlying Kubernetes API kind of this resource.
+	Kind string `json:"kind"`
+	// Metadata that is common to all Kubernetes API resources.
+	Metadata ObjectMeta `json:"metadata"`
+	// The desired state of this resource.
+	Spec ProviderSpec `json:"spec"`
+	// The observed state of this resource.
+	Status *ProviderStatus `json:"status,omitempty"`
+	// An unstructured JSON representation of the underlying Kubernetes resource.
+	SkipUnstructured `json:"unstructured"`
+	// A JSON representation of a field within the underlying Kubernetes resource.
+	//
+	// API conventions describe the syntax as:
+	// > standard JavaScript syntax for accessing that field, assuming the JSON
+	// > object was transformed into a JavaScript object, without the leading dot,
+	// > such as `metadata.name`.
+	//
+	// Valid examples:
+	//
+	// * `metadata.name`
+	// * `spec.containers[0].name`
+	// * `data[.config.yml]`
+	// * `metadata.annotations['crossplane.io/external-name']`
+	// * `spec.items[0][8]`
+	// * `apiVersion`
+	// * `[42]`
+	// * `spec.containers[*].args[*]` - Supports wildcard expansion.
+	//
+	// Invalid examples:
+	//
+	// * `.metadata.name` - Leading period.
+	// * `metadata..name` - Double period.
+	// * `metadata.name.` - Trailing period.
+	// * `spec.containers[]` - Empty brackets.
+	// * `spec.containers.[0].name` - Period before open bracket.
+	//
+	// Wildcards support:
+	//
+	// For an object with the following data:
+	//
+	// ```json
+	// {
+	//   "spec": {
+	//     "containers": [
+	//       {
+	//         "name": "cool",
+	//         "image": "latest",
+	//         "args": [
+	//           "start",
+	//           "now",
+	//           "debug"
+	//         ]
+	//       }
+	//     ]
+	//   }
+	// }
+	// ```
+	//
+	// The wildcard `spec.containers[*].args[*]` will be expanded to:
+	//
+	// ```json
+	// [
+	//   "spec.containers[0].args[0]",
+	//   "spec.containers[0].args[1]",
+	//   "spec.containers[0].args[2]",
+	// ]
+	// ```
+	//
+	// And the following result will be returned:
+	//
+	// ```json
+	// [
+	//   "start",
+	//   "now",
+	//   "debug"
+	// ]
+	// ```
+	//
+	// https://github.com/kubernetes/community/blob/61f3d0/contributors/devel/sig-architecture/api-conventions.md#selecting-fields
+	PavedAccess `json:"fieldPath"`
+	// The conditions of this resource, read from its `status.conditions` field.
+	// Resources that don't report conditions have none.
+	SkipConditions `json:"conditions"`
+	// Whether this resource is ready, read from its `Ready` condition. Null if the
+	// resource doesn't report whether it is ready.
+	SkipReady `json:"ready,omitempty"`
+	// Whether this resource is synced, read from its `Synced` condition. Null if the
+	// resource doesn't report whether it is synced.
+	SkipSynced `json:"synced,omitempty"`
+	// Whether this resource's controller has observed its latest generation, read
+	// by comparing its `metadata.generation` with its `status.observedGeneration`.
+	// Null if the resource doesn't report an observed generation.
+	SkipUpToDate `json:"upToDate,omitempty"`
+	// The serialized manifest of this resource, for example to copy and paste.
+	// Its `metadata.managedFields` are omitted unless included explicitly.
+	SkipManifest `json:"manifest"`
+	// Events pertaining to this resource.
+	Events EventConnection `json:"events"`
+	// Revisions of this provider.
+	Revisions ProviderRevisionConnection `json:"revisions"`
+	// The active revision of this provider.
+	ActiveRevision *ProviderRevision `json:"activeRevision,omitempty"`
+	// The runtime config of this provider. This is the DeploymentRuntimeConfig
+	// named by its `runtimeConfigRef`, or the deprecated ControllerConfig named by
+	// its `controllerConfigRef` in clusters that still use them. Null if the
+	// provider references neither, or the referenced API is not enabled.
+	RuntimeConfig KubernetesResource `json:"runtimeConfig,omitempty"`
+}
+
+func (Provider) IsNode() {}
+
+func (Provider) IsKubernetesResource() {}
+
+// A ProviderConfig configures a provider, in that it provides configuration that
+// is relevant to all managed resources installed by a provider.
+type ProviderConfig struct {
+	// An opaque identifier that is unique across all types.
+	ID ReferenceID `json:"id"`
+	// The underlying Kubernetes API version of this resource.
+	APIVersion string `json:"apiVersion"`
+	// The underlying Kubernetes API kind of this resource.
+	Kind string `json:"kind"`
+	// Metadata that is common to all Kubernetes API resources.
+	Metadata ObjectMeta `json:"metadata"`
+	// The observed state of this resource.
+	Status *ProviderConfigStatus `json:"status,omitempty"`
+	// An unstructured JSON representation of the underlying Kubernetes resource.
+	SkipUnstructured `json:"unstructured"`
+	// A JSON representation of a field within the underlying Kubernetes resource.
+	//
+	// API conventions describe the syntax as:
+	// > standard JavaScript syntax for accessing that field, assuming the JSON
+	// > object was transformed into a JavaScript object, without the leading dot,
+	// > such as `metadata.name`.
+	//
+	// Valid examples:
+	//
+	// * `metadata.name`
+	// * `spec.containers[0].name`
+	// * `data[.config.yml]`
+	// * `metadata.annotations['crossplane.io/external-name']`
+	// * `spec.items[0][8]`
+	// * `apiVersion`
+	// * `[42]`
+	// * `spec.containers[*].args[*]` - Supports wildcard expansion.
+	//
+	// Invalid examples:
+	//
+	// * `.metadata.name` - Leading period.
+	// * `metadata..name` - Double period.
+	// * `metadata.name.` - Trailing period.
+	// * `spec.containers[]` - Empty brackets.
+	// * `spec.containers.[0].name` - Period before open bracket.
+	//
+	// Wildcards support:
+	//
+	// For an object with the following data:
+	//
+	// ```json
+	// {
+	//   "spec": {
+	//     "containers": [
+	//       {
+	//         "name": "cool",
+	//         "image": "latest",
+	//         "args": [
+	//           "start",
+	//           "now",
+	//           "debug"
+	//         ]
+	//       }
+	//     ]
+	//   }
+	// }
+	// ```
+	//
+	// The wildcard `spec.containers[*].args[*]` will be expanded to:
+	//
+	// ```json
+	// [
+	//   "spec.containers[0].args[0]",
+	//   "spec.containers[0].args[1]",
+	//   "spec.containers[0].args[2]",
+	// ]
+	// ```
+	//
+	// And the following result will be returned:
+	//
+	// ```json
+	// [
+	//   "start",
+	//   "now",
+	//   "debug"
+	// ]
+	// ```
+	//
+	// https://github.com/kubernetes/community/blob/61f3d0/contributors/devel/sig-architecture/api-conventions.md#selecting-fields
+	PavedAccess `json:"fieldPath"`
+	// The conditions of this resource, read from its `status.conditions` field.
+	// Resources that don't report conditions have none.
+	SkipConditions `json:"conditions"`
+	// Whether this resource is ready, read from its `Ready` condition. Null if the
+	// resource doesn't report whether it is ready.
+	SkipReady `json:"ready,omitempty"`
+	// Whether this resource is synced, read from its `Synced` condition. Null if the
+	// resource doesn't report whether it is synced.
+	SkipSynced `json:"synced,omitempty"`
+	// Whether this resource's controller has observed its latest generation, read
+	// by comparing its `metadata.generation` with its `status.observedGeneration`.
+	// Null if the resource doesn't report an observed generation.
+	SkipUpToDate `json:"upToDate,omitempty"`
+	// The serialized manifest of this resource, for example to copy and paste.
+	// Its `metadata.managedFields` are omitted unless included explicitly.
+	SkipManifest `json:"manifest"`
+	// Events pertaining to this resource.
+	Events EventConnection `json:"events"`
+	// The definition of this resource.
+	Definition ProviderConfigDefinition `json:"definition,omitempty"`
+	// The managed resources that use this provider config.
+	Usages ProviderConfigUsages `json:"usages"`
+}
+
+func (ProviderConfig) IsNode() {}
+
+func (ProviderConfig) IsKubernetesResource() {}
+
+// A reference to the ProviderConfig used by a particular managed resource.
+type ProviderConfigReference struct {
+	// Name of the provider config.
+	Name string `json:"name"`
+}
+
+// A ProviderConfigStatus represents the observed state of a provider config.
+type ProviderConfigStatus struct {
+	// The observed condition of this resource.
 	Conditions []Condition `json:"conditions,omitempty"`
 	// The number of managed resources currently using this provider config.
 	Users *int `json:"users,omitempty"`
@@ -1885,6 +3064,15 @@ type ProviderConfigStatus struct {
 
 func (ProviderConfigStatus) IsConditionedStatus() {}
 
+// ProviderConfigUsages are the managed resources that use a provider config. A
+// provider config can't be deleted while it is in use.
+type ProviderConfigUsages struct {
+	// The number of managed resources that use the provider config.
+	Count int `json:"count"`
+	// References to the managed resources that use the provider config.
+	Resources []ManagedResourceReference `json:"resources"`
+}
+
 // A ProviderConnection represents a connection to providers.
 type ProviderConnection struct {
 	// Connected nodes.
@@ -1979,8 +3167,36 @@ type ProviderRevision struct {
 	//
 	// https://github.com/kubernetes/community/blob/61f3d0/contributors/devel/sig-architecture/api-conventions.md#selecting-fields
 	PavedAccess `json:"fieldPath"`
+	// The conditions of this resource, read from its `status.conditions` field.
+	// Resources that don't report conditions have none.
+	SkipConditions `json:"conditions"`
+	// Whether this resource is ready, read from its `Ready` condition. Null if the
+	// resource doesn't report whether it is ready.
+	SkipReady `json:"ready,omitempty"`
+	// Whether this resource is synced, read from its `Synced` condition. Null if the
+	// resource doesn't report whether it is synced.
+	SkipSynced `json:"synced,omitempty"`
+	// Whether this resource's controller has observed its latest generation, read
+	// by comparing its `metadata.generation` with its `status.observedGeneration`.
+	// Null if the resource doesn't report an observed generation.
+	SkipUpToDate `json:"upToDate,omitempty"`
+	// The serialized manifest of this resource, for example to copy and paste.
+	// Its `metadata.managedFields` are omitted unless included explicitly.
+	SkipManifest `json:"manifest"`
 	// Events pertaining to this resource.
 	Events EventConnection `json:"events"`
+	// The Deployment that runs this provider revision's controller, if any. Only
+	// active revisions have a Deployment. Crossplane may run the Deployment in any
+	// namespace, so resolving it requires permission to list Deployments in all
+	// namespaces.
+	Deployment KubernetesResource `json:"deployment,omitempty"`
+	// The ServiceAccount that this provider revision's Deployment runs as, if any.
+	ServiceAccount KubernetesResource `json:"serviceAccount,omitempty"`
+	// The ConfigMaps this provider revision's Deployment references, for example
+	// as volumes or environment variables. ConfigMaps are never cached; they're
+	// read from the API server each time this field is resolved. ConfigMaps that
+	// don't exist are omitted.
+	ConfigMaps []ConfigMap `json:"configMaps,omitempty"`
 }
 
 func (ProviderRevision) IsNode() {}
@@ -2019,6 +3235,11 @@ type ProviderRevisionSpec struct {
 type ProviderRevisionStatus struct {
 	// The observed condition of this resource.
 	Conditions []Condition `json:"conditions,omitempty"`
+	// The conditions of this revision that aren't True, i.e. the reasons it isn't
+	// healthy or installed. For example a revision whose package can't be pulled
+	// has a Healthy condition with status False, whose reason and message describe
+	// the pull error.
+	Problems []Condition `json:"problems,omitempty"`
 	// The number of known dependencies.
 	FoundDependencies *int `json:"foundDependencies,omitempty"`
 	// The number of installed dependencies.
@@ -2061,6 +3282,13 @@ type ProviderSpec struct {
 	// SkipDependencyResolution indicates to the package manager whether to skip
 	// resolving dependencies for a package.
 	SkipDependencyResolution *bool `json:"skipDependencyResolution,omitempty"`
+	// A reference to the DeploymentRuntimeConfig used to configure the deployment
+	// of this provider's runtime.
+	RuntimeConfigRef *LocalObjectReference `json:"runtimeConfigRef,omitempty"`
+	// A reference to the ControllerConfig used to configure the deployment of this
+	// provider's runtime. ControllerConfigs are deprecated in favor of
+	// DeploymentRuntimeConfigs.
+	ControllerConfigRef *LocalObjectReference `json:"controllerConfigRef,omitempty"`
 }
 
 // A ProviderStatus represents the observed state of a provider.
@@ -2077,7 +3305,256 @@ type ProviderStatus struct {
 	CurrentIdentifier *string `json:"currentIdentifier,omitempty"`
 }
 
-func (ProviderStatus) IsConditionedStatus() {}
+func (ProviderStatus) IsConditionedStatus() {}
+
+// A ReadinessCheckCondition is a condition a composed resource must have for a
+// `MatchCondition` readiness check to pass.
+type ReadinessCheckCondition struct {
+	// The type of the condition, e.g. `Ready`.
+	Type string `json:"type"`
+	// The status the condition must have.
+	Status ConditionStatus `json:"status"`
+}
+
+// A ReadinessCheckResult is the result of evaluating one of a composition's
+// readiness checks against a composed resource.
+type ReadinessCheckResult struct {
+	// The type of readiness check, e.g. `MatchString` or `MatchCondition`.
+	Type string `json:"type"`
+	// The path of the field the check reads. Null for checks that read no field.
+	FieldPath *string `json:"fieldPath,omitempty"`
+	// The string the field must match. Only set for `MatchString` checks.
+	MatchString *string `json:"matchString,omitempty"`
+	// The integer the field must match. Only set for `MatchInteger` checks.
+	MatchInteger *int `json:"matchInteger,omitempty"`
+	// The condition that must match. Only set for `MatchCondition` checks.
+	MatchCondition *ReadinessCheckCondition `json:"matchCondition,omitempty"`
+	// Whether the composed resource passed the check.
+	Passed bool `json:"passed"`
+	// Why the check could not be evaluated, for example because the field it reads
+	// is not of the expected type. Checks that can't be evaluated don't pass.
+	Error *string `json:"error,omitempty"`
+}
+
+// A RelatedResource is one side of an owner reference between two Kubernetes
+// resources.
+type RelatedResource struct {
+	// The API version of the related resource.
+	APIVersion string `json:"apiVersion"`
+	// The kind of the related resource.
+	Kind string `json:"kind"`
+	// The name of the related resource. Unset if the resources of this kind
+	// couldn't be listed, or if some were omitted, in which case the error explains
+	// why.
+	Name *string `json:"name,omitempty"`
+	// The related resource, if it could be read.
+	Resource KubernetesResource `json:"resource,omitempty"`
+	// Whether the owner is the controller of the owned resource.
+	Controller *bool `json:"controller,omitempty"`
+	// The error encountered reading the related resource, if any.
+	Error *string `json:"error,omitempty"`
+}
+
+// RelatedResources are the resources related to a Kubernetes resource by owner
+// references.
+type RelatedResources struct {
+	// The resources that own the Kubernetes resource.
+	Owners []RelatedResource `json:"owners"`
+	// The resources of the requested kinds that the Kubernetes resource owns.
+	Owned []RelatedResource `json:"owned"`
+}
+
+// RemoveFinalizerPayload is the result of removing a finalizer from a Kubernetes
+// resource.
+type RemoveFinalizerPayload struct {
+	// The updated Kubernetes resource. Null if the mutation failed.
+	Resource KubernetesResource `json:"resource,omitempty"`
+}
+
+// ResourceAttributes describes an action upon a Kubernetes resource.
+type ResourceAttributes struct {
+	// The verb of the action, e.g. get, list, create, or delete.
+	Verb string `json:"verb"`
+	// The API group of the resource.
+	Group *string `json:"group,omitempty"`
+	// The resource, i.e. the lowercase plural form of its kind.
+	Resource string `json:"resource"`
+	// The subresource, if any.
+	Subresource *string `json:"subresource,omitempty"`
+	// The name of the resource.
+	Name *string `json:"name,omitempty"`
+	// The namespace of the resource.
+	Namespace *string `json:"namespace,omitempty"`
+}
+
+// ResourceAttributesInput describes an action upon a Kubernetes resource.
+type ResourceAttributesInput struct {
+	// The verb of the action, e.g. get, list, create, or delete.
+	Verb string `json:"verb"`
+	// The API group of the resource. Leave unset for the core API group.
+	Group *string `json:"group,omitempty"`
+	// The resource, i.e. the lowercase plural form of its kind.
+	Resource string `json:"resource"`
+	// The subresource, if any.
+	Subresource *string `json:"subresource,omitempty"`
+	// The name of the resource. Leave unset to review all resources.
+	Name *string `json:"name,omitempty"`
+	// The namespace of the resource. Leave unset to review cluster scoped
+	// resources, or namespaced resources in all namespaces.
+	Namespace *string `json:"namespace,omitempty"`
+}
+
+// A ResourceQuota limits the aggregate resources that may be consumed in a
+// namespace.
+type ResourceQuota struct {
+	// An opaque identifier that is unique across all types.
+	ID ReferenceID `json:"id"`
+	// The underlying Kubernetes API version of this resource.
+	APIVersion string `json:"apiVersion"`
+	// The underlying Kubernetes API kind of this resource.
+	Kind string `json:"kind"`
+	// Metadata that is common to all Kubernetes API resources.
+	Metadata ObjectMeta `json:"metadata"`
+	// The desired state of this resource quota.
+	Spec ResourceQuotaSpec `json:"spec"`
+	// The observed state of this resource quota.
+	Status *ResourceQuotaStatus `json:"status,omitempty"`
+	// An unstructured JSON representation of the underlying Kubernetes resource.
+	SkipUnstructured `json:"unstructured"`
+	// A JSON representation of a field within the underlying Kubernetes resource.
+	//
+	// API conventions describe the syntax as:
+	// > standard JavaScript syntax for accessing that field, assuming the JSON
+	// > object was transformed into a JavaScript object, without the leading dot,
+	// > such as `metadata.name`.
+	//
+	// Valid examples:
+	//
+	// * `metadata.name`
+	// * `spec.containers[0].name`
+	// * `data[.config.yml]`
+	// * `metadata.annotations['crossplane.io/external-name']`
+	// * `spec.items[0][8]`
+	// * `apiVersion`
+	// * `[42]`
+	// * `spec.containers[*].args[*]` - Supports wildcard expansion.
+	//
+	// Invalid examples:
+	//
+	// * `.metadata.name` - Leading period.
+	// * `metadata..name` - Double period.
+	// * `metadata.name.` - Trailing period.
+	// * `spec.containers[]` - Empty brackets.
+	// * `spec.containers.[0].name` - Period before open bracket.
+	//
+	// Wildcards support:
+	//
+	// For an object with the following data:
+	//
+	// ```json
+	// {
+	//   "spec": {
+	//     "containers": [
+	//       {
+	//         "name": "cool",
+	//         "image": "latest",
+	//         "args": [
+	//           "start",
+	//           "now",
+	//           "debug"
+	//         ]
+	//       }
+	//     ]
+	//   }
+	// }
+	// ```
+	//
+	// The wildcard `spec.containers[*].args[*]` will be expanded to:
+	//
+	// ```json
+	// [
+	//   "spec.containers[0].args[0]",
+	//   "spec.containers[0].args[1]",
+	//   "spec.containers[0].args[2]",
+	// ]
+	// ```
+	//
+	// And the following result will be returned:
+	//
+	// ```json
+	// [
+	//   "start",
+	//   "now",
+	//   "debug"
+	// ]
+	// ```
+	//
+	// https://github.com/kubernetes/community/blob/61f3d0/contributors/devel/sig-architecture/api-conventions.md#selecting-fields
+	PavedAccess `json:"fieldPath"`
+	// The conditions of this resource, read from its `status.conditions` field.
+	// Resources that don't report conditions have none.
+	SkipConditions `json:"conditions"`
+	// Whether this resource is ready, read from its `Ready` condition. Null if the
+	// resource doesn't report whether it is ready.
+	SkipReady `json:"ready,omitempty"`
+	// Whether this resource is synced, read from its `Synced` condition. Null if the
+	// resource doesn't report whether it is synced.
+	SkipSynced `json:"synced,omitempty"`
+	// Whether this resource's controller has observed its latest generation, read
+	// by comparing its `metadata.generation` with its `status.observedGeneration`.
+	// Null if the resource doesn't report an observed generation.
+	SkipUpToDate `json:"upToDate,omitempty"`
+	// The serialized manifest of this resource, for example to copy and paste.
+	// Its `metadata.managedFields` are omitted unless included explicitly.
+	SkipManifest `json:"manifest"`
+	// Events pertaining to this resource.
+	Events EventConnection `json:"events"`
+}
+
+func (ResourceQuota) IsNode() {}
+
+func (ResourceQuota) IsKubernetesResource() {}
+
+// A ResourceQuotaConnection represents a connection to resource quotas.
+type ResourceQuotaConnection struct {
+	// Connected nodes.
+	Nodes []ResourceQuota `json:"nodes,omitempty"`
+	// The total number of connected nodes.
+	TotalCount int `json:"totalCount"`
+}
+
+// The desired state of a resource quota.
+type ResourceQuotaSpec struct {
+	// The hard limit for each named resource, e.g. `requests.cpu` or `pods`, as a
+	// Kubernetes quantity.
+	Hard map[string]string `json:"hard,omitempty"`
+	// The scopes a resource must match to be tracked by this quota, e.g.
+	// `BestEffort` or `Terminating`. Resources in any scope are tracked if unset.
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+// The observed state of a resource quota.
+type ResourceQuotaStatus struct {
+	// The enforced hard limit for each named resource, as a Kubernetes quantity.
+	Hard map[string]string `json:"hard,omitempty"`
+	// The observed usage of each named resource in the namespace, as a Kubernetes
+	// quantity.
+	Used map[string]string `json:"used,omitempty"`
+}
+
+// A ResourceRule describes actions the caller may perform upon Kubernetes
+// resources.
+type ResourceRule struct {
+	// The verbs the caller may use. '*' represents all verbs.
+	Verbs []string `json:"verbs"`
+	// The API groups containing the resources. '*' represents all API groups.
+	APIGroups []string `json:"apiGroups,omitempty"`
+	// The resources the rule applies to. '*' represents all resources.
+	Resources []string `json:"resources,omitempty"`
+	// The names of the resources the rule applies to. An empty set means all
+	// resources are allowed.
+	ResourceNames []string `json:"resourceNames,omitempty"`
+}
 
 // A Secret holds secret data.
 type Secret struct {
@@ -2091,8 +3568,13 @@ type Secret struct {
 	Metadata ObjectMeta `json:"metadata"`
 	// Type of this secret.
 	Type *string `json:"type,omitempty"`
-	// The data stored in this secret. Values are not base64 encoded.
+	// The data stored in this secret. Values are not base64 encoded. Values are
+	// only returned if xgql was started with `--expose-connection-secrets` and the
+	// caller may get this secret; this field is null otherwise. Use `dataKeys` to
+	// read which keys a secret contains.
 	data map[string]string `json:"-"`
+	// The keys of the data stored in this secret, sorted by name.
+	DataKeys []string `json:"dataKeys"`
 	// An unstructured JSON representation of the underlying Kubernetes resource.
 	SkipUnstructured `json:"unstructured"`
 	// A JSON representation of a field within the underlying Kubernetes resource.
@@ -2165,6 +3647,22 @@ type Secret struct {
 	//
 	// https://github.com/kubernetes/community/blob/61f3d0/contributors/devel/sig-architecture/api-conventions.md#selecting-fields
 	PavedAccess `json:"fieldPath"`
+	// The conditions of this resource, read from its `status.conditions` field.
+	// Resources that don't report conditions have none.
+	SkipConditions `json:"conditions"`
+	// Whether this resource is ready, read from its `Ready` condition. Null if the
+	// resource doesn't report whether it is ready.
+	SkipReady `json:"ready,omitempty"`
+	// Whether this resource is synced, read from its `Synced` condition. Null if the
+	// resource doesn't report whether it is synced.
+	SkipSynced `json:"synced,omitempty"`
+	// Whether this resource's controller has observed its latest generation, read
+	// by comparing its `metadata.generation` with its `status.observedGeneration`.
+	// Null if the resource doesn't report an observed generation.
+	SkipUpToDate `json:"upToDate,omitempty"`
+	// The serialized manifest of this resource, for example to copy and paste.
+	// Its `metadata.managedFields` are omitted unless included explicitly.
+	SkipManifest `json:"manifest"`
 	// Events pertaining to this resource.
 	Events EventConnection `json:"events"`
 }
@@ -2181,6 +3679,70 @@ type SecretReference struct {
 	Namespace string `json:"namespace"`
 }
 
+// SetDeletionPolicyPayload is the result of setting the deletion policy of a
+// managed resource.
+type SetDeletionPolicyPayload struct {
+	// The updated managed resource. Null if the mutation failed.
+	Resource KubernetesResource `json:"resource,omitempty"`
+	// The deletion policy of the managed resource.
+	DeletionPolicy *DeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// SetManagementPoliciesPayload is the result of setting the management policies
+// of a managed resource.
+type SetManagementPoliciesPayload struct {
+	// The updated managed resource. Null if the mutation failed.
+	Resource KubernetesResource `json:"resource,omitempty"`
+	// The management policies of the managed resource.
+	ManagementPolicies []ManagementAction `json:"managementPolicies,omitempty"`
+}
+
+// A SpecDifference is a field of a resource's spec whose current value differs
+// from the value that was last applied.
+type SpecDifference struct {
+	// The path to the field, e.g. `spec.forProvider.region`.
+	Path string `json:"path"`
+	// The value of the field that was last applied.
+	LastApplied []byte `json:"lastApplied"`
+	// The current value of the field. Null if the field is not set.
+	Current []byte `json:"current,omitempty"`
+}
+
+// A StringTransform transforms a string, or formats a value as a string.
+type StringTransform struct {
+	// The type of this string transform, for example `Format`.
+	Type string `json:"type"`
+	// The format string, for `Format` transforms.
+	Format *string `json:"format,omitempty"`
+	// The conversion to apply, for `Convert` transforms, for example `ToUpper`.
+	Convert *string `json:"convert,omitempty"`
+	// The prefix or suffix to trim, for `TrimPrefix` and `TrimSuffix` transforms.
+	Trim *string `json:"trim,omitempty"`
+	// The regular expression to match, for `Regexp` transforms.
+	Regexp *string `json:"regexp,omitempty"`
+	// The capture group to return, for `Regexp` transforms.
+	RegexpGroup *int `json:"regexpGroup,omitempty"`
+	// The separator used to join an array, for `Join` transforms.
+	Separator *string `json:"separator,omitempty"`
+}
+
+func (StringTransform) IsTransform() {}
+
+// SubjectRules are the actions the caller may perform within a namespace.
+type SubjectRules struct {
+	// The actions the caller may perform upon Kubernetes resources.
+	ResourceRules []ResourceRule `json:"resourceRules"`
+	// The actions the caller may perform upon non-resource URLs.
+	NonResourceRules []NonResourceRule `json:"nonResourceRules"`
+	// Whether the rules are incomplete. Rules are incomplete when the API server
+	// uses an authorizer that doesn't support rules evaluation, such as a webhook
+	// authorizer. Incomplete rules must not be used to deny an action; use the
+	// `can` query to determine whether a particular action is allowed.
+	Incomplete bool `json:"incomplete"`
+	// Any error encountered while evaluating the rules.
+	EvaluationError *string `json:"evaluationError,omitempty"`
+}
+
 // A TypeReference references a type of Kubernetes resource by API version and
 // kind.
 type TypeReference struct {
@@ -2190,6 +3752,15 @@ type TypeReference struct {
 	Kind string `json:"kind"`
 }
 
+// A `TypeReferenceInput` references a type of Kubernetes resource by its API
+// version and kind.
+type TypeReferenceInput struct {
+	// The Kubernetes API version of the referenced type.
+	APIVersion string `json:"apiVersion"`
+	// The Kubernetes API kind of the referenced type.
+	Kind string `json:"kind"`
+}
+
 // UpdateKubernetesResourceInput is the input required to update a Kubernetes
 // resource.
 type UpdateKubernetesResourceInput struct {
@@ -2205,6 +3776,175 @@ type UpdateKubernetesResourcePayload struct {
 	Resource KubernetesResource `json:"resource,omitempty"`
 }
 
+// A Usage protects a Kubernetes resource from deletion while another resource
+// uses it.
+type Usage struct {
+	// An opaque identifier that is unique across all types.
+	ID ReferenceID `json:"id"`
+	// The underlying Kubernetes API version of this resource.
+	APIVersion string `json:"apiVersion"`
+	// The underlying Kubernetes API kind of this resource.
+	Kind string `json:"kind"`
+	// Metadata that is common to all Kubernetes API resources.
+	Metadata ObjectMeta `json:"metadata"`
+	// The desired state of this resource.
+	Spec UsageSpec `json:"spec"`
+	// The observed state of this resource.
+	Status *UsageStatus `json:"status,omitempty"`
+	// An unstructured JSON representation of the underlying Kubernetes resource.
+	SkipUnstructured `json:"unstructured"`
+	// A JSON representation of a field within the underlying Kubernetes resource.
+	//
+	// API conventions describe the syntax as:
+	// > standard JavaScript syntax for accessing that field, assuming the JSON
+	// > object was transformed into a JavaScript object, without the leading dot,
+	// > such as `metadata.name`.
+	//
+	// Valid examples:
+	//
+	// * `metadata.name`
+	// * `spec.containers[0].name`
+	// * `data[.config.yml]`
+	// * `metadata.annotations['crossplane.io/external-name']`
+	// * `spec.items[0][8]`
+	// * `apiVersion`
+	// * `[42]`
+	// * `spec.containers[*].args[*]` - Supports wildcard expansion.
+	//
+	// Invalid examples:
+	//
+	// * `.metadata.name` - Leading period.
+	// * `metadata..name` - Double period.
+	// * `metadata.name.` - Trailing period.
+	// * `spec.containers[]` - Empty brackets.
+	// * `spec.containers.[0].name` - Period before open bracket.
+	//
+	// Wildcards support:
+	//
+	// For an object with the following data:
+	//
+	// ```json
+	// {
+	//   "spec": {
+	//     "containers": [
+	//       {
+	//         "name": "cool",
+	//         "image": "latest",
+	//         "args": [
+	//           "start",
+	//           "now",
+	//           "debug"
+	//         ]
+	//       }
+	//     ]
+	//   }
+	// }
+	// ```
+	//
+	// The wildcard `spec.containers[*].args[*]` will be expanded to:
+	//
+	// ```json
+	// [
+	//   "spec.containers[0].args[0]",
+	//   "spec.containers[0].args[1]",
+	//   "spec.containers[0].args[2]",
+	// ]
+	// ```
+	//
+	// And the following result will be returned:
+	//
+	// ```json
+	// [
+	//   "start",
+	//   "now",
+	//   "debug"
+	// ]
+	// ```
+	//
+	// https://github.com/kubernetes/community/blob/61f3d0/contributors/devel/sig-architecture/api-conventions.md#selecting-fields
+	PavedAccess `json:"fieldPath"`
+	// The conditions of this resource, read from its `status.conditions` field.
+	// Resources that don't report conditions have none.
+	SkipConditions `json:"conditions"`
+	// Whether this resource is ready, read from its `Ready` condition. Null if the
+	// resource doesn't report whether it is ready.
+	SkipReady `json:"ready,omitempty"`
+	// Whether this resource is synced, read from its `Synced` condition. Null if the
+	// resource doesn't report whether it is synced.
+	SkipSynced `json:"synced,omitempty"`
+	// Whether this resource's controller has observed its latest generation, read
+	// by comparing its `metadata.generation` with its `status.observedGeneration`.
+	// Null if the resource doesn't report an observed generation.
+	SkipUpToDate `json:"upToDate,omitempty"`
+	// The serialized manifest of this resource, for example to copy and paste.
+	// Its `metadata.managedFields` are omitted unless included explicitly.
+	SkipManifest `json:"manifest"`
+	// Events pertaining to this resource.
+	Events EventConnection `json:"events"`
+}
+
+func (Usage) IsNode() {}
+
+func (Usage) IsKubernetesResource() {}
+
+// A UsageConnection represents a connection to usages.
+type UsageConnection struct {
+	// Connected nodes.
+	Nodes []Usage `json:"nodes,omitempty"`
+	// The total number of connected nodes.
+	TotalCount int `json:"totalCount"`
+}
+
+// A UsageResource identifies a resource involved in a usage.
+type UsageResource struct {
+	// The API version of the resource.
+	APIVersion *string `json:"apiVersion,omitempty"`
+	// The kind of the resource.
+	Kind *string `json:"kind,omitempty"`
+	// A reference to the resource by name.
+	ResourceRef *UsageResourceReference `json:"resourceRef,omitempty"`
+	// A selector for the resource. Ignored if a resource reference is set.
+	ResourceSelector *UsageResourceSelector `json:"resourceSelector,omitempty"`
+	// The resource. Null if the resource has not yet been resolved to a reference.
+	Resource KubernetesResource `json:"resource,omitempty"`
+}
+
+// A UsageResourceReference references a resource by name.
+type UsageResourceReference struct {
+	// The name of the resource.
+	Name string `json:"name"`
+}
+
+// A UsageResourceSelector selects a resource by its labels or controller.
+type UsageResourceSelector struct {
+	// Select a resource with matching labels.
+	MatchLabels map[string]string `json:"matchLabels,omitempty"`
+	// Select a resource with the same controller reference as the selecting
+	// resource.
+	MatchControllerRef *bool `json:"matchControllerRef,omitempty"`
+}
+
+// A UsageSpec represents the desired state of a usage.
+type UsageSpec struct {
+	// The resource that is being used.
+	Of UsageResource `json:"of"`
+	// The resource that is using the other resource.
+	By *UsageResource `json:"by,omitempty"`
+	// The reason the used resource is protected from deletion.
+	Reason *string `json:"reason,omitempty"`
+	// Whether deletion of the used resource will be replayed when this usage is
+	// deleted, if its deletion was previously attempted.
+	ReplayDeletion *bool `json:"replayDeletion,omitempty"`
+}
+
+// A UsageStatus represents the observed state of a usage.
+type UsageStatus struct {
+	// The observed condition of this resource.
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+func (UsageStatus) IsConditionedStatus() {}
+
 // A ConditionStatus represensts the status of a condition.
 type ConditionStatus string
 
@@ -2252,6 +3992,51 @@ func (e ConditionStatus) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+// A CrossplaneHealth indicates whether Crossplane, or one of its components, is
+// healthy.
+type CrossplaneHealth string
+
+const (
+	// The component, or every component, is healthy.
+	CrossplaneHealthHealthy CrossplaneHealth = "HEALTHY"
+	// The component, or at least one component, is unhealthy or can't be checked.
+	CrossplaneHealthDegraded CrossplaneHealth = "DEGRADED"
+)
+
+var AllCrossplaneHealth = []CrossplaneHealth{
+	CrossplaneHealthHealthy,
+	CrossplaneHealthDegraded,
+}
+
+func (e CrossplaneHealth) IsValid() bool {
+	switch e {
+	case CrossplaneHealthHealthy, CrossplaneHealthDegraded:
+		return true
+	}
+	return false
+}
+
+func (e CrossplaneHealth) String() string {
+	return string(e)
+}
+
+func (e *CrossplaneHealth) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CrossplaneHealth(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid CrossplaneHealth", str)
+	}
+	return nil
+}
+
+func (e CrossplaneHealth) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 // A DeletionPolicy specifies what will happen to the underlying external resource
 // when this managed resource is deleted - either "Delete" or "Orphan" the external
 // resource.
@@ -2344,6 +4129,108 @@ func (e EventType) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+// A ManagementAction is an action that Crossplane may take on the external
+// resource underlying a managed resource.
+type ManagementAction string
+
+const (
+	// All of the below actions. Equivalent to `*` in a Kubernetes resource.
+	ManagementActionAll ManagementAction = "ALL"
+	// Observe the external resource and update the managed resource's status.
+	ManagementActionObserve ManagementAction = "OBSERVE"
+	// Create the external resource.
+	ManagementActionCreate ManagementAction = "CREATE"
+	// Update the external resource to match the managed resource's spec.
+	ManagementActionUpdate ManagementAction = "UPDATE"
+	// Delete the external resource when the managed resource is deleted.
+	ManagementActionDelete ManagementAction = "DELETE"
+	// Update unspecified fields of the managed resource's spec with the state of
+	// the external resource.
+	ManagementActionLateInitialize ManagementAction = "LATE_INITIALIZE"
+)
+
+var AllManagementAction = []ManagementAction{
+	ManagementActionAll,
+	ManagementActionObserve,
+	ManagementActionCreate,
+	ManagementActionUpdate,
+	ManagementActionDelete,
+	ManagementActionLateInitialize,
+}
+
+func (e ManagementAction) IsValid() bool {
+	switch e {
+	case ManagementActionAll, ManagementActionObserve, ManagementActionCreate, ManagementActionUpdate, ManagementActionDelete, ManagementActionLateInitialize:
+		return true
+	}
+	return false
+}
+
+func (e ManagementAction) String() string {
+	return string(e)
+}
+
+func (e *ManagementAction) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ManagementAction(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ManagementAction", str)
+	}
+	return nil
+}
+
+func (e ManagementAction) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// A ManifestFormat is a format in which a Kubernetes resource may be serialized.
+type ManifestFormat string
+
+const (
+	// YAML, as typically used to apply resources with kubectl.
+	ManifestFormatYaml ManifestFormat = "YAML"
+	// Indented JSON.
+	ManifestFormatJSON ManifestFormat = "JSON"
+)
+
+var AllManifestFormat = []ManifestFormat{
+	ManifestFormatYaml,
+	ManifestFormatJSON,
+}
+
+func (e ManifestFormat) IsValid() bool {
+	switch e {
+	case ManifestFormatYaml, ManifestFormatJSON:
+		return true
+	}
+	return false
+}
+
+func (e ManifestFormat) String() string {
+	return string(e)
+}
+
+func (e *ManifestFormat) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ManifestFormat(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ManifestFormat", str)
+	}
+	return nil
+}
+
+func (e ManifestFormat) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 // A PackagePullPolicy represents when to pull a package OCI image from a registry.
 type PackagePullPolicy string
 
@@ -2436,6 +4323,56 @@ func (e PackageRevisionDesiredState) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+// PatchType is the kind of patch to apply to a Kubernetes resource.
+type PatchType string
+
+const (
+	// A JSON merge patch, per RFC 7386.
+	PatchTypeMerge PatchType = "MERGE"
+	// A Kubernetes strategic merge patch. Only supported by built-in Kubernetes
+	// types; custom resources must use a JSON merge patch.
+	PatchTypeStrategicMerge PatchType = "STRATEGIC_MERGE"
+	// A Kubernetes server-side apply patch. The patch must be a fully specified
+	// intent, including the resource's apiVersion, kind, and name. Fields set by the
+	// patch are owned by xgql's field manager.
+	PatchTypeApply PatchType = "APPLY"
+)
+
+var AllPatchType = []PatchType{
+	PatchTypeMerge,
+	PatchTypeStrategicMerge,
+	PatchTypeApply,
+}
+
+func (e PatchType) IsValid() bool {
+	switch e {
+	case PatchTypeMerge, PatchTypeStrategicMerge, PatchTypeApply:
+		return true
+	}
+	return false
+}
+
+func (e PatchType) String() string {
+	return string(e)
+}
+
+func (e *PatchType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PatchType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PatchType", str)
+	}
+	return nil
+}
+
+func (e PatchType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 // ResourceScope defines the scopes available to custom resources.
 type ResourceScope string
 
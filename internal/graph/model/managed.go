@@ -15,18 +15,27 @@
 package model
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
 	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 
 	"github.com/upbound/xgql/internal/unstructured"
 )
 
+const (
+	errFmtUnknownDeletionPolicy   = "unknown deletion policy %q"
+	errFmtUnknownManagementAction = "unknown management action %q"
+)
+
 // A ManagedResourceSpec specifies the desired state of a managed resource.
 type ManagedResourceSpec struct {
-	ProviderConfigRef *ProviderConfigReference `json:"providerConfigRef"`
-	DeletionPolicy    *DeletionPolicy          `json:"deletionPolicy"`
+	ProviderConfigRef  *ProviderConfigReference `json:"providerConfigRef"`
+	DeletionPolicy     *DeletionPolicy          `json:"deletionPolicy"`
+	ManagementPolicies []ManagementAction       `json:"managementPolicies"`
 
 	WriteConnectionSecretToReference *xpv1.SecretReference
 }
@@ -45,6 +54,68 @@ func GetDeletionPolicy(p xpv1.DeletionPolicy) *DeletionPolicy {
 	}
 }
 
+// ToDeletionPolicy converts the supplied deletion policy to a Crossplane
+// deletion policy. It returns an error if the policy is unknown.
+func ToDeletionPolicy(p DeletionPolicy) (xpv1.DeletionPolicy, error) {
+	switch p {
+	case DeletionPolicyDelete:
+		return xpv1.DeletionDelete, nil
+	case DeletionPolicyOrphan:
+		return xpv1.DeletionOrphan, nil
+	default:
+		return "", errors.Errorf(errFmtUnknownDeletionPolicy, p)
+	}
+}
+
+// Crossplane management actions, and their equivalents in our model.
+var managementActions = map[xpv1.ManagementAction]ManagementAction{
+	xpv1.ManagementActionAll:            ManagementActionAll,
+	xpv1.ManagementActionObserve:        ManagementActionObserve,
+	xpv1.ManagementActionCreate:         ManagementActionCreate,
+	xpv1.ManagementActionUpdate:         ManagementActionUpdate,
+	xpv1.ManagementActionDelete:         ManagementActionDelete,
+	xpv1.ManagementActionLateInitialize: ManagementActionLateInitialize,
+}
+
+// GetManagementPolicies from the supplied Crossplane policies. Resources that
+// don't specify any management policies are fully managed by Crossplane, as
+// if their policies were ["*"]. Unknown actions are omitted.
+func GetManagementPolicies(p xpv1.ManagementPolicies) []ManagementAction {
+	if len(p) == 0 {
+		return []ManagementAction{ManagementActionAll}
+	}
+	out := make([]ManagementAction, 0, len(p))
+	for _, a := range p {
+		if ma, ok := managementActions[a]; ok {
+			out = append(out, ma)
+		}
+	}
+	return out
+}
+
+// ToManagementPolicies converts the supplied management actions to Crossplane
+// management policies. It returns an error if any action is unknown.
+func ToManagementPolicies(in []ManagementAction) (xpv1.ManagementPolicies, error) {
+	out := make(xpv1.ManagementPolicies, 0, len(in))
+	for _, ma := range in {
+		a, ok := crossplaneManagementAction(ma)
+		if !ok {
+			return nil, errors.Errorf(errFmtUnknownManagementAction, ma)
+		}
+		out = append(out, a)
+	}
+	return out, nil
+}
+
+func crossplaneManagementAction(ma ManagementAction) (xpv1.ManagementAction, bool) {
+	for a, m := range managementActions {
+		if m == ma {
+			return a, true
+		}
+	}
+	return "", false
+}
+
 // GetProviderConfigReference from the supplied Crossplane reference.
 func GetProviderConfigReference(in *xpv1.Reference) *ProviderConfigReference {
 	if in == nil {
@@ -59,7 +130,41 @@ func GetManagedResourceStatus(in *unstructured.Managed) *ManagedResourceStatus {
 	if len(c) == 0 {
 		return nil
 	}
-	return &ManagedResourceStatus{Conditions: GetConditions(c)}
+	out := &ManagedResourceStatus{Conditions: GetConditions(c)}
+
+	// Managed resource reconcilers set the Synced condition at the end of
+	// every reconcile, but its transition time only changes with its status.
+	synced := in.GetCondition(xpv1.TypeSynced)
+	if synced.Type != xpv1.TypeSynced {
+		return out
+	}
+	out.LastReconcileTime = getTimePtr(synced.LastTransitionTime.Time)
+	if synced.Status == corev1.ConditionFalse {
+		out.LastReconcileError = getStringPtr(synced.Message)
+	}
+	return out
+}
+
+// GetExternalName from the supplied Crossplane resource.
+func GetExternalName(in *unstructured.Managed) *string {
+	n := meta.GetExternalName(in)
+	if n == "" {
+		return nil
+	}
+	return &n
+}
+
+// GetManagedResourceExternalCreate from the supplied Crossplane resource.
+func GetManagedResourceExternalCreate(in *unstructured.Managed) *ManagedResourceExternalCreate {
+	out := &ManagedResourceExternalCreate{
+		Pending:   getTimePtr(meta.GetExternalCreatePending(in)),
+		Succeeded: getTimePtr(meta.GetExternalCreateSucceeded(in)),
+		Failed:    getTimePtr(meta.GetExternalCreateFailed(in)),
+	}
+	if out.Pending == nil && out.Succeeded == nil && out.Failed == nil {
+		return nil
+	}
+	return out
 }
 
 // GetManagedResource from the supplied Crossplane resource.
@@ -79,8 +184,11 @@ func GetManagedResource(u *kunstructured.Unstructured) ManagedResource {
 			WriteConnectionSecretToReference: mg.GetWriteConnectionSecretToReference(),
 			ProviderConfigRef:                GetProviderConfigReference(mg.GetProviderConfigReference()),
 			DeletionPolicy:                   GetDeletionPolicy(mg.GetDeletionPolicy()),
+			ManagementPolicies:               GetManagementPolicies(mg.GetManagementPolicies()),
 		},
-		Status: GetManagedResourceStatus(mg),
+		Status:         GetManagedResourceStatus(mg),
+		ExternalName:   GetExternalName(mg),
+		ExternalCreate: GetManagedResourceExternalCreate(mg),
 		PavedAccess: PavedAccess{
 			Paved: fieldpath.Pave(u.Object),
 		},
@@ -16,12 +16,17 @@ package model
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 
 	"github.com/upbound/xgql/internal/unstructured"
 )
@@ -29,6 +34,8 @@ import (
 func TestGetManagedResource(t *testing.T) {
 	delete := DeletionPolicyDelete
 	orphan := DeletionPolicyOrphan
+	pending := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	succeeded := pending.Add(time.Minute)
 
 	cases := map[string]struct {
 		reason string
@@ -47,6 +54,10 @@ func TestGetManagedResource(t *testing.T) {
 				mr.SetWriteConnectionSecretToReference(&xpv1.SecretReference{Name: "coolsecret"})
 				mr.SetConditions(xpv1.Condition{})
 				mr.SetDeletionPolicy(xpv1.DeletionOrphan)
+				mr.SetManagementPolicies(xpv1.ManagementPolicies{xpv1.ManagementActionObserve})
+				meta.SetExternalName(mr, "cool-external")
+				meta.SetExternalCreatePending(mr, pending)
+				meta.SetExternalCreateSucceeded(mr, succeeded)
 
 				return mr.GetUnstructured()
 			}(),
@@ -60,15 +71,26 @@ func TestGetManagedResource(t *testing.T) {
 				Kind:       "ManagedResource",
 				Metadata: ObjectMeta{
 					Name: "cool",
+					annotations: map[string]string{
+						meta.AnnotationKeyExternalName:            "cool-external",
+						meta.AnnotationKeyExternalCreatePending:   pending.Format(time.RFC3339),
+						meta.AnnotationKeyExternalCreateSucceeded: succeeded.Format(time.RFC3339),
+					},
 				},
 				Spec: ManagedResourceSpec{
 					ProviderConfigRef:                &ProviderConfigReference{Name: "coolprov"},
 					DeletionPolicy:                   &orphan,
+					ManagementPolicies:               []ManagementAction{ManagementActionObserve},
 					WriteConnectionSecretToReference: &xpv1.SecretReference{Name: "coolsecret"},
 				},
 				Status: &ManagedResourceStatus{
 					Conditions: []Condition{{}},
 				},
+				ExternalName: ptr.To("cool-external"),
+				ExternalCreate: &ManagedResourceExternalCreate{
+					Pending:   &pending,
+					Succeeded: &succeeded,
+				},
 			},
 		},
 		"Empty": {
@@ -81,6 +103,9 @@ func TestGetManagedResource(t *testing.T) {
 					// set to 'delete' using CRD defaulting. We also default it
 					// to 'delete' in unstructured.Managed.
 					DeletionPolicy: &delete,
+					// Resources without management policies are fully
+					// managed.
+					ManagementPolicies: []ManagementAction{ManagementActionAll},
 				},
 			},
 		},
@@ -96,3 +121,59 @@ func TestGetManagedResource(t *testing.T) {
 		})
 	}
 }
+
+func TestGetManagedResourceStatus(t *testing.T) {
+	reconciled := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := map[string]struct {
+		reason     string
+		conditions []xpv1.Condition
+		want       *ManagedResourceStatus
+	}{
+		"NoConditions": {
+			reason: "A managed resource without conditions should have no status.",
+		},
+		"NotSynced": {
+			reason: "A managed resource without a Synced condition should not report its last reconcile.",
+			conditions: []xpv1.Condition{
+				{Type: xpv1.TypeReady, Status: corev1.ConditionTrue, LastTransitionTime: metav1.NewTime(reconciled)},
+			},
+			want: &ManagedResourceStatus{
+				Conditions: []Condition{{Type: string(xpv1.TypeReady), Status: ConditionStatusTrue, LastTransitionTime: reconciled}},
+			},
+		},
+		"ReconcileSuccess": {
+			reason: "A managed resource that was last reconciled successfully should report when, but no error.",
+			conditions: []xpv1.Condition{
+				{Type: xpv1.TypeSynced, Status: corev1.ConditionTrue, LastTransitionTime: metav1.NewTime(reconciled), Reason: xpv1.ReasonReconcileSuccess},
+			},
+			want: &ManagedResourceStatus{
+				Conditions:        []Condition{{Type: string(xpv1.TypeSynced), Status: ConditionStatusTrue, LastTransitionTime: reconciled, Reason: string(xpv1.ReasonReconcileSuccess)}},
+				LastReconcileTime: &reconciled,
+			},
+		},
+		"ReconcileError": {
+			reason: "A managed resource whose last reconcile failed should report when, and the error.",
+			conditions: []xpv1.Condition{
+				{Type: xpv1.TypeSynced, Status: corev1.ConditionFalse, LastTransitionTime: metav1.NewTime(reconciled), Reason: xpv1.ReasonReconcileError, Message: "boom"},
+			},
+			want: &ManagedResourceStatus{
+				Conditions:         []Condition{{Type: string(xpv1.TypeSynced), Status: ConditionStatusFalse, LastTransitionTime: reconciled, Reason: string(xpv1.ReasonReconcileError), Message: ptr.To("boom")}},
+				LastReconcileTime:  &reconciled,
+				LastReconcileError: ptr.To("boom"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := &unstructured.Managed{Unstructured: kunstructured.Unstructured{Object: map[string]interface{}{}}}
+			mg.SetConditions(tc.conditions...)
+
+			got := GetManagedResourceStatus(mg)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGetManagedResourceStatus(...): -want, +got\n:%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -31,6 +31,7 @@ type ObjectMeta struct {
 	Generation      int        `json:"generation"`
 	CreationTime    time.Time  `json:"creationTime"`
 	DeletionTime    *time.Time `json:"deletionTime"`
+	Finalizers      []string   `json:"finalizers"`
 
 	OwnerReferences []metav1.OwnerReference
 	labels          map[string]string
@@ -45,6 +46,7 @@ func GetObjectMeta(m metav1.Object) ObjectMeta {
 		ResourceVersion: m.GetResourceVersion(),
 		Generation:      int(m.GetGeneration()),
 		CreationTime:    m.GetCreationTimestamp().Time,
+		Finalizers:      m.GetFinalizers(),
 		OwnerReferences: m.GetOwnerReferences(),
 		labels:          m.GetLabels(),
 		annotations:     m.GetAnnotations(),
@@ -63,6 +65,20 @@ func GetObjectMeta(m metav1.Object) ObjectMeta {
 	return *om
 }
 
+// GetLastModified returns the time the supplied Kubernetes object was last
+// modified, per its managed fields. Kubernetes doesn't otherwise record when
+// an object was modified, so this is the latest of the object's creation time
+// and the times at which its field managers last changed it.
+func GetLastModified(m metav1.Object) time.Time {
+	t := m.GetCreationTimestamp().Time
+	for _, mf := range m.GetManagedFields() {
+		if mf.Time != nil && mf.Time.After(t) {
+			t = mf.Time.Time
+		}
+	}
+	return t
+}
+
 // Labels this ObjectMeta contains.
 func (om ObjectMeta) Labels(keys []string) map[string]string {
 	if keys == nil || om.labels == nil {
@@ -87,6 +87,51 @@ func TestGetObjectMeta(t *testing.T) {
 	}
 }
 
+func TestGetLastModified(t *testing.T) {
+	created := metav1.NewTime(time.Unix(1700000000, 0))
+	applied := metav1.NewTime(created.Add(time.Hour))
+	updated := metav1.NewTime(created.Add(2 * time.Hour))
+
+	cases := map[string]struct {
+		reason string
+		o      metav1.Object
+		want   time.Time
+	}{
+		"NoManagedFields": {
+			reason: "An object without managed fields was last modified when it was created.",
+			o: func() *unstructured.Unstructured {
+				u := &unstructured.Unstructured{}
+				u.SetCreationTimestamp(created)
+				return u
+			}(),
+			want: created.Time,
+		},
+		"ManagedFields": {
+			reason: "An object was last modified at the latest of its managed fields times.",
+			o: func() *unstructured.Unstructured {
+				u := &unstructured.Unstructured{}
+				u.SetCreationTimestamp(created)
+				u.SetManagedFields([]metav1.ManagedFieldsEntry{
+					{Manager: "kubectl", Time: &updated},
+					{Manager: "crossplane", Time: &applied},
+					{Manager: "rando"},
+				})
+				return u
+			}(),
+			want: updated.Time,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GetLastModified(tc.o)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGetLastModified(...): -want, +got\n:%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
 func TestObjectMetaLabels(t *testing.T) {
 	l := map[string]string{
 		"some":   "data",
@@ -0,0 +1,113 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"sort"
+
+	kextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+)
+
+const errUnmarshalSchema = "cannot unmarshal OpenAPI v3 schema"
+
+// GetCompositeResourceDefinitionSchema returns the OpenAPI v3 schema of the
+// supplied served version of an XRD, or of its referenceable version if no
+// version is supplied. It returns nil if the version isn't served or has no
+// schema.
+func GetCompositeResourceDefinitionSchema(versions []CompositeResourceDefinitionVersion, version *string) (*OpenAPISchema, error) {
+	for _, v := range versions {
+		switch {
+		case !v.Served:
+			continue
+		case version != nil && v.Name != *version:
+			continue
+		case version == nil && !v.Referenceable:
+			continue
+		case v.Schema == nil || len(v.Schema.OpenAPIV3Schema) == 0:
+			return nil, nil
+		}
+
+		in := &kextv1.JSONSchemaProps{}
+		if err := json.Unmarshal(v.Schema.OpenAPIV3Schema, in); err != nil {
+			return nil, errors.Wrap(err, errUnmarshalSchema)
+		}
+		return GetOpenAPISchema(in), nil
+	}
+	return nil, nil
+}
+
+// GetOpenAPISchema from the supplied Kubernetes JSON schema.
+func GetOpenAPISchema(in *kextv1.JSONSchemaProps) *OpenAPISchema {
+	if in == nil {
+		return nil
+	}
+
+	out := &OpenAPISchema{
+		Type:                  getStringPtr(in.Type),
+		Format:                getStringPtr(in.Format),
+		Description:           getStringPtr(in.Description),
+		Nullable:              in.Nullable,
+		Required:              in.Required,
+		Minimum:               in.Minimum,
+		Maximum:               in.Maximum,
+		MinLength:             getIntPtr(in.MinLength),
+		MaxLength:             getIntPtr(in.MaxLength),
+		MinItems:              getIntPtr(in.MinItems),
+		MaxItems:              getIntPtr(in.MaxItems),
+		Pattern:               getStringPtr(in.Pattern),
+		PreserveUnknownFields: in.XPreserveUnknownFields != nil && *in.XPreserveUnknownFields,
+	}
+
+	if len(in.Properties) > 0 {
+		required := make(map[string]bool, len(in.Required))
+		for _, r := range in.Required {
+			required[r] = true
+		}
+		out.Properties = make([]OpenAPISchemaProperty, 0, len(in.Properties))
+		for name := range in.Properties {
+			p := in.Properties[name] // So we don't take the address of a range variable.
+			out.Properties = append(out.Properties, OpenAPISchemaProperty{
+				Name:     name,
+				Required: required[name],
+				Schema:   *GetOpenAPISchema(&p),
+			})
+		}
+		sort.Slice(out.Properties, func(i, j int) bool { return out.Properties[i].Name < out.Properties[j].Name })
+	}
+
+	// Kubernetes structural schemas don't support tuples, so items always
+	// have a single schema.
+	if in.Items != nil {
+		out.Items = GetOpenAPISchema(in.Items.Schema)
+	}
+	if in.AdditionalProperties != nil {
+		out.AdditionalProperties = GetOpenAPISchema(in.AdditionalProperties.Schema)
+	}
+
+	if len(in.Enum) > 0 {
+		out.Enum = make([][]byte, len(in.Enum))
+		for i := range in.Enum {
+			out.Enum[i] = in.Enum[i].Raw
+		}
+	}
+	if in.Default != nil {
+		out.Default = in.Default.Raw
+	}
+
+	return out
+}
@@ -0,0 +1,152 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	kextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestGetCompositeResourceDefinitionSchema(t *testing.T) {
+	v1 := []byte(`{"type":"object","properties":{"spec":{"type":"object"}}}`)
+	v2 := []byte(`{"type":"object","properties":{"status":{"type":"object"}}}`)
+
+	versions := []CompositeResourceDefinitionVersion{
+		{Name: "v1", Served: true, Schema: &CompositeResourceValidation{OpenAPIV3Schema: v1}},
+		{Name: "v2", Served: true, Referenceable: true, Schema: &CompositeResourceValidation{OpenAPIV3Schema: v2}},
+		{Name: "v3", Served: false, Schema: &CompositeResourceValidation{OpenAPIV3Schema: v1}},
+		{Name: "v4", Served: true},
+		{Name: "v5", Served: true, Schema: &CompositeResourceValidation{OpenAPIV3Schema: []byte(`{`)}},
+	}
+
+	object := ptr.To("object")
+	errMalformed := json.Unmarshal([]byte(`{`), &kextv1.JSONSchemaProps{})
+
+	type want struct {
+		s   *OpenAPISchema
+		err error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		version *string
+		want    want
+	}{
+		"Referenceable": {
+			reason: "The schema of the referenceable version should be returned when no version is supplied.",
+			want: want{s: &OpenAPISchema{
+				Type:       object,
+				Properties: []OpenAPISchemaProperty{{Name: "status", Schema: OpenAPISchema{Type: object}}},
+			}},
+		},
+		"Version": {
+			reason:  "The schema of the supplied version should be returned.",
+			version: ptr.To("v1"),
+			want: want{s: &OpenAPISchema{
+				Type:       object,
+				Properties: []OpenAPISchemaProperty{{Name: "spec", Schema: OpenAPISchema{Type: object}}},
+			}},
+		},
+		"NotServed": {
+			reason:  "No schema should be returned for a version that isn't served.",
+			version: ptr.To("v3"),
+		},
+		"NoSchema": {
+			reason:  "No schema should be returned for a version without a schema.",
+			version: ptr.To("v4"),
+		},
+		"UnknownVersion": {
+			reason:  "No schema should be returned for a version that doesn't exist.",
+			version: ptr.To("v9"),
+		},
+		"MalformedSchema": {
+			reason:  "An error should be returned if the schema can't be unmarshalled.",
+			version: ptr.To("v5"),
+			want:    want{err: errors.Wrap(errMalformed, errUnmarshalSchema)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := GetCompositeResourceDefinitionSchema(versions, tc.version)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nGetCompositeResourceDefinitionSchema(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.s, got); diff != "" {
+				t.Errorf("\n%s\nGetCompositeResourceDefinitionSchema(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGetOpenAPISchema(t *testing.T) {
+	in := []byte(`{
+		"type": "object",
+		"required": ["size"],
+		"properties": {
+			"size": {"type": "string", "enum": ["small", "large"], "default": "small"},
+			"count": {"type": "integer", "minimum": 1, "maximum": 10},
+			"tags": {"type": "array", "maxItems": 5, "items": {"type": "string", "pattern": "^[a-z]+$", "maxLength": 63}},
+			"labels": {"type": "object", "nullable": true, "additionalProperties": {"type": "string"}},
+			"raw": {"type": "object", "x-kubernetes-preserve-unknown-fields": true}
+		}
+	}`)
+
+	props := &kextv1.JSONSchemaProps{}
+	if err := json.Unmarshal(in, props); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]struct {
+		reason string
+		in     *kextv1.JSONSchemaProps
+		want   *OpenAPISchema
+	}{
+		"Nil": {
+			reason: "A nil JSON schema should be converted to a nil schema.",
+		},
+		"Full": {
+			reason: "A nested JSON schema should be converted, with properties sorted by name.",
+			in:     props,
+			want: &OpenAPISchema{
+				Type:     ptr.To("object"),
+				Required: []string{"size"},
+				Properties: []OpenAPISchemaProperty{
+					{Name: "count", Schema: OpenAPISchema{Type: ptr.To("integer"), Minimum: ptr.To(1.0), Maximum: ptr.To(10.0)}},
+					{Name: "labels", Schema: OpenAPISchema{Type: ptr.To("object"), Nullable: true, AdditionalProperties: &OpenAPISchema{Type: ptr.To("string")}}},
+					{Name: "raw", Schema: OpenAPISchema{Type: ptr.To("object"), PreserveUnknownFields: true}},
+					{Name: "size", Required: true, Schema: OpenAPISchema{Type: ptr.To("string"), Enum: [][]byte{[]byte(`"small"`), []byte(`"large"`)}, Default: []byte(`"small"`)}},
+					{Name: "tags", Schema: OpenAPISchema{Type: ptr.To("array"), MaxItems: ptr.To(5), Items: &OpenAPISchema{Type: ptr.To("string"), Pattern: ptr.To("^[a-z]+$"), MaxLength: ptr.To(63)}}},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GetOpenAPISchema(tc.in)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGetOpenAPISchema(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
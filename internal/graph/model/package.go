@@ -94,7 +94,7 @@ func GetProviderStatus(in pkgv1.ProviderStatus) *ProviderStatus {
 
 // GetProvider from the supplied Kubernetes provider.
 func GetProvider(p *pkgv1.Provider) Provider {
-	return Provider{
+	out := Provider{
 		ID: ReferenceID{
 			APIVersion: p.APIVersion,
 			Kind:       p.Kind,
@@ -117,6 +117,13 @@ func GetProvider(p *pkgv1.Provider) Provider {
 			Paved: paveObject(p),
 		},
 	}
+	if ref := p.Spec.RuntimeConfigReference; ref != nil {
+		out.Spec.RuntimeConfigRef = &LocalObjectReference{Name: ref.Name}
+	}
+	if ref := p.Spec.ControllerConfigReference; ref != nil {
+		out.Spec.ControllerConfigRef = &LocalObjectReference{Name: ref.Name}
+	}
+	return out
 }
 
 // GetPackageRevisionDesiredState from the supplies Crossplane state.
@@ -132,8 +139,10 @@ func GetPackageRevisionDesiredState(in pkgv1.PackageRevisionDesiredState) Packag
 
 // GetProviderRevisionStatus from the supplied Crossplane provider revision.
 func GetProviderRevisionStatus(in pkgv1.PackageRevisionStatus) *ProviderRevisionStatus {
+	conds := GetConditions(in.Conditions)
 	out := &ProviderRevisionStatus{
-		Conditions:            GetConditions(in.Conditions),
+		Conditions:            conds,
+		Problems:              GetProblems(conds),
 		ObjectRefs:            in.ObjectRefs,
 		FoundDependencies:     getIntPtr(&in.FoundDependencies),
 		InstalledDependencies: getIntPtr(&in.InstalledDependencies),
@@ -173,6 +182,93 @@ func GetProviderRevision(pr *pkgv1.ProviderRevision) ProviderRevision {
 	}
 }
 
+// GetFunctionStatus from the supplied Kubernetes status.
+func GetFunctionStatus(in pkgv1.FunctionStatus) *FunctionStatus {
+	out := &FunctionStatus{Conditions: GetConditions(in.Conditions)}
+	if in.CurrentRevision != "" {
+		out.CurrentRevision = &in.CurrentRevision
+	}
+	if in.CurrentIdentifier != "" {
+		out.CurrentIdentifier = &in.CurrentIdentifier
+	}
+	if cmp.Equal(out, &FunctionStatus{}) {
+		return nil
+	}
+	return out
+}
+
+// GetFunction from the supplied Kubernetes function.
+func GetFunction(fn *pkgv1.Function) Function {
+	return Function{
+		ID: ReferenceID{
+			APIVersion: fn.APIVersion,
+			Kind:       fn.Kind,
+			Name:       fn.GetName(),
+		},
+
+		APIVersion: fn.APIVersion,
+		Kind:       fn.Kind,
+		Metadata:   GetObjectMeta(fn),
+		Spec: FunctionSpec{
+			Package:                     fn.Spec.Package,
+			RevisionActivationPolicy:    GetRevisionActivationPolicy(fn.Spec.RevisionActivationPolicy),
+			RevisionHistoryLimit:        getIntPtr(fn.Spec.RevisionHistoryLimit),
+			PackagePullPolicy:           GetPackagePullPolicy(fn.Spec.PackagePullPolicy),
+			IgnoreCrossplaneConstraints: fn.Spec.IgnoreCrossplaneConstraints,
+			SkipDependencyResolution:    fn.Spec.SkipDependencyResolution,
+		},
+		Status: GetFunctionStatus(fn.Status),
+		PavedAccess: PavedAccess{
+			Paved: paveObject(fn),
+		},
+	}
+}
+
+// GetFunctionRevisionStatus from the supplied Crossplane function revision.
+func GetFunctionRevisionStatus(in pkgv1.FunctionRevisionStatus) *FunctionRevisionStatus {
+	out := &FunctionRevisionStatus{
+		Conditions:            GetConditions(in.Conditions),
+		FoundDependencies:     getIntPtr(&in.FoundDependencies),
+		InstalledDependencies: getIntPtr(&in.InstalledDependencies),
+		InvalidDependencies:   getIntPtr(&in.InvalidDependencies),
+		PermissionRequests:    GetPolicyRules(in.PermissionRequests),
+	}
+	if in.Endpoint != "" {
+		out.Endpoint = &in.Endpoint
+	}
+	if cmp.Equal(out, &FunctionRevisionStatus{}) {
+		return nil
+	}
+	return out
+}
+
+// GetFunctionRevision from the supplied Crossplane function revision.
+func GetFunctionRevision(fr *pkgv1.FunctionRevision) FunctionRevision {
+	return FunctionRevision{
+		ID: ReferenceID{
+			APIVersion: fr.APIVersion,
+			Kind:       fr.Kind,
+			Name:       fr.GetName(),
+		},
+
+		APIVersion: fr.APIVersion,
+		Kind:       fr.Kind,
+		Metadata:   GetObjectMeta(fr),
+		Spec: FunctionRevisionSpec{
+			DesiredState:                GetPackageRevisionDesiredState(fr.Spec.DesiredState),
+			Package:                     fr.Spec.Package,
+			PackagePullPolicy:           GetPackagePullPolicy(fr.Spec.PackagePullPolicy),
+			Revision:                    int(fr.Spec.Revision),
+			IgnoreCrossplaneConstraints: fr.Spec.IgnoreCrossplaneConstraints,
+			SkipDependencyResolution:    fr.Spec.SkipDependencyResolution,
+		},
+		Status: GetFunctionRevisionStatus(fr.Status),
+		PavedAccess: PavedAccess{
+			Paved: paveObject(fr),
+		},
+	}
+}
+
 // GetConfigurationStatus from the supplied Kubernetes status.
 func GetConfigurationStatus(in pkgv1.ConfigurationStatus) *ConfigurationStatus {
 	out := &ConfigurationStatus{Conditions: GetConditions(in.Conditions)}
@@ -217,8 +313,10 @@ func GetConfiguration(c *pkgv1.Configuration) Configuration {
 
 // GetConfigurationRevisionStatus from the supplied Crossplane provider revision.
 func GetConfigurationRevisionStatus(in pkgv1.PackageRevisionStatus) *ConfigurationRevisionStatus {
+	conds := GetConditions(in.Conditions)
 	out := &ConfigurationRevisionStatus{
-		Conditions:            GetConditions(in.Conditions),
+		Conditions:            conds,
+		Problems:              GetProblems(conds),
 		ObjectRefs:            in.ObjectRefs,
 		FoundDependencies:     getIntPtr(&in.FoundDependencies),
 		InstalledDependencies: getIntPtr(&in.InstalledDependencies),
@@ -61,6 +61,10 @@ func TestGetProvider(t *testing.T) {
 						IgnoreCrossplaneConstraints: ptr.To(true),
 						SkipDependencyResolution:    ptr.To(true),
 					},
+					PackageRuntimeSpec: pkgv1.PackageRuntimeSpec{
+						RuntimeConfigReference:    &pkgv1.RuntimeConfigReference{Name: "runtime"},
+						ControllerConfigReference: &pkgv1.ControllerConfigReference{Name: "controller"},
+					},
 				},
 				Status: pkgv1.ProviderStatus{
 					ConditionedStatus: xpv1.ConditionedStatus{
@@ -90,6 +94,8 @@ func TestGetProvider(t *testing.T) {
 					PackagePullPolicy:           &mppp,
 					IgnoreCrossplaneConstraints: ptr.To(true),
 					SkipDependencyResolution:    ptr.To(true),
+					RuntimeConfigRef:            &LocalObjectReference{Name: "runtime"},
+					ControllerConfigRef:         &LocalObjectReference{Name: "controller"},
 				},
 				Status: &ProviderStatus{
 					Conditions:        []Condition{{}},
@@ -188,6 +194,7 @@ func TestGetProviderRevision(t *testing.T) {
 				},
 				Status: &ProviderRevisionStatus{
 					Conditions:            []Condition{{}},
+					Problems:              []Condition{{}},
 					ObjectRefs:            []xpv1.TypedReference{{Name: "coolcrd"}},
 					FoundDependencies:     &found,
 					InstalledDependencies: &installed,
@@ -222,6 +229,202 @@ func TestGetProviderRevision(t *testing.T) {
 	}
 }
 
+func TestGetFunction(t *testing.T) {
+	rap := pkgv1.ManualActivation
+	lim := int64(42)
+	ppp := corev1.PullAlways
+
+	mrap := RevisionActivationPolicyManual
+	mlim := 42
+	mppp := PackagePullPolicyAlways
+
+	cases := map[string]struct {
+		reason string
+		cfg    *pkgv1.Function
+		want   Function
+	}{
+		"Full": {
+			reason: "All supported fields should be converted to our model",
+			cfg: &pkgv1.Function{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: pkgv1.FunctionGroupVersionKind.GroupVersion().String(),
+					Kind:       pkgv1.FunctionKind,
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cool",
+				},
+				Spec: pkgv1.FunctionSpec{
+					PackageSpec: pkgv1.PackageSpec{
+						Package:                     "coolthing:v1",
+						RevisionActivationPolicy:    &rap,
+						RevisionHistoryLimit:        &lim,
+						PackagePullPolicy:           &ppp,
+						IgnoreCrossplaneConstraints: ptr.To(true),
+						SkipDependencyResolution:    ptr.To(true),
+					},
+				},
+				Status: pkgv1.FunctionStatus{
+					ConditionedStatus: xpv1.ConditionedStatus{
+						Conditions: []xpv1.Condition{{}},
+					},
+					PackageStatus: pkgv1.PackageStatus{
+						CurrentRevision:   "8",
+						CurrentIdentifier: "coolthing:v1",
+					},
+				},
+			},
+			want: Function{
+				ID: ReferenceID{
+					APIVersion: pkgv1.FunctionGroupVersionKind.GroupVersion().String(),
+					Kind:       pkgv1.FunctionKind,
+					Name:       "cool",
+				},
+				APIVersion: pkgv1.FunctionGroupVersionKind.GroupVersion().String(),
+				Kind:       pkgv1.FunctionKind,
+				Metadata: ObjectMeta{
+					Name: "cool",
+				},
+				Spec: FunctionSpec{
+					Package:                     "coolthing:v1",
+					RevisionActivationPolicy:    &mrap,
+					RevisionHistoryLimit:        &mlim,
+					PackagePullPolicy:           &mppp,
+					IgnoreCrossplaneConstraints: ptr.To(true),
+					SkipDependencyResolution:    ptr.To(true),
+				},
+				Status: &FunctionStatus{
+					Conditions:        []Condition{{}},
+					CurrentRevision:   ptr.To("8"),
+					CurrentIdentifier: ptr.To("coolthing:v1"),
+				},
+			},
+		},
+		"Empty": {
+			reason: "Absent optional fields should be absent in our model",
+			cfg:    &pkgv1.Function{},
+			want: Function{
+				Metadata: ObjectMeta{},
+				Spec:     FunctionSpec{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GetFunction(tc.cfg)
+			if diff := cmp.Diff(tc.want, got, cmpopts.IgnoreFields(Function{}, "PavedAccess"), cmp.AllowUnexported(ObjectMeta{})); diff != "" {
+				t.Errorf("\n%s\nGetFunction(...): -want, +got\n:%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGetFunctionRevision(t *testing.T) {
+	ppp := corev1.PullAlways
+	mppp := PackagePullPolicyAlways
+	found := 42
+	installed := 32
+	invalid := 10
+
+	cases := map[string]struct {
+		reason string
+		cfg    *pkgv1.FunctionRevision
+		want   FunctionRevision
+	}{
+		"Full": {
+			reason: "All supported fields should be converted to our model",
+			cfg: &pkgv1.FunctionRevision{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: pkgv1.FunctionRevisionGroupVersionKind.GroupVersion().String(),
+					Kind:       pkgv1.FunctionRevisionKind,
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cool",
+				},
+				Spec: pkgv1.FunctionRevisionSpec{
+					PackageRevisionSpec: pkgv1.PackageRevisionSpec{
+						DesiredState:                pkgv1.PackageRevisionActive,
+						Package:                     "coolthing:v1",
+						PackagePullPolicy:           &ppp,
+						Revision:                    42,
+						IgnoreCrossplaneConstraints: ptr.To(true),
+						SkipDependencyResolution:    ptr.To(true),
+					},
+				},
+				Status: pkgv1.FunctionRevisionStatus{
+					PackageRevisionStatus: pkgv1.PackageRevisionStatus{
+						ConditionedStatus: xpv1.ConditionedStatus{
+							Conditions: []xpv1.Condition{{}},
+						},
+						FoundDependencies:     int64(found),
+						InstalledDependencies: int64(installed),
+						InvalidDependencies:   int64(invalid),
+						PermissionRequests: []rbacv1.PolicyRule{{
+							Verbs:           []string{"verb"},
+							APIGroups:       []string{"group"},
+							Resources:       []string{"resources"},
+							ResourceNames:   []string{"resourceNames"},
+							NonResourceURLs: []string{"nonResourceURLs"},
+						}},
+					},
+					Endpoint: "function.crossplane-system:9443",
+				},
+			},
+			want: FunctionRevision{
+				ID: ReferenceID{
+					APIVersion: pkgv1.FunctionRevisionGroupVersionKind.GroupVersion().String(),
+					Kind:       pkgv1.FunctionRevisionKind,
+					Name:       "cool",
+				},
+				APIVersion: pkgv1.FunctionRevisionGroupVersionKind.GroupVersion().String(),
+				Kind:       pkgv1.FunctionRevisionKind,
+				Metadata: ObjectMeta{
+					Name: "cool",
+				},
+				Spec: FunctionRevisionSpec{
+					DesiredState:                PackageRevisionDesiredStateActive,
+					Package:                     "coolthing:v1",
+					PackagePullPolicy:           &mppp,
+					Revision:                    42,
+					IgnoreCrossplaneConstraints: ptr.To(true),
+					SkipDependencyResolution:    ptr.To(true),
+				},
+				Status: &FunctionRevisionStatus{
+					Conditions:            []Condition{{}},
+					FoundDependencies:     &found,
+					InstalledDependencies: &installed,
+					InvalidDependencies:   &invalid,
+					PermissionRequests: []PolicyRule{{
+						Verbs:           []string{"verb"},
+						APIGroups:       []string{"group"},
+						Resources:       []string{"resources"},
+						ResourceNames:   []string{"resourceNames"},
+						NonResourceURLs: []string{"nonResourceURLs"},
+					}},
+					Endpoint: ptr.To("function.crossplane-system:9443"),
+				},
+			},
+		},
+		"Empty": {
+			reason: "Absent optional fields should be absent in our model",
+			cfg:    &pkgv1.FunctionRevision{},
+			want: FunctionRevision{
+				Metadata: ObjectMeta{},
+				Spec:     FunctionRevisionSpec{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GetFunctionRevision(tc.cfg)
+			if diff := cmp.Diff(tc.want, got, cmpopts.IgnoreFields(FunctionRevision{}, "PavedAccess"), cmp.AllowUnexported(ObjectMeta{})); diff != "" {
+				t.Errorf("\n%s\nGetFunctionRevision(...): -want, +got\n:%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
 func TestGetConfiguration(t *testing.T) {
 	rap := pkgv1.ManualActivation
 	lim := int64(42)
@@ -380,6 +583,7 @@ func TestGetConfigurationRevision(t *testing.T) {
 				},
 				Status: &ConfigurationRevisionStatus{
 					Conditions:            []Condition{{}},
+					Problems:              []Condition{{}},
 					ObjectRefs:            []xpv1.TypedReference{{Name: "coolcrd"}},
 					FoundDependencies:     &found,
 					InstalledDependencies: &installed,
@@ -57,3 +57,21 @@ func GetProviderConfig(u *kunstructured.Unstructured) ProviderConfig {
 		},
 	}
 }
+
+// GetProviderConfigUsages from the supplied Crossplane ProviderConfigUsages.
+func GetProviderConfigUsages(in []kunstructured.Unstructured) ProviderConfigUsages {
+	out := ProviderConfigUsages{
+		Count:     len(in),
+		Resources: make([]ManagedResourceReference, len(in)),
+	}
+	for i := range in {
+		pcu := &unstructured.ProviderConfigUsage{Unstructured: in[i]}
+		ref := pcu.GetResourceReference()
+		out.Resources[i] = ManagedResourceReference{
+			APIVersion: ref.APIVersion,
+			Kind:       ref.Kind,
+			Name:       ref.Name,
+		}
+	}
+	return out
+}
@@ -81,3 +81,46 @@ func TestGetProviderConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestGetProviderConfigUsages(t *testing.T) {
+	newPCU := func(name string) kunstructured.Unstructured {
+		pcu := &unstructured.ProviderConfigUsage{Unstructured: kunstructured.Unstructured{Object: map[string]interface{}{}}}
+		pcu.SetProviderConfigReference(xpv1.Reference{Name: "default"})
+		pcu.SetResourceReference(xpv1.TypedReference{APIVersion: "example.org/v1", Kind: "Example", Name: name})
+		return pcu.Unstructured
+	}
+
+	cases := map[string]struct {
+		reason string
+		in     []kunstructured.Unstructured
+		want   ProviderConfigUsages
+	}{
+		"Full": {
+			reason: "Each usage should be converted to a reference to the resource using the provider config",
+			in:     []kunstructured.Unstructured{newPCU("cool"), newPCU("lame")},
+			want: ProviderConfigUsages{
+				Count: 2,
+				Resources: []ManagedResourceReference{
+					{APIVersion: "example.org/v1", Kind: "Example", Name: "cool"},
+					{APIVersion: "example.org/v1", Kind: "Example", Name: "lame"},
+				},
+			},
+		},
+		"Empty": {
+			reason: "No usages should produce a zero count and an empty, non-nil list of resources",
+			want: ProviderConfigUsages{
+				Resources: []ManagedResourceReference{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GetProviderConfigUsages(tc.in)
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGetProviderConfigUsages(...): -want, +got\n:%s", tc.reason, diff)
+			}
+		})
+	}
+}
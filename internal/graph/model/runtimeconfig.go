@@ -0,0 +1,38 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	pkgv1beta1 "github.com/crossplane/crossplane/apis/pkg/v1beta1"
+)
+
+// GetDeploymentRuntimeConfig from the supplied Crossplane deployment runtime
+// config.
+func GetDeploymentRuntimeConfig(rc *pkgv1beta1.DeploymentRuntimeConfig) DeploymentRuntimeConfig {
+	return DeploymentRuntimeConfig{
+		ID: ReferenceID{
+			APIVersion: rc.APIVersion,
+			Kind:       rc.Kind,
+			Name:       rc.GetName(),
+		},
+
+		APIVersion: rc.APIVersion,
+		Kind:       rc.Kind,
+		Metadata:   GetObjectMeta(rc),
+		PavedAccess: PavedAccess{
+			Paved: paveObject(rc),
+		},
+	}
+}
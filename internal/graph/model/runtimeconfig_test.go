@@ -0,0 +1,74 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	pkgv1beta1 "github.com/crossplane/crossplane/apis/pkg/v1beta1"
+)
+
+func TestGetDeploymentRuntimeConfig(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		rc     *pkgv1beta1.DeploymentRuntimeConfig
+		want   DeploymentRuntimeConfig
+	}{
+		"Full": {
+			reason: "All supported fields should be converted to our model",
+			rc: &pkgv1beta1.DeploymentRuntimeConfig{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: pkgv1beta1.DeploymentRuntimeConfigGroupVersionKind.GroupVersion().String(),
+					Kind:       pkgv1beta1.DeploymentRuntimeConfigKind,
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cool",
+				},
+			},
+			want: DeploymentRuntimeConfig{
+				ID: ReferenceID{
+					APIVersion: pkgv1beta1.DeploymentRuntimeConfigGroupVersionKind.GroupVersion().String(),
+					Kind:       pkgv1beta1.DeploymentRuntimeConfigKind,
+					Name:       "cool",
+				},
+				APIVersion: pkgv1beta1.DeploymentRuntimeConfigGroupVersionKind.GroupVersion().String(),
+				Kind:       pkgv1beta1.DeploymentRuntimeConfigKind,
+				Metadata: ObjectMeta{
+					Name: "cool",
+				},
+			},
+		},
+		"Empty": {
+			reason: "Absent optional fields should be absent in our model",
+			rc:     &pkgv1beta1.DeploymentRuntimeConfig{},
+			want: DeploymentRuntimeConfig{
+				Metadata: ObjectMeta{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GetDeploymentRuntimeConfig(tc.rc)
+			if diff := cmp.Diff(tc.want, got, cmpopts.IgnoreFields(DeploymentRuntimeConfig{}, "PavedAccess"), cmp.AllowUnexported(ObjectMeta{})); diff != "" {
+				t.Errorf("\n%s\nGetDeploymentRuntimeConfig(...): -want, +got\n:%s", tc.reason, diff)
+			}
+		})
+	}
+}
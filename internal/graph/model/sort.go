@@ -28,13 +28,18 @@ func (r CompositeResource) id() ReferenceID           { return r.ID }
 func (r CompositeResourceClaim) id() ReferenceID      { return r.ID }
 func (r Provider) id() ReferenceID                    { return r.ID }
 func (r ProviderRevision) id() ReferenceID            { return r.ID }
+func (r Function) id() ReferenceID                    { return r.ID }
+func (r FunctionRevision) id() ReferenceID            { return r.ID }
 func (r Configuration) id() ReferenceID               { return r.ID }
 func (r ConfigurationRevision) id() ReferenceID       { return r.ID }
 func (r CompositeResourceDefinition) id() ReferenceID { return r.ID }
 func (r Composition) id() ReferenceID                 { return r.ID }
+func (r Usage) id() ReferenceID                       { return r.ID }
+func (r EnvironmentConfig) id() ReferenceID           { return r.ID }
 func (r CustomResourceDefinition) id() ReferenceID    { return r.ID }
 func (r Secret) id() ReferenceID                      { return r.ID }
 func (r ConfigMap) id() ReferenceID                   { return r.ID }
+func (r ResourceQuota) id() ReferenceID               { return r.ID }
 func (r GenericResource) id() ReferenceID             { return r.ID }
 
 func (c *KubernetesResourceConnection) Len() int { return c.TotalCount }
@@ -69,6 +74,22 @@ func (c *ProviderRevisionConnection) Swap(i, j int) {
 	c.Nodes[i], c.Nodes[j] = c.Nodes[j], c.Nodes[i]
 }
 
+func (c *FunctionConnection) Len() int { return c.TotalCount }
+func (c *FunctionConnection) Less(i, j int) bool {
+	return join(c.Nodes[i].ID) < join(c.Nodes[j].ID)
+}
+func (c *FunctionConnection) Swap(i, j int) {
+	c.Nodes[i], c.Nodes[j] = c.Nodes[j], c.Nodes[i]
+}
+
+func (c *FunctionRevisionConnection) Len() int { return c.TotalCount }
+func (c *FunctionRevisionConnection) Less(i, j int) bool {
+	return join(c.Nodes[i].ID) < join(c.Nodes[j].ID)
+}
+func (c *FunctionRevisionConnection) Swap(i, j int) {
+	c.Nodes[i], c.Nodes[j] = c.Nodes[j], c.Nodes[i]
+}
+
 func (c *CustomResourceDefinitionConnection) Len() int { return c.TotalCount }
 func (c *CustomResourceDefinitionConnection) Less(i, j int) bool {
 	return join(c.Nodes[i].ID) < join(c.Nodes[j].ID)
@@ -109,6 +130,30 @@ func (c *CompositionConnection) Swap(i, j int) {
 	c.Nodes[i], c.Nodes[j] = c.Nodes[j], c.Nodes[i]
 }
 
+func (c *UsageConnection) Len() int { return c.TotalCount }
+func (c *UsageConnection) Less(i, j int) bool {
+	return join(c.Nodes[i].ID) < join(c.Nodes[j].ID)
+}
+func (c *UsageConnection) Swap(i, j int) {
+	c.Nodes[i], c.Nodes[j] = c.Nodes[j], c.Nodes[i]
+}
+
+func (c *EnvironmentConfigConnection) Len() int { return c.TotalCount }
+func (c *EnvironmentConfigConnection) Less(i, j int) bool {
+	return join(c.Nodes[i].ID) < join(c.Nodes[j].ID)
+}
+func (c *EnvironmentConfigConnection) Swap(i, j int) {
+	c.Nodes[i], c.Nodes[j] = c.Nodes[j], c.Nodes[i]
+}
+
+func (c *DeploymentRuntimeConfigConnection) Len() int { return c.TotalCount }
+func (c *DeploymentRuntimeConfigConnection) Less(i, j int) bool {
+	return join(c.Nodes[i].ID) < join(c.Nodes[j].ID)
+}
+func (c *DeploymentRuntimeConfigConnection) Swap(i, j int) {
+	c.Nodes[i], c.Nodes[j] = c.Nodes[j], c.Nodes[i]
+}
+
 func (c *CompositeResourceConnection) Len() int { return c.TotalCount }
 func (c *CompositeResourceConnection) Less(i, j int) bool {
 	return join(c.Nodes[i].ID) < join(c.Nodes[j].ID)
@@ -124,3 +169,11 @@ func (c *CompositeResourceClaimConnection) Less(i, j int) bool {
 func (c *CompositeResourceClaimConnection) Swap(i, j int) {
 	c.Nodes[i], c.Nodes[j] = c.Nodes[j], c.Nodes[i]
 }
+
+func (c *ResourceQuotaConnection) Len() int { return c.TotalCount }
+func (c *ResourceQuotaConnection) Less(i, j int) bool {
+	return join(c.Nodes[i].ID) < join(c.Nodes[j].ID)
+}
+func (c *ResourceQuotaConnection) Swap(i, j int) {
+	c.Nodes[i], c.Nodes[j] = c.Nodes[j], c.Nodes[i]
+}
@@ -0,0 +1,82 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+
+	pkgv1 "github.com/crossplane/crossplane/apis/pkg/v1"
+	pkgv1beta1 "github.com/crossplane/crossplane/apis/pkg/v1beta1"
+)
+
+// GetCrossplaneStatus from the supplied component statuses. Crossplane is
+// degraded if any of its components are.
+func GetCrossplaneStatus(components ...CrossplaneComponentStatus) CrossplaneStatus {
+	out := CrossplaneStatus{Health: CrossplaneHealthHealthy, Components: components}
+	for _, c := range components {
+		if c.Health != CrossplaneHealthHealthy {
+			out.Health = CrossplaneHealthDegraded
+		}
+	}
+	return out
+}
+
+// GetPackagesStatus from the supplied packages. A package is healthy if it's
+// both installed and healthy.
+func GetPackagesStatus(name string, pkgs ...pkgv1.Package) CrossplaneComponentStatus {
+	out := CrossplaneComponentStatus{Name: name, Health: CrossplaneHealthHealthy, Total: len(pkgs), Unhealthy: []string{}}
+	for _, p := range pkgs {
+		if p.GetCondition(pkgv1.TypeInstalled).Status == corev1.ConditionTrue && p.GetCondition(pkgv1.TypeHealthy).Status == corev1.ConditionTrue {
+			continue
+		}
+		out.Unhealthy = append(out.Unhealthy, p.GetName())
+	}
+	return degraded(out)
+}
+
+// GetLockStatus from the supplied package lock. A locked package is healthy
+// if all of its dependencies are locked. A nil lock has no packages.
+func GetLockStatus(name string, l *pkgv1beta1.Lock) CrossplaneComponentStatus {
+	out := CrossplaneComponentStatus{Name: name, Health: CrossplaneHealthHealthy, Unhealthy: []string{}}
+	if l == nil {
+		return out
+	}
+
+	locked := make(map[string]bool, len(l.Packages))
+	for _, p := range l.Packages {
+		locked[p.Source] = true
+	}
+
+	out.Total = len(l.Packages)
+	for _, p := range l.Packages {
+		for _, d := range p.Dependencies {
+			if !locked[d.Package] {
+				out.Unhealthy = append(out.Unhealthy, p.Name)
+				break
+			}
+		}
+	}
+	return degraded(out)
+}
+
+func degraded(s CrossplaneComponentStatus) CrossplaneComponentStatus {
+	if len(s.Unhealthy) > 0 {
+		s.Health = CrossplaneHealthDegraded
+	}
+	sort.Strings(s.Unhealthy)
+	return s
+}
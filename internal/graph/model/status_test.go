@@ -0,0 +1,136 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	pkgv1 "github.com/crossplane/crossplane/apis/pkg/v1"
+	pkgv1beta1 "github.com/crossplane/crossplane/apis/pkg/v1beta1"
+)
+
+func TestGetCrossplaneStatus(t *testing.T) {
+	healthy := CrossplaneComponentStatus{Name: "Providers", Health: CrossplaneHealthHealthy}
+	degraded := CrossplaneComponentStatus{Name: "Functions", Health: CrossplaneHealthDegraded}
+
+	cases := map[string]struct {
+		reason string
+		in     []CrossplaneComponentStatus
+		want   CrossplaneStatus
+	}{
+		"Healthy": {
+			reason: "Crossplane should be healthy if all of its components are.",
+			in:     []CrossplaneComponentStatus{healthy},
+			want:   CrossplaneStatus{Health: CrossplaneHealthHealthy, Components: []CrossplaneComponentStatus{healthy}},
+		},
+		"Degraded": {
+			reason: "Crossplane should be degraded if any of its components are.",
+			in:     []CrossplaneComponentStatus{healthy, degraded},
+			want:   CrossplaneStatus{Health: CrossplaneHealthDegraded, Components: []CrossplaneComponentStatus{healthy, degraded}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GetCrossplaneStatus(tc.in...)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGetCrossplaneStatus(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGetPackagesStatus(t *testing.T) {
+	healthy := &pkgv1.Provider{}
+	healthy.SetName("healthy")
+	healthy.SetConditions(pkgv1.Active(), pkgv1.Healthy())
+
+	unhealthy := &pkgv1.Provider{}
+	unhealthy.SetName("unhealthy")
+	unhealthy.SetConditions(pkgv1.Active(), pkgv1.Unhealthy())
+
+	uninstalled := &pkgv1.Provider{}
+	uninstalled.SetName("uninstalled")
+
+	cases := map[string]struct {
+		reason string
+		pkgs   []pkgv1.Package
+		want   CrossplaneComponentStatus
+	}{
+		"NoPackages": {
+			reason: "A component with no packages should be healthy.",
+			want:   CrossplaneComponentStatus{Name: "Providers", Health: CrossplaneHealthHealthy, Unhealthy: []string{}},
+		},
+		"Healthy": {
+			reason: "A component should be healthy if all of its packages are installed and healthy.",
+			pkgs:   []pkgv1.Package{healthy},
+			want:   CrossplaneComponentStatus{Name: "Providers", Health: CrossplaneHealthHealthy, Total: 1, Unhealthy: []string{}},
+		},
+		"Degraded": {
+			reason: "A component should be degraded if any of its packages aren't installed or aren't healthy.",
+			pkgs:   []pkgv1.Package{uninstalled, healthy, unhealthy},
+			want:   CrossplaneComponentStatus{Name: "Providers", Health: CrossplaneHealthDegraded, Total: 3, Unhealthy: []string{"unhealthy", "uninstalled"}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GetPackagesStatus("Providers", tc.pkgs...)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGetPackagesStatus(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGetLockStatus(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		lock   *pkgv1beta1.Lock
+		want   CrossplaneComponentStatus
+	}{
+		"NoLock": {
+			reason: "A missing lock should be healthy.",
+			want:   CrossplaneComponentStatus{Name: "Lock", Health: CrossplaneHealthHealthy, Unhealthy: []string{}},
+		},
+		"Healthy": {
+			reason: "A lock should be healthy if all of its packages' dependencies are locked.",
+			lock: &pkgv1beta1.Lock{Packages: []pkgv1beta1.LockPackage{
+				{Name: "config", Source: "example.org/config", Dependencies: []pkgv1beta1.Dependency{{Package: "example.org/provider"}}},
+				{Name: "provider", Source: "example.org/provider"},
+			}},
+			want: CrossplaneComponentStatus{Name: "Lock", Health: CrossplaneHealthHealthy, Total: 2, Unhealthy: []string{}},
+		},
+		"Degraded": {
+			reason: "A lock should be degraded if any of its packages' dependencies aren't locked.",
+			lock: &pkgv1beta1.Lock{Packages: []pkgv1beta1.LockPackage{
+				{Name: "config", Source: "example.org/config", Dependencies: []pkgv1beta1.Dependency{{Package: "example.org/missing"}}},
+				{Name: "provider", Source: "example.org/provider"},
+			}},
+			want: CrossplaneComponentStatus{Name: "Lock", Health: CrossplaneHealthDegraded, Total: 2, Unhealthy: []string{"config"}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GetLockStatus("Lock", tc.lock)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGetLockStatus(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"github.com/google/go-cmp/cmp"
+
+	extv1alpha1 "github.com/crossplane/crossplane/apis/apiextensions/v1alpha1"
+)
+
+// GetUsageResource from the supplied Crossplane usage resource.
+func GetUsageResource(in extv1alpha1.Resource) UsageResource {
+	out := UsageResource{
+		APIVersion: getStringPtr(in.APIVersion),
+		Kind:       getStringPtr(in.Kind),
+	}
+	if in.ResourceRef != nil {
+		out.ResourceRef = &UsageResourceReference{Name: in.ResourceRef.Name}
+	}
+	if in.ResourceSelector != nil {
+		out.ResourceSelector = &UsageResourceSelector{
+			MatchLabels:        in.ResourceSelector.MatchLabels,
+			MatchControllerRef: in.ResourceSelector.MatchControllerRef,
+		}
+	}
+	return out
+}
+
+// GetUsageStatus from the supplied Crossplane usage status.
+func GetUsageStatus(in extv1alpha1.UsageStatus) *UsageStatus {
+	out := &UsageStatus{Conditions: GetConditions(in.Conditions)}
+	if cmp.Equal(out, &UsageStatus{}) {
+		return nil
+	}
+	return out
+}
+
+// GetUsage from the supplied Crossplane usage.
+func GetUsage(u *extv1alpha1.Usage) Usage {
+	out := Usage{
+		ID: ReferenceID{
+			APIVersion: u.APIVersion,
+			Kind:       u.Kind,
+			Name:       u.GetName(),
+		},
+
+		APIVersion: u.APIVersion,
+		Kind:       u.Kind,
+		Metadata:   GetObjectMeta(u),
+		Spec: UsageSpec{
+			Of:             GetUsageResource(u.Spec.Of),
+			Reason:         u.Spec.Reason,
+			ReplayDeletion: u.Spec.ReplayDeletion,
+		},
+		Status: GetUsageStatus(u.Status),
+		PavedAccess: PavedAccess{
+			Paved: paveObject(u),
+		},
+	}
+	if u.Spec.By != nil {
+		by := GetUsageResource(*u.Spec.By)
+		out.Spec.By = &by
+	}
+	return out
+}
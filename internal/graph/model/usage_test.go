@@ -0,0 +1,121 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	extv1alpha1 "github.com/crossplane/crossplane/apis/apiextensions/v1alpha1"
+)
+
+func TestGetUsage(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		u      *extv1alpha1.Usage
+		want   Usage
+	}{
+		"Full": {
+			reason: "All supported fields should be converted to our model",
+			u: &extv1alpha1.Usage{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: extv1alpha1.UsageGroupVersionKind.GroupVersion().String(),
+					Kind:       extv1alpha1.UsageKind,
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cool",
+				},
+				Spec: extv1alpha1.UsageSpec{
+					Of: extv1alpha1.Resource{
+						APIVersion:  "example.org/v1",
+						Kind:        "Database",
+						ResourceRef: &extv1alpha1.ResourceRef{Name: "cool-db"},
+					},
+					By: &extv1alpha1.Resource{
+						APIVersion: "example.org/v1",
+						Kind:       "Cluster",
+						ResourceSelector: &extv1alpha1.ResourceSelector{
+							MatchLabels:        map[string]string{"cool": "true"},
+							MatchControllerRef: ptr.To(true),
+						},
+					},
+					Reason:         ptr.To("it's in use"),
+					ReplayDeletion: ptr.To(true),
+				},
+				Status: extv1alpha1.UsageStatus{
+					ConditionedStatus: xpv1.ConditionedStatus{
+						Conditions: []xpv1.Condition{{}},
+					},
+				},
+			},
+			want: Usage{
+				ID: ReferenceID{
+					APIVersion: extv1alpha1.UsageGroupVersionKind.GroupVersion().String(),
+					Kind:       extv1alpha1.UsageKind,
+					Name:       "cool",
+				},
+				APIVersion: extv1alpha1.UsageGroupVersionKind.GroupVersion().String(),
+				Kind:       extv1alpha1.UsageKind,
+				Metadata: ObjectMeta{
+					Name: "cool",
+				},
+				Spec: UsageSpec{
+					Of: UsageResource{
+						APIVersion:  ptr.To("example.org/v1"),
+						Kind:        ptr.To("Database"),
+						ResourceRef: &UsageResourceReference{Name: "cool-db"},
+					},
+					By: &UsageResource{
+						APIVersion: ptr.To("example.org/v1"),
+						Kind:       ptr.To("Cluster"),
+						ResourceSelector: &UsageResourceSelector{
+							MatchLabels:        map[string]string{"cool": "true"},
+							MatchControllerRef: ptr.To(true),
+						},
+					},
+					Reason:         ptr.To("it's in use"),
+					ReplayDeletion: ptr.To(true),
+				},
+				Status: &UsageStatus{
+					Conditions: []Condition{{}},
+				},
+			},
+		},
+		"Empty": {
+			reason: "Absent optional fields should be absent in our model",
+			u:      &extv1alpha1.Usage{},
+			want: Usage{
+				Metadata: ObjectMeta{},
+				Spec: UsageSpec{
+					Of: UsageResource{},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GetUsage(tc.u)
+			if diff := cmp.Diff(tc.want, got, cmpopts.IgnoreFields(Usage{}, "PavedAccess"), cmp.AllowUnexported(ObjectMeta{})); diff != "" {
+				t.Errorf("\n%s\nGetUsage(...): -want, +got\n:%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -15,6 +15,8 @@
 package model
 
 import (
+	"time"
+
 	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -34,6 +36,7 @@ func convert(from *kunstructured.Unstructured, to runtime.Object) error {
 	return nil
 }
 
+// getIntPtr returns nil if the supplied integer is nil or zero.
 func getIntPtr(i *int64) *int {
 	if i == nil || *i == 0 {
 		return nil
@@ -42,3 +45,17 @@ func getIntPtr(i *int64) *int {
 	out := int(*i)
 	return &out
 }
+
+func getTimePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+func getStringPtr(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -16,9 +16,11 @@ package present
 
 import (
 	"context"
+	"runtime/debug"
 	"syscall"
 
 	"github.com/99designs/gqlgen/graphql"
+	"github.com/go-chi/chi/v5/middleware"
 	"github.com/vektah/gqlparser/v2/gqlerror"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	meta "k8s.io/apimachinery/pkg/api/meta"
@@ -26,10 +28,16 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/upbound/xgql/internal/opentelemetry"
 )
 
 const (
 	errRBAC = "possible RBAC permissions error"
+
+	errInternal      = "internal server error"
+	errFmtInternalID = "internal server error (request ID %s)"
 )
 
 // Error extension fields.
@@ -45,6 +53,10 @@ const (
 
 	// Type is the error type, if any.
 	Type = "type"
+
+	// Causes are the causes of an API server error, if any. For example the
+	// fields of a resource that failed validation.
+	Causes = "causes"
 )
 
 // An ErrorCode indicates the type of error.
@@ -71,6 +83,30 @@ const (
 	ErrorSourceUnknown   ErrorSource = "Unknown"
 )
 
+// A StatusCause is a cause of an API server error.
+type StatusCause struct {
+	// Type of the cause, e.g. FieldValueInvalid.
+	Type string `json:"type,omitempty"`
+
+	// Message describing the cause.
+	Message string `json:"message,omitempty"`
+
+	// Field of the resource that caused the error, if any, e.g.
+	// spec.forProvider.region.
+	Field string `json:"field,omitempty"`
+}
+
+func getStatusCauses(d *metav1.StatusDetails) []StatusCause {
+	if d == nil || len(d.Causes) == 0 {
+		return nil
+	}
+	out := make([]StatusCause, len(d.Causes))
+	for i, c := range d.Causes {
+		out[i] = StatusCause{Type: string(c.Type), Message: c.Message, Field: c.Field}
+	}
+	return out
+}
+
 type serverError struct {
 	Code   ErrorCode
 	Reason string
@@ -187,12 +223,41 @@ func Error(ctx context.Context, err error) *gqlerror.Error {
 		if s.Status().Reason == metav1.StatusReasonTimeout {
 			cerr = wrap(cerr, errRBAC)
 		}
-		return Extend(ctx, cerr, map[string]interface{}{
+		ext := map[string]interface{}{
 			Source: ErrorSourceAPIServer,
 			Reason: s.Status().Reason,
 			Code:   s.Status().Code,
-		})
+		}
+		// Causes let a caller attribute an error to the fields of a
+		// resource, for example to highlight invalid fields of a form.
+		if c := getStatusCauses(s.Status().Details); c != nil {
+			ext[Causes] = c
+		}
+		return Extend(ctx, cerr, ext)
 	default:
 		return Extend(ctx, cerr, map[string]interface{}{Source: ErrorSourceUnknown})
 	}
 }
+
+// Recover returns a function that recovers from a panic while resolving a
+// GraphQL operation. The panic is logged along with its stack and recorded in
+// metrics. The caller receives a sanitized error rather than the panic value,
+// which may include internal details.
+func Recover(log logging.Logger) graphql.RecoverFunc {
+	return func(ctx context.Context, v interface{}) error {
+		opentelemetry.RecordPanic(ctx)
+
+		id := middleware.GetReqID(ctx)
+		kv := []interface{}{"id", id, "panic", v, "stack", string(debug.Stack())}
+		if fc := graphql.GetFieldContext(ctx); fc != nil {
+			kv = append(kv, "path", fc.Path().String())
+		}
+		// Crossplane's logger has no error level; Info is its most severe.
+		log.Info("Panicked while resolving GraphQL operation", kv...)
+
+		if id == "" {
+			return errors.New(errInternal)
+		}
+		return errors.Errorf(errFmtInternalID, id)
+	}
+}
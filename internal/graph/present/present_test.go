@@ -17,14 +17,20 @@ package present
 import (
 	"context"
 	"errors"
+	"fmt"
 	"syscall"
 	"testing"
 
+	"github.com/go-chi/chi/v5/middleware"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/vektah/gqlparser/v2/gqlerror"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	meta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
 )
 
 func TestError(t *testing.T) {
@@ -32,6 +38,10 @@ func TestError(t *testing.T) {
 	errNetwork := syscall.ECONNREFUSED
 	errNoKindMatch := &meta.NoKindMatchError{}
 	errBoom := errors.New("boom")
+	errInvalid := kerrors.NewInvalid(schema.GroupKind{Group: "example.org", Kind: "Example"}, "cool", field.ErrorList{
+		field.Invalid(field.NewPath("spec", "region"), "moon", "unsupported region"),
+		field.Required(field.NewPath("spec", "size"), ""),
+	})
 
 	gerrTimeout := gqlerror.WrapPath(nil, errTimeout)
 	gerrNetwork := gqlerror.WrapPath(nil, errNetwork)
@@ -122,6 +132,25 @@ func TestError(t *testing.T) {
 				},
 			},
 		},
+		"InvalidError": {
+			reason: "The causes of API server errors should be added to the GQL error's extensions.",
+			args: args{
+				ctx: context.Background(),
+				err: fmt.Errorf("cannot create resource: %w", errInvalid),
+			},
+			want: &gqlerror.Error{
+				Message: "cannot create resource: " + errInvalid.Error(),
+				Extensions: map[string]interface{}{
+					Code:   errInvalid.Status().Code,
+					Source: ErrorSourceAPIServer,
+					Reason: errInvalid.Status().Reason,
+					Causes: []StatusCause{
+						{Type: "FieldValueInvalid", Message: `Invalid value: "moon": unsupported region`, Field: "spec.region"},
+						{Type: "FieldValueRequired", Message: "Required value", Field: "spec.size"},
+					},
+				},
+			},
+		},
 		"OtherError": {
 			reason: "Non-GQL errors should be 'upgraded' to a GQL error.",
 			args: args{
@@ -146,3 +175,74 @@ func TestError(t *testing.T) {
 		})
 	}
 }
+
+// A recordingLogger records the messages and key value pairs logged at info
+// level.
+type recordingLogger struct {
+	msgs []string
+	kvs  map[string]interface{}
+}
+
+func (l *recordingLogger) Info(msg string, kv ...interface{}) {
+	l.msgs = append(l.msgs, msg)
+	for i := 0; i+1 < len(kv); i += 2 {
+		l.kvs[kv[i].(string)] = kv[i+1]
+	}
+}
+func (l *recordingLogger) Debug(_ string, _ ...interface{})           {}
+func (l *recordingLogger) WithValues(_ ...interface{}) logging.Logger { return l }
+
+func TestRecover(t *testing.T) {
+	type want struct {
+		err   string
+		id    string
+		panic interface{}
+	}
+
+	cases := map[string]struct {
+		reason string
+		ctx    context.Context
+		want   want
+	}{
+		"WithRequestID": {
+			reason: "We should log the panic with the request ID and return a sanitized error that includes it.",
+			ctx:    context.WithValue(context.Background(), middleware.RequestIDKey, "cool-id"),
+			want: want{
+				err:   "internal server error (request ID cool-id)",
+				id:    "cool-id",
+				panic: "boom",
+			},
+		},
+		"WithoutRequestID": {
+			reason: "We should log the panic and return a sanitized error when there is no request ID.",
+			ctx:    context.Background(),
+			want: want{
+				err:   "internal server error",
+				panic: "boom",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			log := &recordingLogger{kvs: map[string]interface{}{}}
+			err := Recover(log)(tc.ctx, "boom")
+
+			if diff := cmp.Diff(tc.want.err, err.Error()); diff != "" {
+				t.Errorf("\n%s\nRecover(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(1, len(log.msgs)); diff != "" {
+				t.Errorf("\n%s\nRecover(...): -want logged messages, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.id, log.kvs["id"]); diff != "" {
+				t.Errorf("\n%s\nRecover(...): -want logged request ID, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.panic, log.kvs["panic"]); diff != "" {
+				t.Errorf("\n%s\nRecover(...): -want logged panic, +got:\n%s", tc.reason, diff)
+			}
+			if _, ok := log.kvs["stack"]; !ok {
+				t.Errorf("\n%s\nRecover(...): want logged stack", tc.reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package present
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/formatter"
+)
+
+// SchemaHandler returns an HTTP handler that serves the supplied schema in
+// the GraphQL schema definition language (SDL), for example so that clients
+// may generate code without running an introspection query. The schema is
+// formatted once, when the handler is created.
+func SchemaHandler(s *ast.Schema) http.Handler {
+	b := &bytes.Buffer{}
+	formatter.NewFormatter(b).FormatSchema(s)
+	sdl := b.Bytes()
+
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = w.Write(sdl)
+	})
+}
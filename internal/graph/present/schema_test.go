@@ -0,0 +1,51 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package present
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+func TestSchemaHandler(t *testing.T) {
+	s := gqlparser.MustLoadSchema(&ast.Source{Name: "schema.gql", Input: `
+"A cool type."
+type Query {
+  "A cool field."
+  cool: String!
+}
+`})
+
+	rec := httptest.NewRecorder()
+	SchemaHandler(s).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/schema.graphql", nil))
+
+	if diff := cmp.Diff(http.StatusOK, rec.Code); diff != "" {
+		t.Errorf("SchemaHandler(...): -want status, +got status:\n%s", diff)
+	}
+	want := `"""A cool type."""
+type Query {
+	"""A cool field."""
+	cool: String!
+}
+`
+	if diff := cmp.Diff(want, rec.Body.String()); diff != "" {
+		t.Errorf("SchemaHandler(...): -want SDL, +got SDL:\n%s", diff)
+	}
+}
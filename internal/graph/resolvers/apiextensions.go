@@ -21,6 +21,8 @@ import (
 
 	"github.com/99designs/gqlgen/graphql"
 	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
@@ -28,6 +30,8 @@ import (
 
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 	extv1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+	pkgv1 "github.com/crossplane/crossplane/apis/pkg/v1"
+	pkgv1beta1 "github.com/crossplane/crossplane/apis/pkg/v1beta1"
 
 	"github.com/upbound/xgql/internal/auth"
 	"github.com/upbound/xgql/internal/graph/model"
@@ -36,6 +40,9 @@ import (
 
 const (
 	errListResources = "cannot list defined resources"
+	errGetFunction   = "cannot get composition function"
+	errModelFunction = "cannot model composition function"
+	errGetSchema     = "cannot get OpenAPI schema"
 )
 
 type xrd struct {
@@ -61,7 +68,7 @@ func (r *xrd) getCrd(ctx context.Context, group string, names *model.CompositeRe
 	}
 
 	creds, _ := auth.FromContext(ctx)
-	c, err := r.clients.Get(creds)
+	c, err := r.clients.GetWithContext(ctx, creds)
 	if err != nil {
 		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
 		return nil, nil
@@ -86,6 +93,15 @@ func (r *xrd) CompositeResourceClaimCrd(ctx context.Context, obj *model.Composit
 	return r.getCrd(ctx, obj.Spec.Group, obj.Spec.ClaimNames)
 }
 
+func (r *xrd) Schema(ctx context.Context, obj *model.CompositeResourceDefinition, version *string) (*model.OpenAPISchema, error) {
+	out, err := model.GetCompositeResourceDefinitionSchema(obj.Spec.Versions, version)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetSchema))
+		return nil, nil
+	}
+	return out, nil
+}
+
 func (r *xrd) DefinedCompositeResources(ctx context.Context, obj *model.CompositeResourceDefinition, version *string, options *model.DefinedCompositeResourceOptionsInput) (model.CompositeResourceConnection, error) {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
@@ -97,7 +113,7 @@ func (r *xrd) DefinedCompositeResources(ctx context.Context, obj *model.Composit
 	options.DeprecationPatch(version)
 
 	creds, _ := auth.FromContext(ctx)
-	c, err := r.clients.Get(creds)
+	c, err := r.clients.GetWithContext(ctx, creds)
 	if err != nil {
 		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
 		return model.CompositeResourceConnection{}, nil
@@ -169,7 +185,7 @@ func (r *xrd) DefinedCompositeResourceClaims(ctx context.Context, obj *model.Com
 	}
 
 	creds, _ := auth.FromContext(ctx)
-	c, err := r.clients.Get(creds)
+	c, err := r.clients.GetWithContext(ctx, creds)
 	if err != nil {
 		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
 		return model.CompositeResourceClaimConnection{}, nil
@@ -275,7 +291,7 @@ func (r *xrdSpec) DefaultComposition(ctx context.Context, obj *model.CompositeRe
 	defer cancel()
 
 	creds, _ := auth.FromContext(ctx)
-	c, err := r.clients.Get(creds)
+	c, err := r.clients.GetWithContext(ctx, creds)
 	if err != nil {
 		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
 		return nil, nil
@@ -300,7 +316,7 @@ func (r *xrdSpec) EnforcedComposition(ctx context.Context, obj *model.CompositeR
 	defer cancel()
 
 	creds, _ := auth.FromContext(ctx)
-	c, err := r.clients.Get(creds)
+	c, err := r.clients.GetWithContext(ctx, creds)
 	if err != nil {
 		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
 		return nil, nil
@@ -330,3 +346,42 @@ func (r *composition) Events(ctx context.Context, obj *model.Composition) (model
 		UID:        types.UID(obj.Metadata.UID),
 	})
 }
+
+type pipelineStep struct {
+	clients ClientCache
+}
+
+func (r *pipelineStep) Function(ctx context.Context, obj *model.PipelineStep) (model.KubernetesResource, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.GetWithContext(ctx, creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return nil, nil
+	}
+
+	fn := &kunstructured.Unstructured{}
+	fn.SetGroupVersionKind(pkgv1.FunctionGroupVersionKind)
+	err = c.Get(ctx, types.NamespacedName{Name: obj.FunctionRef.Name}, fn)
+	if meta.IsNoMatchError(err) {
+		// Crossplane serves functions at v1beta1 before v1.17.
+		fn.SetGroupVersionKind(pkgv1beta1.FunctionGroupVersionKind)
+		err = c.Get(ctx, types.NamespacedName{Name: obj.FunctionRef.Name}, fn)
+	}
+	if err != nil {
+		// A pipeline may reference a function that isn't installed.
+		if !kerrors.IsNotFound(err) {
+			graphql.AddError(ctx, errors.Wrap(err, errGetFunction))
+		}
+		return nil, nil
+	}
+
+	out, err := model.GetKubernetesResource(fn)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errModelFunction))
+		return nil, nil
+	}
+	return out, nil
+}
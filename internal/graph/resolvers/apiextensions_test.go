@@ -16,6 +16,7 @@ package resolvers
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 
 	"github.com/99designs/gqlgen/graphql"
@@ -23,6 +24,8 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/vektah/gqlparser/v2/gqlerror"
 	corev1 "k8s.io/api/core/v1"
+	kextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/utils/ptr"
@@ -33,6 +36,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 	extv1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+	pkgv1 "github.com/crossplane/crossplane/apis/pkg/v1"
 
 	"github.com/upbound/xgql/internal/auth"
 	"github.com/upbound/xgql/internal/clients"
@@ -44,6 +48,7 @@ var (
 	_ generated.CompositeResourceDefinitionResolver     = &xrd{}
 	_ generated.CompositeResourceDefinitionSpecResolver = &xrdSpec{}
 	_ generated.CompositionResolver                     = &composition{}
+	_ generated.PipelineStepResolver                    = &pipelineStep{}
 )
 
 func TestCompositeResourceCrd(t *testing.T) {
@@ -67,7 +72,7 @@ func TestCompositeResourceCrd(t *testing.T) {
 	}{
 		"GetClientError": {
 			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, errBoom
 			}),
 			args: args{
@@ -82,7 +87,7 @@ func TestCompositeResourceCrd(t *testing.T) {
 		},
 		"GetError": {
 			reason: "If we can't get a CompositeResourceDefinition we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
 						return errBoom
@@ -101,7 +106,7 @@ func TestCompositeResourceCrd(t *testing.T) {
 		},
 		"Success": {
 			reason: "Successfully return a CompositeResourceDefinition",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
 						if diff := cmp.Diff(client.ObjectKey{Name: "things.some.group"}, key); diff != "" {
@@ -171,7 +176,7 @@ func TestCompositeResourceClaimCrd(t *testing.T) {
 	}{
 		"NoClaimNames": {
 			reason: "If the XRD has no Names we return nil.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, nil
 			}),
 			args: args{
@@ -182,7 +187,7 @@ func TestCompositeResourceClaimCrd(t *testing.T) {
 		},
 		"GetClientError": {
 			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, errBoom
 			}),
 			args: args{
@@ -197,7 +202,7 @@ func TestCompositeResourceClaimCrd(t *testing.T) {
 		},
 		"GetError": {
 			reason: "If we can't get a CompositeResourceDefinition we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
 						return errBoom
@@ -216,7 +221,7 @@ func TestCompositeResourceClaimCrd(t *testing.T) {
 		},
 		"Success": {
 			reason: "Successfully return a CompositeResourceDefinition",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
 						if diff := cmp.Diff(client.ObjectKey{Name: "things.some.group"}, key); diff != "" {
@@ -265,6 +270,78 @@ func TestCompositeResourceClaimCrd(t *testing.T) {
 	}
 }
 
+func TestXRDSchema(t *testing.T) {
+	malformed := []byte(`{`)
+	errMalformed := json.Unmarshal(malformed, &kextv1.JSONSchemaProps{})
+
+	type args struct {
+		ctx     context.Context
+		obj     *model.CompositeResourceDefinition
+		version *string
+	}
+	type want struct {
+		s    *model.OpenAPISchema
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"MalformedSchema": {
+			reason: "If we can't unmarshal the schema we should add the error to the GraphQL context and return early.",
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &model.CompositeResourceDefinition{Spec: model.CompositeResourceDefinitionSpec{Versions: []model.CompositeResourceDefinitionVersion{
+					{Name: "v1", Served: true, Referenceable: true, Schema: &model.CompositeResourceValidation{OpenAPIV3Schema: malformed}},
+				}}},
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errors.Wrap(errMalformed, "cannot unmarshal OpenAPI v3 schema"), errGetSchema)),
+				},
+			},
+		},
+		"Success": {
+			reason: "We should return the schema of the requested version.",
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &model.CompositeResourceDefinition{Spec: model.CompositeResourceDefinitionSpec{Versions: []model.CompositeResourceDefinitionVersion{
+					{Name: "v1", Served: true, Schema: &model.CompositeResourceValidation{OpenAPIV3Schema: []byte(`{"type":"object"}`)}},
+					{Name: "v2", Served: true, Referenceable: true, Schema: &model.CompositeResourceValidation{OpenAPIV3Schema: malformed}},
+				}}},
+				version: ptr.To("v1"),
+			},
+			want: want{
+				s: &model.OpenAPISchema{Type: ptr.To("object")},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			x := &xrd{}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := x.Schema(tc.args.ctx, tc.args.obj, tc.args.version)
+			errs := graphql.GetErrors(tc.args.ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nx.Schema(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nx.Schema(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.s, got); diff != "" {
+				t.Errorf("\n%s\nx.Schema(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
 func TestXRDDefinedCompositeResources(t *testing.T) {
 	errBoom := errors.New("boom")
 
@@ -308,7 +385,7 @@ func TestXRDDefinedCompositeResources(t *testing.T) {
 	}{
 		"GetClientError": {
 			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, errBoom
 			}),
 			args: args{
@@ -322,7 +399,7 @@ func TestXRDDefinedCompositeResources(t *testing.T) {
 		},
 		"ListDefinedCompositeResourcesError": {
 			reason: "If we can't list defined resources we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(errBoom),
 				}, nil
@@ -344,7 +421,7 @@ func TestXRDDefinedCompositeResources(t *testing.T) {
 		},
 		"InferReferencableVersion": {
 			reason: "We should successfully infer the referencable version and return any defined resources we can list and model.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
 						u := *obj.(*unstructured.UnstructuredList)
@@ -394,7 +471,7 @@ func TestXRDDefinedCompositeResources(t *testing.T) {
 		},
 		"InferServedVersion": {
 			reason: "We should successfully infer the served version (if none is referenceable) and return any defined resources we can list and model.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
 						u := *obj.(*unstructured.UnstructuredList)
@@ -443,7 +520,7 @@ func TestXRDDefinedCompositeResources(t *testing.T) {
 		},
 		"SpecificVersion": {
 			reason: "We should successfully return any defined resources of the requested version that we can list and model.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
 						u := *obj.(*unstructured.UnstructuredList)
@@ -495,7 +572,7 @@ func TestXRDDefinedCompositeResources(t *testing.T) {
 		},
 		"SpecificVersionDeprecated": {
 			reason: "We should successfully return any defined resources of the requested version that we can list and model.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
 						u := *obj.(*unstructured.UnstructuredList)
@@ -547,7 +624,7 @@ func TestXRDDefinedCompositeResources(t *testing.T) {
 		},
 		"SpecificVersionPerferNonDeprecated": {
 			reason: "We should successfully return any defined resources of the requested version that we can list and model.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
 						u := *obj.(*unstructured.UnstructuredList)
@@ -600,7 +677,7 @@ func TestXRDDefinedCompositeResources(t *testing.T) {
 		},
 		"ReadyNull": {
 			reason: "We should successfully return any defined claims of any ready status",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
 						*obj.(*unstructured.UnstructuredList) = unstructured.UnstructuredList{Items: []unstructured.Unstructured{xr, xrNotReady, xrReady, xrReadyUnknown}}
@@ -643,7 +720,7 @@ func TestXRDDefinedCompositeResources(t *testing.T) {
 		},
 		"ReadyFalse": {
 			reason: "We should successfully return any defined claims that are not ready",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
 						*obj.(*unstructured.UnstructuredList) = unstructured.UnstructuredList{Items: []unstructured.Unstructured{xr, xrNotReady, xrReady, xrReadyUnknown}}
@@ -686,7 +763,7 @@ func TestXRDDefinedCompositeResources(t *testing.T) {
 		},
 		"ReadyTrue": {
 			reason: "We should successfully return any defined claims that are ready",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
 						*obj.(*unstructured.UnstructuredList) = unstructured.UnstructuredList{Items: []unstructured.Unstructured{xr, xrNotReady, xrReady, xrReadyUnknown}}
@@ -810,7 +887,7 @@ func TestXRDDefinedCompositeResourceClaims(t *testing.T) {
 		},
 		"GetClientError": {
 			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, errBoom
 			}),
 			args: args{
@@ -829,7 +906,7 @@ func TestXRDDefinedCompositeResourceClaims(t *testing.T) {
 		},
 		"ListDefinedCompositeResourceClaimsError": {
 			reason: "If we can't list defined claims we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(errBoom),
 				}, nil
@@ -851,7 +928,7 @@ func TestXRDDefinedCompositeResourceClaims(t *testing.T) {
 		},
 		"InferReferencableVersion": {
 			reason: "We should successfully infer the referencable version and return any defined claims we can list and model.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
 						u := *obj.(*unstructured.UnstructuredList)
@@ -901,7 +978,7 @@ func TestXRDDefinedCompositeResourceClaims(t *testing.T) {
 		},
 		"InferServedVersion": {
 			reason: "We should successfully infer the served version (if none is referenceable) and return any defined claims we can list and model.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
 						u := *obj.(*unstructured.UnstructuredList)
@@ -950,7 +1027,7 @@ func TestXRDDefinedCompositeResourceClaims(t *testing.T) {
 		},
 		"SpecificVersion": {
 			reason: "We should successfully return any defined claims of the requested version that we can list and model.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
 						u := *obj.(*unstructured.UnstructuredList)
@@ -1002,7 +1079,7 @@ func TestXRDDefinedCompositeResourceClaims(t *testing.T) {
 		},
 		"SpecificVersionDeprecated": {
 			reason: "We should successfully return any defined claims of the requested deprecated version that we can list and model.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
 						u := *obj.(*unstructured.UnstructuredList)
@@ -1054,7 +1131,7 @@ func TestXRDDefinedCompositeResourceClaims(t *testing.T) {
 		},
 		"SpecificVersionPreferNonDeprecated": {
 			reason: "We should successfully return any defined claims of the requested version ignoring deprecated version that we can list and model.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
 						u := *obj.(*unstructured.UnstructuredList)
@@ -1107,7 +1184,7 @@ func TestXRDDefinedCompositeResourceClaims(t *testing.T) {
 		},
 		"Namespace": {
 			reason: "We should successfully return any defined claims in namespace that we can list and model.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: func(_ context.Context, list client.ObjectList, opts ...client.ListOption) error {
 						u := *list.(*unstructured.UnstructuredList)
@@ -1163,7 +1240,7 @@ func TestXRDDefinedCompositeResourceClaims(t *testing.T) {
 		},
 		"NamespaceDeprecated": {
 			reason: "We should successfully return any defined claims in deprecated namespace that we can list and model.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: func(_ context.Context, list client.ObjectList, opts ...client.ListOption) error {
 						u := *list.(*unstructured.UnstructuredList)
@@ -1220,7 +1297,7 @@ func TestXRDDefinedCompositeResourceClaims(t *testing.T) {
 		},
 		"NamespacePreferNonDeprecated": {
 			reason: "We should successfully return any defined claims in namespace ignoring deprecated namespace that we can list and model.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: func(_ context.Context, list client.ObjectList, opts ...client.ListOption) error {
 						u := *list.(*unstructured.UnstructuredList)
@@ -1277,7 +1354,7 @@ func TestXRDDefinedCompositeResourceClaims(t *testing.T) {
 		},
 		"ReadyNull": {
 			reason: "We should successfully return any defined claims of any ready status",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
 						*obj.(*unstructured.UnstructuredList) = unstructured.UnstructuredList{Items: []unstructured.Unstructured{xrc, xrcNotReady, xrcReady, xrcReadyUnknown}}
@@ -1320,7 +1397,7 @@ func TestXRDDefinedCompositeResourceClaims(t *testing.T) {
 		},
 		"ReadyFalse": {
 			reason: "We should successfully return any defined claims that are not ready",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
 						*obj.(*unstructured.UnstructuredList) = unstructured.UnstructuredList{Items: []unstructured.Unstructured{xrc, xrcNotReady, xrcReady, xrcReadyUnknown}}
@@ -1363,7 +1440,7 @@ func TestXRDDefinedCompositeResourceClaims(t *testing.T) {
 		},
 		"ReadyTrue": {
 			reason: "We should successfully return any defined claims that are ready",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
 						*obj.(*unstructured.UnstructuredList) = unstructured.UnstructuredList{Items: []unstructured.Unstructured{xrc, xrcNotReady, xrcReady, xrcReadyUnknown}}
@@ -1462,7 +1539,7 @@ func TestCompositeResourceDefinitionSpecDefaultComposition(t *testing.T) {
 		},
 		"GetClientError": {
 			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, errBoom
 			}),
 			args: args{
@@ -1479,7 +1556,7 @@ func TestCompositeResourceDefinitionSpecDefaultComposition(t *testing.T) {
 		},
 		"GetDefaultCompositionError": {
 			reason: "If we can't get the composition we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(errBoom),
 				}, nil
@@ -1498,7 +1575,7 @@ func TestCompositeResourceDefinitionSpecDefaultComposition(t *testing.T) {
 		},
 		"Success": {
 			reason: "If we can get and model the composition we should return it.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(nil),
 				}, nil
@@ -1568,7 +1645,7 @@ func TestCompositeResourceDefinitionSpecEnforcedComposition(t *testing.T) {
 		},
 		"GetClientError": {
 			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, errBoom
 			}),
 			args: args{
@@ -1585,7 +1662,7 @@ func TestCompositeResourceDefinitionSpecEnforcedComposition(t *testing.T) {
 		},
 		"GetEnforcedCompositionError": {
 			reason: "If we can't get the composition we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(errBoom),
 				}, nil
@@ -1604,7 +1681,7 @@ func TestCompositeResourceDefinitionSpecEnforcedComposition(t *testing.T) {
 		},
 		"Success": {
 			reason: "If we can get and model the composition we should return it.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(nil),
 				}, nil
@@ -1642,3 +1719,117 @@ func TestCompositeResourceDefinitionSpecEnforcedComposition(t *testing.T) {
 		})
 	}
 }
+
+func TestPipelineStepFunction(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	fn := &unstructured.Unstructured{}
+	fn.SetGroupVersionKind(pkgv1.FunctionGroupVersionKind)
+	fn.SetName("cool-function")
+	gfn, _ := model.GetKubernetesResource(fn)
+
+	type args struct {
+		ctx context.Context
+		obj *model.PipelineStep
+	}
+	type want struct {
+		fn   model.KubernetesResource
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		args    args
+		want    want
+	}{
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, errBoom
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &model.PipelineStep{FunctionRef: model.FunctionReference{Name: "cool-function"}},
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"GetFunctionError": {
+			reason: "If we can't get the function we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &model.PipelineStep{FunctionRef: model.FunctionReference{Name: "cool-function"}},
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetFunction)),
+				},
+			},
+		},
+		"FunctionNotFound": {
+			reason: "If the function isn't installed we should return nil without error.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "cool-function")),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &model.PipelineStep{FunctionRef: model.FunctionReference{Name: "cool-function"}},
+			},
+			want: want{},
+		},
+		"Success": {
+			reason: "If we can get and model the function we should return it.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+						if key.Name != "cool-function" {
+							return errBoom
+						}
+						obj.SetName(key.Name)
+						return nil
+					},
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &model.PipelineStep{FunctionRef: model.FunctionReference{Name: "cool-function"}},
+			},
+			want: want{
+				fn: gfn,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := &pipelineStep{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := s.Function(tc.args.ctx, tc.args.obj)
+			errs := graphql.GetErrors(tc.args.ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ns.Function(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ns.Function(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.fn, got, cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
+				t.Errorf("\n%s\ns.Function(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
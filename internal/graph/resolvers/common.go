@@ -19,13 +19,16 @@ import (
 	"sort"
 
 	"github.com/99designs/gqlgen/graphql"
+	authv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
 	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
 
 	"github.com/upbound/xgql/internal/auth"
 	"github.com/upbound/xgql/internal/graph/model"
@@ -52,6 +55,52 @@ func (r *genericResource) Events(ctx context.Context, obj *model.GenericResource
 
 type secret struct {
 	clients ClientCache
+	log     logging.Logger
+	expose  bool
+}
+
+func (r *secret) Data(ctx context.Context, obj *model.Secret, keys []string) (map[string]string, error) {
+	if !r.expose {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.GetWithContext(ctx, creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return nil, nil
+	}
+
+	// Having read the secret doesn't mean the caller may get it; it may have
+	// been found via a resource they may get, like a composite resource. We
+	// only return its values if they may get the secret itself.
+	ns := ptr.Deref(obj.Metadata.Namespace, "")
+	ar := &authv1.SelfSubjectAccessReview{
+		Spec: authv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authv1.ResourceAttributes{Verb: "get", Resource: "secrets", Namespace: ns, Name: obj.Metadata.Name},
+		},
+	}
+	if err := c.Create(ctx, ar); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errReviewAccess))
+		return nil, nil
+	}
+	if !ar.Status.Allowed {
+		return nil, nil
+	}
+
+	// Exposing secret values is sensitive, so we want a record of who did it
+	// even when we're not debugging.
+	r.log.Info("Exposing secret values",
+		"namespace", ns,
+		"name", obj.Metadata.Name,
+		"token-hash", creds.TokenHash(),
+		"impersonated-user", creds.Impersonate.Username,
+	)
+
+	return obj.Data(keys), nil
 }
 
 func (r *secret) Events(ctx context.Context, obj *model.Secret) (model.EventConnection, error) {
@@ -67,6 +116,14 @@ func (r *secret) Events(ctx context.Context, obj *model.Secret) (model.EventConn
 
 type configMap struct {
 	clients ClientCache
+	expose  bool
+}
+
+func (r *configMap) Data(_ context.Context, obj *model.ConfigMap, keys []string) (map[string]string, error) {
+	if !r.expose {
+		return nil, nil
+	}
+	return obj.Data(keys), nil
 }
 
 func (r *configMap) Events(ctx context.Context, obj *model.ConfigMap) (model.EventConnection, error) {
@@ -80,6 +137,21 @@ func (r *configMap) Events(ctx context.Context, obj *model.ConfigMap) (model.Eve
 	})
 }
 
+type resourceQuota struct {
+	clients ClientCache
+}
+
+func (r *resourceQuota) Events(ctx context.Context, obj *model.ResourceQuota) (model.EventConnection, error) {
+	e := &events{clients: r.clients}
+	return e.Resolve(ctx, &corev1.ObjectReference{
+		APIVersion: obj.APIVersion,
+		Kind:       obj.Kind,
+		Name:       obj.Metadata.Name,
+		Namespace:  ptr.Deref(obj.Metadata.Namespace, ""),
+		UID:        types.UID(obj.Metadata.UID),
+	})
+}
+
 type crd struct {
 	clients ClientCache
 }
@@ -94,12 +166,12 @@ func (r *crd) Events(ctx context.Context, obj *model.CustomResourceDefinition) (
 	})
 }
 
-func (r *crd) DefinedResources(ctx context.Context, obj *model.CustomResourceDefinition, version *string) (model.KubernetesResourceConnection, error) {
+func (r *crd) DefinedResources(ctx context.Context, obj *model.CustomResourceDefinition, version *string, namespace *string) (model.KubernetesResourceConnection, error) {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
 	creds, _ := auth.FromContext(ctx)
-	c, err := r.clients.Get(creds)
+	c, err := r.clients.GetWithContext(ctx, creds)
 	if err != nil {
 		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
 		return model.KubernetesResourceConnection{}, nil
@@ -120,19 +192,22 @@ func (r *crd) DefinedResources(ctx context.Context, obj *model.CustomResourceDef
 		in.SetKind(*lk)
 	}
 
-	// TODO(negz): Support filtering this by a particular namespace? Currently
-	// we assume the caller has access to list the defined resource in all
-	// namespaces (or at cluster scope). In practice we expect this call to be
-	// used by platform operators to list managed resources, which are cluster
-	// scoped, but in theory a CRD could define any kind of custom resource. We
-	// could accept an optional 'namespace' argument and pass client.InNamespace
-	// to c.List. We'd also need to fetch client with a namespaced cache by
-	// passing clients.WithNamespace to r.clients.Get above.
-	if err := c.List(ctx, in); err != nil {
+	// Namespaces are meaningless for cluster scoped resources, so we only
+	// filter by namespace when this CRD defines namespaced resources.
+	lopts := []client.ListOption{}
+	if namespace != nil && obj.Spec.Scope == model.ResourceScopeNamespaceScoped {
+		lopts = append(lopts, client.InNamespace(*namespace))
+	}
+
+	if err := c.List(ctx, in, lopts...); err != nil {
 		graphql.AddError(ctx, errors.Wrap(err, errListResources))
 		return model.KubernetesResourceConnection{}, nil
 	}
 
+	if !selected(ctx, fieldNodes) {
+		return model.KubernetesResourceConnection{TotalCount: len(in.Items)}, nil
+	}
+
 	out := &model.KubernetesResourceConnection{
 		Nodes:      make([]model.KubernetesResource, 0, len(in.Items)),
 		TotalCount: len(in.Items),
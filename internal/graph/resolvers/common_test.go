@@ -22,6 +22,9 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/vektah/gqlparser/v2/gqlerror"
+	authv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/utils/ptr"
@@ -29,6 +32,7 @@ import (
 
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 
 	"github.com/upbound/xgql/internal/auth"
@@ -47,6 +51,162 @@ var (
 	crdKind       = "CustomResourceDefinition"
 )
 
+func TestSecretData(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	s := model.GetSecret(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cool"},
+		Data:       map[string][]byte{"username": []byte("admin"), "password": []byte("hunter2")},
+	})
+
+	// Allow only getting the secret named cool in the default namespace.
+	review := func(obj client.Object) error {
+		ra := obj.(*authv1.SelfSubjectAccessReview).Spec.ResourceAttributes
+		obj.(*authv1.SelfSubjectAccessReview).Status.Allowed = ra.Verb == "get" && ra.Resource == "secrets" && ra.Namespace == "default" && ra.Name == "cool"
+		return nil
+	}
+
+	type args struct {
+		obj  *model.Secret
+		keys []string
+	}
+	type want struct {
+		data map[string]string
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		expose  bool
+		clients ClientCache
+		args    args
+		want    want
+	}{
+		"NotExposed": {
+			reason: "We should not return values if secret values aren't exposed.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockCreate: test.NewMockCreateFn(nil, review)}, nil
+			}),
+			args: args{obj: &s},
+			want: want{},
+		},
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			expose: true,
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return nil, errBoom
+			}),
+			args: args{obj: &s},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"ReviewError": {
+			reason: "If we can't review access to the secret we should add the error to the GraphQL context and return early.",
+			expose: true,
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockCreate: test.NewMockCreateFn(errBoom)}, nil
+			}),
+			args: args{obj: &s},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errReviewAccess)),
+				},
+			},
+		},
+		"Denied": {
+			reason: "We should not return values if the caller may not get the secret.",
+			expose: true,
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockCreate: test.NewMockCreateFn(nil, review)}, nil
+			}),
+			args: args{obj: func() *model.Secret {
+				s := model.GetSecret(&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "other"}, Data: map[string][]byte{"cool": []byte("secret")}})
+				return &s
+			}()},
+			want: want{},
+		},
+		"Allowed": {
+			reason: "We should return the requested values if they're exposed and the caller may get the secret.",
+			expose: true,
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockCreate: test.NewMockCreateFn(nil, review)}, nil
+			}),
+			args: args{obj: &s, keys: []string{"username"}},
+			want: want{
+				data: map[string]string{"username": "admin"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &secret{clients: tc.clients, log: logging.NewNopLogger(), expose: tc.expose}
+			ctx := graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover)
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := r.Data(ctx, tc.args.obj, tc.args.keys)
+			errs := graphql.GetErrors(ctx)
+
+			if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Data(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Data(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.data, got); diff != "" {
+				t.Errorf("\n%s\nr.Data(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestConfigMapData(t *testing.T) {
+	cm := model.GetConfigMap(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cool"},
+		Data:       map[string]string{"region": "us-east-1", "zone": "a"},
+	})
+
+	cases := map[string]struct {
+		reason string
+		expose bool
+		keys   []string
+		want   map[string]string
+	}{
+		"NotExposed": {
+			reason: "Config map values should not be returned unless they're exposed.",
+			want:   nil,
+		},
+		"Exposed": {
+			reason: "Config map values should be returned if they're exposed.",
+			expose: true,
+			want:   map[string]string{"region": "us-east-1", "zone": "a"},
+		},
+		"ExposedKeys": {
+			reason: "Only the values of the requested keys should be returned.",
+			expose: true,
+			keys:   []string{"region"},
+			want:   map[string]string{"region": "us-east-1"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &configMap{expose: tc.expose}
+			got, err := r.Data(context.Background(), &cm, tc.keys)
+			if err != nil {
+				t.Fatalf("\n%s\nr.Data(...): %s", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nr.Data(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
 func TestCRDDefinedResources(t *testing.T) {
 	errBoom := errors.New("boom")
 
@@ -62,9 +222,10 @@ func TestCRDDefinedResources(t *testing.T) {
 	listKind := "Examples"
 
 	type args struct {
-		ctx     context.Context
-		obj     *model.CustomResourceDefinition
-		version *string
+		ctx       context.Context
+		obj       *model.CustomResourceDefinition
+		version   *string
+		namespace *string
 	}
 	type want struct {
 		krc  model.KubernetesResourceConnection
@@ -80,7 +241,7 @@ func TestCRDDefinedResources(t *testing.T) {
 	}{
 		"GetClientError": {
 			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, errBoom
 			}),
 			args: args{
@@ -94,7 +255,7 @@ func TestCRDDefinedResources(t *testing.T) {
 		},
 		"ListDefinedResourcesError": {
 			reason: "If we can't list defined resources we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(errBoom),
 				}, nil
@@ -116,7 +277,7 @@ func TestCRDDefinedResources(t *testing.T) {
 		},
 		"InferServedVersion": {
 			reason: "We should successfully infer the served version (if none is referenceable) and return any defined resources we can list and model.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
 						u := *obj.(*unstructured.UnstructuredList)
@@ -165,7 +326,7 @@ func TestCRDDefinedResources(t *testing.T) {
 		},
 		"SpecificVersion": {
 			reason: "We should successfully return any defined resources of the requested version that we can list and model.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
 						u := *obj.(*unstructured.UnstructuredList)
@@ -215,6 +376,74 @@ func TestCRDDefinedResources(t *testing.T) {
 				},
 			},
 		},
+		"NamespacedResources": {
+			reason: "We should only list defined resources in the requested namespace when the CRD defines namespaced resources.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: func(_ context.Context, obj client.ObjectList, opts ...client.ListOption) error {
+						lo := &client.ListOptions{}
+						lo.ApplyOptions(opts)
+						if diff := cmp.Diff("default", lo.Namespace); diff != "" {
+							t.Errorf("-want namespace, +got namespace:\n%s", diff)
+						}
+
+						*obj.(*unstructured.UnstructuredList) = unstructured.UnstructuredList{Items: []unstructured.Unstructured{gr}}
+						return nil
+					},
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &model.CustomResourceDefinition{
+					Spec: model.CustomResourceDefinitionSpec{
+						Group: group,
+						Names: model.CustomResourceDefinitionNames{Kind: kind},
+						Scope: model.ResourceScopeNamespaceScoped,
+					},
+				},
+				namespace: ptr.To("default"),
+			},
+			want: want{
+				krc: model.KubernetesResourceConnection{
+					Nodes:      []model.KubernetesResource{ggr},
+					TotalCount: 1,
+				},
+			},
+		},
+		"ClusterScopedResources": {
+			reason: "We should ignore the requested namespace when the CRD defines cluster scoped resources.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: func(_ context.Context, obj client.ObjectList, opts ...client.ListOption) error {
+						lo := &client.ListOptions{}
+						lo.ApplyOptions(opts)
+						if diff := cmp.Diff("", lo.Namespace); diff != "" {
+							t.Errorf("-want namespace, +got namespace:\n%s", diff)
+						}
+
+						*obj.(*unstructured.UnstructuredList) = unstructured.UnstructuredList{Items: []unstructured.Unstructured{gr}}
+						return nil
+					},
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &model.CustomResourceDefinition{
+					Spec: model.CustomResourceDefinitionSpec{
+						Group: group,
+						Names: model.CustomResourceDefinitionNames{Kind: kind},
+						Scope: model.ResourceScopeClusterScoped,
+					},
+				},
+				namespace: ptr.To("default"),
+			},
+			want: want{
+				krc: model.KubernetesResourceConnection{
+					Nodes:      []model.KubernetesResource{ggr},
+					TotalCount: 1,
+				},
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -223,7 +452,7 @@ func TestCRDDefinedResources(t *testing.T) {
 
 			// Our GraphQL resolvers never return errors. We instead add an
 			// error to the GraphQL context and return early.
-			got, err := x.DefinedResources(tc.args.ctx, tc.args.obj, tc.args.version)
+			got, err := x.DefinedResources(tc.args.ctx, tc.args.obj, tc.args.version, tc.args.namespace)
 			errs := graphql.GetErrors(tc.args.ctx)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
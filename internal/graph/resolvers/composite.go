@@ -22,12 +22,14 @@ import (
 	"github.com/99designs/gqlgen/graphql"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 	extv1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+	extv1alpha1 "github.com/crossplane/crossplane/apis/apiextensions/v1alpha1"
 
 	"github.com/upbound/xgql/internal/auth"
 	"github.com/upbound/xgql/internal/graph/model"
@@ -41,6 +43,7 @@ const (
 	errGetXRC              = "cannot get composite resource claim"
 	errGetComposed         = "cannot get composed resource"
 	errModelComposed       = "cannot model composed resource"
+	errModelEnvironment    = "cannot model environment"
 )
 
 type compositeResource struct {
@@ -62,7 +65,7 @@ func (r *compositeResource) Definition(ctx context.Context, obj *model.Composite
 	defer cancel()
 
 	creds, _ := auth.FromContext(ctx)
-	c, err := r.clients.Get(creds)
+	c, err := r.clients.GetWithContext(ctx, creds)
 	if err != nil {
 		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
 		return nil, nil
@@ -103,6 +106,51 @@ func (r *compositeResource) Definition(ctx context.Context, obj *model.Composite
 	return nil, nil
 }
 
+func (r *compositeResource) Schema(ctx context.Context, obj *model.CompositeResource) (*model.OpenAPISchema, error) {
+	def, err := r.Definition(ctx, obj)
+	if err != nil || def == nil {
+		return nil, err
+	}
+
+	gv, err := schema.ParseGroupVersion(obj.APIVersion)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errMalformedAPIVersion))
+		return nil, nil
+	}
+
+	out, err := model.GetCompositeResourceDefinitionSchema(def.Spec.Versions, &gv.Version)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetSchema))
+		return nil, nil
+	}
+	return out, nil
+}
+
+func (r *compositeResource) Tree(ctx context.Context, obj *model.CompositeResource) (model.ResourceTreeNode, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.GetWithContext(ctx, creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.ResourceTreeNode{ID: obj.ID, Resource: *obj, Children: []model.ResourceTreeNode{}}, nil
+	}
+
+	t := newResourceTree(c, FromConfig(ctx).MaxTreeDepth, FromConfig(ctx).TreeConcurrency)
+	return t.Resolve(ctx, obj.ID, *obj), nil
+}
+
+func (r *compositeResource) UsedBy(ctx context.Context, obj *model.CompositeResource) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.Resolve(ctx, usageOf(obj.APIVersion, obj.Kind, obj.Metadata.Name))
+}
+
+func (r *compositeResource) Uses(ctx context.Context, obj *model.CompositeResource) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.Resolve(ctx, usageBy(obj.APIVersion, obj.Kind, obj.Metadata.Name))
+}
+
 type compositeResourceSpec struct {
 	clients ClientCache
 }
@@ -115,7 +163,7 @@ func (r *compositeResourceSpec) Composition(ctx context.Context, obj *model.Comp
 	defer cancel()
 
 	creds, _ := auth.FromContext(ctx)
-	c, err := r.clients.Get(creds)
+	c, err := r.clients.GetWithContext(ctx, creds)
 	if err != nil {
 		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
 		return nil, nil
@@ -151,7 +199,7 @@ func (r *compositeResourceSpec) Claim(ctx context.Context, obj *model.CompositeR
 	defer cancel()
 
 	creds, _ := auth.FromContext(ctx)
-	c, err := r.clients.Get(creds)
+	c, err := r.clients.GetWithContext(ctx, creds)
 	if err != nil {
 		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
 		return nil, nil
@@ -194,7 +242,7 @@ func (r *compositeResourceSpec) Resources(ctx context.Context, obj *model.Compos
 	defer cancel()
 
 	creds, _ := auth.FromContext(ctx)
-	c, err := r.clients.Get(creds)
+	c, err := r.clients.GetWithContext(ctx, creds)
 	if err != nil {
 		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
 		return model.KubernetesResourceConnection{}, nil
@@ -204,6 +252,10 @@ func (r *compositeResourceSpec) Resources(ctx context.Context, obj *model.Compos
 		Nodes: make([]model.KubernetesResource, 0, len(obj.ResourceReferences)),
 	}
 
+	// We still need to get each composed resource to know whether it exists,
+	// but there's no need to map it if the client only wants a count.
+	nodes := selected(ctx, fieldNodes)
+
 	// Collect all concurrently.
 	var (
 		mu sync.Mutex
@@ -230,6 +282,13 @@ func (r *compositeResourceSpec) Resources(ctx context.Context, obj *model.Compos
 				return
 			}
 
+			if !nodes {
+				mu.Lock()
+				defer mu.Unlock()
+				out.TotalCount++
+				return
+			}
+
 			kr, err := model.GetKubernetesResource(xrc)
 			if err != nil {
 				graphql.AddError(ctx, errors.Wrap(err, errModelComposed))
@@ -244,6 +303,10 @@ func (r *compositeResourceSpec) Resources(ctx context.Context, obj *model.Compos
 	}
 	wg.Wait()
 
+	if !nodes {
+		return *out, nil
+	}
+
 	sort.Stable(out)
 	return *out, nil
 }
@@ -256,6 +319,88 @@ func (r *compositeResourceSpec) ResourceRefs(ctx context.Context, obj *model.Com
 	return resourceRefs, nil
 }
 
+func (r *compositeResourceSpec) EnvironmentConfigRefs(ctx context.Context, obj *model.CompositeResourceSpec) ([]model.ObjectReference, error) {
+	refs := make([]model.ObjectReference, 0, len(obj.EnvironmentConfigReferences))
+	for i := range obj.EnvironmentConfigReferences {
+		refs = append(refs, *model.GetObjectReference(&obj.EnvironmentConfigReferences[i]))
+	}
+	return refs, nil
+}
+
+func (r *compositeResourceSpec) EnvironmentConfigs(ctx context.Context, obj *model.CompositeResourceSpec) (model.EnvironmentConfigConnection, error) {
+	// Crossplane resolves the environment configs a composition selects,
+	// whether by reference or by label selector, to references.
+	names := make(map[string]bool, len(obj.EnvironmentConfigReferences))
+	for _, ref := range obj.EnvironmentConfigReferences {
+		if ref.Name != "" {
+			names[ref.Name] = true
+		}
+	}
+	if len(names) == 0 {
+		return model.EnvironmentConfigConnection{Nodes: make([]model.EnvironmentConfig, 0)}, nil
+	}
+
+	ec := &environmentConfigs{clients: r.clients}
+	return ec.Resolve(ctx, names)
+}
+
+func (r *compositeResourceSpec) Environment(ctx context.Context, obj *model.CompositeResourceSpec) (*model.CompositeResourceEnvironment, error) {
+	if obj.CompositionReference == nil {
+		return nil, nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.GetWithContext(ctx, creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return nil, nil
+	}
+
+	cmp := &extv1.Composition{}
+	nn := types.NamespacedName{Name: obj.CompositionReference.Name}
+	if err := c.Get(ctx, nn, cmp); err != nil {
+		if !apierrors.IsNotFound(err) {
+			graphql.AddError(ctx, errors.Wrap(err, errGetComposition))
+		}
+		return nil, nil
+	}
+	if cmp.Spec.Environment == nil {
+		return nil, nil
+	}
+
+	// Environment configs are an alpha Crossplane API that may not be
+	// enabled; if it isn't the environment is only the default data.
+	ecs := make([]*extv1alpha1.EnvironmentConfig, 0, len(obj.EnvironmentConfigReferences))
+	if len(obj.EnvironmentConfigReferences) > 0 {
+		in := &extv1alpha1.EnvironmentConfigList{}
+		if err := c.List(ctx, in); err != nil && !meta.IsNoMatchError(err) && !apierrors.IsNotFound(err) {
+			graphql.AddError(ctx, errors.Wrap(err, errListEnvironmentConfigs))
+			return nil, nil
+		}
+		byName := make(map[string]*extv1alpha1.EnvironmentConfig, len(in.Items))
+		for i := range in.Items {
+			byName[in.Items[i].GetName()] = &in.Items[i]
+		}
+
+		// Crossplane merges environment configs in the order the composite
+		// resource references them.
+		for _, ref := range obj.EnvironmentConfigReferences {
+			if ec, ok := byName[ref.Name]; ok {
+				ecs = append(ecs, ec)
+			}
+		}
+	}
+
+	out, err := model.GetCompositeResourceEnvironment(cmp, ecs)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errModelEnvironment))
+		return nil, nil
+	}
+	return out, nil
+}
+
 func (r *compositeResourceSpec) ConnectionSecret(ctx context.Context, obj *model.CompositeResourceSpec) (*model.Secret, error) {
 	if obj.WriteConnectionSecretToReference == nil {
 		return nil, nil
@@ -265,7 +410,7 @@ func (r *compositeResourceSpec) ConnectionSecret(ctx context.Context, obj *model
 	defer cancel()
 
 	creds, _ := auth.FromContext(ctx)
-	c, err := r.clients.Get(creds)
+	c, err := r.clients.GetWithContext(ctx, creds)
 	if err != nil {
 		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
 		return nil, nil
@@ -311,7 +456,7 @@ func (r *compositeResourceClaim) Definition(ctx context.Context, obj *model.Comp
 	defer cancel()
 
 	creds, _ := auth.FromContext(ctx)
-	c, err := r.clients.Get(creds)
+	c, err := r.clients.GetWithContext(ctx, creds)
 	if err != nil {
 		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
 		return nil, nil
@@ -356,6 +501,41 @@ func (r *compositeResourceClaim) Definition(ctx context.Context, obj *model.Comp
 	return nil, nil
 }
 
+func (r *compositeResourceClaim) Schema(ctx context.Context, obj *model.CompositeResourceClaim) (*model.OpenAPISchema, error) {
+	def, err := r.Definition(ctx, obj)
+	if err != nil || def == nil {
+		return nil, err
+	}
+
+	gv, err := schema.ParseGroupVersion(obj.APIVersion)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errMalformedAPIVersion))
+		return nil, nil
+	}
+
+	out, err := model.GetCompositeResourceDefinitionSchema(def.Spec.Versions, &gv.Version)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetSchema))
+		return nil, nil
+	}
+	return out, nil
+}
+
+func (r *compositeResourceClaim) Tree(ctx context.Context, obj *model.CompositeResourceClaim) (model.ResourceTreeNode, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.GetWithContext(ctx, creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.ResourceTreeNode{ID: obj.ID, Resource: *obj, Children: []model.ResourceTreeNode{}}, nil
+	}
+
+	t := newResourceTree(c, FromConfig(ctx).MaxTreeDepth, FromConfig(ctx).TreeConcurrency)
+	return t.Resolve(ctx, obj.ID, *obj), nil
+}
+
 type compositeResourceClaimSpec struct {
 	clients ClientCache
 }
@@ -376,7 +556,7 @@ func (r *compositeResourceClaimSpec) Composition(ctx context.Context, obj *model
 	defer cancel()
 
 	creds, _ := auth.FromContext(ctx)
-	c, err := r.clients.Get(creds)
+	c, err := r.clients.GetWithContext(ctx, creds)
 	if err != nil {
 		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
 		return nil, nil
@@ -404,7 +584,7 @@ func (r *compositeResourceClaimSpec) Resource(ctx context.Context, obj *model.Co
 	defer cancel()
 
 	creds, _ := auth.FromContext(ctx)
-	c, err := r.clients.Get(creds)
+	c, err := r.clients.GetWithContext(ctx, creds)
 	if err != nil {
 		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
 		return nil, nil
@@ -439,7 +619,7 @@ func (r *compositeResourceClaimSpec) ConnectionSecret(ctx context.Context, obj *
 	defer cancel()
 
 	creds, _ := auth.FromContext(ctx)
-	c, err := r.clients.Get(creds)
+	c, err := r.clients.GetWithContext(ctx, creds)
 	if err != nil {
 		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
 		return nil, nil
@@ -25,6 +25,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	kextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -35,6 +36,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/resource/unstructured/claim"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 	extv1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+	extv1alpha1 "github.com/crossplane/crossplane/apis/apiextensions/v1alpha1"
 
 	"github.com/upbound/xgql/internal/auth"
 	"github.com/upbound/xgql/internal/clients"
@@ -92,7 +94,7 @@ func TestCompositeResourceDefinition(t *testing.T) {
 	}{
 		"GetClientError": {
 			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, errBoom
 			}),
 			args: args{
@@ -107,7 +109,7 @@ func TestCompositeResourceDefinition(t *testing.T) {
 		},
 		"ListXRDsError": {
 			reason: "If we can't list XRDs we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(errBoom),
 				}, nil
@@ -124,7 +126,7 @@ func TestCompositeResourceDefinition(t *testing.T) {
 		},
 		"FoundXRD": {
 			reason: "If we can get and model the XRD that defines this XR we should return it.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
 						*obj.(*extv1.CompositeResourceDefinitionList) = extv1.CompositeResourceDefinitionList{
@@ -147,7 +149,7 @@ func TestCompositeResourceDefinition(t *testing.T) {
 		},
 		"NoXRD": {
 			reason: "If we can't get and model the XRD that defines this XR we should return nil.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
 						*obj.(*extv1.CompositeResourceDefinitionList) = extv1.CompositeResourceDefinitionList{
@@ -224,7 +226,7 @@ func TestCompositeResourceSpecComposition(t *testing.T) {
 		},
 		"GetClientError": {
 			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, errBoom
 			}),
 			args: args{
@@ -241,7 +243,7 @@ func TestCompositeResourceSpecComposition(t *testing.T) {
 		},
 		"GetCompositionError": {
 			reason: "If we can't get the composition we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(errBoom),
 				}, nil
@@ -260,7 +262,7 @@ func TestCompositeResourceSpecComposition(t *testing.T) {
 		},
 		"GetCompositionNotFound": {
 			reason: "If the composition is not found we return nil",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(errNotFound),
 				}, nil
@@ -277,7 +279,7 @@ func TestCompositeResourceSpecComposition(t *testing.T) {
 		},
 		"Success": {
 			reason: "If we can get and model the composition we should return it.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(nil),
 				}, nil
@@ -316,6 +318,163 @@ func TestCompositeResourceSpecComposition(t *testing.T) {
 	}
 }
 
+func TestCompositeResourceSpecEnvironment(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	withEnv := func(obj client.Object) error {
+		*obj.(*extv1.Composition) = extv1.Composition{
+			Spec: extv1.CompositionSpec{
+				Environment: &extv1.EnvironmentConfiguration{
+					DefaultData: map[string]kextv1.JSON{"region": {Raw: []byte(`"us-west-2"`)}},
+				},
+			},
+		}
+		return nil
+	}
+
+	type args struct {
+		ctx context.Context
+		obj *model.CompositeResourceSpec
+	}
+	type want struct {
+		env  *model.CompositeResourceEnvironment
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		args    args
+		want    want
+	}{
+		"NoOp": {
+			reason: "If there is no composition we should return early.",
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &model.CompositeResourceSpec{},
+			},
+			want: want{},
+		},
+		"GetCompositionError": {
+			reason: "If we can't get the composition we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &model.CompositeResourceSpec{
+					CompositionReference: &corev1.ObjectReference{},
+				},
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetComposition)),
+				},
+			},
+		},
+		"NoEnvironment": {
+			reason: "If the composition doesn't configure an environment we should return nil.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(nil),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &model.CompositeResourceSpec{
+					CompositionReference: &corev1.ObjectReference{},
+				},
+			},
+			want: want{},
+		},
+		"ListEnvironmentConfigsError": {
+			reason: "If we can't list environment configs we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet:  test.NewMockGetFn(nil, withEnv),
+					MockList: test.NewMockListFn(errBoom),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &model.CompositeResourceSpec{
+					CompositionReference:        &corev1.ObjectReference{},
+					EnvironmentConfigReferences: []corev1.ObjectReference{{Name: "a"}},
+				},
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errListEnvironmentConfigs)),
+				},
+			},
+		},
+		"Success": {
+			reason: "We should merge the referenced environment configs over the default data in the order they're referenced.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, withEnv),
+					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+						*obj.(*extv1alpha1.EnvironmentConfigList) = extv1alpha1.EnvironmentConfigList{
+							Items: []extv1alpha1.EnvironmentConfig{
+								{
+									ObjectMeta: metav1.ObjectMeta{Name: "a"},
+									Data:       map[string]kextv1.JSON{"region": {Raw: []byte(`"eu-west-1"`)}},
+								},
+								{
+									ObjectMeta: metav1.ObjectMeta{Name: "b"},
+									Data:       map[string]kextv1.JSON{"region": {Raw: []byte(`"ap-south-1"`)}},
+								},
+								{
+									ObjectMeta: metav1.ObjectMeta{Name: "unreferenced"},
+									Data:       map[string]kextv1.JSON{"region": {Raw: []byte(`"us-east-1"`)}},
+								},
+							},
+						}
+						return nil
+					}),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &model.CompositeResourceSpec{
+					CompositionReference:        &corev1.ObjectReference{},
+					EnvironmentConfigReferences: []corev1.ObjectReference{{Name: "b"}, {Name: "a"}},
+				},
+			},
+			want: want{
+				env: &model.CompositeResourceEnvironment{
+					Data:    []byte(`{"region":"eu-west-1"}`),
+					Patches: []model.EnvironmentPatch{},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := &compositeResourceSpec{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := s.Environment(tc.args.ctx, tc.args.obj)
+			errs := graphql.GetErrors(tc.args.ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ns.Environment(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ns.Environment(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.env, got); diff != "" {
+				t.Errorf("\n%s\ns.Environment(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
 func TestCompositeResourceSpecCompositionRef(t *testing.T) {
 	type args struct {
 		ctx context.Context
@@ -411,7 +570,7 @@ func TestCompositeResourceSpecClaim(t *testing.T) {
 		},
 		"GetClientError": {
 			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, errBoom
 			}),
 			args: args{
@@ -428,7 +587,7 @@ func TestCompositeResourceSpecClaim(t *testing.T) {
 		},
 		"GetClaimError": {
 			reason: "If we can't get the claim we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(errBoom),
 				}, nil
@@ -447,7 +606,7 @@ func TestCompositeResourceSpecClaim(t *testing.T) {
 		},
 		"GetClaimNotFound": {
 			reason: "If the claim is not found we return nil",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(errNotFound),
 				}, nil
@@ -464,7 +623,7 @@ func TestCompositeResourceSpecClaim(t *testing.T) {
 		},
 		"Success": {
 			reason: "If we can get and model the claim we should return it.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(nil),
 				}, nil
@@ -600,7 +759,7 @@ func TestCompositeResourceSpecResources(t *testing.T) {
 	}{
 		"GetClientError": {
 			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, errBoom
 			}),
 			args: args{
@@ -614,7 +773,7 @@ func TestCompositeResourceSpecResources(t *testing.T) {
 		},
 		"GetComposedError": {
 			reason: "If we can't get a composed resource we should add the error to the GraphQL context and continue.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
 						// Return an error only if this is KR 'A'.
@@ -647,7 +806,7 @@ func TestCompositeResourceSpecResources(t *testing.T) {
 		},
 		"IgnoreEmptyName": {
 			reason: "If we can get and model composed resources we should return them.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(nil),
 				}, nil
@@ -671,7 +830,7 @@ func TestCompositeResourceSpecResources(t *testing.T) {
 		},
 		"IgnoreMissingResources": {
 			reason: "If the resource is not found, skip it.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
 						if key.Name == "not-existing" {
@@ -700,7 +859,7 @@ func TestCompositeResourceSpecResources(t *testing.T) {
 		},
 		"Success": {
 			reason: "If we can get and model composed resources we should return them.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(nil),
 				}, nil
@@ -721,6 +880,35 @@ func TestCompositeResourceSpecResources(t *testing.T) {
 				},
 			},
 		},
+		"CountOnly": {
+			reason: "If only the count of composed resources was requested we should not model them.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+						if key.Name == "not-existing" {
+							return apierrors.NewNotFound(schema.GroupResource{}, key.Name)
+						}
+						return nil
+					},
+				}, nil
+			}),
+			args: args{
+				ctx: withSelection(graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover), "totalCount"),
+				obj: &model.CompositeResourceSpec{
+					ResourceReferences: []corev1.ObjectReference{
+						{Kind: kra.GetKind(), Name: "an-a"},
+						{Kind: krc.GetKind(), Name: "not-existing"},
+						{Kind: krb.GetKind(), Name: "a-b"},
+					},
+				},
+			},
+			want: want{
+				krc: model.KubernetesResourceConnection{
+					TotalCount: 2,
+					Nodes:      []model.KubernetesResource{},
+				},
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -777,7 +965,7 @@ func TestCompositeResourceSpecConnectionSecret(t *testing.T) {
 		},
 		"GetClientError": {
 			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, errBoom
 			}),
 			args: args{
@@ -794,7 +982,7 @@ func TestCompositeResourceSpecConnectionSecret(t *testing.T) {
 		},
 		"GetSecretError": {
 			reason: "If we can't get the secret we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(errBoom),
 				}, nil
@@ -813,7 +1001,7 @@ func TestCompositeResourceSpecConnectionSecret(t *testing.T) {
 		},
 		"GetSecretNotFound": {
 			reason: "If the secret is not found we return nil",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(errNotFound),
 				}, nil
@@ -830,7 +1018,7 @@ func TestCompositeResourceSpecConnectionSecret(t *testing.T) {
 		},
 		"Success": {
 			reason: "If we can get and model the secret we should return it.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(nil),
 				}, nil
@@ -985,7 +1173,7 @@ func TestCompositeResourceClaimDefinition(t *testing.T) {
 	}{
 		"GetClientError": {
 			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, errBoom
 			}),
 			args: args{
@@ -1000,7 +1188,7 @@ func TestCompositeResourceClaimDefinition(t *testing.T) {
 		},
 		"ListXRDsError": {
 			reason: "If we can't list XRDs we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(errBoom),
 				}, nil
@@ -1017,7 +1205,7 @@ func TestCompositeResourceClaimDefinition(t *testing.T) {
 		},
 		"FoundXRD": {
 			reason: "If we can get and model the XRD that defines this XR we should return it.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
 						*obj.(*extv1.CompositeResourceDefinitionList) = extv1.CompositeResourceDefinitionList{
@@ -1040,7 +1228,7 @@ func TestCompositeResourceClaimDefinition(t *testing.T) {
 		},
 		"NoXRD": {
 			reason: "If we can't get and model the XRD that defines this XR we should return nil.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
 						*obj.(*extv1.CompositeResourceDefinitionList) = extv1.CompositeResourceDefinitionList{
@@ -1117,7 +1305,7 @@ func TestCompositeResourceClaimSpecComposition(t *testing.T) {
 		},
 		"GetClientError": {
 			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, errBoom
 			}),
 			args: args{
@@ -1134,7 +1322,7 @@ func TestCompositeResourceClaimSpecComposition(t *testing.T) {
 		},
 		"GetCompositionError": {
 			reason: "If we can't get the composition we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(errBoom),
 				}, nil
@@ -1153,7 +1341,7 @@ func TestCompositeResourceClaimSpecComposition(t *testing.T) {
 		},
 		"GetCompositionNotFound": {
 			reason: "If the composition is not found we return nil",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(errNotFound),
 				}, nil
@@ -1170,7 +1358,7 @@ func TestCompositeResourceClaimSpecComposition(t *testing.T) {
 		},
 		"Success": {
 			reason: "If we can get and model the composition we should return it.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(nil),
 				}, nil
@@ -1304,7 +1492,7 @@ func TestCompositeResourceClaimSpecResource(t *testing.T) {
 		},
 		"GetClientError": {
 			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, errBoom
 			}),
 			args: args{
@@ -1321,7 +1509,7 @@ func TestCompositeResourceClaimSpecResource(t *testing.T) {
 		},
 		"GetResourceError": {
 			reason: "If we can't get the resource we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(errBoom),
 				}, nil
@@ -1340,7 +1528,7 @@ func TestCompositeResourceClaimSpecResource(t *testing.T) {
 		},
 		"GetResourceNotFound": {
 			reason: "If the resource is not found we return nil",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(errNotFound),
 				}, nil
@@ -1357,7 +1545,7 @@ func TestCompositeResourceClaimSpecResource(t *testing.T) {
 		},
 		"Success": {
 			reason: "If we can get and model the resource we should return it.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(nil),
 				}, nil
@@ -1384,13 +1572,13 @@ func TestCompositeResourceClaimSpecResource(t *testing.T) {
 			errs := graphql.GetErrors(tc.args.ctx)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("\n%s\ns.Claim(...): -want error, +got error:\n%s\n", tc.reason, diff)
+				t.Errorf("\n%s\ns.Resource(...): -want error, +got error:\n%s\n", tc.reason, diff)
 			}
 			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
-				t.Errorf("\n%s\ns.Claim(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+				t.Errorf("\n%s\ns.Resource(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
 			}
 			if diff := cmp.Diff(tc.want.xr, got, cmpopts.IgnoreFields(model.CompositeResource{}, "PavedAccess"), cmpopts.IgnoreUnexported(model.ObjectMeta{})); diff != "" {
-				t.Errorf("\n%s\ns.Claim(...): -want, +got:\n%s\n", tc.reason, diff)
+				t.Errorf("\n%s\ns.Resource(...): -want, +got:\n%s\n", tc.reason, diff)
 			}
 		})
 	}
@@ -1493,7 +1681,7 @@ func TestCompositeResourceClaimSpecConnectionSecret(t *testing.T) {
 		},
 		"GetClientError": {
 			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, errBoom
 			}),
 			args: args{
@@ -1510,7 +1698,7 @@ func TestCompositeResourceClaimSpecConnectionSecret(t *testing.T) {
 		},
 		"GetSecretError": {
 			reason: "If we can't get the secret we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(errBoom),
 				}, nil
@@ -1529,7 +1717,7 @@ func TestCompositeResourceClaimSpecConnectionSecret(t *testing.T) {
 		},
 		"GetSecretNotFound": {
 			reason: "If the secret is not found we return nil",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(errNotFound),
 				}, nil
@@ -1546,7 +1734,7 @@ func TestCompositeResourceClaimSpecConnectionSecret(t *testing.T) {
 		},
 		"Success": {
 			reason: "If we can get and model the secret we should return it.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(nil),
 				}, nil
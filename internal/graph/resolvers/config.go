@@ -22,6 +22,18 @@ import (
 type Config struct {
 	GlobalEventsTarget int
 	GlobalEventsCap    int
+
+	// MaxTreeDepth is the maximum depth of a resource tree, where a tree's
+	// root is at depth zero.
+	MaxTreeDepth int
+
+	// TreeConcurrency is the maximum number of resources each resource tree
+	// reads concurrently.
+	TreeConcurrency int
+
+	// FieldManager is the name of the field manager used by server-side
+	// apply patches.
+	FieldManager string
 }
 
 type configKeyType int
@@ -40,6 +52,9 @@ func FromConfig(ctx context.Context) *Config {
 		return &Config{
 			GlobalEventsTarget: 500,
 			GlobalEventsCap:    1000,
+			MaxTreeDepth:       10,
+			TreeConcurrency:    treeWorkers,
+			FieldManager:       "xgql",
 		}
 	}
 	return c
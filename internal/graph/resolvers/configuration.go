@@ -58,7 +58,7 @@ func (r *configuration) Revisions(ctx context.Context, obj *model.Configuration)
 	defer cancel()
 
 	creds, _ := auth.FromContext(ctx)
-	c, err := r.clients.Get(creds)
+	c, err := r.clients.GetWithContext(ctx, creds)
 	if err != nil {
 		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
 		return model.ConfigurationRevisionConnection{}, nil
@@ -97,7 +97,7 @@ func (r *configuration) ActiveRevision(ctx context.Context, obj *model.Configura
 	defer cancel()
 
 	creds, _ := auth.FromContext(ctx)
-	c, err := r.clients.Get(creds)
+	c, err := r.clients.GetWithContext(ctx, creds)
 	if err != nil {
 		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
 		return nil, nil
@@ -154,7 +154,7 @@ func (r *configurationRevisionStatus) Objects(ctx context.Context, obj *model.Co
 	defer cancel()
 
 	creds, _ := auth.FromContext(ctx)
-	c, err := r.clients.Get(creds)
+	c, err := r.clients.GetWithContext(ctx, creds)
 	if err != nil {
 		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
 		return model.KubernetesResourceConnection{}, nil
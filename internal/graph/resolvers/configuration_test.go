@@ -93,7 +93,7 @@ func TestConfigurationRevisions(t *testing.T) {
 	}{
 		"GetClientError": {
 			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, errBoom
 			}),
 			args: args{
@@ -107,7 +107,7 @@ func TestConfigurationRevisions(t *testing.T) {
 		},
 		"ListRevisionsError": {
 			reason: "If we can't list revisions we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(errBoom),
 				}, nil
@@ -123,7 +123,7 @@ func TestConfigurationRevisions(t *testing.T) {
 		},
 		"AllRevisions": {
 			reason: "We should successfully return any revisions we own that we can list and model.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
 						*obj.(*pkgv1.ConfigurationRevisionList) = pkgv1.ConfigurationRevisionList{
@@ -218,7 +218,7 @@ func TestConfigurationActiveRevision(t *testing.T) {
 	}{
 		"GetClientError": {
 			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, errBoom
 			}),
 			args: args{
@@ -232,7 +232,7 @@ func TestConfigurationActiveRevision(t *testing.T) {
 		},
 		"ListRevisionsError": {
 			reason: "If we can't list revisions we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(errBoom),
 				}, nil
@@ -248,7 +248,7 @@ func TestConfigurationActiveRevision(t *testing.T) {
 		},
 		"FoundActiveRevision": {
 			reason: "We should successfully return the active revision.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
 						*obj.(*pkgv1.ConfigurationRevisionList) = pkgv1.ConfigurationRevisionList{
@@ -270,7 +270,7 @@ func TestConfigurationActiveRevision(t *testing.T) {
 		},
 		"NoActiveRevision": {
 			reason: "If there is no active revision we should return nil.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
 						*obj.(*pkgv1.ConfigurationRevisionList) = pkgv1.ConfigurationRevisionList{
@@ -338,7 +338,7 @@ func TestConfigurationRevisionStatusObjects(t *testing.T) {
 	}{
 		"GetClientError": {
 			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, errBoom
 			}),
 			args: args{
@@ -352,7 +352,7 @@ func TestConfigurationRevisionStatusObjects(t *testing.T) {
 		},
 		"UnknownObject": {
 			reason: "We should not attempt to get an object that doesn't seem to be part of the API extensions group.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, nil
 			}),
 			args: args{
@@ -374,7 +374,7 @@ func TestConfigurationRevisionStatusObjects(t *testing.T) {
 		},
 		"GetXRDError": {
 			reason: "If we can't get an XRD we should add the error to the GraphQL context and continue.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
 						if _, ok := obj.(*extv1.CompositeResourceDefinition); ok {
@@ -413,7 +413,7 @@ func TestConfigurationRevisionStatusObjects(t *testing.T) {
 		},
 		"GetCompositionError": {
 			reason: "If we can't get a Composition we should add the error to the GraphQL context and continue.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
 						if _, ok := obj.(*extv1.Composition); ok {
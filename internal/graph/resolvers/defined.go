@@ -0,0 +1,65 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolvers
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	extv1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+
+	"github.com/upbound/xgql/internal/auth"
+	"github.com/upbound/xgql/internal/clients"
+)
+
+const errGetDefinedKinds = "cannot get kinds defined by composite resource definitions"
+
+// getDefinedKinds returns the composite resource and claim kinds defined by
+// XRDs. It uses the supplied DefinedKindCache if there is one, and otherwise
+// lists XRDs using the supplied client.
+func getDefinedKinds(ctx context.Context, kc DefinedKindCache, c client.Client, cr auth.Credentials) (clients.DefinedKinds, error) {
+	if kc != nil {
+		k, err := kc.DefinedKinds(ctx, cr)
+		return k, errors.Wrap(err, errGetDefinedKinds)
+	}
+
+	in := &extv1.CompositeResourceDefinitionList{}
+	if err := c.List(ctx, in); err != nil {
+		return clients.DefinedKinds{}, errors.Wrap(err, errListXRDs)
+	}
+	return clients.GetDefinedKinds(in.Items), nil
+}
+
+// listDefined lists resources of each of the supplied kinds. The caller may
+// not be able to list every kind, so rather than failing we add an error to
+// the GraphQL context for each kind that can't be listed and return the
+// resources of the kinds that can.
+func listDefined(ctx context.Context, c client.Client, kinds []clients.DefinedKind, opts ...client.ListOption) []kunstructured.Unstructured {
+	out := make([]kunstructured.Unstructured, 0)
+	for _, k := range kinds {
+		in := &kunstructured.UnstructuredList{}
+		in.SetGroupVersionKind(k.ListGroupVersionKind())
+		if err := c.List(ctx, in, opts...); err != nil {
+			graphql.AddError(ctx, errors.Wrapf(err, errFmtListDefined, k.GroupVersionKind))
+			continue
+		}
+		out = append(out, in.Items...)
+	}
+	return out
+}
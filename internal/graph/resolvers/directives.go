@@ -0,0 +1,162 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolvers
+
+import (
+	"context"
+	"sync"
+
+	"github.com/99designs/gqlgen/graphql"
+	authv1 "k8s.io/api/authorization/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+
+	"github.com/upbound/xgql/internal/auth"
+	"github.com/upbound/xgql/internal/graph/generated"
+)
+
+const errFmtAccessDenied = "access denied: cannot %s %s"
+
+// Directives returns the implementations of the schema's directives.
+func (r *Root) Directives() generated.DirectiveRoot {
+	return generated.DirectiveRoot{
+		RequiresAccess: (&directives{clients: r.clients}).RequiresAccess,
+	}
+}
+
+type directives struct {
+	clients ClientCache
+}
+
+// RequiresAccess resolves the field only if the caller may perform the
+// supplied verb upon the supplied resource.
+func (d *directives) RequiresAccess(ctx context.Context, _ interface{}, next graphql.Resolver, verb string, group *string, resource string) (interface{}, error) {
+	ra := accessAttributes{verb: verb, group: ptr.Deref(group, ""), resource: resource}
+
+	allowed, err := reviewsFrom(ctx).Get(ra, func() (bool, error) {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		creds, _ := auth.FromContext(ctx)
+		c, err := d.clients.GetWithContext(ctx, creds)
+		if err != nil {
+			return false, errors.Wrap(err, errGetClient)
+		}
+
+		ar := &authv1.SelfSubjectAccessReview{
+			Spec: authv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authv1.ResourceAttributes{Verb: ra.verb, Group: ra.group, Resource: ra.resource},
+			},
+		}
+		if err := c.Create(ctx, ar); err != nil {
+			return false, errors.Wrap(err, errReviewAccess)
+		}
+		return ar.Status.Allowed, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, errors.Errorf(errFmtAccessDenied, ra.verb, ra.String())
+	}
+	return next(ctx)
+}
+
+// accessAttributes are the attributes of a reviewed action.
+type accessAttributes struct {
+	verb     string
+	group    string
+	resource string
+}
+
+func (a accessAttributes) String() string {
+	if a.group == "" {
+		return a.resource
+	}
+	return a.resource + "." + a.group
+}
+
+// An accessReview is the cached result of reviewing an action.
+type accessReview struct {
+	once    sync.Once
+	allowed bool
+	err     error
+}
+
+// accessReviews are the access reviews made during a single operation.
+type accessReviews struct {
+	mx sync.Mutex
+	m  map[accessAttributes]*accessReview
+}
+
+// Get the result of reviewing the supplied action, calling review if it hasn't
+// yet been reviewed. Concurrent callers wait for a single review. A nil
+// accessReviews doesn't cache.
+func (rs *accessReviews) Get(ra accessAttributes, review func() (bool, error)) (bool, error) {
+	if rs == nil {
+		return review()
+	}
+
+	rs.mx.Lock()
+	r, ok := rs.m[ra]
+	if !ok {
+		r = &accessReview{}
+		rs.m[ra] = r
+	}
+	rs.mx.Unlock()
+
+	r.once.Do(func() { r.allowed, r.err = review() })
+	return r.allowed, r.err
+}
+
+type accessReviewsKeyType int
+
+const accessReviewsKey accessReviewsKeyType = iota
+
+func withAccessReviews(ctx context.Context) context.Context {
+	return context.WithValue(ctx, accessReviewsKey, &accessReviews{m: make(map[accessAttributes]*accessReview)})
+}
+
+func reviewsFrom(ctx context.Context) *accessReviews {
+	rs, _ := ctx.Value(accessReviewsKey).(*accessReviews)
+	return rs
+}
+
+// AccessReviewCache is a GraphQL handler extension that caches the access
+// reviews made by the @requiresAccess directive for the duration of each
+// operation. Reviews are made for every field the directive gates if this
+// extension isn't used.
+type AccessReviewCache struct{}
+
+var (
+	_ graphql.HandlerExtension     = AccessReviewCache{}
+	_ graphql.OperationInterceptor = AccessReviewCache{}
+)
+
+// ExtensionName returns the name of the extension.
+func (AccessReviewCache) ExtensionName() string {
+	return "AccessReviewCache"
+}
+
+// Validate the extension.
+func (AccessReviewCache) Validate(_ graphql.ExecutableSchema) error {
+	return nil
+}
+
+// InterceptOperation adds an access review cache to the operation's context.
+func (AccessReviewCache) InterceptOperation(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	return next(withAccessReviews(ctx))
+}
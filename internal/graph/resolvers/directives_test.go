@@ -0,0 +1,212 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolvers
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	gqlclient "github.com/99designs/gqlgen/client"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/google/go-cmp/cmp"
+	authv1 "k8s.io/api/authorization/v1"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/upbound/xgql/internal/auth"
+	"github.com/upbound/xgql/internal/clients"
+	"github.com/upbound/xgql/internal/graph/generated"
+	"github.com/upbound/xgql/internal/graph/model"
+)
+
+func TestRequiresAccess(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	// Allow only listing XRDs.
+	review := func(obj client.Object) error {
+		ra := obj.(*authv1.SelfSubjectAccessReview).Spec.ResourceAttributes
+		obj.(*authv1.SelfSubjectAccessReview).Status.Allowed = ra.Verb == "list" && ra.Group == "apiextensions.crossplane.io" && ra.Resource == "compositeresourcedefinitions"
+		return nil
+	}
+
+	type args struct {
+		ctx      context.Context
+		verb     string
+		group    *string
+		resource string
+		calls    int
+	}
+	type want struct {
+		res     interface{}
+		err     error
+		reviews int
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients func(reviews *int) ClientCache
+		args    args
+		want    want
+	}{
+		"GetClientError": {
+			reason: "If we can't get a client we should return an error without resolving the field.",
+			clients: func(_ *int) ClientCache {
+				return ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+					return nil, errBoom
+				})
+			},
+			args: args{ctx: context.Background(), verb: "get", resource: "secrets", calls: 1},
+			want: want{err: errors.Wrap(errBoom, errGetClient)},
+		},
+		"ReviewError": {
+			reason: "If we can't review access we should return an error without resolving the field.",
+			clients: func(reviews *int) ClientCache {
+				return ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+					return &test.MockClient{MockCreate: test.NewMockCreateFn(nil, func(_ client.Object) error {
+						*reviews++
+						return errBoom
+					})}, nil
+				})
+			},
+			args: args{ctx: context.Background(), verb: "get", resource: "secrets", calls: 1},
+			want: want{err: errors.Wrap(errBoom, errReviewAccess), reviews: 1},
+		},
+		"Denied": {
+			reason: "If the caller may not perform the action we should return an error without resolving the field.",
+			clients: func(reviews *int) ClientCache {
+				return ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+					return &test.MockClient{MockCreate: test.NewMockCreateFn(nil, func(obj client.Object) error {
+						*reviews++
+						return review(obj)
+					})}, nil
+				})
+			},
+			args: args{ctx: context.Background(), verb: "get", resource: "secrets", calls: 1},
+			want: want{err: errors.Errorf(errFmtAccessDenied, "get", "secrets"), reviews: 1},
+		},
+		"Allowed": {
+			reason: "If the caller may perform the action we should resolve the field.",
+			clients: func(reviews *int) ClientCache {
+				return ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+					return &test.MockClient{MockCreate: test.NewMockCreateFn(nil, func(obj client.Object) error {
+						*reviews++
+						return review(obj)
+					})}, nil
+				})
+			},
+			args: args{ctx: context.Background(), verb: "list", group: ptr.To("apiextensions.crossplane.io"), resource: "compositeresourcedefinitions", calls: 1},
+			want: want{res: "resolved", reviews: 1},
+		},
+		"Uncached": {
+			reason: "We should review access for every field if there's no access review cache in the context.",
+			clients: func(reviews *int) ClientCache {
+				return ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+					return &test.MockClient{MockCreate: test.NewMockCreateFn(nil, func(obj client.Object) error {
+						*reviews++
+						return review(obj)
+					})}, nil
+				})
+			},
+			args: args{ctx: context.Background(), verb: "list", group: ptr.To("apiextensions.crossplane.io"), resource: "compositeresourcedefinitions", calls: 3},
+			want: want{res: "resolved", reviews: 3},
+		},
+		"Cached": {
+			reason: "We should review access once per operation if there's an access review cache in the context.",
+			clients: func(reviews *int) ClientCache {
+				return ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+					return &test.MockClient{MockCreate: test.NewMockCreateFn(nil, func(obj client.Object) error {
+						*reviews++
+						return review(obj)
+					})}, nil
+				})
+			},
+			args: args{ctx: withAccessReviews(context.Background()), verb: "list", group: ptr.To("apiextensions.crossplane.io"), resource: "compositeresourcedefinitions", calls: 3},
+			want: want{res: "resolved", reviews: 1},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			reviews := 0
+			d := New(tc.clients(&reviews)).Directives()
+			next := func(_ context.Context) (interface{}, error) { return "resolved", nil }
+
+			var (
+				got interface{}
+				err error
+			)
+			for i := 0; i < tc.args.calls; i++ {
+				got, err = d.RequiresAccess(tc.args.ctx, nil, next, tc.args.verb, tc.args.group, tc.args.resource)
+			}
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nd.RequiresAccess(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.res, got); diff != "" {
+				t.Errorf("\n%s\nd.RequiresAccess(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.reviews, reviews); diff != "" {
+				t.Errorf("\n%s\nd.RequiresAccess(...): -want reviews, +got reviews:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestTreeRequiresAccess(t *testing.T) {
+	xr := model.ReferenceID{APIVersion: "example.org/v1", Kind: "XCool", Name: "cool"}
+
+	reviewed := &authv1.ResourceAttributes{}
+	c := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			u := obj.(*kunstructured.Unstructured)
+			u.SetName(xr.Name)
+			u.Object["spec"] = map[string]interface{}{"compositionRef": map[string]interface{}{"name": "cool"}}
+			return nil
+		}),
+		MockCreate: test.NewMockCreateFn(nil, func(obj client.Object) error {
+			reviewed = obj.(*authv1.SelfSubjectAccessReview).Spec.ResourceAttributes
+			return nil
+		}),
+	}
+	rs := New(ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+		return c, nil
+	}))
+	gc := gqlclient.New(handler.NewDefaultServer(generated.NewExecutableSchema(generated.Config{Resolvers: rs, Directives: rs.Directives()})))
+
+	q := `query($id: ID!) { kubernetesResource(id: $id) { ... on CompositeResource { tree { id } } } }`
+	rsp, err := gc.RawPost(q, gqlclient.Var("id", xr.String()))
+	if err != nil {
+		t.Fatalf("RawPost(...): %v", err)
+	}
+
+	errs := []struct{ Message string }{}
+	if err := json.Unmarshal(rsp.Errors, &errs); err != nil {
+		t.Fatalf("json.Unmarshal(...): %v", err)
+	}
+
+	want := []struct{ Message string }{{Message: errors.Errorf(errFmtAccessDenied, "list", "compositeresourcedefinitions.apiextensions.crossplane.io").Error()}}
+	if diff := cmp.Diff(want, errs); diff != "" {
+		t.Errorf("A caller who may not list XRDs should not be able to walk a composite resource's tree: -want errors, +got errors:\n%s", diff)
+	}
+	wantReviewed := &authv1.ResourceAttributes{Verb: "list", Group: "apiextensions.crossplane.io", Resource: "compositeresourcedefinitions"}
+	if diff := cmp.Diff(wantReviewed, reviewed); diff != "" {
+		t.Errorf("RawPost(...): -want reviewed attributes, +got reviewed attributes:\n%s", diff)
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolvers
+
+import (
+	"context"
+	"sort"
+
+	"github.com/99designs/gqlgen/graphql"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	extv1alpha1 "github.com/crossplane/crossplane/apis/apiextensions/v1alpha1"
+
+	"github.com/upbound/xgql/internal/auth"
+	"github.com/upbound/xgql/internal/graph/model"
+)
+
+const (
+	errListEnvironmentConfigs = "cannot list environment configs"
+)
+
+type environmentConfigs struct {
+	clients ClientCache
+}
+
+// Resolve the environment configs with the supplied names, or all environment
+// configs if names is nil. Environment configs are an alpha Crossplane API
+// that may not be enabled; if it isn't we return no environment configs.
+func (r *environmentConfigs) Resolve(ctx context.Context, names map[string]bool) (model.EnvironmentConfigConnection, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.GetWithContext(ctx, creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.EnvironmentConfigConnection{}, nil
+	}
+
+	in := &extv1alpha1.EnvironmentConfigList{}
+	if err := c.List(ctx, in); err != nil {
+		if meta.IsNoMatchError(err) || kerrors.IsNotFound(err) {
+			return model.EnvironmentConfigConnection{Nodes: make([]model.EnvironmentConfig, 0)}, nil
+		}
+		graphql.AddError(ctx, errors.Wrap(err, errListEnvironmentConfigs))
+		return model.EnvironmentConfigConnection{}, nil
+	}
+
+	out := &model.EnvironmentConfigConnection{
+		Nodes: make([]model.EnvironmentConfig, 0, len(in.Items)),
+	}
+
+	for i := range in.Items {
+		ec := &in.Items[i] // So we don't take the address of a range variable.
+
+		if names != nil && !names[ec.GetName()] {
+			continue
+		}
+
+		out.Nodes = append(out.Nodes, model.GetEnvironmentConfig(ec))
+		out.TotalCount++
+	}
+
+	sort.Stable(out)
+	return *out, nil
+}
+
+type environmentConfig struct {
+	clients ClientCache
+}
+
+func (r *environmentConfig) Events(ctx context.Context, obj *model.EnvironmentConfig) (model.EventConnection, error) {
+	e := &events{clients: r.clients}
+	return e.Resolve(ctx, &corev1.ObjectReference{
+		APIVersion: obj.APIVersion,
+		Kind:       obj.Kind,
+		Name:       obj.Metadata.Name,
+		UID:        types.UID(obj.Metadata.UID),
+	})
+}
@@ -0,0 +1,228 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolvers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	corev1 "k8s.io/api/core/v1"
+	kextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	extv1alpha1 "github.com/crossplane/crossplane/apis/apiextensions/v1alpha1"
+
+	"github.com/upbound/xgql/internal/auth"
+	"github.com/upbound/xgql/internal/clients"
+	"github.com/upbound/xgql/internal/graph/generated"
+	"github.com/upbound/xgql/internal/graph/model"
+)
+
+var _ generated.EnvironmentConfigResolver = &environmentConfig{}
+
+func TestEnvironmentConfigsResolve(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	eca := extv1alpha1.EnvironmentConfig{}
+	eca.SetName("a")
+	eca.Data = map[string]kextv1.JSON{"region": {Raw: []byte(`"us-east-1"`)}}
+	geca := model.GetEnvironmentConfig(&eca)
+
+	ecb := extv1alpha1.EnvironmentConfig{}
+	ecb.SetName("b")
+	gecb := model.GetEnvironmentConfig(&ecb)
+
+	list := func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+		*obj.(*extv1alpha1.EnvironmentConfigList) = extv1alpha1.EnvironmentConfigList{Items: []extv1alpha1.EnvironmentConfig{ecb, eca}}
+		return nil
+	}
+
+	type args struct {
+		ctx   context.Context
+		names map[string]bool
+	}
+	type want struct {
+		ecc  model.EnvironmentConfigConnection
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		args    args
+		want    want
+	}{
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, errBoom
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"ListEnvironmentConfigsError": {
+			reason: "If we can't list environment configs we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(errBoom),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errListEnvironmentConfigs)),
+				},
+			},
+		},
+		"EnvironmentConfigAPINotEnabled": {
+			reason: "If the EnvironmentConfig API isn't enabled we should return no environment configs without an error.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(&meta.NoKindMatchError{GroupKind: extv1alpha1.EnvironmentConfigGroupVersionKind.GroupKind()}),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				ecc: model.EnvironmentConfigConnection{
+					Nodes: []model.EnvironmentConfig{},
+				},
+			},
+		},
+		"AllEnvironmentConfigs": {
+			reason: "If no names are supplied we should return all environment configs.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: list,
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				ecc: model.EnvironmentConfigConnection{
+					Nodes:      []model.EnvironmentConfig{geca, gecb},
+					TotalCount: 2,
+				},
+			},
+		},
+		"NamedEnvironmentConfigs": {
+			reason: "If names are supplied we should only return the environment configs with those names.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: list,
+				}, nil
+			}),
+			args: args{
+				ctx:   graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				names: map[string]bool{"a": true},
+			},
+			want: want{
+				ecc: model.EnvironmentConfigConnection{
+					Nodes:      []model.EnvironmentConfig{geca},
+					TotalCount: 1,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ec := &environmentConfigs{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := ec.Resolve(tc.args.ctx, tc.args.names)
+			errs := graphql.GetErrors(tc.args.ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nec.Resolve(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nec.Resolve(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.ecc, got, cmpopts.IgnoreFields(model.EnvironmentConfig{}, "PavedAccess"), cmpopts.IgnoreUnexported(model.ObjectMeta{})); diff != "" {
+				t.Errorf("\n%s\nec.Resolve(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCompositeResourceSpecEnvironmentConfigs(t *testing.T) {
+	eca := extv1alpha1.EnvironmentConfig{}
+	eca.SetName("a")
+	ecb := extv1alpha1.EnvironmentConfig{}
+	ecb.SetName("b")
+
+	c := ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+		return &test.MockClient{
+			MockList: func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+				*obj.(*extv1alpha1.EnvironmentConfigList) = extv1alpha1.EnvironmentConfigList{Items: []extv1alpha1.EnvironmentConfig{eca, ecb}}
+				return nil
+			},
+		}, nil
+	})
+
+	cases := map[string]struct {
+		reason string
+		obj    *model.CompositeResourceSpec
+		want   model.EnvironmentConfigConnection
+	}{
+		"NoEnvironmentConfigRefs": {
+			reason: "A composite resource that selects no environment configs should have none.",
+			obj:    &model.CompositeResourceSpec{},
+			want:   model.EnvironmentConfigConnection{Nodes: []model.EnvironmentConfig{}},
+		},
+		"EnvironmentConfigRefs": {
+			reason: "We should return the environment configs a composite resource references.",
+			obj: &model.CompositeResourceSpec{
+				EnvironmentConfigReferences: []corev1.ObjectReference{{Name: "b"}, {Name: ""}},
+			},
+			want: model.EnvironmentConfigConnection{
+				Nodes:      []model.EnvironmentConfig{model.GetEnvironmentConfig(&ecb)},
+				TotalCount: 1,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &compositeResourceSpec{clients: c}
+
+			ctx := graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover)
+			got, _ := r.EnvironmentConfigs(ctx, tc.obj)
+			if diff := cmp.Diff(tc.want, got, cmpopts.IgnoreFields(model.EnvironmentConfig{}, "PavedAccess"), cmpopts.IgnoreUnexported(model.ObjectMeta{})); diff != "" {
+				t.Errorf("\n%s\nr.EnvironmentConfigs(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -45,7 +45,7 @@ func (r *events) Resolve(ctx context.Context, obj *corev1.ObjectReference) (mode
 	defer cancel()
 
 	creds, _ := auth.FromContext(ctx)
-	c, err := r.clients.Get(creds)
+	c, err := r.clients.GetWithContext(ctx, creds)
 	if err != nil {
 		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
 		return model.EventConnection{}, nil
@@ -144,7 +144,7 @@ func (r *event) InvolvedObject(ctx context.Context, obj *model.Event) (model.Kub
 	defer cancel()
 
 	creds, _ := auth.FromContext(ctx)
-	c, err := r.clients.Get(creds)
+	c, err := r.clients.GetWithContext(ctx, creds)
 	if err != nil {
 		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
 		return nil, nil
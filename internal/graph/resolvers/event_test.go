@@ -70,7 +70,7 @@ func TestEvent(t *testing.T) {
 	}{
 		"GetClientError": {
 			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, errBoom
 			}),
 			args: args{
@@ -84,7 +84,7 @@ func TestEvent(t *testing.T) {
 		},
 		"ListEventsError": {
 			reason: "If we can't list events we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(errBoom),
 				}, nil
@@ -100,7 +100,7 @@ func TestEvent(t *testing.T) {
 		},
 		"ListAllEvents": {
 			reason: "We should successfully return events for all resources if the involved object is nil.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
 						*obj.(*corev1.EventList) = corev1.EventList{Items: []corev1.Event{related, unrelated}}
@@ -120,7 +120,7 @@ func TestEvent(t *testing.T) {
 		},
 		"ListEventsInvolving": {
 			reason: "We should successfully return events for all resources if the involved object is nil.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
 						*obj.(*corev1.EventList) = corev1.EventList{Items: []corev1.Event{related, unrelated}}
@@ -292,7 +292,7 @@ func TestEventInvolvedObject(t *testing.T) {
 	}{
 		"GetClientError": {
 			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, errBoom
 			}),
 			args: args{
@@ -309,7 +309,7 @@ func TestEventInvolvedObject(t *testing.T) {
 		},
 		"GetInvolvedError": {
 			reason: "If we can't get the involved object we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(errBoom),
 				}, nil
@@ -328,7 +328,7 @@ func TestEventInvolvedObject(t *testing.T) {
 		},
 		"Success": {
 			reason: "If we can get and model the involved object we should return it.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(nil),
 				}, nil
@@ -0,0 +1,84 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolvers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/google/go-cmp/cmp"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// withSelection returns a context in which the field being resolved selects
+// the supplied subfields.
+func withSelection(ctx context.Context, fields ...string) context.Context {
+	ss := make(ast.SelectionSet, 0, len(fields))
+	for _, f := range fields {
+		ss = append(ss, &ast.Field{Name: f, Alias: f})
+	}
+	ctx = graphql.WithOperationContext(ctx, &graphql.OperationContext{})
+	return graphql.WithFieldContext(ctx, &graphql.FieldContext{
+		Field: graphql.CollectedField{Field: &ast.Field{}, Selections: ss},
+	})
+}
+
+func TestSelected(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		field string
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   bool
+	}{
+		"NoOperation": {
+			reason: "Fields should be considered selected when we're not resolving a GraphQL operation.",
+			args: args{
+				ctx:   context.Background(),
+				field: fieldNodes,
+			},
+			want: true,
+		},
+		"Selected": {
+			reason: "A field that appears in the selection set should be considered selected.",
+			args: args{
+				ctx:   withSelection(context.Background(), "totalCount", fieldNodes),
+				field: fieldNodes,
+			},
+			want: true,
+		},
+		"NotSelected": {
+			reason: "A field that does not appear in the selection set should not be considered selected.",
+			args: args{
+				ctx:   withSelection(context.Background(), "totalCount"),
+				field: fieldNodes,
+			},
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := selected(tc.args.ctx, tc.args.field)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nselected(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolvers
+
+import (
+	"context"
+	"sort"
+
+	"github.com/99designs/gqlgen/graphql"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	pkgv1 "github.com/crossplane/crossplane/apis/pkg/v1"
+	pkgv1beta1 "github.com/crossplane/crossplane/apis/pkg/v1beta1"
+
+	"github.com/upbound/xgql/internal/auth"
+	"github.com/upbound/xgql/internal/graph/model"
+)
+
+const (
+	errListFunctionRevs = "cannot list function revisions"
+)
+
+type function struct {
+	clients ClientCache
+}
+
+// listFunctions lists functions. Crossplane serves functions at
+// pkg.crossplane.io/v1 from v1.17, and only at pkg.crossplane.io/v1beta1
+// before that, so we fall back to v1beta1 if v1 isn't served.
+func listFunctions(ctx context.Context, c client.Reader, l *pkgv1.FunctionList) error {
+	return listFallback(ctx, c, l, &pkgv1beta1.FunctionList{})
+}
+
+// listFunctionRevisions lists function revisions, falling back to v1beta1 like
+// listFunctions.
+func listFunctionRevisions(ctx context.Context, c client.Reader, l *pkgv1.FunctionRevisionList) error {
+	return listFallback(ctx, c, l, &pkgv1beta1.FunctionRevisionList{})
+}
+
+// listFallback lists into l. If l's API version isn't served it lists into
+// fallback instead, then converts fallback into l. The two must share a
+// schema.
+func listFallback(ctx context.Context, c client.Reader, l, fallback client.ObjectList) error {
+	err := c.List(ctx, l)
+	if !meta.IsNoMatchError(err) {
+		return err
+	}
+	if err := c.List(ctx, fallback); err != nil {
+		return err
+	}
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(fallback)
+	if err != nil {
+		return err
+	}
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(u, l)
+}
+
+func (r *function) Events(ctx context.Context, obj *model.Function) (model.EventConnection, error) {
+	e := &events{clients: r.clients}
+	return e.Resolve(ctx, &corev1.ObjectReference{
+		APIVersion: obj.APIVersion,
+		Kind:       obj.Kind,
+		Name:       obj.Metadata.Name,
+		UID:        types.UID(obj.Metadata.UID),
+	})
+}
+
+func (r *function) Revisions(ctx context.Context, obj *model.Function) (model.FunctionRevisionConnection, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.GetWithContext(ctx, creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.FunctionRevisionConnection{}, nil
+	}
+
+	in := &pkgv1.FunctionRevisionList{}
+	if err := listFunctionRevisions(ctx, c, in); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errListFunctionRevs))
+		return model.FunctionRevisionConnection{}, nil
+	}
+
+	out := &model.FunctionRevisionConnection{
+		Nodes: make([]model.FunctionRevision, 0),
+	}
+
+	for i := range in.Items {
+		fr := in.Items[i] // So we don't take the address of a range variable.
+
+		// We're not the controller reference of this FunctionRevision; it's not
+		// one of ours.
+		// https://github.com/kubernetes/community/blob/0331e/contributors/design-proposals/api-machinery/controller-ref.md
+		if c := metav1.GetControllerOf(&fr); c == nil || c.UID != types.UID(obj.Metadata.UID) {
+			continue
+		}
+
+		out.Nodes = append(out.Nodes, model.GetFunctionRevision(&fr))
+		out.TotalCount++
+	}
+
+	sort.Stable(out)
+	return *out, nil
+}
+
+func (r *function) ActiveRevision(ctx context.Context, obj *model.Function) (*model.FunctionRevision, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.GetWithContext(ctx, creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return nil, nil
+	}
+
+	in := &pkgv1.FunctionRevisionList{}
+	if err := listFunctionRevisions(ctx, c, in); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errListFunctionRevs))
+		return nil, nil
+	}
+
+	for i := range in.Items {
+		fr := in.Items[i] // So we don't take the address of a range variable.
+
+		// This revision is not active.
+		if fr.Spec.DesiredState != pkgv1.PackageRevisionActive {
+			continue
+		}
+
+		// We're not the controller reference of this FunctionRevision;
+		// it's not one of ours.
+		// https://github.com/kubernetes/community/blob/0331e/contributors/design-proposals/api-machinery/controller-ref.md
+		if c := metav1.GetControllerOf(&fr); c == nil || c.UID != types.UID(obj.Metadata.UID) {
+			continue
+		}
+
+		out := model.GetFunctionRevision(&fr)
+		return &out, nil
+	}
+
+	return nil, nil
+}
+
+type functionRevision struct {
+	clients ClientCache
+}
+
+func (r *functionRevision) Events(ctx context.Context, obj *model.FunctionRevision) (model.EventConnection, error) {
+	e := &events{clients: r.clients}
+	return e.Resolve(ctx, &corev1.ObjectReference{
+		APIVersion: obj.APIVersion,
+		Kind:       obj.Kind,
+		Name:       obj.Metadata.Name,
+		UID:        types.UID(obj.Metadata.UID),
+	})
+}
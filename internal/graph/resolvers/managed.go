@@ -18,12 +18,17 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/99designs/gqlgen/graphql"
 	corev1 "k8s.io/api/core/v1"
+	kextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 
@@ -33,11 +38,18 @@ import (
 )
 
 const (
-	errListCRDs = "cannot list custom resource definitions"
+	errListCRDs          = "cannot list custom resource definitions"
+	errGetProviderConfig = "cannot get provider config"
+	errDiffSpec          = "cannot compare last applied and current spec"
 )
 
+// The name of the provider config used by managed resources that don't
+// reference one.
+const defaultProviderConfigName = "default"
+
 type managedResource struct {
 	clients ClientCache
+	kinds   *providerConfigKinds
 }
 
 func (r *managedResource) Events(ctx context.Context, obj *model.ManagedResource) (model.EventConnection, error) {
@@ -58,7 +70,7 @@ func (r *managedResource) Definition(ctx context.Context, obj *model.ManagedReso
 	defer cancel()
 
 	creds, _ := auth.FromContext(ctx)
-	c, err := r.clients.Get(creds)
+	c, err := r.clients.GetWithContext(ctx, creds)
 	if err != nil {
 		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
 		return nil, nil
@@ -118,6 +130,166 @@ func (r *managedResource) Definition(ctx context.Context, obj *model.ManagedReso
 	return nil, nil
 }
 
+func (r *managedResource) Diff(ctx context.Context, obj *model.ManagedResource) ([]model.SpecDifference, error) {
+	d, err := model.GetSpecDifferences(obj.Paved)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errDiffSpec))
+		return nil, nil
+	}
+	return d, nil
+}
+
+func (r *managedResource) ProviderConfig(ctx context.Context, obj *model.ManagedResource) (*model.ProviderConfig, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.GetWithContext(ctx, creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return nil, nil
+	}
+
+	gv, err := schema.ParseGroupVersion(obj.APIVersion)
+	if err != nil {
+		// This should be pretty much impossible - the API server should not
+		// return resources with malformed API versions.
+		graphql.AddError(ctx, errors.Wrap(err, errMalformedAPIVersion))
+		return nil, nil
+	}
+
+	// The kind of provider config a managed resource uses is defined by its
+	// provider, so we must find it by looking at the CRDs that are installed.
+	gvk, ok, err := r.kinds.Get(ctx, c, gv.Group)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetCRD))
+		return nil, nil
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	name := defaultProviderConfigName
+	if obj.Spec.ProviderConfigRef != nil && obj.Spec.ProviderConfigRef.Name != "" {
+		name = obj.Spec.ProviderConfigRef.Name
+	}
+
+	u := &kunstructured.Unstructured{}
+	u.SetGroupVersionKind(gvk)
+	if err := c.Get(ctx, types.NamespacedName{Name: name}, u); err != nil {
+		// A managed resource may reference a provider config that doesn't
+		// exist (yet), including the default provider config.
+		if !kerrors.IsNotFound(err) {
+			graphql.AddError(ctx, errors.Wrap(err, errGetProviderConfig))
+		}
+		return nil, nil
+	}
+
+	out := model.GetProviderConfig(u)
+	return &out, nil
+}
+
+func (r *managedResource) UsedBy(ctx context.Context, obj *model.ManagedResource) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.Resolve(ctx, usageOf(obj.APIVersion, obj.Kind, obj.Metadata.Name))
+}
+
+func (r *managedResource) Uses(ctx context.Context, obj *model.ManagedResource) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.Resolve(ctx, usageBy(obj.APIVersion, obj.Kind, obj.Metadata.Name))
+}
+
+// providerConfigKindTTL is how long the kind of provider config used by the
+// managed resources in an API group is cached. Providers rarely change it, but
+// may be installed, uninstalled, or upgraded at any time.
+const providerConfigKindTTL = 1 * time.Minute
+
+// providerConfigKinds caches the kind of provider config used by the managed
+// resources in each API group, so that resolving the provider configs of many
+// managed resources doesn't find the same kind for each of them. A nil
+// *providerConfigKinds caches nothing.
+type providerConfigKinds struct {
+	mx sync.RWMutex
+	m  map[string]providerConfigKind
+}
+
+type providerConfigKind struct {
+	gvk     schema.GroupVersionKind
+	found   bool
+	expires time.Time
+}
+
+func newProviderConfigKinds() *providerConfigKinds {
+	return &providerConfigKinds{m: make(map[string]providerConfigKind)}
+}
+
+// Get the GroupVersionKind of the provider config used by managed resources in
+// the supplied API group, using the supplied client if it isn't cached.
+func (k *providerConfigKinds) Get(ctx context.Context, c client.Reader, group string) (schema.GroupVersionKind, bool, error) {
+	if k == nil {
+		return providerConfigGVK(ctx, c, group)
+	}
+
+	k.mx.RLock()
+	pk, ok := k.m[group]
+	k.mx.RUnlock()
+	if ok && time.Now().Before(pk.expires) {
+		return pk.gvk, pk.found, nil
+	}
+
+	gvk, found, err := providerConfigGVK(ctx, c, group)
+	if err != nil {
+		return schema.GroupVersionKind{}, false, err
+	}
+
+	k.mx.Lock()
+	k.m[group] = providerConfigKind{gvk: gvk, found: found, expires: time.Now().Add(providerConfigKindTTL)}
+	k.mx.Unlock()
+	return gvk, found, nil
+}
+
+// providerConfigGVK returns the GroupVersionKind of the provider config used
+// by managed resources in the supplied API group. Providers define their
+// provider config either in their managed resources' API group, or in one of
+// its parent groups - e.g. managed resources in ec2.aws.upbound.io use provider
+// configs in aws.upbound.io. The most specific group wins.
+func providerConfigGVK(ctx context.Context, c client.Reader, group string) (schema.GroupVersionKind, bool, error) {
+	for g := group; strings.Contains(g, "."); g = g[strings.Index(g, ".")+1:] {
+		crd := unstructured.NewCRD()
+		err := c.Get(ctx, types.NamespacedName{Name: "providerconfigs." + g}, crd.GetUnstructured())
+		if kerrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return schema.GroupVersionKind{}, false, err
+		}
+		if crd.GetSpecNames().Kind != "ProviderConfig" || crd.GetSpecScope() != kextv1.ClusterScoped {
+			continue
+		}
+		v := storageVersion(crd.GetSpecVersions())
+		if v == "" {
+			continue
+		}
+		return schema.GroupVersionKind{Group: g, Version: v, Kind: "ProviderConfig"}, true, nil
+	}
+	return schema.GroupVersionKind{}, false, nil
+}
+
+// storageVersion returns the storage version of the supplied CRD versions, or
+// the first served version if no version is marked as the storage version.
+func storageVersion(vs []kextv1.CustomResourceDefinitionVersion) string {
+	served := ""
+	for _, v := range vs {
+		if v.Storage {
+			return v.Name
+		}
+		if v.Served && served == "" {
+			served = v.Name
+		}
+	}
+	return served
+}
+
 type managedResourceSpec struct {
 	clients ClientCache
 }
@@ -131,7 +303,7 @@ func (r *managedResourceSpec) ConnectionSecret(ctx context.Context, obj *model.M
 	defer cancel()
 
 	creds, _ := auth.FromContext(ctx)
-	c, err := r.clients.Get(creds)
+	c, err := r.clients.GetWithContext(ctx, creds)
 	if err != nil {
 		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
 		return nil, nil
@@ -17,6 +17,7 @@ package resolvers
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/99designs/gqlgen/graphql"
 	"github.com/google/go-cmp/cmp"
@@ -27,6 +28,7 @@ import (
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
@@ -88,7 +90,7 @@ func TestManagedResourceDefinition(t *testing.T) {
 	}{
 		"GetClientError": {
 			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, errBoom
 			}),
 			args: args{
@@ -103,7 +105,7 @@ func TestManagedResourceDefinition(t *testing.T) {
 		},
 		"GetCRDError": {
 			reason: "If we can't get the CRD we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(errBoom),
 				}, nil
@@ -120,7 +122,7 @@ func TestManagedResourceDefinition(t *testing.T) {
 		},
 		"ListCRDsError": {
 			reason: "If we can't list CRDs we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
 						return errNotFound
@@ -140,7 +142,7 @@ func TestManagedResourceDefinition(t *testing.T) {
 		},
 		"FoundCRD": {
 			reason: "If we can get and model the CRD that defines this managed resource we should return it.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
 						*obj.(*kunstructured.Unstructured) = *crd.GetUnstructured()
@@ -163,7 +165,7 @@ func TestManagedResourceDefinition(t *testing.T) {
 			reason: `In the event we get a request for an object whose CRD has
 			a non-predictable plural form, ensure the CRD list contains the
 			expected resource.`,
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
 						return errNotFound
@@ -189,7 +191,7 @@ func TestManagedResourceDefinition(t *testing.T) {
 		},
 		"NoCRD": {
 			reason: "If we can't get and model the CRD that defines this managed resource we should return nil.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
 						return errNotFound
@@ -240,6 +242,272 @@ func TestManagedResourceDefinition(t *testing.T) {
 	}
 }
 
+func TestManagedResourceProviderConfig(t *testing.T) {
+	errBoom := errors.New("boom")
+	errNotFound := &kerrors.StatusError{
+		ErrStatus: metav1.Status{
+			Reason: metav1.StatusReasonNotFound,
+		},
+	}
+
+	newPCCRD := func(group string) kunstructured.Unstructured {
+		crd := unstructured.NewCRD()
+		crd.SetSpecGroup(group)
+		crd.SetSpecNames(kextv1.CustomResourceDefinitionNames{Kind: "ProviderConfig"})
+		crd.SetSpecScope(kextv1.ClusterScoped)
+		crd.SetSpecVersions([]kextv1.CustomResourceDefinitionVersion{
+			{Name: "v1alpha1", Served: true},
+			{Name: "v1beta1", Served: true, Storage: true},
+		})
+		return crd.Unstructured
+	}
+
+	// A CRD named like a provider config CRD, but of another kind.
+	otherKind := unstructured.NewCRD()
+	otherKind.SetSpecGroup("ec2.aws.example.org")
+	otherKind.SetSpecNames(kextv1.CustomResourceDefinitionNames{Kind: "ProviderConfigUsage"})
+	otherKind.SetSpecScope(kextv1.ClusterScoped)
+
+	crds := map[string]kunstructured.Unstructured{
+		"providerconfigs.ec2.aws.example.org": otherKind.Unstructured,
+		"providerconfigs.aws.example.org":     newPCCRD("aws.example.org"),
+		"providerconfigs.example.org":         newPCCRD("example.org"),
+	}
+
+	// getCRDs gets the above CRDs, and calls the supplied function to get
+	// anything else.
+	getCRDs := func(fn test.MockGetFn) test.MockGetFn {
+		return func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+			u := obj.(*kunstructured.Unstructured)
+			if u.GetKind() != "CustomResourceDefinition" {
+				return fn(ctx, key, obj)
+			}
+			crd, ok := crds[key.Name]
+			if !ok {
+				return errNotFound
+			}
+			crd.DeepCopyInto(u)
+			return nil
+		}
+	}
+
+	// getPC gets a provider config of the expected kind with the supplied name.
+	getPC := func(name string) test.MockGetFn {
+		return getCRDs(func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			u := obj.(*kunstructured.Unstructured)
+			if u.GetAPIVersion() != "aws.example.org/v1beta1" || u.GetKind() != "ProviderConfig" || key.Name != name {
+				return errNotFound
+			}
+			u.SetName(name)
+			return nil
+		})
+	}
+
+	// cached caches the kind of provider config used by the managed resource.
+	cached := newProviderConfigKinds()
+	cached.m["ec2.aws.example.org"] = providerConfigKind{
+		gvk:     schema.GroupVersionKind{Group: "aws.example.org", Version: "v1beta1", Kind: "ProviderConfig"},
+		found:   true,
+		expires: time.Now().Add(time.Hour),
+	}
+
+	mr := &model.ManagedResource{
+		APIVersion: "ec2.aws.example.org/v1",
+		Kind:       "Instance",
+		Spec: model.ManagedResourceSpec{
+			ProviderConfigRef: &model.ProviderConfigReference{Name: "cool"},
+		},
+	}
+
+	type args struct {
+		ctx context.Context
+		obj *model.ManagedResource
+	}
+	type want struct {
+		pc   *model.ProviderConfig
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		kinds   *providerConfigKinds
+		args    args
+		want    want
+	}{
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, errBoom
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: mr,
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"GetCRDError": {
+			reason: "If we can't get a provider config CRD we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: mr,
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetCRD)),
+				},
+			},
+		},
+		"NoProviderConfigCRD": {
+			reason: "If we can't find the kind of provider config this managed resource uses we should return nil.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(errNotFound),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: mr,
+			},
+			want: want{
+				pc: nil,
+			},
+		},
+		"GetProviderConfigError": {
+			reason: "If we can't get the provider config we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: getCRDs(test.NewMockGetFn(errBoom)),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: mr,
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetProviderConfig)),
+				},
+			},
+		},
+		"ProviderConfigNotFound": {
+			reason: "If the provider config doesn't exist we should return nil.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: getCRDs(test.NewMockGetFn(errNotFound)),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: mr,
+			},
+			want: want{
+				pc: nil,
+			},
+		},
+		"ReferencedProviderConfig": {
+			reason: "We should return the provider config referenced by the managed resource.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: getPC("cool"),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: mr,
+			},
+			want: want{
+				pc: &model.ProviderConfig{
+					ID:         model.ReferenceID{APIVersion: "aws.example.org/v1beta1", Kind: "ProviderConfig", Name: "cool"},
+					APIVersion: "aws.example.org/v1beta1",
+					Kind:       "ProviderConfig",
+					Metadata:   model.ObjectMeta{Name: "cool"},
+				},
+			},
+		},
+		"DefaultProviderConfig": {
+			reason: "We should return the default provider config if the managed resource doesn't reference one.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: getPC(defaultProviderConfigName),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &model.ManagedResource{APIVersion: "ec2.aws.example.org/v1", Kind: "Instance"},
+			},
+			want: want{
+				pc: &model.ProviderConfig{
+					ID:         model.ReferenceID{APIVersion: "aws.example.org/v1beta1", Kind: "ProviderConfig", Name: "default"},
+					APIVersion: "aws.example.org/v1beta1",
+					Kind:       "ProviderConfig",
+					Metadata:   model.ObjectMeta{Name: "default"},
+				},
+			},
+		},
+		"CachedKind": {
+			reason: "We should use the cached kind of provider config rather than reading CRDs.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+						if obj.(*kunstructured.Unstructured).GetKind() == "CustomResourceDefinition" {
+							return errBoom
+						}
+						return getPC("cool")(ctx, key, obj)
+					},
+				}, nil
+			}),
+			kinds: cached,
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: mr,
+			},
+			want: want{
+				pc: &model.ProviderConfig{
+					ID:         model.ReferenceID{APIVersion: "aws.example.org/v1beta1", Kind: "ProviderConfig", Name: "cool"},
+					APIVersion: "aws.example.org/v1beta1",
+					Kind:       "ProviderConfig",
+					Metadata:   model.ObjectMeta{Name: "cool"},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mr := &managedResource{clients: tc.clients, kinds: tc.kinds}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := mr.ProviderConfig(tc.args.ctx, tc.args.obj)
+			errs := graphql.GetErrors(tc.args.ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ns.ProviderConfig(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ns.ProviderConfig(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.pc, got,
+				cmpopts.IgnoreUnexported(model.ObjectMeta{}),
+				cmpopts.IgnoreFields(model.ProviderConfig{}, "PavedAccess"),
+			); diff != "" {
+				t.Errorf("\n%s\ns.ProviderConfig(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
 func TestManagedResourceSpecConnectionSecret(t *testing.T) {
 	errBoom := errors.New("boom")
 
@@ -271,7 +539,7 @@ func TestManagedResourceSpecConnectionSecret(t *testing.T) {
 		},
 		"GetClientError": {
 			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, errBoom
 			}),
 			args: args{
@@ -288,7 +556,7 @@ func TestManagedResourceSpecConnectionSecret(t *testing.T) {
 		},
 		"GetSecretError": {
 			reason: "If we can't get the secret we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(errBoom),
 				}, nil
@@ -307,7 +575,7 @@ func TestManagedResourceSpecConnectionSecret(t *testing.T) {
 		},
 		"Success": {
 			reason: "If we can get and model the secret we should return it.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(nil),
 				}, nil
@@ -17,31 +17,100 @@ package resolvers
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"strings"
 
 	"github.com/99designs/gqlgen/graphql"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	pkgv1 "github.com/crossplane/crossplane/apis/pkg/v1"
 
 	"github.com/upbound/xgql/internal/auth"
 	"github.com/upbound/xgql/internal/graph/model"
+	xunstructured "github.com/upbound/xgql/internal/unstructured"
 )
 
 const (
 	errCreateResource        = "cannot create Kubernetes resource"
 	errUpdateResource        = "cannot update Kubernetes resource"
 	errDeleteResource        = "cannot delete Kubernetes resource"
+	errPatchResource         = "cannot patch Kubernetes resource"
+	errPauseResource         = "cannot pause or resume Kubernetes resource"
+	errSetManagementPolicies = "cannot set management policies of managed resource"
+	errNoManagementPolicies  = "at least one management policy must be specified"
+	errSetDeletionPolicy     = "cannot set deletion policy of managed resource"
 	errUnmarshalUnstructured = "cannot unmarshal input unstructured JSON"
+	errUnmarshalPatch        = "cannot unmarshal patch JSON"
+	errForceWithoutApply     = "force is only supported by server-side apply patches"
+	errGetPackage            = "cannot get package"
+	errGetRevision           = "cannot get package revision"
+	errListRevisions         = "cannot list package revisions"
+	errSetActivationPolicy   = "cannot set revision activation policy of package"
+	errDeactivateRevision    = "cannot deactivate package revision"
+	errActivateRevision      = "cannot activate package revision"
+	errRemoveFinalizer       = "cannot remove finalizer"
+	errFinalizersDisabled    = "finalizer removal is disabled; xgql must be started with finalizer removal enabled"
 
 	errFmtUnmarshalPatch = "cannot unmarshal unstructured patch JSON at index %d"
 	errFmtPatch          = "cannot apply patch at index %d"
+	errFmtForbiddenPatch = "patches may not modify %s"
+	errFmtNotPackage     = "%s is not a provider, configuration, or function"
+	errFmtReactivate     = "cannot reactivate package revision %s"
+	errFmtNotRevisionOf  = "%s is not a revision of %s"
+	errFmtNoFinalizer    = "resource has no finalizer %q"
 )
 
+// forbiddenPatchFields are field paths that may not be modified by the
+// patchResource mutation.
+var forbiddenPatchFields = []string{
+	"metadata.ownerReferences",
+}
+
+// forbiddenPatchField returns the forbidden field the supplied patch may
+// modify, if any. Besides the forbidden fields themselves, a patch may not
+// replace or delete the whole of the object or its metadata, or use strategic
+// merge patch directives (e.g. $patch or $retainKeys) at either level, because
+// doing so may modify forbidden fields without naming them.
+func forbiddenPatchField(p map[string]interface{}) (string, bool) {
+	pv := fieldpath.Pave(p)
+	for _, fp := range forbiddenPatchFields {
+		if _, err := pv.GetValue(fp); err == nil {
+			return fp, true
+		}
+	}
+	for k := range p {
+		if strings.HasPrefix(k, "$") {
+			return k, true
+		}
+	}
+	md, ok := p["metadata"]
+	if !ok {
+		return "", false
+	}
+	m, ok := md.(map[string]interface{})
+	if !ok {
+		return "metadata", true
+	}
+	for k := range m {
+		if strings.HasPrefix(k, "$") {
+			return "metadata." + k, true
+		}
+	}
+	return "", false
+}
+
 // IsRetriable indicates that an error may succeed if retried.
 func IsRetriable(err error) bool { //nolint:gocyclo // It's just a big old switch.
 	switch {
@@ -64,7 +133,9 @@ func IsRetriable(err error) bool { //nolint:gocyclo // It's just a big old switc
 }
 
 type mutation struct {
-	clients ClientCache
+	clients    ClientCache
+	log        logging.Logger
+	finalizers bool
 }
 
 func (r *mutation) CreateKubernetesResource(ctx context.Context, input model.CreateKubernetesResourceInput) (model.CreateKubernetesResourcePayload, error) {
@@ -72,7 +143,7 @@ func (r *mutation) CreateKubernetesResource(ctx context.Context, input model.Cre
 	defer cancel()
 
 	creds, _ := auth.FromContext(ctx)
-	c, err := r.clients.Get(creds)
+	c, err := r.clients.GetWithContext(ctx, creds)
 	if err != nil {
 		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
 		return model.CreateKubernetesResourcePayload{}, nil
@@ -115,7 +186,7 @@ func (r *mutation) UpdateKubernetesResource(ctx context.Context, id model.Refere
 	defer cancel()
 
 	creds, _ := auth.FromContext(ctx)
-	c, err := r.clients.Get(creds)
+	c, err := r.clients.GetWithContext(ctx, creds)
 	if err != nil {
 		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
 		return model.UpdateKubernetesResourcePayload{}, nil
@@ -166,7 +237,7 @@ func (r *mutation) DeleteKubernetesResource(ctx context.Context, id model.Refere
 	defer cancel()
 
 	creds, _ := auth.FromContext(ctx)
-	c, err := r.clients.Get(creds)
+	c, err := r.clients.GetWithContext(ctx, creds)
 	if err != nil {
 		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
 		return model.DeleteKubernetesResourcePayload{}, nil
@@ -189,3 +260,402 @@ func (r *mutation) DeleteKubernetesResource(ctx context.Context, id model.Refere
 	}
 	return model.DeleteKubernetesResourcePayload{Resource: kr}, nil
 }
+
+func (r *mutation) PatchResource(ctx context.Context, id model.ReferenceID, patch []byte, pt *model.PatchType, force *bool) (model.PatchResourcePayload, error) { //nolint:gocyclo
+	// This isn't _really_ that complex; it's mostly validation.
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.GetWithContext(ctx, creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.PatchResourcePayload{}, nil
+	}
+
+	p := map[string]interface{}{}
+	if err := json.Unmarshal(patch, &p); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errUnmarshalPatch))
+		return model.PatchResourcePayload{}, nil
+	}
+
+	if fp, ok := forbiddenPatchField(p); ok {
+		graphql.AddError(ctx, errors.Errorf(errFmtForbiddenPatch, fp))
+		return model.PatchResourcePayload{}, nil
+	}
+
+	t := types.MergePatchType
+	var popts []client.PatchOption
+	switch {
+	case pt != nil && *pt == model.PatchTypeStrategicMerge:
+		t = types.StrategicMergePatchType
+	case pt != nil && *pt == model.PatchTypeApply:
+		t = types.ApplyPatchType
+		popts = append(popts, client.FieldOwner(FromConfig(ctx).FieldManager))
+	}
+	if ptr.Deref(force, false) {
+		if t != types.ApplyPatchType {
+			graphql.AddError(ctx, errors.New(errForceWithoutApply))
+			return model.PatchResourcePayload{}, nil
+		}
+		popts = append(popts, client.ForceOwnership)
+	}
+
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion(id.APIVersion)
+	u.SetKind(id.Kind)
+	u.SetNamespace(id.Namespace)
+	u.SetName(id.Name)
+	if err := retry.OnError(retry.DefaultBackoff, IsRetriable, func() error { return c.Patch(ctx, u, client.RawPatch(t, patch), popts...) }); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errPatchResource))
+		return model.PatchResourcePayload{}, nil
+	}
+
+	kr, err := model.GetKubernetesResource(u)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errModelResource))
+		return model.PatchResourcePayload{}, nil
+	}
+	return model.PatchResourcePayload{Resource: kr}, nil
+}
+
+func (r *mutation) PauseResource(ctx context.Context, id model.ReferenceID, paused bool) (model.PauseResourcePayload, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.GetWithContext(ctx, creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.PauseResourcePayload{}, nil
+	}
+
+	// A JSON merge patch removes a key whose value is null, so resuming a
+	// resource removes the annotation rather than setting it to "false".
+	var v *string
+	if paused {
+		v = ptr.To("true")
+	}
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]any{meta.AnnotationKeyReconciliationPaused: v},
+		},
+	})
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errPauseResource))
+		return model.PauseResourcePayload{}, nil
+	}
+
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion(id.APIVersion)
+	u.SetKind(id.Kind)
+	u.SetNamespace(id.Namespace)
+	u.SetName(id.Name)
+	if err := retry.OnError(retry.DefaultBackoff, IsRetriable, func() error { return c.Patch(ctx, u, client.RawPatch(types.MergePatchType, patch)) }); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errPauseResource))
+		return model.PauseResourcePayload{}, nil
+	}
+
+	kr, err := model.GetKubernetesResource(u)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errModelResource))
+		return model.PauseResourcePayload{}, nil
+	}
+	return model.PauseResourcePayload{Resource: kr, Paused: meta.IsPaused(u)}, nil
+}
+
+func (r *mutation) SetManagementPolicies(ctx context.Context, id model.ReferenceID, policies []model.ManagementAction) (model.SetManagementPoliciesPayload, error) {
+	if len(policies) == 0 {
+		graphql.AddError(ctx, errors.New(errNoManagementPolicies))
+		return model.SetManagementPoliciesPayload{}, nil
+	}
+	p, err := model.ToManagementPolicies(policies)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errSetManagementPolicies))
+		return model.SetManagementPoliciesPayload{}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.GetWithContext(ctx, creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.SetManagementPoliciesPayload{}, nil
+	}
+
+	// A JSON merge patch replaces arrays wholesale, so the supplied policies
+	// replace any existing policies.
+	patch, err := json.Marshal(map[string]any{
+		"spec": map[string]any{"managementPolicies": p},
+	})
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errSetManagementPolicies))
+		return model.SetManagementPoliciesPayload{}, nil
+	}
+
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion(id.APIVersion)
+	u.SetKind(id.Kind)
+	u.SetNamespace(id.Namespace)
+	u.SetName(id.Name)
+	if err := retry.OnError(retry.DefaultBackoff, IsRetriable, func() error { return c.Patch(ctx, u, client.RawPatch(types.MergePatchType, patch)) }); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errSetManagementPolicies))
+		return model.SetManagementPoliciesPayload{}, nil
+	}
+
+	kr, err := model.GetKubernetesResource(u)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errModelResource))
+		return model.SetManagementPoliciesPayload{}, nil
+	}
+	mg := &xunstructured.Managed{Unstructured: *u}
+	return model.SetManagementPoliciesPayload{Resource: kr, ManagementPolicies: model.GetManagementPolicies(mg.GetManagementPolicies())}, nil
+}
+
+func (r *mutation) SetDeletionPolicy(ctx context.Context, id model.ReferenceID, policy model.DeletionPolicy) (model.SetDeletionPolicyPayload, error) {
+	p, err := model.ToDeletionPolicy(policy)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errSetDeletionPolicy))
+		return model.SetDeletionPolicyPayload{}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.GetWithContext(ctx, creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.SetDeletionPolicyPayload{}, nil
+	}
+
+	patch, err := json.Marshal(map[string]any{
+		"spec": map[string]any{"deletionPolicy": p},
+	})
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errSetDeletionPolicy))
+		return model.SetDeletionPolicyPayload{}, nil
+	}
+
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion(id.APIVersion)
+	u.SetKind(id.Kind)
+	u.SetNamespace(id.Namespace)
+	u.SetName(id.Name)
+	if err := retry.OnError(retry.DefaultBackoff, IsRetriable, func() error { return c.Patch(ctx, u, client.RawPatch(types.MergePatchType, patch)) }); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errSetDeletionPolicy))
+		return model.SetDeletionPolicyPayload{}, nil
+	}
+
+	kr, err := model.GetKubernetesResource(u)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errModelResource))
+		return model.SetDeletionPolicyPayload{}, nil
+	}
+	mg := &xunstructured.Managed{Unstructured: *u}
+	return model.SetDeletionPolicyPayload{Resource: kr, DeletionPolicy: model.GetDeletionPolicy(mg.GetDeletionPolicy())}, nil
+}
+
+func (r *mutation) ActivateRevision(ctx context.Context, id model.ReferenceID, revision string) (model.ActivateRevisionPayload, error) { //nolint:gocyclo // Only slightly over.
+	gv, _ := schema.ParseGroupVersion(id.APIVersion)
+	if gv.Group != pkgv1.Group || (id.Kind != pkgv1.ProviderKind && id.Kind != pkgv1.ConfigurationKind && id.Kind != pkgv1.FunctionKind) {
+		graphql.AddError(ctx, errors.Errorf(errFmtNotPackage, id.Name))
+		return model.ActivateRevisionPayload{}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.GetWithContext(ctx, creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.ActivateRevisionPayload{}, nil
+	}
+
+	pkg := &unstructured.Unstructured{}
+	pkg.SetAPIVersion(id.APIVersion)
+	pkg.SetKind(id.Kind)
+	if err := c.Get(ctx, types.NamespacedName{Name: id.Name}, pkg); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetPackage))
+		return model.ActivateRevisionPayload{}, nil
+	}
+
+	// Every kind of package revision is named for the kind of package it's a
+	// revision of, and has the same API version.
+	rev := &unstructured.Unstructured{}
+	rev.SetAPIVersion(id.APIVersion)
+	rev.SetKind(id.Kind + "Revision")
+	if err := c.Get(ctx, types.NamespacedName{Name: revision}, rev); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetRevision))
+		return model.ActivateRevisionPayload{}, nil
+	}
+
+	// We're not the controller reference of this revision; it's not one of
+	// ours.
+	if ref := v1.GetControllerOf(rev); ref == nil || ref.UID != pkg.GetUID() {
+		graphql.AddError(ctx, errors.Errorf(errFmtNotRevisionOf, revision, id.Name))
+		return model.ActivateRevisionPayload{}, nil
+	}
+
+	// Crossplane would otherwise activate the revision of the package's
+	// current image, undoing our activation.
+	policy := map[string]any{"spec": map[string]any{"revisionActivationPolicy": pkgv1.ManualActivation}}
+	if err := patchRetry(ctx, c, pkg, policy); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errSetActivationPolicy))
+		return model.ActivateRevisionPayload{}, nil
+	}
+
+	// Crossplane deactivates the current revision before activating a new one
+	// when it upgrades a package, so we do the same. If we can't activate the
+	// new revision we reactivate the revisions we deactivated, so that we don't
+	// leave the package without an active revision.
+	l := &unstructured.UnstructuredList{}
+	l.SetAPIVersion(id.APIVersion)
+	l.SetKind(id.Kind + "RevisionList")
+	if err := c.List(ctx, l, client.MatchingLabels{pkgv1.LabelParentPackage: id.Name}); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errListRevisions))
+		return model.ActivateRevisionPayload{}, nil
+	}
+	deactivated := make([]*unstructured.Unstructured, 0, 1)
+	for i := range l.Items {
+		other := &l.Items[i]
+		if other.GetName() == revision {
+			continue
+		}
+		if ref := v1.GetControllerOf(other); ref == nil || ref.UID != pkg.GetUID() {
+			continue
+		}
+		if ds, _, _ := unstructured.NestedString(other.Object, "spec", "desiredState"); ds != string(pkgv1.PackageRevisionActive) {
+			continue
+		}
+		inactive := map[string]any{"spec": map[string]any{"desiredState": pkgv1.PackageRevisionInactive}}
+		if err := patchRetry(ctx, c, other, inactive); err != nil {
+			graphql.AddError(ctx, errors.Wrap(err, errDeactivateRevision))
+			reactivate(ctx, c, deactivated)
+			return model.ActivateRevisionPayload{}, nil
+		}
+		deactivated = append(deactivated, other)
+	}
+
+	active := map[string]any{"spec": map[string]any{"desiredState": pkgv1.PackageRevisionActive}}
+	if err := patchRetry(ctx, c, rev, active); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errActivateRevision))
+		reactivate(ctx, c, deactivated)
+		return model.ActivateRevisionPayload{}, nil
+	}
+
+	pkr, err := model.GetKubernetesResource(pkg)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errModelResource))
+		return model.ActivateRevisionPayload{}, nil
+	}
+	rkr, err := model.GetKubernetesResource(rev)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errModelResource))
+		return model.ActivateRevisionPayload{}, nil
+	}
+	return model.ActivateRevisionPayload{Package: pkr, ActiveRevision: rkr}, nil
+}
+
+// reactivate rolls back the deactivation of the supplied revisions. It adds an
+// error to the GraphQL context for any revision it can't reactivate. The
+// supplied context may have expired, so the rollback has its own timeout.
+func reactivate(ctx context.Context, c client.Client, revs []*unstructured.Unstructured) {
+	rctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+	defer cancel()
+
+	active := map[string]any{"spec": map[string]any{"desiredState": pkgv1.PackageRevisionActive}}
+	for _, r := range revs {
+		if err := patchRetry(rctx, c, r, active); err != nil {
+			graphql.AddError(ctx, errors.Wrapf(err, errFmtReactivate, r.GetName()))
+		}
+	}
+}
+
+// patchRetry applies the supplied JSON merge patch to the supplied object,
+// retrying errors that may succeed if retried.
+func patchRetry(ctx context.Context, c client.Client, u *unstructured.Unstructured, patch map[string]any) error {
+	p, err := json.Marshal(patch)
+	if err != nil {
+		return err
+	}
+	return retry.OnError(retry.DefaultBackoff, IsRetriable, func() error { return c.Patch(ctx, u, client.RawPatch(types.MergePatchType, p)) })
+}
+
+func (r *mutation) RemoveFinalizer(ctx context.Context, id model.ReferenceID, finalizer string) (model.RemoveFinalizerPayload, error) {
+	if !r.finalizers {
+		graphql.AddError(ctx, errors.New(errFinalizersDisabled))
+		return model.RemoveFinalizerPayload{}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.GetWithContext(ctx, creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.RemoveFinalizerPayload{}, nil
+	}
+
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion(id.APIVersion)
+	u.SetKind(id.Kind)
+	if err := c.Get(ctx, types.NamespacedName{Namespace: id.Namespace, Name: id.Name}, u); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetResource))
+		return model.RemoveFinalizerPayload{}, nil
+	}
+
+	i := -1
+	for j, f := range u.GetFinalizers() {
+		if f == finalizer {
+			i = j
+			break
+		}
+	}
+	if i < 0 {
+		graphql.AddError(ctx, errors.Errorf(errFmtNoFinalizer, finalizer))
+		return model.RemoveFinalizerPayload{}, nil
+	}
+
+	// The test operation ensures we remove the finalizer we found, even if the
+	// resource's finalizers changed since we read it. The patch fails if they
+	// did.
+	path := fmt.Sprintf("/metadata/finalizers/%d", i)
+	patch, err := json.Marshal([]map[string]any{
+		{"op": "test", "path": path, "value": finalizer},
+		{"op": "remove", "path": path},
+	})
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errRemoveFinalizer))
+		return model.RemoveFinalizerPayload{}, nil
+	}
+
+	// Removing a finalizer may orphan external resources, so we want a record
+	// of who did it even when we're not debugging.
+	r.log.Info("WARNING: Removing finalizer. Cleanup it guards will be skipped, which may orphan external resources.",
+		"finalizer", finalizer,
+		"apiVersion", id.APIVersion,
+		"kind", id.Kind,
+		"namespace", id.Namespace,
+		"name", id.Name,
+		"token-hash", creds.TokenHash(),
+		"impersonated-user", creds.Impersonate.Username,
+	)
+
+	if err := retry.OnError(retry.DefaultBackoff, IsRetriable, func() error { return c.Patch(ctx, u, client.RawPatch(types.JSONPatchType, patch)) }); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errRemoveFinalizer))
+		return model.RemoveFinalizerPayload{}, nil
+	}
+
+	kr, err := model.GetKubernetesResource(u)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errModelResource))
+		return model.RemoveFinalizerPayload{}, nil
+	}
+	return model.RemoveFinalizerPayload{Resource: kr}, nil
+}
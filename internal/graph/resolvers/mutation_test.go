@@ -25,12 +25,17 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/vektah/gqlparser/v2/gqlerror"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 
 	"github.com/upbound/xgql/internal/auth"
@@ -121,7 +126,7 @@ func TestCreateKubernetesResource(t *testing.T) {
 	}{
 		"GetClientError": {
 			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, errBoom
 			}),
 			args: args{
@@ -135,7 +140,7 @@ func TestCreateKubernetesResource(t *testing.T) {
 		},
 		"UnmarshalUnstructuredError": {
 			reason: "If we can't get unmarshal the unstructured input we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, nil
 			}),
 			args: args{
@@ -152,7 +157,7 @@ func TestCreateKubernetesResource(t *testing.T) {
 		},
 		"UnmarshalPatchError": {
 			reason: "If we can't get unmarshal an unstructured patch we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, nil
 			}),
 			args: args{
@@ -172,7 +177,7 @@ func TestCreateKubernetesResource(t *testing.T) {
 		},
 		"SetPatchValueError": {
 			reason: "If we can't apply a patch we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, nil
 			}),
 			args: args{
@@ -193,7 +198,7 @@ func TestCreateKubernetesResource(t *testing.T) {
 		},
 		"CreateError": {
 			reason: "If we can't create a Kubernetes resource we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockCreate: test.NewMockCreateFn(errBoom),
 				}, nil
@@ -212,7 +217,7 @@ func TestCreateKubernetesResource(t *testing.T) {
 		},
 		"Success": {
 			reason: "If we successfully create a Kubernetes resource we should model and return it.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockCreate: test.NewMockCreateFn(nil),
 				}, nil
@@ -289,7 +294,7 @@ func TestUpdateKubernetesResource(t *testing.T) {
 	}{
 		"GetClientError": {
 			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, errBoom
 			}),
 			args: args{
@@ -303,7 +308,7 @@ func TestUpdateKubernetesResource(t *testing.T) {
 		},
 		"UnmarshalUnstructuredError": {
 			reason: "If we can't get unmarshal the unstructured input we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, nil
 			}),
 			args: args{
@@ -320,7 +325,7 @@ func TestUpdateKubernetesResource(t *testing.T) {
 		},
 		"UnmarshalPatchError": {
 			reason: "If we can't get unmarshal an unstructured patch we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, nil
 			}),
 			args: args{
@@ -340,7 +345,7 @@ func TestUpdateKubernetesResource(t *testing.T) {
 		},
 		"SetPatchValueError": {
 			reason: "If we can't apply a patch we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, nil
 			}),
 			args: args{
@@ -361,7 +366,7 @@ func TestUpdateKubernetesResource(t *testing.T) {
 		},
 		"UpdateError": {
 			reason: "If we can't update a Kubernetes resource we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockUpdate: test.NewMockUpdateFn(errBoom),
 				}, nil
@@ -380,7 +385,7 @@ func TestUpdateKubernetesResource(t *testing.T) {
 		},
 		"Success": {
 			reason: "If we successfully update a Kubernetes resource we should model and return it.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockUpdate: test.NewMockUpdateFn(nil),
 				}, nil
@@ -454,7 +459,7 @@ func TestDeleteKubernetesResource(t *testing.T) {
 	}{
 		"GetClientError": {
 			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, errBoom
 			}),
 			args: args{
@@ -469,7 +474,7 @@ func TestDeleteKubernetesResource(t *testing.T) {
 
 		"DeleteError": {
 			reason: "If we can't update a Kubernetes resource we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockDelete: test.NewMockDeleteFn(errBoom),
 				}, nil
@@ -485,7 +490,7 @@ func TestDeleteKubernetesResource(t *testing.T) {
 		},
 		"Success": {
 			reason: "If we successfully update a Kubernetes resource we should model and return it.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockDelete: test.NewMockDeleteFn(nil),
 				}, nil
@@ -528,3 +533,1188 @@ func TestDeleteKubernetesResource(t *testing.T) {
 		})
 	}
 }
+
+func TestPatchResource(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type args struct {
+		ctx   context.Context
+		id    model.ReferenceID
+		patch []byte
+		pt    *model.PatchType
+		force *bool
+	}
+	type want struct {
+		payload model.PatchResourcePayload
+		err     error
+		errs    gqlerror.List
+	}
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("example.org/v1")
+	u.SetKind("Example")
+	u.SetName("example")
+
+	id := model.ReferenceID{
+		APIVersion: u.GetAPIVersion(),
+		Kind:       u.GetKind(),
+		Namespace:  u.GetNamespace(),
+		Name:       u.GetName(),
+	}
+
+	kr, _ := model.GetKubernetesResource(u)
+
+	patch := []byte(`{"spec":{"size":"large"}}`)
+	strategic := model.PatchTypeStrategicMerge
+	apply := model.PatchTypeApply
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		args    args
+		want    want
+	}{
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, errBoom
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"UnmarshalPatchError": {
+			reason: "If we can't unmarshal the patch we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, nil
+			}),
+			args: args{
+				ctx:   graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				patch: []byte("{"),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(json.Unmarshal([]byte("{"), &map[string]interface{}{}), errUnmarshalPatch)),
+				},
+			},
+		},
+		"ForbiddenPatch": {
+			reason: "If the patch modifies owner references we should add an error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockPatch: func(_ context.Context, _ client.Object, _ client.Patch, _ ...client.PatchOption) error {
+						t.Error("Patch should not be called for a forbidden patch")
+						return nil
+					},
+				}, nil
+			}),
+			args: args{
+				ctx:   graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				id:    id,
+				patch: []byte(`{"metadata":{"ownerReferences":null}}`),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Errorf(errFmtForbiddenPatch, "metadata.ownerReferences")),
+				},
+			},
+		},
+		"ForbiddenPatchMetadataDirective": {
+			reason: "If the patch uses a strategic merge directive in metadata we should add an error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockPatch: func(_ context.Context, _ client.Object, _ client.Patch, _ ...client.PatchOption) error {
+						t.Error("Patch should not be called for a forbidden patch")
+						return nil
+					},
+				}, nil
+			}),
+			args: args{
+				ctx:   graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				id:    id,
+				patch: []byte(`{"metadata":{"$patch":"replace","name":"cool"}}`),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Errorf(errFmtForbiddenPatch, "metadata.$patch")),
+				},
+			},
+		},
+		"ForbiddenPatchRetainKeys": {
+			reason: "If the patch retains only some metadata keys we should add an error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockPatch: func(_ context.Context, _ client.Object, _ client.Patch, _ ...client.PatchOption) error {
+						t.Error("Patch should not be called for a forbidden patch")
+						return nil
+					},
+				}, nil
+			}),
+			args: args{
+				ctx:   graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				id:    id,
+				patch: []byte(`{"metadata":{"$retainKeys":["name"]}}`),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Errorf(errFmtForbiddenPatch, "metadata.$retainKeys")),
+				},
+			},
+		},
+		"ForbiddenPatchObjectDirective": {
+			reason: "If the patch uses a strategic merge directive on the whole object we should add an error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockPatch: func(_ context.Context, _ client.Object, _ client.Patch, _ ...client.PatchOption) error {
+						t.Error("Patch should not be called for a forbidden patch")
+						return nil
+					},
+				}, nil
+			}),
+			args: args{
+				ctx:   graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				id:    id,
+				patch: []byte(`{"$patch":"replace","metadata":{"name":"cool"}}`),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Errorf(errFmtForbiddenPatch, "$patch")),
+				},
+			},
+		},
+		"ForbiddenPatchNullMetadata": {
+			reason: "If the patch deletes metadata we should add an error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockPatch: func(_ context.Context, _ client.Object, _ client.Patch, _ ...client.PatchOption) error {
+						t.Error("Patch should not be called for a forbidden patch")
+						return nil
+					},
+				}, nil
+			}),
+			args: args{
+				ctx:   graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				id:    id,
+				patch: []byte(`{"metadata":null}`),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Errorf(errFmtForbiddenPatch, "metadata")),
+				},
+			},
+		},
+		"PatchError": {
+			reason: "If we can't patch a Kubernetes resource we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockPatch: test.NewMockPatchFn(errBoom),
+				}, nil
+			}),
+			args: args{
+				ctx:   graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				id:    id,
+				patch: patch,
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errPatchResource)),
+				},
+			},
+		},
+		"MergePatch": {
+			reason: "If no patch type is supplied we should apply a JSON merge patch and return the patched resource.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockPatch: func(_ context.Context, _ client.Object, p client.Patch, _ ...client.PatchOption) error {
+						if diff := cmp.Diff(types.MergePatchType, p.Type()); diff != "" {
+							t.Errorf("-want patch type, +got patch type:\n%s", diff)
+						}
+						return nil
+					},
+				}, nil
+			}),
+			args: args{
+				ctx:   graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				id:    id,
+				patch: patch,
+			},
+			want: want{
+				payload: model.PatchResourcePayload{
+					Resource: kr,
+				},
+			},
+		},
+		"StrategicMergePatch": {
+			reason: "If a strategic merge patch is requested we should apply one and return the patched resource.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockPatch: func(_ context.Context, _ client.Object, p client.Patch, _ ...client.PatchOption) error {
+						if diff := cmp.Diff(types.StrategicMergePatchType, p.Type()); diff != "" {
+							t.Errorf("-want patch type, +got patch type:\n%s", diff)
+						}
+						return nil
+					},
+				}, nil
+			}),
+			args: args{
+				ctx:   graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				id:    id,
+				patch: patch,
+				pt:    &strategic,
+			},
+			want: want{
+				payload: model.PatchResourcePayload{
+					Resource: kr,
+				},
+			},
+		},
+		"ForceWithoutApply": {
+			reason: "If force is requested for a patch that isn't a server-side apply patch we should add an error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockPatch: func(_ context.Context, _ client.Object, _ client.Patch, _ ...client.PatchOption) error {
+						t.Error("Patch should not be called when force is requested without apply")
+						return nil
+					},
+				}, nil
+			}),
+			args: args{
+				ctx:   graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				id:    id,
+				patch: patch,
+				force: ptr.To(true),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.New(errForceWithoutApply)),
+				},
+			},
+		},
+		"ApplyPatch": {
+			reason: "If a server-side apply patch is requested we should apply one as the configured field manager, forcing ownership if requested.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockPatch: func(_ context.Context, _ client.Object, p client.Patch, opts ...client.PatchOption) error {
+						if diff := cmp.Diff(types.ApplyPatchType, p.Type()); diff != "" {
+							t.Errorf("-want patch type, +got patch type:\n%s", diff)
+						}
+						po := &client.PatchOptions{}
+						po.ApplyOptions(opts)
+						want := &client.PatchOptions{FieldManager: "cool-manager", Force: ptr.To(true)}
+						if diff := cmp.Diff(want, po); diff != "" {
+							t.Errorf("-want patch options, +got patch options:\n%s", diff)
+						}
+						return nil
+					},
+				}, nil
+			}),
+			args: args{
+				ctx:   WithConfig(graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover), &Config{FieldManager: "cool-manager"}),
+				id:    id,
+				patch: patch,
+				pt:    &apply,
+				force: ptr.To(true),
+			},
+			want: want{
+				payload: model.PatchResourcePayload{
+					Resource: kr,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := &mutation{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := m.PatchResource(tc.args.ctx, tc.args.id, tc.args.patch, tc.args.pt, tc.args.force)
+			errs := graphql.GetErrors(tc.args.ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ns.PatchResource(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ns.PatchResource(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.payload, got, cmpopts.IgnoreFields(model.GenericResource{}, "PavedAccess"), cmpopts.IgnoreUnexported(model.ObjectMeta{})); diff != "" {
+				t.Errorf("\n%s\ns.PatchResource(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestPauseResource(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type args struct {
+		id     model.ReferenceID
+		paused bool
+	}
+	type want struct {
+		payload model.PauseResourcePayload
+		err     error
+		errs    gqlerror.List
+	}
+
+	id := model.ReferenceID{
+		APIVersion: "example.org/v1",
+		Kind:       "Example",
+		Name:       "example",
+	}
+
+	// patchFn returns a MockPatchFn that expects the supplied merge patch,
+	// and returns an object with the supplied annotations.
+	patchFn := func(want string, annotations map[string]string) test.MockPatchFn {
+		return func(_ context.Context, obj client.Object, p client.Patch, _ ...client.PatchOption) error {
+			if diff := cmp.Diff(types.MergePatchType, p.Type()); diff != "" {
+				t.Errorf("-want patch type, +got patch type:\n%s", diff)
+			}
+			got, _ := p.Data(obj)
+			if diff := cmp.Diff(want, string(got)); diff != "" {
+				t.Errorf("-want patch, +got patch:\n%s", diff)
+			}
+			obj.SetAnnotations(annotations)
+			return nil
+		}
+	}
+
+	paused := &unstructured.Unstructured{}
+	paused.SetAPIVersion(id.APIVersion)
+	paused.SetKind(id.Kind)
+	paused.SetName(id.Name)
+	paused.SetAnnotations(map[string]string{meta.AnnotationKeyReconciliationPaused: "true"})
+	pkr, _ := model.GetKubernetesResource(paused)
+
+	resumed := paused.DeepCopy()
+	resumed.SetAnnotations(nil)
+	rkr, _ := model.GetKubernetesResource(resumed)
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		args    args
+		want    want
+	}{
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return nil, errBoom
+			}),
+			args: args{
+				id: id,
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"PatchError": {
+			reason: "If we can't patch the resource we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockPatch: test.NewMockPatchFn(errBoom)}, nil
+			}),
+			args: args{
+				id:     id,
+				paused: true,
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errPauseResource)),
+				},
+			},
+		},
+		"Pause": {
+			reason: "Pausing a resource should set only its paused annotation.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockPatch: patchFn(`{"metadata":{"annotations":{"crossplane.io/paused":"true"}}}`, paused.GetAnnotations()),
+				}, nil
+			}),
+			args: args{
+				id:     id,
+				paused: true,
+			},
+			want: want{
+				payload: model.PauseResourcePayload{Resource: pkr, Paused: true},
+			},
+		},
+		"Resume": {
+			reason: "Resuming a resource should remove only its paused annotation.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockPatch: patchFn(`{"metadata":{"annotations":{"crossplane.io/paused":null}}}`, nil),
+				}, nil
+			}),
+			args: args{
+				id: id,
+			},
+			want: want{
+				payload: model.PauseResourcePayload{Resource: rkr, Paused: false},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := &mutation{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			ctx := graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover)
+			got, err := m.PauseResource(ctx, tc.args.id, tc.args.paused)
+			errs := graphql.GetErrors(ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ns.PauseResource(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ns.PauseResource(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.payload, got, cmpopts.IgnoreFields(model.GenericResource{}, "PavedAccess"), cmpopts.IgnoreUnexported(model.ObjectMeta{})); diff != "" {
+				t.Errorf("\n%s\ns.PauseResource(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSetManagementPolicies(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type args struct {
+		id       model.ReferenceID
+		policies []model.ManagementAction
+	}
+	type want struct {
+		payload model.SetManagementPoliciesPayload
+		err     error
+		errs    gqlerror.List
+	}
+
+	id := model.ReferenceID{
+		APIVersion: "example.org/v1",
+		Kind:       "Example",
+		Name:       "example",
+	}
+
+	observed := &unstructured.Unstructured{}
+	observed.SetAPIVersion(id.APIVersion)
+	observed.SetKind(id.Kind)
+	observed.SetName(id.Name)
+	_ = unstructured.SetNestedStringSlice(observed.Object, []string{"Observe", "LateInitialize"}, "spec", "managementPolicies")
+	okr, _ := model.GetKubernetesResource(observed)
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		args    args
+		want    want
+	}{
+		"NoPolicies": {
+			reason: "If no policies are supplied we should add an error to the GraphQL context and return early.",
+			args: args{
+				id: id,
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.New(errNoManagementPolicies)),
+				},
+			},
+		},
+		"UnknownPolicy": {
+			reason: "If an unknown policy is supplied we should add an error to the GraphQL context and return early.",
+			args: args{
+				id:       id,
+				policies: []model.ManagementAction{"Wat"},
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errors.New(`unknown management action "Wat"`), errSetManagementPolicies)),
+				},
+			},
+		},
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return nil, errBoom
+			}),
+			args: args{
+				id:       id,
+				policies: []model.ManagementAction{model.ManagementActionAll},
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"PatchError": {
+			reason: "If we can't patch the resource we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockPatch: test.NewMockPatchFn(errBoom)}, nil
+			}),
+			args: args{
+				id:       id,
+				policies: []model.ManagementAction{model.ManagementActionAll},
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errSetManagementPolicies)),
+				},
+			},
+		},
+		"Success": {
+			reason: "Setting management policies should replace the resource's management policies.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockPatch: func(_ context.Context, obj client.Object, p client.Patch, _ ...client.PatchOption) error {
+						if diff := cmp.Diff(types.MergePatchType, p.Type()); diff != "" {
+							t.Errorf("-want patch type, +got patch type:\n%s", diff)
+						}
+						want := `{"spec":{"managementPolicies":["Observe","LateInitialize"]}}`
+						got, _ := p.Data(obj)
+						if diff := cmp.Diff(want, string(got)); diff != "" {
+							t.Errorf("-want patch, +got patch:\n%s", diff)
+						}
+						u := obj.(*unstructured.Unstructured)
+						_ = unstructured.SetNestedStringSlice(u.Object, []string{"Observe", "LateInitialize"}, "spec", "managementPolicies")
+						return nil
+					},
+				}, nil
+			}),
+			args: args{
+				id:       id,
+				policies: []model.ManagementAction{model.ManagementActionObserve, model.ManagementActionLateInitialize},
+			},
+			want: want{
+				payload: model.SetManagementPoliciesPayload{
+					Resource:           okr,
+					ManagementPolicies: []model.ManagementAction{model.ManagementActionObserve, model.ManagementActionLateInitialize},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := &mutation{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			ctx := graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover)
+			got, err := m.SetManagementPolicies(ctx, tc.args.id, tc.args.policies)
+			errs := graphql.GetErrors(ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ns.SetManagementPolicies(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ns.SetManagementPolicies(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.payload, got, cmpopts.IgnoreFields(model.GenericResource{}, "PavedAccess"), cmpopts.IgnoreUnexported(model.ObjectMeta{})); diff != "" {
+				t.Errorf("\n%s\ns.SetManagementPolicies(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSetDeletionPolicy(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type args struct {
+		id     model.ReferenceID
+		policy model.DeletionPolicy
+	}
+	type want struct {
+		payload model.SetDeletionPolicyPayload
+		err     error
+		errs    gqlerror.List
+	}
+
+	id := model.ReferenceID{
+		APIVersion: "example.org/v1",
+		Kind:       "Example",
+		Name:       "example",
+	}
+
+	orphaned := &unstructured.Unstructured{}
+	orphaned.SetAPIVersion(id.APIVersion)
+	orphaned.SetKind(id.Kind)
+	orphaned.SetName(id.Name)
+	_ = unstructured.SetNestedField(orphaned.Object, "Orphan", "spec", "deletionPolicy")
+	okr, _ := model.GetKubernetesResource(orphaned)
+
+	orphan := model.DeletionPolicyOrphan
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		args    args
+		want    want
+	}{
+		"UnknownPolicy": {
+			reason: "If an unknown policy is supplied we should add an error to the GraphQL context and return early.",
+			args: args{
+				id:     id,
+				policy: "Wat",
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errors.New(`unknown deletion policy "Wat"`), errSetDeletionPolicy)),
+				},
+			},
+		},
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return nil, errBoom
+			}),
+			args: args{
+				id:     id,
+				policy: model.DeletionPolicyOrphan,
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"PatchError": {
+			reason: "If we can't patch the resource we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockPatch: test.NewMockPatchFn(errBoom)}, nil
+			}),
+			args: args{
+				id:     id,
+				policy: model.DeletionPolicyOrphan,
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errSetDeletionPolicy)),
+				},
+			},
+		},
+		"Success": {
+			reason: "Setting the deletion policy should patch the resource's deletion policy.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockPatch: func(_ context.Context, obj client.Object, p client.Patch, _ ...client.PatchOption) error {
+						if diff := cmp.Diff(types.MergePatchType, p.Type()); diff != "" {
+							t.Errorf("-want patch type, +got patch type:\n%s", diff)
+						}
+						want := `{"spec":{"deletionPolicy":"Orphan"}}`
+						got, _ := p.Data(obj)
+						if diff := cmp.Diff(want, string(got)); diff != "" {
+							t.Errorf("-want patch, +got patch:\n%s", diff)
+						}
+						u := obj.(*unstructured.Unstructured)
+						_ = unstructured.SetNestedField(u.Object, "Orphan", "spec", "deletionPolicy")
+						return nil
+					},
+				}, nil
+			}),
+			args: args{
+				id:     id,
+				policy: model.DeletionPolicyOrphan,
+			},
+			want: want{
+				payload: model.SetDeletionPolicyPayload{
+					Resource:       okr,
+					DeletionPolicy: &orphan,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := &mutation{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			ctx := graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover)
+			got, err := m.SetDeletionPolicy(ctx, tc.args.id, tc.args.policy)
+			errs := graphql.GetErrors(ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ns.SetDeletionPolicy(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ns.SetDeletionPolicy(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.payload, got, cmpopts.IgnoreFields(model.GenericResource{}, "PavedAccess"), cmpopts.IgnoreUnexported(model.ObjectMeta{})); diff != "" {
+				t.Errorf("\n%s\ns.SetDeletionPolicy(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestActivateRevision(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	id := model.ReferenceID{
+		APIVersion: "pkg.crossplane.io/v1",
+		Kind:       "Provider",
+		Name:       "provider-example",
+	}
+
+	pkg := &unstructured.Unstructured{}
+	pkg.SetAPIVersion(id.APIVersion)
+	pkg.SetKind(id.Kind)
+	pkg.SetName(id.Name)
+	pkg.SetUID("pkg-uid")
+
+	revision := func(name, owner, desiredState string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion(id.APIVersion)
+		u.SetKind("ProviderRevision")
+		u.SetName(name)
+		u.SetOwnerReferences([]v1.OwnerReference{{UID: types.UID(owner), Controller: ptr.To(true)}})
+		_ = unstructured.SetNestedField(u.Object, desiredState, "spec", "desiredState")
+		return u
+	}
+	revs := []*unstructured.Unstructured{
+		revision("old", "pkg-uid", "Active"),
+		revision("older", "pkg-uid", "Inactive"),
+		revision("new", "pkg-uid", "Inactive"),
+		revision("theirs", "other-uid", "Active"),
+	}
+
+	// get returns the package, or the named revision.
+	get := func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+		u := obj.(*unstructured.Unstructured)
+		if u.GetKind() == id.Kind {
+			*u = *pkg.DeepCopy()
+			return nil
+		}
+		for _, r := range revs {
+			if r.GetName() == key.Name {
+				*u = *r.DeepCopy()
+				return nil
+			}
+		}
+		return kerrors.NewNotFound(schema.GroupResource{Resource: "providerrevisions"}, key.Name)
+	}
+	list := test.NewMockListFn(nil, func(obj client.ObjectList) error {
+		l := obj.(*unstructured.UnstructuredList)
+		for _, r := range revs {
+			l.Items = append(l.Items, *r.DeepCopy())
+		}
+		return nil
+	})
+
+	// patch applies the supplied patch to the supplied object, and records it
+	// by object name. It fails to patch objects with the supplied names.
+	patch := func(patches map[string]string, fail ...string) func(_ context.Context, obj client.Object, p client.Patch, _ ...client.PatchOption) error {
+		return func(_ context.Context, obj client.Object, p client.Patch, _ ...client.PatchOption) error {
+			for _, n := range fail {
+				if obj.GetName() == n {
+					return errBoom
+				}
+			}
+			data, _ := p.Data(obj)
+			patches[obj.GetName()] = string(data)
+			u := obj.(*unstructured.Unstructured)
+			m := map[string]any{}
+			_ = json.Unmarshal(data, &m)
+			spec, _ := m["spec"].(map[string]any)
+			for k, v := range spec {
+				_ = unstructured.SetNestedField(u.Object, v, "spec", k)
+			}
+			return nil
+		}
+	}
+
+	activated := pkg.DeepCopy()
+	_ = unstructured.SetNestedField(activated.Object, "Manual", "spec", "revisionActivationPolicy")
+	pkr, _ := model.GetKubernetesResource(activated)
+	active := revision("new", "pkg-uid", "Active")
+	rkr, _ := model.GetKubernetesResource(active)
+
+	type args struct {
+		id       model.ReferenceID
+		revision string
+	}
+	type want struct {
+		payload model.ActivateRevisionPayload
+		err     error
+		errs    gqlerror.List
+		patches map[string]string
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients func(patches map[string]string) ClientCache
+		args    args
+		want    want
+	}{
+		"NotPackage": {
+			reason: "If the supplied ID isn't a package we should add an error to the GraphQL context and return early.",
+			args: args{
+				id:       model.ReferenceID{APIVersion: "example.org/v1", Kind: "Provider", Name: "example"},
+				revision: "new",
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Errorf(errFmtNotPackage, "example")),
+				},
+			},
+		},
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: func(_ map[string]string) ClientCache {
+				return ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+					return nil, errBoom
+				})
+			},
+			args: args{id: id, revision: "new"},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"GetPackageError": {
+			reason: "If we can't get the package we should add the error to the GraphQL context and return early.",
+			clients: func(_ map[string]string) ClientCache {
+				return ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+					return &test.MockClient{MockGet: test.NewMockGetFn(errBoom)}, nil
+				})
+			},
+			args: args{id: id, revision: "new"},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetPackage)),
+				},
+			},
+		},
+		"GetRevisionError": {
+			reason: "If we can't get the revision we should add the error to the GraphQL context and return early.",
+			clients: func(_ map[string]string) ClientCache {
+				return ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+					return &test.MockClient{MockGet: get}, nil
+				})
+			},
+			args: args{id: id, revision: "nonexistent"},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(kerrors.NewNotFound(schema.GroupResource{Resource: "providerrevisions"}, "nonexistent"), errGetRevision)),
+				},
+			},
+		},
+		"NotRevisionOfPackage": {
+			reason: "If the revision isn't controlled by the package we should add an error to the GraphQL context and return early.",
+			clients: func(_ map[string]string) ClientCache {
+				return ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+					return &test.MockClient{MockGet: get}, nil
+				})
+			},
+			args: args{id: id, revision: "theirs"},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Errorf(errFmtNotRevisionOf, "theirs", id.Name)),
+				},
+			},
+		},
+		"SetActivationPolicyError": {
+			reason: "If we can't set the package's activation policy we should add the error to the GraphQL context and return early.",
+			clients: func(_ map[string]string) ClientCache {
+				return ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+					return &test.MockClient{MockGet: get, MockPatch: test.NewMockPatchFn(errBoom)}, nil
+				})
+			},
+			args: args{id: id, revision: "new"},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errSetActivationPolicy)),
+				},
+			},
+		},
+		"ListRevisionsError": {
+			reason: "If we can't list the package's revisions we should add the error to the GraphQL context and return early.",
+			clients: func(_ map[string]string) ClientCache {
+				return ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+					return &test.MockClient{MockGet: get, MockPatch: test.NewMockPatchFn(nil), MockList: test.NewMockListFn(errBoom)}, nil
+				})
+			},
+			args: args{id: id, revision: "new"},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errListRevisions)),
+				},
+			},
+		},
+		"ActivateRevisionError": {
+			reason: "If we can't activate the revision we should add the error to the GraphQL context and reactivate the revisions we deactivated.",
+			clients: func(patches map[string]string) ClientCache {
+				return ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+					return &test.MockClient{MockGet: get, MockList: list, MockPatch: patch(patches, "new")}, nil
+				})
+			},
+			args: args{id: id, revision: "new"},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errActivateRevision)),
+				},
+				patches: map[string]string{
+					"provider-example": `{"spec":{"revisionActivationPolicy":"Manual"}}`,
+					"old":              `{"spec":{"desiredState":"Active"}}`,
+				},
+			},
+		},
+		"ReactivateRevisionError": {
+			reason: "If we can't reactivate a revision we deactivated we should add the error to the GraphQL context.",
+			clients: func(patches map[string]string) ClientCache {
+				return ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+					mp := patch(patches, "new")
+					return &test.MockClient{
+						MockGet:  get,
+						MockList: list,
+						MockPatch: func(ctx context.Context, obj client.Object, p client.Patch, o ...client.PatchOption) error {
+							// Deactivate old, then fail to reactivate it.
+							if _, ok := patches["old"]; ok && obj.GetName() == "old" {
+								return errBoom
+							}
+							return mp(ctx, obj, p, o...)
+						},
+					}, nil
+				})
+			},
+			args: args{id: id, revision: "new"},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errActivateRevision)),
+					gqlerror.Wrap(errors.Wrapf(errBoom, errFmtReactivate, "old")),
+				},
+				patches: map[string]string{
+					"provider-example": `{"spec":{"revisionActivationPolicy":"Manual"}}`,
+					"old":              `{"spec":{"desiredState":"Inactive"}}`,
+				},
+			},
+		},
+		"Success": {
+			reason: "We should pin the package to the revision, deactivating its other active revisions.",
+			clients: func(patches map[string]string) ClientCache {
+				return ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+					return &test.MockClient{MockGet: get, MockList: list, MockPatch: patch(patches)}, nil
+				})
+			},
+			args: args{id: id, revision: "new"},
+			want: want{
+				payload: model.ActivateRevisionPayload{Package: pkr, ActiveRevision: rkr},
+				patches: map[string]string{
+					"provider-example": `{"spec":{"revisionActivationPolicy":"Manual"}}`,
+					"old":              `{"spec":{"desiredState":"Inactive"}}`,
+					"new":              `{"spec":{"desiredState":"Active"}}`,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			patches := map[string]string{}
+			m := &mutation{}
+			if tc.clients != nil {
+				m.clients = tc.clients(patches)
+			}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			ctx := graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover)
+			got, err := m.ActivateRevision(ctx, tc.args.id, tc.args.revision)
+			errs := graphql.GetErrors(ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ns.ActivateRevision(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ns.ActivateRevision(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.payload, got, cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
+				t.Errorf("\n%s\ns.ActivateRevision(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.patches, patches, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("\n%s\ns.ActivateRevision(...): -want patches, +got patches:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRemoveFinalizer(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type args struct {
+		disabled  bool
+		id        model.ReferenceID
+		finalizer string
+	}
+	type want struct {
+		payload model.RemoveFinalizerPayload
+		err     error
+		errs    gqlerror.List
+	}
+
+	id := model.ReferenceID{
+		APIVersion: "example.org/v1",
+		Kind:       "Example",
+		Name:       "example",
+	}
+
+	stuck := &unstructured.Unstructured{}
+	stuck.SetAPIVersion(id.APIVersion)
+	stuck.SetKind(id.Kind)
+	stuck.SetName(id.Name)
+	stuck.SetFinalizers([]string{"example.org/a", "example.org/b"})
+
+	unstuck := stuck.DeepCopy()
+	unstuck.SetFinalizers([]string{"example.org/a"})
+	ukr, _ := model.GetKubernetesResource(unstuck)
+
+	getStuck := test.NewMockGetFn(nil, func(obj client.Object) error {
+		stuck.DeepCopyInto(obj.(*unstructured.Unstructured))
+		return nil
+	})
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		args    args
+		want    want
+	}{
+		"Disabled": {
+			reason: "If finalizer removal isn't enabled we should add an error to the GraphQL context and return early.",
+			args: args{
+				disabled:  true,
+				id:        id,
+				finalizer: "example.org/b",
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.New(errFinalizersDisabled)),
+				},
+			},
+		},
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return nil, errBoom
+			}),
+			args: args{
+				id:        id,
+				finalizer: "example.org/b",
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"GetResourceError": {
+			reason: "If we can't get the resource we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockGet: test.NewMockGetFn(errBoom)}, nil
+			}),
+			args: args{
+				id:        id,
+				finalizer: "example.org/b",
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetResource)),
+				},
+			},
+		},
+		"NoSuchFinalizer": {
+			reason: "If the resource doesn't have the finalizer we should add an error to the GraphQL context without patching it.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: getStuck,
+					MockPatch: func(_ context.Context, _ client.Object, _ client.Patch, _ ...client.PatchOption) error {
+						t.Errorf("Patch(...): unexpected patch")
+						return nil
+					},
+				}, nil
+			}),
+			args: args{
+				id:        id,
+				finalizer: "example.org/c",
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Errorf(errFmtNoFinalizer, "example.org/c")),
+				},
+			},
+		},
+		"PatchError": {
+			reason: "If we can't patch the resource we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockGet: getStuck, MockPatch: test.NewMockPatchFn(errBoom)}, nil
+			}),
+			args: args{
+				id:        id,
+				finalizer: "example.org/b",
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errRemoveFinalizer)),
+				},
+			},
+		},
+		"Success": {
+			reason: "Removing a finalizer should patch out only that finalizer.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: getStuck,
+					MockPatch: func(_ context.Context, obj client.Object, p client.Patch, _ ...client.PatchOption) error {
+						if diff := cmp.Diff(types.JSONPatchType, p.Type()); diff != "" {
+							t.Errorf("-want patch type, +got patch type:\n%s", diff)
+						}
+						want := `[{"op":"test","path":"/metadata/finalizers/1","value":"example.org/b"},{"op":"remove","path":"/metadata/finalizers/1"}]`
+						got, _ := p.Data(obj)
+						if diff := cmp.Diff(want, string(got)); diff != "" {
+							t.Errorf("-want patch, +got patch:\n%s", diff)
+						}
+						unstuck.DeepCopyInto(obj.(*unstructured.Unstructured))
+						return nil
+					},
+				}, nil
+			}),
+			args: args{
+				id:        id,
+				finalizer: "example.org/b",
+			},
+			want: want{
+				payload: model.RemoveFinalizerPayload{Resource: ukr},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := &mutation{clients: tc.clients, log: logging.NewNopLogger(), finalizers: !tc.args.disabled}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			ctx := graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover)
+			got, err := m.RemoveFinalizer(ctx, tc.args.id, tc.args.finalizer)
+			errs := graphql.GetErrors(ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ns.RemoveFinalizer(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ns.RemoveFinalizer(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.payload, got, cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
+				t.Errorf("\n%s\ns.RemoveFinalizer(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
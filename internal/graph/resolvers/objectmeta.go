@@ -16,34 +16,79 @@ package resolvers
 
 import (
 	"context"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/99designs/gqlgen/graphql"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/duration"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 
 	"github.com/upbound/xgql/internal/auth"
+	"github.com/upbound/xgql/internal/clients"
 	"github.com/upbound/xgql/internal/graph/model"
 )
 
 const (
 	errGetOwner   = "cannot get owner"
 	errModelOwner = "cannot model owner"
+	errListOwned  = "cannot list owned resources"
+	errModelOwned = "cannot model owned resource"
+
+	errFmtTooManyOwnedKinds = "cannot search more than %d kinds of owned resource"
+	errFmtTooManyOwned      = "only the first %d owned resources of this kind are returned"
+)
+
+const (
+	// relatedWorkers is the maximum number of related resources, or kinds of
+	// owned resource, read concurrently.
+	relatedWorkers = 8
+
+	// maxOwnedKinds is the maximum number of kinds of owned resource that may
+	// be searched at once. Each kind costs at least one list.
+	maxOwnedKinds = 16
+
+	// maxOwned is the maximum number of owned resources of each kind that are
+	// returned.
+	maxOwned = 100
 )
 
 type objectMeta struct {
 	clients ClientCache
 }
 
+func (r *objectMeta) Age(ctx context.Context, obj *model.ObjectMeta) (*string, error) {
+	if obj.CreationTime.IsZero() {
+		return nil, nil
+	}
+
+	// Ages are relative to when the request started, so that every resource
+	// in a response is aged relative to the same time.
+	now := time.Now()
+	if graphql.HasOperationContext(ctx) {
+		if start := graphql.GetOperationContext(ctx).Stats.OperationStart; !start.IsZero() {
+			now = start
+		}
+	}
+
+	// The API server's clock may be slightly ahead of ours.
+	age := max(now.Sub(obj.CreationTime), 0)
+	return ptr.To(duration.HumanDuration(age)), nil
+}
+
 func (r *objectMeta) Owners(ctx context.Context, obj *model.ObjectMeta) (model.OwnerConnection, error) {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
 	creds, _ := auth.FromContext(ctx)
-	c, err := r.clients.Get(creds)
+	c, err := r.clients.GetWithContext(ctx, creds)
 	if err != nil {
 		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
 		return model.OwnerConnection{}, nil
@@ -91,7 +136,7 @@ func (r *objectMeta) Controller(ctx context.Context, obj *model.ObjectMeta) (mod
 	defer cancel()
 
 	creds, _ := auth.FromContext(ctx)
-	c, err := r.clients.Get(creds)
+	c, err := r.clients.GetWithContext(ctx, creds)
 	if err != nil {
 		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
 		return nil, nil
@@ -122,3 +167,176 @@ func (r *objectMeta) Controller(ctx context.Context, obj *model.ObjectMeta) (mod
 
 	return nil, nil
 }
+
+func (r *objectMeta) Related(ctx context.Context, obj *model.ObjectMeta, ownedKinds []model.TypeReferenceInput) (model.RelatedResources, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.GetWithContext(ctx, creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.RelatedResources{Owners: []model.RelatedResource{}, Owned: []model.RelatedResource{}}, nil
+	}
+
+	if len(ownedKinds) > maxOwnedKinds {
+		graphql.AddError(ctx, errors.Errorf(errFmtTooManyOwnedKinds, maxOwnedKinds))
+		return model.RelatedResources{Owners: []model.RelatedResource{}, Owned: []model.RelatedResource{}}, nil
+	}
+
+	owners := make([]model.RelatedResource, len(obj.OwnerReferences))
+	owned := make([][]model.RelatedResource, len(ownedKinds))
+
+	// Read owners and list owned kinds concurrently, but bound how many we
+	// read at once. Each error is recorded alongside its relationship rather
+	// than failing the whole result.
+	workers := make(chan struct{}, relatedWorkers)
+	var wg sync.WaitGroup
+	for i, ref := range obj.OwnerReferences {
+		select {
+		case workers <- struct{}{}:
+		case <-ctx.Done():
+			owners[i] = model.RelatedResource{
+				APIVersion: ref.APIVersion,
+				Kind:       ref.Kind,
+				Name:       ptr.To(ref.Name),
+				Controller: ref.Controller,
+				Error:      ptr.To(errors.Wrap(ctx.Err(), errGetOwner).Error()),
+			}
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-workers }()
+			owners[i] = getOwner(ctx, c, ptr.Deref(obj.Namespace, ""), ref)
+		}()
+	}
+	for i, kind := range ownedKinds {
+		select {
+		case workers <- struct{}{}:
+		case <-ctx.Done():
+			owned[i] = []model.RelatedResource{{
+				APIVersion: kind.APIVersion,
+				Kind:       kind.Kind,
+				Error:      ptr.To(errors.Wrap(ctx.Err(), errListOwned).Error()),
+			}}
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-workers }()
+			owned[i] = listOwned(ctx, c, obj, kind)
+		}()
+	}
+	wg.Wait()
+
+	out := model.RelatedResources{Owners: owners, Owned: []model.RelatedResource{}}
+	for _, rrs := range owned {
+		out.Owned = append(out.Owned, rrs...)
+	}
+	return out, nil
+}
+
+// getOwner returns the owner referenced by the supplied owner reference.
+func getOwner(ctx context.Context, c client.Reader, namespace string, ref metav1.OwnerReference) model.RelatedResource {
+	rr := model.RelatedResource{
+		APIVersion: ref.APIVersion,
+		Kind:       ref.Kind,
+		Name:       ptr.To(ref.Name),
+		Controller: ref.Controller,
+	}
+
+	u := &kunstructured.Unstructured{}
+	u.SetAPIVersion(ref.APIVersion)
+	u.SetKind(ref.Kind)
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, u); err != nil {
+		rr.Error = ptr.To(errors.Wrap(err, errGetOwner).Error())
+		return rr
+	}
+
+	kr, err := model.GetKubernetesResource(u)
+	if err != nil {
+		rr.Error = ptr.To(errors.Wrap(err, errModelOwner).Error())
+		return rr
+	}
+	rr.Resource = kr
+	return rr
+}
+
+// listOwned returns the resources of the supplied kind that are owned by the
+// supplied resource. At most maxOwned are returned; if there are more an error
+// is recorded alongside the kind.
+func listOwned(ctx context.Context, c client.Reader, owner *model.ObjectMeta, kind model.TypeReferenceInput) []model.RelatedResource {
+	// A namespaced resource may only own resources in its own namespace.
+	lopts := []client.ListOption{}
+	if ns := ptr.Deref(owner.Namespace, ""); ns != "" {
+		lopts = append(lopts, client.InNamespace(ns))
+	}
+
+	// We find owned resources using the owner UID index if the kind is
+	// indexed. Not every kind is, so if the index is missing we list every
+	// resource of the kind and filter them.
+	l := &kunstructured.UnstructuredList{}
+	l.SetAPIVersion(kind.APIVersion)
+	l.SetKind(kind.Kind + "List")
+	err := c.List(ctx, l, append(lopts, client.MatchingFields{clients.IndexFieldOwnerUIDs: owner.UID})...)
+	if err != nil && indexMissing(err) {
+		l = &kunstructured.UnstructuredList{}
+		l.SetAPIVersion(kind.APIVersion)
+		l.SetKind(kind.Kind + "List")
+		err = c.List(ctx, l, lopts...)
+	}
+	if err != nil {
+		return []model.RelatedResource{{
+			APIVersion: kind.APIVersion,
+			Kind:       kind.Kind,
+			Error:      ptr.To(errors.Wrap(err, errListOwned).Error()),
+		}}
+	}
+
+	out := make([]model.RelatedResource, 0)
+	for i := range l.Items {
+		u := &l.Items[i]
+		for _, ref := range u.GetOwnerReferences() {
+			if string(ref.UID) != owner.UID {
+				continue
+			}
+			if len(out) == maxOwned {
+				return append(out, model.RelatedResource{
+					APIVersion: kind.APIVersion,
+					Kind:       kind.Kind,
+					Error:      ptr.To(errors.Errorf(errFmtTooManyOwned, maxOwned).Error()),
+				})
+			}
+			rr := model.RelatedResource{
+				APIVersion: u.GetAPIVersion(),
+				Kind:       u.GetKind(),
+				Name:       ptr.To(u.GetName()),
+				Controller: ref.Controller,
+			}
+			kr, err := model.GetKubernetesResource(u)
+			if err != nil {
+				rr.Error = ptr.To(errors.Wrap(err, errModelOwned).Error())
+			} else {
+				rr.Resource = kr
+			}
+			out = append(out, rr)
+			break
+		}
+	}
+	return out
+}
+
+// indexMissing returns true if the supplied error indicates that resources
+// couldn't be listed using an index because the index doesn't exist. Cached
+// lists fail this way if the kind isn't indexed. Lists served by the API server
+// fail with a bad request, because it doesn't support the index's field.
+func indexMissing(err error) bool {
+	if kerrors.IsBadRequest(err) {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "index with name") && strings.Contains(msg, "does not exist")
+}
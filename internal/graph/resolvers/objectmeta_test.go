@@ -17,11 +17,13 @@ package resolvers
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/99designs/gqlgen/graphql"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/vektah/gqlparser/v2/gqlerror"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/utils/ptr"
@@ -38,6 +40,69 @@ import (
 
 var _ generated.ObjectMetaResolver = &objectMeta{}
 
+func TestObjectMetaAge(t *testing.T) {
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	withStart := func(start time.Time) context.Context {
+		return graphql.WithOperationContext(context.Background(), &graphql.OperationContext{Stats: graphql.Stats{OperationStart: start}})
+	}
+
+	type args struct {
+		ctx context.Context
+		obj *model.ObjectMeta
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   *string
+	}{
+		"NoCreationTime": {
+			reason: "We should return null if the resource has no creation time.",
+			args: args{
+				ctx: withStart(start),
+				obj: &model.ObjectMeta{},
+			},
+		},
+		"Seconds": {
+			reason: "We should return the age of the resource relative to when the request started.",
+			args: args{
+				ctx: withStart(start),
+				obj: &model.ObjectMeta{CreationTime: start.Add(-45 * time.Second)},
+			},
+			want: ptr.To("45s"),
+		},
+		"Days": {
+			reason: "We should format the age of the resource like kubectl does.",
+			args: args{
+				ctx: withStart(start),
+				obj: &model.ObjectMeta{CreationTime: start.Add(-3*24*time.Hour - 5*time.Hour)},
+			},
+			want: ptr.To("3d5h"),
+		},
+		"CreatedAfterStart": {
+			reason: "We should not return a negative age if the resource appears to have been created after the request started.",
+			args: args{
+				ctx: withStart(start),
+				obj: &model.ObjectMeta{CreationTime: start.Add(time.Second)},
+			},
+			want: ptr.To("0s"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			om := &objectMeta{}
+			got, err := om.Age(tc.args.ctx, tc.args.obj)
+			if err != nil {
+				t.Fatalf("\n%s\nom.Age(...): unexpected error: %s", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nom.Age(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
 func TestObjectMetaOwners(t *testing.T) {
 	errBoom := errors.New("boom")
 
@@ -70,7 +135,7 @@ func TestObjectMetaOwners(t *testing.T) {
 	}{
 		"GetClientError": {
 			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, errBoom
 			}),
 			args: args{
@@ -84,7 +149,7 @@ func TestObjectMetaOwners(t *testing.T) {
 		},
 		"GetOwnerError": {
 			reason: "If we can't get an owner we should add the error to the GraphQL context and continue.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
 						if k, ok := obj.(interface {
@@ -181,7 +246,7 @@ func TestObjectMetaController(t *testing.T) {
 	}{
 		"GetClientError": {
 			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, errBoom
 			}),
 			args: args{
@@ -195,7 +260,7 @@ func TestObjectMetaController(t *testing.T) {
 		},
 		"GetControllerError": {
 			reason: "If we can't get the controller we should add the error to the GraphQL context and continue.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(errBoom),
 				}, nil
@@ -220,7 +285,7 @@ func TestObjectMetaController(t *testing.T) {
 		},
 		"FoundController": {
 			reason: "If we find and model the controller we should return it.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(nil),
 				}, nil
@@ -247,7 +312,7 @@ func TestObjectMetaController(t *testing.T) {
 		},
 		"NoController": {
 			reason: "If there is no controller we should return nil.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(nil),
 				}, nil
@@ -293,3 +358,321 @@ func TestObjectMetaController(t *testing.T) {
 		})
 	}
 }
+
+func TestObjectMetaRelated(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	// An owner
+	own := unstructured.Unstructured{}
+	own.SetAPIVersion("example.org/v1")
+	own.SetKind("AnOwner")
+	own.SetName("cool-owner")
+	gown, _ := model.GetKubernetesResource(&own)
+
+	// An owned resource
+	owned := unstructured.Unstructured{}
+	owned.SetAPIVersion("example.org/v1")
+	owned.SetKind("Owned")
+	owned.SetName("cool-owned")
+	owned.SetOwnerReferences([]metav1.OwnerReference{{UID: "cool-uid", Controller: ptr.To(true)}})
+	gowned, _ := model.GetKubernetesResource(&owned)
+
+	// A resource owned by something else
+	other := unstructured.Unstructured{}
+	other.SetAPIVersion("example.org/v1")
+	other.SetKind("Owned")
+	other.SetName("other-owned")
+	other.SetOwnerReferences([]metav1.OwnerReference{{UID: "other-uid"}})
+
+	obj := &model.ObjectMeta{
+		UID: "cool-uid",
+		OwnerReferences: []metav1.OwnerReference{
+			{APIVersion: own.GetAPIVersion(), Kind: own.GetKind(), Name: own.GetName()},
+			{APIVersion: "example.org/v1", Kind: "Missing", Name: "missing", Controller: ptr.To(true)},
+		},
+	}
+	kinds := []model.TypeReferenceInput{{APIVersion: "example.org/v1", Kind: "Owned"}}
+
+	getOwner := test.NewMockGetFn(nil, func(obj client.Object) error {
+		if obj.(*unstructured.Unstructured).GetKind() != own.GetKind() {
+			return errBoom
+		}
+		own.DeepCopyInto(obj.(*unstructured.Unstructured))
+		return nil
+	})
+	indexed := func(_ context.Context, _ client.ObjectList, opts ...client.ListOption) error {
+		for _, o := range opts {
+			if _, ok := o.(client.MatchingFields); ok {
+				return nil
+			}
+		}
+		return errBoom
+	}
+	// unindexed lists return the supplied error if they try to use an index.
+	unindexed := func(err error) test.MockListFn {
+		return func(ctx context.Context, l client.ObjectList, opts ...client.ListOption) error {
+			if indexed(ctx, l, opts...) == nil {
+				return err
+			}
+			l.(*unstructured.UnstructuredList).Items = []unstructured.Unstructured{other, owned}
+			return nil
+		}
+	}
+
+	type args struct {
+		ctx   context.Context
+		obj   *model.ObjectMeta
+		kinds []model.TypeReferenceInput
+	}
+	type want struct {
+		rr   model.RelatedResources
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		args    args
+		want    want
+	}{
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, errBoom
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: obj,
+			},
+			want: want{
+				rr: model.RelatedResources{Owners: []model.RelatedResource{}, Owned: []model.RelatedResource{}},
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"Indexed": {
+			reason: "We should return owners and owned resources, recording errors alongside the relationships they affect.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: getOwner,
+					MockList: func(ctx context.Context, l client.ObjectList, opts ...client.ListOption) error {
+						if err := indexed(ctx, l, opts...); err != nil {
+							return err
+						}
+						l.(*unstructured.UnstructuredList).Items = []unstructured.Unstructured{owned}
+						return nil
+					},
+				}, nil
+			}),
+			args: args{
+				ctx:   graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj:   obj,
+				kinds: kinds,
+			},
+			want: want{
+				rr: model.RelatedResources{
+					Owners: []model.RelatedResource{
+						{APIVersion: "example.org/v1", Kind: "AnOwner", Name: ptr.To("cool-owner"), Resource: gown},
+						{APIVersion: "example.org/v1", Kind: "Missing", Name: ptr.To("missing"), Controller: ptr.To(true), Error: ptr.To(errors.Wrap(errBoom, errGetOwner).Error())},
+					},
+					Owned: []model.RelatedResource{
+						{APIVersion: "example.org/v1", Kind: "Owned", Name: ptr.To("cool-owned"), Controller: ptr.To(true), Resource: gowned},
+					},
+				},
+			},
+		},
+		"Unindexed": {
+			reason: "If the kind isn't indexed we should list and filter owned resources.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: unindexed(errors.Errorf("Index with name field:%s does not exist", clients.IndexFieldOwnerUIDs)),
+				}, nil
+			}),
+			args: args{
+				ctx:   graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj:   &model.ObjectMeta{UID: "cool-uid"},
+				kinds: kinds,
+			},
+			want: want{
+				rr: model.RelatedResources{
+					Owners: []model.RelatedResource{},
+					Owned: []model.RelatedResource{
+						{APIVersion: "example.org/v1", Kind: "Owned", Name: ptr.To("cool-owned"), Controller: ptr.To(true), Resource: gowned},
+					},
+				},
+			},
+		},
+		"UncachedUnindexed": {
+			reason: "If the API server doesn't support listing by the index we should list and filter owned resources.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: unindexed(kerrors.NewBadRequest("field label not supported: " + clients.IndexFieldOwnerUIDs)),
+				}, nil
+			}),
+			args: args{
+				ctx:   graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj:   &model.ObjectMeta{UID: "cool-uid"},
+				kinds: kinds,
+			},
+			want: want{
+				rr: model.RelatedResources{
+					Owners: []model.RelatedResource{},
+					Owned: []model.RelatedResource{
+						{APIVersion: "example.org/v1", Kind: "Owned", Name: ptr.To("cool-owned"), Controller: ptr.To(true), Resource: gowned},
+					},
+				},
+			},
+		},
+		"ListIndexedError": {
+			reason: "If we can't list owned resources using the index for a reason other than the index being missing we should record the error rather than listing every resource of the kind.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: unindexed(errBoom),
+				}, nil
+			}),
+			args: args{
+				ctx:   graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj:   &model.ObjectMeta{UID: "cool-uid"},
+				kinds: kinds,
+			},
+			want: want{
+				rr: model.RelatedResources{
+					Owners: []model.RelatedResource{},
+					Owned: []model.RelatedResource{
+						{APIVersion: "example.org/v1", Kind: "Owned", Error: ptr.To(errors.Wrap(errBoom, errListOwned).Error())},
+					},
+				},
+			},
+		},
+		"ListOwnedError": {
+			reason: "If we can't list a kind of owned resource we should record the error alongside that kind.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(errBoom),
+				}, nil
+			}),
+			args: args{
+				ctx:   graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj:   &model.ObjectMeta{UID: "cool-uid"},
+				kinds: kinds,
+			},
+			want: want{
+				rr: model.RelatedResources{
+					Owners: []model.RelatedResource{},
+					Owned: []model.RelatedResource{
+						{APIVersion: "example.org/v1", Kind: "Owned", Error: ptr.To(errors.Wrap(errBoom, errListOwned).Error())},
+					},
+				},
+			},
+		},
+		"TooManyOwnedKinds": {
+			reason: "If too many kinds of owned resource are supplied we should add an error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, nil
+			}),
+			args: args{
+				ctx:   graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj:   obj,
+				kinds: make([]model.TypeReferenceInput, maxOwnedKinds+1),
+			},
+			want: want{
+				rr: model.RelatedResources{Owners: []model.RelatedResource{}, Owned: []model.RelatedResource{}},
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Errorf(errFmtTooManyOwnedKinds, maxOwnedKinds)),
+				},
+			},
+		},
+		"TooManyOwned": {
+			reason: "If there are too many owned resources of a kind we should return the first few, and record an error alongside the kind.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: func(_ context.Context, l client.ObjectList, _ ...client.ListOption) error {
+						items := make([]unstructured.Unstructured, maxOwned+1)
+						for i := range items {
+							owned.DeepCopyInto(&items[i])
+						}
+						l.(*unstructured.UnstructuredList).Items = items
+						return nil
+					},
+				}, nil
+			}),
+			args: args{
+				ctx:   graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj:   &model.ObjectMeta{UID: "cool-uid"},
+				kinds: kinds,
+			},
+			want: want{
+				rr: model.RelatedResources{
+					Owners: []model.RelatedResource{},
+					Owned: func() []model.RelatedResource {
+						out := make([]model.RelatedResource, 0, maxOwned+1)
+						for range maxOwned {
+							out = append(out, model.RelatedResource{APIVersion: "example.org/v1", Kind: "Owned", Name: ptr.To("cool-owned"), Controller: ptr.To(true), Resource: gowned})
+						}
+						return append(out, model.RelatedResource{APIVersion: "example.org/v1", Kind: "Owned", Error: ptr.To(errors.Errorf(errFmtTooManyOwned, maxOwned).Error())})
+					}(),
+				},
+			},
+		},
+		"ContextDone": {
+			reason: "If the context is done before we can read a relationship we should record the error alongside it.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				// A relationship may be read if a worker is free when the
+				// context is done, in which case the read fails the same way.
+				return &test.MockClient{
+					MockGet: func(ctx context.Context, _ client.ObjectKey, _ client.Object) error {
+						return ctx.Err()
+					},
+					MockList: func(ctx context.Context, _ client.ObjectList, _ ...client.ListOption) error {
+						return ctx.Err()
+					},
+				}, nil
+			}),
+			args: args{
+				ctx: func() context.Context {
+					ctx, cancel := context.WithCancel(graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover))
+					cancel()
+					return ctx
+				}(),
+				obj:   &model.ObjectMeta{UID: "cool-uid", OwnerReferences: obj.OwnerReferences[:1]},
+				kinds: kinds,
+			},
+			want: want{
+				rr: model.RelatedResources{
+					Owners: []model.RelatedResource{
+						{APIVersion: "example.org/v1", Kind: "AnOwner", Name: ptr.To("cool-owner"), Error: ptr.To(errors.Wrap(context.Canceled, errGetOwner).Error())},
+					},
+					Owned: []model.RelatedResource{
+						{APIVersion: "example.org/v1", Kind: "Owned", Error: ptr.To(errors.Wrap(context.Canceled, errListOwned).Error())},
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := &objectMeta{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := m.Related(tc.args.ctx, tc.args.obj, tc.args.kinds)
+			errs := graphql.GetErrors(tc.args.ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nq.Related(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nq.Related(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.rr, got,
+				cmpopts.IgnoreUnexported(model.ObjectMeta{}),
+				cmpopts.IgnoreFields(model.GenericResource{}, "PavedAccess"),
+			); diff != "" {
+				t.Errorf("\n%s\nq.Related(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -21,13 +21,21 @@ import (
 	"sync"
 
 	"github.com/99designs/gqlgen/graphql"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	kextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 	pkgv1 "github.com/crossplane/crossplane/apis/pkg/v1"
+	pkgv1alpha1 "github.com/crossplane/crossplane/apis/pkg/v1alpha1"
+	pkgv1beta1 "github.com/crossplane/crossplane/apis/pkg/v1beta1"
 
 	"github.com/upbound/xgql/internal/auth"
 	"github.com/upbound/xgql/internal/graph/model"
@@ -35,8 +43,14 @@ import (
 )
 
 const (
-	errListProviderRevs = "cannot list provider revisions"
-	errGetCRD           = "cannot get custom resource definition"
+	errListProviderRevs    = "cannot list provider revisions"
+	errGetCRD              = "cannot get custom resource definition"
+	errGetRuntimeConfig    = "cannot get runtime config"
+	errModelRuntimeConfig  = "cannot model runtime config"
+	errListDeployments     = "cannot list deployments"
+	errModelDeployment     = "cannot model deployment"
+	errGetServiceAccount   = "cannot get service account"
+	errModelServiceAccount = "cannot model service account"
 )
 
 type provider struct {
@@ -58,7 +72,7 @@ func (r *provider) Revisions(ctx context.Context, obj *model.Provider) (model.Pr
 	defer cancel()
 
 	creds, _ := auth.FromContext(ctx)
-	c, err := r.clients.Get(creds)
+	c, err := r.clients.GetWithContext(ctx, creds)
 	if err != nil {
 		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
 		return model.ProviderRevisionConnection{}, nil
@@ -97,7 +111,7 @@ func (r *provider) ActiveRevision(ctx context.Context, obj *model.Provider) (*mo
 	defer cancel()
 
 	creds, _ := auth.FromContext(ctx)
-	c, err := r.clients.Get(creds)
+	c, err := r.clients.GetWithContext(ctx, creds)
 	if err != nil {
 		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
 		return nil, nil
@@ -131,6 +145,58 @@ func (r *provider) ActiveRevision(ctx context.Context, obj *model.Provider) (*mo
 	return nil, nil
 }
 
+func (r *provider) RuntimeConfig(ctx context.Context, obj *model.Provider) (model.KubernetesResource, error) {
+	// Prefer a provider's DeploymentRuntimeConfig, but fall back to its
+	// ControllerConfig. Crossplane clusters older than v1.14 don't serve
+	// DeploymentRuntimeConfigs, and newer ones may no longer serve
+	// ControllerConfigs.
+	refs := make([]*kunstructured.Unstructured, 0, 2)
+	if ref := obj.Spec.RuntimeConfigRef; ref != nil {
+		u := &kunstructured.Unstructured{}
+		u.SetGroupVersionKind(pkgv1beta1.DeploymentRuntimeConfigGroupVersionKind)
+		u.SetName(ref.Name)
+		refs = append(refs, u)
+	}
+	if ref := obj.Spec.ControllerConfigRef; ref != nil {
+		u := &kunstructured.Unstructured{}
+		u.SetGroupVersionKind(pkgv1alpha1.ControllerConfigGroupVersionKind)
+		u.SetName(ref.Name)
+		refs = append(refs, u)
+	}
+	if len(refs) == 0 {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.GetWithContext(ctx, creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return nil, nil
+	}
+
+	for _, u := range refs {
+		if err := c.Get(ctx, types.NamespacedName{Name: u.GetName()}, u); err != nil {
+			if !meta.IsNoMatchError(err) && !kerrors.IsNotFound(err) {
+				graphql.AddError(ctx, errors.Wrap(err, errGetRuntimeConfig))
+				return nil, nil
+			}
+			continue
+		}
+
+		out, err := model.GetKubernetesResource(u)
+		if err != nil {
+			graphql.AddError(ctx, errors.Wrap(err, errModelRuntimeConfig))
+			return nil, nil
+		}
+		return out, nil
+	}
+
+	return nil, nil
+}
+
 type providerRevision struct {
 	clients ClientCache
 }
@@ -145,6 +211,185 @@ func (r *providerRevision) Events(ctx context.Context, obj *model.ProviderRevisi
 	})
 }
 
+func (r *providerRevision) Deployment(ctx context.Context, obj *model.ProviderRevision) (model.KubernetesResource, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.GetWithContext(ctx, creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return nil, nil
+	}
+
+	d, err := getRuntimeDeployment(ctx, c, types.UID(obj.Metadata.UID))
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errListDeployments))
+		return nil, nil
+	}
+	if d == nil {
+		return nil, nil
+	}
+
+	out, err := model.GetKubernetesResource(d)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errModelDeployment))
+		return nil, nil
+	}
+	return out, nil
+}
+
+func (r *providerRevision) ServiceAccount(ctx context.Context, obj *model.ProviderRevision) (model.KubernetesResource, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.GetWithContext(ctx, creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return nil, nil
+	}
+
+	d, err := getRuntimeDeployment(ctx, c, types.UID(obj.Metadata.UID))
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errListDeployments))
+		return nil, nil
+	}
+	if d == nil {
+		return nil, nil
+	}
+
+	// Pods that don't specify a service account run as the default one.
+	name, _, _ := kunstructured.NestedString(d.Object, "spec", "template", "spec", "serviceAccountName")
+	if name == "" {
+		name = "default"
+	}
+
+	sa := &kunstructured.Unstructured{}
+	sa.SetAPIVersion("v1")
+	sa.SetKind("ServiceAccount")
+	if err := c.Get(ctx, types.NamespacedName{Namespace: d.GetNamespace(), Name: name}, sa); err != nil {
+		if !kerrors.IsNotFound(err) {
+			graphql.AddError(ctx, errors.Wrap(err, errGetServiceAccount))
+		}
+		return nil, nil
+	}
+
+	out, err := model.GetKubernetesResource(sa)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errModelServiceAccount))
+		return nil, nil
+	}
+	return out, nil
+}
+
+func (r *providerRevision) ConfigMaps(ctx context.Context, obj *model.ProviderRevision) ([]model.ConfigMap, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.GetWithContext(ctx, creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return nil, nil
+	}
+
+	u, err := getRuntimeDeployment(ctx, c, types.UID(obj.Metadata.UID))
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errListDeployments))
+		return nil, nil
+	}
+	if u == nil {
+		return nil, nil
+	}
+
+	d := &appsv1.Deployment{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, d); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errModelDeployment))
+		return nil, nil
+	}
+
+	// ConfigMaps are never cached, so each of these reads goes to the API
+	// server.
+	out := make([]model.ConfigMap, 0)
+	for _, name := range configMapNames(d.Spec.Template.Spec) {
+		cm := &corev1.ConfigMap{}
+		if err := c.Get(ctx, types.NamespacedName{Namespace: d.GetNamespace(), Name: name}, cm); err != nil {
+			if !kerrors.IsNotFound(err) {
+				graphql.AddError(ctx, errors.Wrap(err, errGetConfigMap))
+			}
+			continue
+		}
+		out = append(out, model.GetConfigMap(cm))
+	}
+	return out, nil
+}
+
+// configMapNames returns the sorted names of the ConfigMaps the supplied pod
+// spec references as volumes or as environment variables.
+func configMapNames(spec corev1.PodSpec) []string {
+	names := map[string]bool{}
+	for _, v := range spec.Volumes {
+		if v.ConfigMap != nil {
+			names[v.ConfigMap.Name] = true
+		}
+		if v.Projected == nil {
+			continue
+		}
+		for _, src := range v.Projected.Sources {
+			if src.ConfigMap != nil {
+				names[src.ConfigMap.Name] = true
+			}
+		}
+	}
+	for _, ctrs := range [][]corev1.Container{spec.InitContainers, spec.Containers} {
+		for _, ctr := range ctrs {
+			for _, e := range ctr.EnvFrom {
+				if e.ConfigMapRef != nil {
+					names[e.ConfigMapRef.Name] = true
+				}
+			}
+			for _, e := range ctr.Env {
+				if e.ValueFrom != nil && e.ValueFrom.ConfigMapKeyRef != nil {
+					names[e.ValueFrom.ConfigMapKeyRef.Name] = true
+				}
+			}
+		}
+	}
+
+	out := make([]string, 0, len(names))
+	for n := range names {
+		out = append(out, n)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// getRuntimeDeployment returns the Deployment controlled by the package
+// revision with the supplied UID, or nil if there is none. Crossplane may run a
+// revision's Deployment in any namespace, under any name, so we must list
+// Deployments in all namespaces.
+func getRuntimeDeployment(ctx context.Context, c client.Client, uid types.UID) (*kunstructured.Unstructured, error) {
+	in := &kunstructured.UnstructuredList{}
+	in.SetAPIVersion("apps/v1")
+	in.SetKind("DeploymentList")
+	if err := c.List(ctx, in); err != nil {
+		return nil, err
+	}
+
+	for i := range in.Items {
+		d := &in.Items[i]
+
+		// We're not the controller reference of this Deployment; it's not
+		// one of ours.
+		if c := metav1.GetControllerOf(d); c == nil || c.UID != uid {
+			continue
+		}
+		return d, nil
+	}
+	return nil, nil
+}
+
 type providerRevisionStatus struct {
 	clients ClientCache
 }
@@ -154,7 +399,7 @@ func (r *providerRevisionStatus) Objects(ctx context.Context, obj *model.Provide
 	defer cancel()
 
 	creds, _ := auth.FromContext(ctx)
-	c, err := r.clients.Get(creds)
+	c, err := r.clients.GetWithContext(ctx, creds)
 	if err != nil {
 		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
 		return model.KubernetesResourceConnection{}, nil
@@ -22,10 +22,15 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/vektah/gqlparser/v2/gqlerror"
+	corev1 "k8s.io/api/core/v1"
 	kextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	kmeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
@@ -34,6 +39,8 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 	pkgv1 "github.com/crossplane/crossplane/apis/pkg/v1"
+	pkgv1alpha1 "github.com/crossplane/crossplane/apis/pkg/v1alpha1"
+	pkgv1beta1 "github.com/crossplane/crossplane/apis/pkg/v1beta1"
 
 	"github.com/upbound/xgql/internal/auth"
 	"github.com/upbound/xgql/internal/clients"
@@ -94,7 +101,7 @@ func TestProviderRevisions(t *testing.T) {
 	}{
 		"GetClientError": {
 			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, errBoom
 			}),
 			args: args{
@@ -108,7 +115,7 @@ func TestProviderRevisions(t *testing.T) {
 		},
 		"ListRevisionsError": {
 			reason: "If we can't list revisions we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(errBoom),
 				}, nil
@@ -124,7 +131,7 @@ func TestProviderRevisions(t *testing.T) {
 		},
 		"AllRevisions": {
 			reason: "We should successfully return any revisions we own that we can list and model.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
 						*obj.(*pkgv1.ProviderRevisionList) = pkgv1.ProviderRevisionList{
@@ -219,7 +226,7 @@ func TestProviderActiveRevision(t *testing.T) {
 	}{
 		"GetClientError": {
 			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, errBoom
 			}),
 			args: args{
@@ -233,7 +240,7 @@ func TestProviderActiveRevision(t *testing.T) {
 		},
 		"ListRevisionsError": {
 			reason: "If we can't list revisions we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(errBoom),
 				}, nil
@@ -249,7 +256,7 @@ func TestProviderActiveRevision(t *testing.T) {
 		},
 		"FoundActiveRevision": {
 			reason: "We should successfully return the active revision.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
 						*obj.(*pkgv1.ProviderRevisionList) = pkgv1.ProviderRevisionList{
@@ -271,7 +278,7 @@ func TestProviderActiveRevision(t *testing.T) {
 		},
 		"NoActiveRevision": {
 			reason: "If there is no active revision we should return nil.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
 						*obj.(*pkgv1.ProviderRevisionList) = pkgv1.ProviderRevisionList{
@@ -315,6 +322,498 @@ func TestProviderActiveRevision(t *testing.T) {
 	}
 }
 
+func TestProviderRuntimeConfig(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	drc := &kunstructured.Unstructured{}
+	drc.SetGroupVersionKind(pkgv1beta1.DeploymentRuntimeConfigGroupVersionKind)
+	drc.SetName("runtime")
+	gdrc, _ := model.GetKubernetesResource(drc)
+
+	cc := &kunstructured.Unstructured{}
+	cc.SetGroupVersionKind(pkgv1alpha1.ControllerConfigGroupVersionKind)
+	cc.SetName("controller")
+	gcc, _ := model.GetKubernetesResource(cc)
+
+	both := &model.Provider{Spec: model.ProviderSpec{
+		RuntimeConfigRef:    &model.LocalObjectReference{Name: "runtime"},
+		ControllerConfigRef: &model.LocalObjectReference{Name: "controller"},
+	}}
+
+	// get returns the supplied objects, keyed by kind. Kinds without an object
+	// are not served by the API server.
+	get := func(objs ...*kunstructured.Unstructured) test.MockGetFn {
+		return func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			u := obj.(*kunstructured.Unstructured)
+			for _, o := range objs {
+				if o.GetKind() == u.GetKind() {
+					*u = *o.DeepCopy()
+					return nil
+				}
+			}
+			return &kmeta.NoKindMatchError{GroupKind: u.GroupVersionKind().GroupKind()}
+		}
+	}
+
+	type want struct {
+		kr   model.KubernetesResource
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		obj     *model.Provider
+		want    want
+	}{
+		"NoRuntimeConfig": {
+			reason: "A provider that references no runtime config has none.",
+			obj:    &model.Provider{},
+			want:   want{},
+		},
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return nil, errBoom
+			}),
+			obj: both,
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"GetRuntimeConfigError": {
+			reason: "If we can't get the runtime config we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockGet: test.NewMockGetFn(errBoom)}, nil
+			}),
+			obj: both,
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetRuntimeConfig)),
+				},
+			},
+		},
+		"DeploymentRuntimeConfig": {
+			reason: "We should prefer a provider's deployment runtime config.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockGet: get(drc, cc)}, nil
+			}),
+			obj: both,
+			want: want{
+				kr: gdrc,
+			},
+		},
+		"ControllerConfig": {
+			reason: "We should fall back to a provider's controller config if deployment runtime configs aren't served.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockGet: get(cc)}, nil
+			}),
+			obj: both,
+			want: want{
+				kr: gcc,
+			},
+		},
+		"NotServed": {
+			reason: "If neither runtime config API is served the provider has no runtime config.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockGet: get()}, nil
+			}),
+			obj:  both,
+			want: want{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			p := &provider{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			ctx := graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover)
+			got, err := p.RuntimeConfig(ctx, tc.obj)
+			errs := graphql.GetErrors(ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\np.RuntimeConfig(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\np.RuntimeConfig(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.kr, got, cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
+				t.Errorf("\n%s\np.RuntimeConfig(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestProviderRevisionDeployment(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	rev := &model.ProviderRevision{Metadata: model.ObjectMeta{UID: "rev-uid"}}
+
+	ours := &kunstructured.Unstructured{}
+	ours.SetAPIVersion("apps/v1")
+	ours.SetKind("Deployment")
+	ours.SetNamespace("crossplane-system")
+	ours.SetName("ours")
+	ours.SetOwnerReferences([]metav1.OwnerReference{{UID: "rev-uid", Controller: ptr.To(true)}})
+	gours, _ := model.GetKubernetesResource(ours)
+
+	theirs := &kunstructured.Unstructured{}
+	theirs.SetAPIVersion("apps/v1")
+	theirs.SetKind("Deployment")
+	theirs.SetNamespace("crossplane-system")
+	theirs.SetName("theirs")
+	theirs.SetOwnerReferences([]metav1.OwnerReference{{UID: "other-uid", Controller: ptr.To(true)}})
+
+	list := func(objs ...*kunstructured.Unstructured) test.MockListFn {
+		return test.NewMockListFn(nil, func(obj client.ObjectList) error {
+			u := obj.(*kunstructured.UnstructuredList)
+			for _, o := range objs {
+				u.Items = append(u.Items, *o.DeepCopy())
+			}
+			return nil
+		})
+	}
+
+	type want struct {
+		kr   model.KubernetesResource
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		want    want
+	}{
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return nil, errBoom
+			}),
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"ListDeploymentsError": {
+			reason: "If we can't list deployments we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockList: test.NewMockListFn(errBoom)}, nil
+			}),
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errListDeployments)),
+				},
+			},
+		},
+		"NoDeployment": {
+			reason: "A revision that controls no deployment has none.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockList: list(theirs)}, nil
+			}),
+			want: want{},
+		},
+		"Success": {
+			reason: "We should return the deployment controlled by the revision.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockList: list(theirs, ours)}, nil
+			}),
+			want: want{
+				kr: gours,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &providerRevision{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			ctx := graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover)
+			got, err := r.Deployment(ctx, rev)
+			errs := graphql.GetErrors(ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Deployment(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Deployment(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.kr, got, cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
+				t.Errorf("\n%s\nr.Deployment(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestProviderRevisionServiceAccount(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	rev := &model.ProviderRevision{Metadata: model.ObjectMeta{UID: "rev-uid"}}
+
+	deployment := func(sa string) *kunstructured.Unstructured {
+		d := &kunstructured.Unstructured{}
+		d.SetAPIVersion("apps/v1")
+		d.SetKind("Deployment")
+		d.SetNamespace("crossplane-system")
+		d.SetName("ours")
+		d.SetOwnerReferences([]metav1.OwnerReference{{UID: "rev-uid", Controller: ptr.To(true)}})
+		if sa != "" {
+			_ = kunstructured.SetNestedField(d.Object, sa, "spec", "template", "spec", "serviceAccountName")
+		}
+		return d
+	}
+	list := func(objs ...*kunstructured.Unstructured) test.MockListFn {
+		return test.NewMockListFn(nil, func(obj client.ObjectList) error {
+			u := obj.(*kunstructured.UnstructuredList)
+			for _, o := range objs {
+				u.Items = append(u.Items, *o.DeepCopy())
+			}
+			return nil
+		})
+	}
+
+	// get returns a service account with the requested name and namespace.
+	get := func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+		obj.SetNamespace(key.Namespace)
+		obj.SetName(key.Name)
+		return nil
+	}
+	sa := func(name string) model.KubernetesResource {
+		u := &kunstructured.Unstructured{}
+		u.SetAPIVersion("v1")
+		u.SetKind("ServiceAccount")
+		u.SetNamespace("crossplane-system")
+		u.SetName(name)
+		out, _ := model.GetKubernetesResource(u)
+		return out
+	}
+
+	type want struct {
+		kr   model.KubernetesResource
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		want    want
+	}{
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return nil, errBoom
+			}),
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"ListDeploymentsError": {
+			reason: "If we can't list deployments we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockList: test.NewMockListFn(errBoom)}, nil
+			}),
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errListDeployments)),
+				},
+			},
+		},
+		"NoDeployment": {
+			reason: "A revision that controls no deployment has no service account.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockList: list()}, nil
+			}),
+			want: want{},
+		},
+		"GetServiceAccountError": {
+			reason: "If we can't get the service account we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockList: list(deployment("ours")), MockGet: test.NewMockGetFn(errBoom)}, nil
+			}),
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetServiceAccount)),
+				},
+			},
+		},
+		"ServiceAccountNotFound": {
+			reason: "If the service account doesn't exist the revision has none.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: list(deployment("ours")),
+					MockGet:  test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{Resource: "serviceaccounts"}, "ours")),
+				}, nil
+			}),
+			want: want{},
+		},
+		"Success": {
+			reason: "We should return the service account the revision's deployment runs as.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockList: list(deployment("ours")), MockGet: get}, nil
+			}),
+			want: want{
+				kr: sa("ours"),
+			},
+		},
+		"DefaultServiceAccount": {
+			reason: "We should return the default service account if the revision's deployment doesn't specify one.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockList: list(deployment("")), MockGet: get}, nil
+			}),
+			want: want{
+				kr: sa("default"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &providerRevision{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			ctx := graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover)
+			got, err := r.ServiceAccount(ctx, rev)
+			errs := graphql.GetErrors(ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.ServiceAccount(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.ServiceAccount(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.kr, got, cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
+				t.Errorf("\n%s\nr.ServiceAccount(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestProviderRevisionConfigMaps(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	rev := &model.ProviderRevision{Metadata: model.ObjectMeta{UID: "rev-uid"}}
+
+	d := &kunstructured.Unstructured{}
+	d.SetAPIVersion("apps/v1")
+	d.SetKind("Deployment")
+	d.SetNamespace("crossplane-system")
+	d.SetName("ours")
+	d.SetOwnerReferences([]metav1.OwnerReference{{UID: "rev-uid", Controller: ptr.To(true)}})
+	_ = kunstructured.SetNestedSlice(d.Object, []any{
+		map[string]any{"name": "config", "configMap": map[string]any{"name": "volume"}},
+		map[string]any{"name": "projected", "projected": map[string]any{"sources": []any{
+			map[string]any{"configMap": map[string]any{"name": "projected"}},
+		}}},
+	}, "spec", "template", "spec", "volumes")
+	_ = kunstructured.SetNestedSlice(d.Object, []any{
+		map[string]any{
+			"name":    "provider",
+			"envFrom": []any{map[string]any{"configMapRef": map[string]any{"name": "env-from"}}},
+			"env": []any{
+				map[string]any{"name": "COOL", "valueFrom": map[string]any{"configMapKeyRef": map[string]any{"name": "volume", "key": "cool"}}},
+			},
+		},
+	}, "spec", "template", "spec", "containers")
+
+	list := test.NewMockListFn(nil, func(obj client.ObjectList) error {
+		obj.(*kunstructured.UnstructuredList).Items = []kunstructured.Unstructured{*d.DeepCopy()}
+		return nil
+	})
+	cm := func(name string) model.ConfigMap {
+		return model.GetConfigMap(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "crossplane-system", Name: name}})
+	}
+
+	type want struct {
+		cms  []model.ConfigMap
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		want    want
+	}{
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return nil, errBoom
+			}),
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"NoDeployment": {
+			reason: "A revision that controls no deployment references no config maps.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockList: test.NewMockListFn(nil)}, nil
+			}),
+			want: want{},
+		},
+		"Success": {
+			reason: "We should return the config maps the revision's deployment references, omitting those that don't exist and reporting those we can't get.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: list,
+					MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+						switch key.Name {
+						case "projected":
+							return kerrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, key.Name)
+						case "env-from":
+							return errBoom
+						}
+						obj.SetNamespace(key.Namespace)
+						obj.SetName(key.Name)
+						return nil
+					},
+				}, nil
+			}),
+			want: want{
+				cms: []model.ConfigMap{cm("volume")},
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetConfigMap)),
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &providerRevision{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			ctx := graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover)
+			got, err := r.ConfigMaps(ctx, rev)
+			errs := graphql.GetErrors(ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.ConfigMaps(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.ConfigMaps(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cms, got, cmp.AllowUnexported(model.ConfigMap{}), cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
+				t.Errorf("\n%s\nr.ConfigMaps(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
 func TestProviderRevisionStatusObjects(t *testing.T) {
 	errBoom := errors.New("boom")
 
@@ -338,7 +837,7 @@ func TestProviderRevisionStatusObjects(t *testing.T) {
 	}{
 		"GetClientError": {
 			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, errBoom
 			}),
 			args: args{
@@ -352,7 +851,7 @@ func TestProviderRevisionStatusObjects(t *testing.T) {
 		},
 		"UnknownObject": {
 			reason: "We should not attempt to get an object that doesn't seem to be a CRD.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, nil
 			}),
 			args: args{
@@ -378,7 +877,7 @@ func TestProviderRevisionStatusObjects(t *testing.T) {
 		},
 		"GetCRDError": {
 			reason: "If we can't get an CRD we should add the error to the GraphQL context and continue.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(errBoom),
 				}, nil
@@ -406,7 +905,7 @@ func TestProviderRevisionStatusObjects(t *testing.T) {
 		},
 		"Success": {
 			reason: "We should return all the CRDs that we can get and model.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(nil),
 				}, nil
@@ -21,11 +21,14 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/99designs/gqlgen/graphql"
 
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 
 	"github.com/upbound/xgql/internal/auth"
@@ -33,6 +36,10 @@ import (
 	"github.com/upbound/xgql/internal/unstructured"
 )
 
+const (
+	errListProviderConfigUsages = "cannot list provider config usages"
+)
+
 type providerConfig struct {
 	clients ClientCache
 }
@@ -56,7 +63,7 @@ func (r *providerConfig) Definition(ctx context.Context, obj *model.ProviderConf
 	defer cancel()
 
 	creds, _ := auth.FromContext(ctx)
-	c, err := r.clients.Get(creds)
+	c, err := r.clients.GetWithContext(ctx, creds)
 	if err != nil {
 		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
 		return nil, nil
@@ -116,3 +123,49 @@ func (r *providerConfig) Definition(ctx context.Context, obj *model.ProviderConf
 
 	return nil, nil
 }
+
+func (r *providerConfig) Usages(ctx context.Context, obj *model.ProviderConfig) (model.ProviderConfigUsages, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	none := model.ProviderConfigUsages{Resources: []model.ManagedResourceReference{}}
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.GetWithContext(ctx, creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return none, nil
+	}
+
+	gv, err := schema.ParseGroupVersion(obj.APIVersion)
+	if err != nil {
+		// This should be pretty much impossible - the API server should not
+		// return resources with malformed API versions.
+		graphql.AddError(ctx, errors.Wrap(err, errMalformedAPIVersion))
+		return none, nil
+	}
+
+	// Each provider defines its own kind of provider config usage, in the same
+	// API group as its provider config. A provider that doesn't define one
+	// doesn't track usages.
+	crd := unstructured.NewCRD()
+	if err := c.Get(ctx, types.NamespacedName{Name: "providerconfigusages." + gv.Group}, crd.GetUnstructured()); err != nil {
+		if !kerrors.IsNotFound(err) {
+			graphql.AddError(ctx, errors.Wrap(err, errGetCRD))
+		}
+		return none, nil
+	}
+	v := storageVersion(crd.GetSpecVersions())
+	if v == "" {
+		return none, nil
+	}
+
+	l := &kunstructured.UnstructuredList{}
+	l.SetGroupVersionKind(schema.GroupVersionKind{Group: gv.Group, Version: v, Kind: crd.GetSpecNames().Kind + "List"})
+	if err := c.List(ctx, l, client.MatchingLabels{xpv1.LabelKeyProviderName: obj.Metadata.Name}); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errListProviderConfigUsages))
+		return none, nil
+	}
+
+	return model.GetProviderConfigUsages(l.Items), nil
+}
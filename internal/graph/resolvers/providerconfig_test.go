@@ -28,6 +28,7 @@ import (
 	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 
@@ -85,7 +86,7 @@ func TestProviderConfigDefinition(t *testing.T) {
 	}{
 		"GetClientError": {
 			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, errBoom
 			}),
 			args: args{
@@ -100,7 +101,7 @@ func TestProviderConfigDefinition(t *testing.T) {
 		},
 		"GetCRDError": {
 			reason: "If we can't get the CRD we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(errBoom),
 				}, nil
@@ -117,7 +118,7 @@ func TestProviderConfigDefinition(t *testing.T) {
 		},
 		"ListCRDsError": {
 			reason: "If we can't list CRDs we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
 						return errNotFound
@@ -137,7 +138,7 @@ func TestProviderConfigDefinition(t *testing.T) {
 		},
 		"FoundCRD": {
 			reason: "If we can get and model the CRD that defines this provider config we should return it.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
 						*obj.(*kunstructured.Unstructured) = *crd.GetUnstructured()
@@ -160,7 +161,7 @@ func TestProviderConfigDefinition(t *testing.T) {
 			reason: `In the event we get a request for an object whose CRD has 
 			a non-predictable plural form, ensure the CRD list contains the 
 			expected resource.`,
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
 						return errNotFound
@@ -186,7 +187,7 @@ func TestProviderConfigDefinition(t *testing.T) {
 		},
 		"NoCRD": {
 			reason: "If we can't get and model the CRD that defines this provider config we should return nil.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
 						return errNotFound
@@ -236,3 +237,196 @@ func TestProviderConfigDefinition(t *testing.T) {
 		})
 	}
 }
+
+func TestProviderConfigUsages(t *testing.T) {
+	errBoom := errors.New("boom")
+	errNotFound := &kerrors.StatusError{
+		ErrStatus: metav1.Status{
+			Reason: metav1.StatusReasonNotFound,
+		},
+	}
+
+	crd := unstructured.NewCRD()
+	crd.SetSpecGroup("example.org")
+	crd.SetSpecNames(kextv1.CustomResourceDefinitionNames{Kind: "ProviderConfigUsage"})
+	crd.SetSpecVersions([]kextv1.CustomResourceDefinitionVersion{{Name: "v1", Served: true, Storage: true}})
+
+	getCRD := func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+		if key.Name != "providerconfigusages.example.org" {
+			return errNotFound
+		}
+		*obj.(*kunstructured.Unstructured) = *crd.GetUnstructured()
+		return nil
+	}
+
+	newPCU := func(name string) kunstructured.Unstructured {
+		pcu := &unstructured.ProviderConfigUsage{Unstructured: kunstructured.Unstructured{Object: map[string]interface{}{}}}
+		pcu.SetProviderConfigReference(xpv1.Reference{Name: "default"})
+		pcu.SetResourceReference(xpv1.TypedReference{APIVersion: "example.org/v1", Kind: "Example", Name: name})
+		return pcu.Unstructured
+	}
+
+	pc := &model.ProviderConfig{
+		APIVersion: "example.org/v1",
+		Kind:       "ProviderConfig",
+		Metadata:   model.ObjectMeta{Name: "default"},
+	}
+
+	none := model.ProviderConfigUsages{Resources: []model.ManagedResourceReference{}}
+
+	type args struct {
+		ctx context.Context
+		obj *model.ProviderConfig
+	}
+	type want struct {
+		usages model.ProviderConfigUsages
+		err    error
+		errs   gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		args    args
+		want    want
+	}{
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, errBoom
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: pc,
+			},
+			want: want{
+				usages: none,
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"GetCRDError": {
+			reason: "If we can't get the provider config usage CRD we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: pc,
+			},
+			want: want{
+				usages: none,
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetCRD)),
+				},
+			},
+		},
+		"NoUsageCRD": {
+			reason: "If the provider doesn't define a provider config usage we should return no usages.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(errNotFound),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: pc,
+			},
+			want: want{
+				usages: none,
+			},
+		},
+		"ListUsagesError": {
+			reason: "If we can't list provider config usages we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet:  getCRD,
+					MockList: test.NewMockListFn(errBoom),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: pc,
+			},
+			want: want{
+				usages: none,
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errListProviderConfigUsages)),
+				},
+			},
+		},
+		"NoUsages": {
+			reason: "If no managed resources use the provider config we should return no usages.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet:  getCRD,
+					MockList: test.NewMockListFn(nil),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: pc,
+			},
+			want: want{
+				usages: none,
+			},
+		},
+		"Usages": {
+			reason: "We should return the managed resources that use the provider config.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: getCRD,
+					MockList: func(_ context.Context, obj client.ObjectList, opts ...client.ListOption) error {
+						l := obj.(*kunstructured.UnstructuredList)
+						if l.GetAPIVersion() != "example.org/v1" || l.GetKind() != "ProviderConfigUsageList" {
+							return errBoom
+						}
+						lo := &client.ListOptions{}
+						lo.ApplyOptions(opts)
+						if lo.LabelSelector.String() != xpv1.LabelKeyProviderName+"=default" {
+							return errBoom
+						}
+						l.Items = []kunstructured.Unstructured{newPCU("cool")}
+						return nil
+					},
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: pc,
+			},
+			want: want{
+				usages: model.ProviderConfigUsages{
+					Count: 1,
+					Resources: []model.ManagedResourceReference{
+						{APIVersion: "example.org/v1", Kind: "Example", Name: "cool"},
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			pc := &providerConfig{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := pc.Usages(tc.args.ctx, tc.args.obj)
+			errs := graphql.GetErrors(tc.args.ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\npc.Usages(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\npc.Usages(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.usages, got); diff != "" {
+				t.Errorf("\n%s\npc.Usages(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
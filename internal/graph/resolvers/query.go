@@ -18,11 +18,16 @@ import (
 	"context"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/99designs/gqlgen/graphql"
+	authv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -30,8 +35,10 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 	extv1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
 	pkgv1 "github.com/crossplane/crossplane/apis/pkg/v1"
+	pkgv1beta1 "github.com/crossplane/crossplane/apis/pkg/v1beta1"
 
 	"github.com/upbound/xgql/internal/auth"
+	"github.com/upbound/xgql/internal/clients"
 	"github.com/upbound/xgql/internal/graph/model"
 	xunstructured "github.com/upbound/xgql/internal/unstructured"
 )
@@ -42,12 +49,33 @@ const (
 	errGetClient     = "cannot get client"
 	errGetSecret     = "cannot get secret"
 	errGetConfigMap  = "cannot get config map"
+	errListQuotas    = "cannot list resource quotas"
 	errListProviders = "cannot list providers"
+	errListFunctions = "cannot list functions"
 	errListConfigs   = "cannot list configurations"
+	errReviewAccess  = "cannot review access"
+	errReviewRules   = "cannot review rules"
+	errNoDiscovery   = "API resource discovery is not supported"
+	errDiscover      = "cannot discover API resources"
+	errGetLock       = "cannot get package lock"
+
+	errFmtListDefined = "cannot list %s"
 )
 
+// lockName is the name of the package lock. There is only ever one.
+const lockName = "lock"
+
+// listPageSize is the maximum number of resources requested per page when a
+// list is limited.
+const listPageSize = 500
+
+// batchWorkers is the maximum number of resources a batch reads concurrently.
+const batchWorkers = 16
+
 type query struct {
-	clients ClientCache
+	clients   ClientCache
+	discovery Discoverer
+	kinds     DefinedKindCache
 }
 
 // Recursively collect `CrossplaneResourceTreeNode`s from the given KubernetesResource
@@ -133,7 +161,7 @@ func (r *query) KubernetesResource(ctx context.Context, id model.ReferenceID) (m
 	defer cancel()
 
 	creds, _ := auth.FromContext(ctx)
-	c, err := r.clients.Get(creds)
+	c, err := r.clients.GetWithContext(ctx, creds)
 	if err != nil {
 		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
 		return nil, nil
@@ -156,7 +184,59 @@ func (r *query) KubernetesResource(ctx context.Context, id model.ReferenceID) (m
 	return out, nil
 }
 
-func (r *query) KubernetesResources(ctx context.Context, apiVersion, kind string, listKind, namespace *string) (model.KubernetesResourceConnection, error) {
+func (r *query) Resources(ctx context.Context, refs []model.ObjectReferenceInput) (model.KubernetesResourceBatch, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.GetWithContext(ctx, creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.KubernetesResourceBatch{}, nil
+	}
+
+	out := model.KubernetesResourceBatch{
+		Resources: make([]model.KubernetesResource, len(refs)),
+		Errors:    make([]*string, len(refs)),
+	}
+
+	// Get all resources concurrently, but bound how many we get at once. Each
+	// error is recorded alongside its resource rather than failing the batch.
+	workers := make(chan struct{}, batchWorkers)
+	var wg sync.WaitGroup
+	for i := range refs {
+		i := i // So we don't capture the loop variable.
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			workers <- struct{}{}
+			defer func() { <-workers }()
+
+			u := &kunstructured.Unstructured{}
+			u.SetAPIVersion(refs[i].APIVersion)
+			u.SetKind(refs[i].Kind)
+			nn := types.NamespacedName{Namespace: ptr.Deref(refs[i].Namespace, ""), Name: refs[i].Name}
+			if err := c.Get(ctx, nn, u); err != nil {
+				if !kerrors.IsNotFound(err) {
+					out.Errors[i] = ptr.To(errors.Wrap(err, errGetResource).Error())
+				}
+				return
+			}
+
+			kr, err := model.GetKubernetesResource(u)
+			if err != nil {
+				out.Errors[i] = ptr.To(errors.Wrap(err, errModelResource).Error())
+				return
+			}
+			out.Resources[i] = kr
+		}()
+	}
+	wg.Wait()
+
+	return out, nil
+}
+
+func (r *query) KubernetesResources(ctx context.Context, apiVersion, kind string, listKind, namespace *string, limit *int) (model.KubernetesResourceConnection, error) { //nolint:gocyclo // Only slightly over.
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
@@ -166,7 +246,7 @@ func (r *query) KubernetesResources(ctx context.Context, apiVersion, kind string
 	}
 
 	creds, _ := auth.FromContext(ctx)
-	c, err := r.clients.Get(creds)
+	c, err := r.clients.GetWithContext(ctx, creds)
 	if err != nil {
 		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
 		return model.KubernetesResourceConnection{}, nil
@@ -179,11 +259,29 @@ func (r *query) KubernetesResources(ctx context.Context, apiVersion, kind string
 		in.SetKind(*listKind)
 	}
 
-	if err := c.List(ctx, in, lopts...); err != nil {
+	list := c.List
+	if limit != nil && *limit >= 0 {
+		list = func(ctx context.Context, l client.ObjectList, o ...client.ListOption) error {
+			return listLimited(ctx, c, l.(*kunstructured.UnstructuredList), *limit, o...)
+		}
+	}
+	if err := list(ctx, in, lopts...); err != nil {
 		graphql.AddError(ctx, errors.Wrap(err, errListResources))
 		return model.KubernetesResourceConnection{}, nil
 	}
 
+	// The API server may report how many resources remain unread.
+	remaining := 0
+	if rc := in.GetRemainingItemCount(); rc != nil {
+		remaining = int(*rc)
+	}
+
+	// Don't bother mapping (potentially large) resources that weren't asked
+	// for; a client may only care how many there are.
+	if !selected(ctx, fieldNodes) {
+		return model.KubernetesResourceConnection{TotalCount: len(in.Items) + remaining}, nil
+	}
+
 	out := &model.KubernetesResourceConnection{
 		Nodes: make([]model.KubernetesResource, 0, len(in.Items)),
 	}
@@ -198,10 +296,93 @@ func (r *query) KubernetesResources(ctx context.Context, apiVersion, kind string
 		out.TotalCount++
 	}
 
+	// Sort before counting remaining resources; sorting assumes every counted
+	// resource is a node.
 	sort.Stable(out)
+	out.TotalCount += remaining
 	return *out, nil
 }
 
+func (r *query) RecentChanges(ctx context.Context, group, version, kind string, since time.Time) (model.KubernetesResourceConnection, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.GetWithContext(ctx, creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.KubernetesResourceConnection{}, nil
+	}
+
+	in := &kunstructured.UnstructuredList{}
+	in.SetGroupVersionKind(schema.GroupVersionKind{Group: group, Version: version, Kind: kind + "List"})
+	if err := c.List(ctx, in); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errListResources))
+		return model.KubernetesResourceConnection{}, nil
+	}
+
+	type change struct {
+		kr       model.KubernetesResource
+		modified time.Time
+	}
+	changes := make([]change, 0)
+	for i := range in.Items {
+		m := model.GetLastModified(&in.Items[i])
+		if m.Before(since) {
+			continue
+		}
+		kr, err := model.GetKubernetesResource(&in.Items[i])
+		if err != nil {
+			graphql.AddError(ctx, errors.Wrap(err, errModelResource))
+			continue
+		}
+		changes = append(changes, change{kr: kr, modified: m})
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool { return changes[i].modified.After(changes[j].modified) })
+
+	out := model.KubernetesResourceConnection{
+		Nodes:      make([]model.KubernetesResource, len(changes)),
+		TotalCount: len(changes),
+	}
+	for i := range changes {
+		out.Nodes[i] = changes[i].kr
+	}
+	return out, nil
+}
+
+// listLimited lists at most limit resources into the supplied list. Resources
+// are requested in pages of at most listPageSize, and no more pages are
+// requested once limit resources have been read. The list's remaining item
+// count is that of the last page read.
+func listLimited(ctx context.Context, c client.Reader, in *kunstructured.UnstructuredList, limit int, opts ...client.ListOption) error {
+	items := make([]kunstructured.Unstructured, 0, min(limit, listPageSize))
+	cont := ""
+	for len(items) < limit {
+		page := &kunstructured.UnstructuredList{}
+		page.SetAPIVersion(in.GetAPIVersion())
+		page.SetKind(in.GetKind())
+
+		popts := make([]client.ListOption, 0, len(opts)+2)
+		popts = append(popts, opts...)
+		popts = append(popts, client.Limit(int64(min(limit-len(items), listPageSize))), client.Continue(cont))
+		if err := c.List(ctx, page, popts...); err != nil {
+			return err
+		}
+
+		// Some readers, like a cache, may return more than we asked for.
+		items = append(items, page.Items[:min(len(page.Items), limit-len(items))]...)
+		in.SetRemainingItemCount(page.GetRemainingItemCount())
+
+		cont = page.GetContinue()
+		if cont == "" {
+			break
+		}
+	}
+	in.Items = items
+	return nil
+}
+
 func (r *query) Events(ctx context.Context, involved *model.ReferenceID) (model.EventConnection, error) {
 	e := events{clients: r.clients}
 	if involved == nil {
@@ -223,12 +404,13 @@ func (r *query) Secret(ctx context.Context, namespace, name string) (*model.Secr
 	defer cancel()
 
 	creds, _ := auth.FromContext(ctx)
-	c, err := r.clients.Get(creds)
+	c, err := r.clients.GetWithContext(ctx, creds)
 	if err != nil {
 		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
 		return nil, nil
 	}
 
+	// Secrets are never cached, so this read goes to the API server.
 	s := &corev1.Secret{}
 	nn := types.NamespacedName{Namespace: namespace, Name: name}
 	if err := c.Get(ctx, nn, s); err != nil {
@@ -245,16 +427,20 @@ func (r *query) ConfigMap(ctx context.Context, namespace, name string) (*model.C
 	defer cancel()
 
 	creds, _ := auth.FromContext(ctx)
-	c, err := r.clients.Get(creds)
+	c, err := r.clients.GetWithContext(ctx, creds)
 	if err != nil {
 		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
 		return nil, nil
 	}
 
+	// ConfigMaps are never cached, so this read goes to the API server. A
+	// ConfigMap that doesn't exist is null, not an error.
 	cm := &corev1.ConfigMap{}
 	nn := types.NamespacedName{Namespace: namespace, Name: name}
 	if err := c.Get(ctx, nn, cm); err != nil {
-		graphql.AddError(ctx, errors.Wrap(err, errGetConfigMap))
+		if !kerrors.IsNotFound(err) {
+			graphql.AddError(ctx, errors.Wrap(err, errGetConfigMap))
+		}
 		return nil, nil
 	}
 
@@ -262,12 +448,45 @@ func (r *query) ConfigMap(ctx context.Context, namespace, name string) (*model.C
 	return &out, nil
 }
 
+func (r *query) ResourceQuotas(ctx context.Context, namespace string) (model.ResourceQuotaConnection, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.GetWithContext(ctx, creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.ResourceQuotaConnection{}, nil
+	}
+
+	// ResourceQuotas are never watched. This list goes to the API server (or
+	// is served from a recent identical list made using the same credentials),
+	// so the caller must be allowed to list quotas in the namespace.
+	in := &corev1.ResourceQuotaList{}
+	if err := c.List(ctx, in, client.InNamespace(namespace)); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errListQuotas))
+		return model.ResourceQuotaConnection{}, nil
+	}
+
+	out := &model.ResourceQuotaConnection{
+		Nodes:      make([]model.ResourceQuota, 0, len(in.Items)),
+		TotalCount: len(in.Items),
+	}
+
+	for i := range in.Items {
+		out.Nodes = append(out.Nodes, model.GetResourceQuota(&in.Items[i]))
+	}
+
+	sort.Stable(out)
+	return *out, nil
+}
+
 func (r *query) Providers(ctx context.Context) (model.ProviderConnection, error) {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
 	creds, _ := auth.FromContext(ctx)
-	c, err := r.clients.Get(creds)
+	c, err := r.clients.GetWithContext(ctx, creds)
 	if err != nil {
 		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
 		return model.ProviderConnection{}, nil
@@ -297,7 +516,7 @@ func (r *query) ProviderRevisions(ctx context.Context, provider *model.Reference
 	defer cancel()
 
 	creds, _ := auth.FromContext(ctx)
-	c, err := r.clients.Get(creds)
+	c, err := r.clients.GetWithContext(ctx, creds)
 	if err != nil {
 		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
 		return model.ProviderRevisionConnection{}, nil
@@ -334,12 +553,84 @@ func (r *query) ProviderRevisions(ctx context.Context, provider *model.Reference
 	return *out, nil
 }
 
-func (r *query) CustomResourceDefinitions(ctx context.Context, revision *model.ReferenceID) (model.CustomResourceDefinitionConnection, error) {
+func (r *query) Functions(ctx context.Context) (model.FunctionConnection, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.GetWithContext(ctx, creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.FunctionConnection{}, nil
+	}
+
+	in := &pkgv1.FunctionList{}
+	if err := listFunctions(ctx, c, in); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errListFunctions))
+		return model.FunctionConnection{}, nil
+	}
+
+	out := &model.FunctionConnection{
+		Nodes:      make([]model.Function, 0, len(in.Items)),
+		TotalCount: len(in.Items),
+	}
+
+	for i := range in.Items {
+		out.Nodes = append(out.Nodes, model.GetFunction(&in.Items[i]))
+	}
+
+	sort.Stable(out)
+	return *out, nil
+}
+
+func (r *query) FunctionRevisions(ctx context.Context, function *model.ReferenceID, active *bool) (model.FunctionRevisionConnection, error) {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
 	creds, _ := auth.FromContext(ctx)
-	c, err := r.clients.Get(creds)
+	c, err := r.clients.GetWithContext(ctx, creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.FunctionRevisionConnection{}, nil
+	}
+
+	in := &pkgv1.FunctionRevisionList{}
+	if err := listFunctionRevisions(ctx, c, in); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errListFunctionRevs))
+		return model.FunctionRevisionConnection{}, nil
+	}
+
+	out := &model.FunctionRevisionConnection{
+		Nodes: make([]model.FunctionRevision, 0),
+	}
+
+	for i := range in.Items {
+		fr := in.Items[i] // So we don't take the address of a range variable.
+
+		// The supplied function is not an owner of this PackageRevision.
+		if function != nil && !containsID(fr.OwnerReferences, *function) {
+			continue
+		}
+
+		// We only want the active PackageRevision, and this isn't it.
+		if ptr.Deref(active, false) && fr.Spec.DesiredState != pkgv1.PackageRevisionActive {
+			continue
+		}
+
+		out.Nodes = append(out.Nodes, model.GetFunctionRevision(&fr))
+		out.TotalCount++
+	}
+
+	sort.Stable(out)
+	return *out, nil
+}
+
+func (r *query) CustomResourceDefinitions(ctx context.Context, revision *model.ReferenceID, group *string, offset *int, limit *int) (model.CustomResourceDefinitionConnection, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.GetWithContext(ctx, creds)
 	if err != nil {
 		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
 		return model.CustomResourceDefinitionConnection{}, nil
@@ -363,20 +654,41 @@ func (r *query) CustomResourceDefinitions(ctx context.Context, revision *model.R
 			continue
 		}
 
+		// We only want CRDs in this group, but this one isn't.
+		if group != nil && xrd.GetSpecGroup() != *group {
+			continue
+		}
+
 		out.Nodes = append(out.Nodes, model.GetCustomResourceDefinition(xrd))
 		out.TotalCount++
 	}
 
 	sort.Stable(out)
+	out.Nodes = paginate(out.Nodes, offset, limit)
 	return *out, nil
 }
 
+// paginate returns the page of the supplied nodes that starts at offset and
+// contains at most limit nodes. A nil offset starts at the first node, and a
+// nil limit includes all subsequent nodes.
+func paginate[T any](nodes []T, offset, limit *int) []T {
+	start := 0
+	if offset != nil && *offset > 0 {
+		start = min(*offset, len(nodes))
+	}
+	end := len(nodes)
+	if limit != nil && *limit >= 0 {
+		end = min(start+*limit, len(nodes))
+	}
+	return nodes[start:end]
+}
+
 func (r *query) Configurations(ctx context.Context) (model.ConfigurationConnection, error) {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
 	creds, _ := auth.FromContext(ctx)
-	c, err := r.clients.Get(creds)
+	c, err := r.clients.GetWithContext(ctx, creds)
 	if err != nil {
 		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
 		return model.ConfigurationConnection{}, nil
@@ -406,7 +718,7 @@ func (r *query) ConfigurationRevisions(ctx context.Context, configuration *model
 	defer cancel()
 
 	creds, _ := auth.FromContext(ctx)
-	c, err := r.clients.Get(creds)
+	c, err := r.clients.GetWithContext(ctx, creds)
 	if err != nil {
 		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
 		return model.ConfigurationRevisionConnection{}, nil
@@ -448,7 +760,7 @@ func (r *query) CompositeResourceDefinitions(ctx context.Context, revision *mode
 	defer cancel()
 
 	creds, _ := auth.FromContext(ctx)
-	c, err := r.clients.Get(creds)
+	c, err := r.clients.GetWithContext(ctx, creds)
 	if err != nil {
 		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
 		return model.CompositeResourceDefinitionConnection{}, nil
@@ -490,7 +802,7 @@ func (r *query) Compositions(ctx context.Context, revision *model.ReferenceID, d
 	defer cancel()
 
 	creds, _ := auth.FromContext(ctx)
-	c, err := r.clients.Get(creds)
+	c, err := r.clients.GetWithContext(ctx, creds)
 	if err != nil {
 		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
 		return model.CompositionConnection{}, nil
@@ -527,6 +839,192 @@ func (r *query) Compositions(ctx context.Context, revision *model.ReferenceID, d
 	return *out, nil
 }
 
+func (r *query) Claims(ctx context.Context, namespace *string) (model.CompositeResourceClaimConnection, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.GetWithContext(ctx, creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.CompositeResourceClaimConnection{}, nil
+	}
+
+	defined, err := getDefinedKinds(ctx, r.kinds, c, creds)
+	if err != nil {
+		graphql.AddError(ctx, err)
+		return model.CompositeResourceClaimConnection{}, nil
+	}
+
+	lopts := []client.ListOption{}
+	if namespace != nil {
+		lopts = append(lopts, client.InNamespace(*namespace))
+	}
+
+	in := listDefined(ctx, c, defined.Claims, lopts...)
+	out := &model.CompositeResourceClaimConnection{
+		Nodes: make([]model.CompositeResourceClaim, 0, len(in)),
+	}
+	for i := range in {
+		out.Nodes = append(out.Nodes, model.GetCompositeResourceClaim(&in[i]))
+		out.TotalCount++
+	}
+
+	sort.Stable(out)
+	return *out, nil
+}
+
+func (r *query) CompositeResources(ctx context.Context, kind *string, offset *int, limit *int) (model.CompositeResourceConnection, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.GetWithContext(ctx, creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.CompositeResourceConnection{}, nil
+	}
+
+	defined, err := getDefinedKinds(ctx, r.kinds, c, creds)
+	if err != nil {
+		graphql.AddError(ctx, err)
+		return model.CompositeResourceConnection{}, nil
+	}
+	kinds := defined.CompositeResources
+
+	// Listing every kind of composite resource is expensive, so we only list
+	// the kind we were asked for.
+	if kind != nil {
+		filtered := make([]clients.DefinedKind, 0, 1)
+		for _, k := range kinds {
+			if k.Kind == *kind {
+				filtered = append(filtered, k)
+			}
+		}
+		kinds = filtered
+	}
+
+	in := listDefined(ctx, c, kinds)
+	out := &model.CompositeResourceConnection{
+		Nodes: make([]model.CompositeResource, 0, len(in)),
+	}
+	for i := range in {
+		out.Nodes = append(out.Nodes, model.GetCompositeResource(&in[i]))
+		out.TotalCount++
+	}
+
+	sort.Stable(out)
+	out.Nodes = paginate(out.Nodes, offset, limit)
+	return *out, nil
+}
+
+func (r *query) Usages(ctx context.Context) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.Resolve(ctx, nil)
+}
+
+func (r *query) EnvironmentConfigs(ctx context.Context) (model.EnvironmentConfigConnection, error) {
+	ec := &environmentConfigs{clients: r.clients}
+	return ec.Resolve(ctx, nil)
+}
+
+func (r *query) DeploymentRuntimeConfigs(ctx context.Context) (model.DeploymentRuntimeConfigConnection, error) {
+	rc := &deploymentRuntimeConfigs{clients: r.clients}
+	return rc.Resolve(ctx)
+}
+
+func (r *query) Can(ctx context.Context, actions []model.ResourceAttributesInput) ([]model.AccessReview, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.GetWithContext(ctx, creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return []model.AccessReview{}, nil
+	}
+
+	// Review all actions concurrently. Actions we fail to review are treated
+	// as not allowed.
+	out := make([]model.AccessReview, len(actions))
+	var wg sync.WaitGroup
+	for i := range actions {
+		i := i // So we don't capture the loop variable.
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ar := &authv1.SelfSubjectAccessReview{
+				Spec: authv1.SelfSubjectAccessReviewSpec{
+					ResourceAttributes: model.GetKubernetesResourceAttributes(actions[i]),
+				},
+			}
+			if err := c.Create(ctx, ar); err != nil {
+				graphql.AddError(ctx, errors.Wrap(err, errReviewAccess))
+				ar.Status = authv1.SubjectAccessReviewStatus{}
+			}
+			out[i] = model.GetAccessReview(ar)
+		}()
+	}
+	wg.Wait()
+
+	return out, nil
+}
+
+func (r *query) SelfSubjectRules(ctx context.Context, namespace string) (*model.SubjectRules, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.GetWithContext(ctx, creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return nil, nil
+	}
+
+	rr := &authv1.SelfSubjectRulesReview{
+		Spec: authv1.SelfSubjectRulesReviewSpec{Namespace: namespace},
+	}
+	err = c.Create(ctx, rr)
+
+	// Being forbidden from reviewing rules tells us nothing about which
+	// actions the caller may perform, so we return incomplete rules rather
+	// than an error. Callers can fall back to the can query.
+	if kerrors.IsForbidden(err) {
+		return &model.SubjectRules{
+			ResourceRules:    []model.ResourceRule{},
+			NonResourceRules: []model.NonResourceRule{},
+			Incomplete:       true,
+			EvaluationError:  ptr.To(errors.Wrap(err, errReviewRules).Error()),
+		}, nil
+	}
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errReviewRules))
+		return nil, nil
+	}
+
+	out := model.GetSubjectRules(rr)
+	return &out, nil
+}
+
+func (r *query) APIResources(ctx context.Context, group *string) ([]model.APIResource, error) {
+	if r.discovery == nil {
+		graphql.AddError(ctx, errors.New(errNoDiscovery))
+		return []model.APIResource{}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	rs, err := r.discovery.ServerPreferredResources(ctx, creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errDiscover))
+		return []model.APIResource{}, nil
+	}
+
+	return model.GetAPIResources(rs, group), nil
+}
+
 func containsCR(in []metav1.OwnerReference) bool {
 	for _, ref := range in {
 		switch {
@@ -554,3 +1052,77 @@ func containsID(in []metav1.OwnerReference, id model.ReferenceID) bool {
 	}
 	return false
 }
+
+func (r *query) CrossplaneStatus(ctx context.Context) (*model.CrossplaneStatus, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.GetWithContext(ctx, creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return nil, nil
+	}
+
+	components := []model.CrossplaneComponentStatus{
+		getPackagesStatus(ctx, c, "Providers", &pkgv1.ProviderList{}, listPackages, errListProviders),
+		getPackagesStatus(ctx, c, "Configurations", &pkgv1.ConfigurationList{}, listPackages, errListConfigs),
+		getPackagesStatus(ctx, c, "Functions", &pkgv1.FunctionList{}, listFunctions, errListFunctions),
+	}
+
+	l := &pkgv1beta1.Lock{}
+	switch err := c.Get(ctx, types.NamespacedName{Name: lockName}, l); {
+	case err == nil:
+		components = append(components, model.GetLockStatus("Lock", l))
+	case componentAbsent(err):
+		components = append(components, model.GetLockStatus("Lock", nil))
+	default:
+		components = append(components, unknownComponent(ctx, "Lock", errors.Wrap(err, errGetLock)))
+	}
+
+	out := model.GetCrossplaneStatus(components...)
+	return &out, nil
+}
+
+// getPackagesStatus lists packages into the supplied list using the supplied
+// function, and returns the status of the named component they comprise.
+func getPackagesStatus[L client.ObjectList](ctx context.Context, c client.Reader, name string, l L, list func(context.Context, client.Reader, L) error, errList string) model.CrossplaneComponentStatus {
+	switch err := list(ctx, c, l); {
+	case componentAbsent(err):
+		return model.GetPackagesStatus(name)
+	case err != nil:
+		return unknownComponent(ctx, name, errors.Wrap(err, errList))
+	}
+
+	items, err := meta.ExtractList(l)
+	if err != nil {
+		return unknownComponent(ctx, name, errors.Wrap(err, errList))
+	}
+	pkgs := make([]pkgv1.Package, 0, len(items))
+	for _, o := range items {
+		if p, ok := o.(pkgv1.Package); ok {
+			pkgs = append(pkgs, p)
+		}
+	}
+	return model.GetPackagesStatus(name, pkgs...)
+}
+
+// listPackages lists packages into the supplied list.
+func listPackages[L client.ObjectList](ctx context.Context, c client.Reader, l L) error {
+	return c.List(ctx, l)
+}
+
+// componentAbsent returns true if a Crossplane component doesn't exist, for
+// example because its API isn't served. A component that doesn't exist is
+// healthy.
+func componentAbsent(err error) bool {
+	return meta.IsNoMatchError(err) || kerrors.IsNotFound(err)
+}
+
+// unknownComponent adds the supplied error to the GraphQL context and returns
+// a degraded status for the named Crossplane component, which we couldn't
+// check.
+func unknownComponent(ctx context.Context, name string, err error) model.CrossplaneComponentStatus {
+	graphql.AddError(ctx, err)
+	return model.CrossplaneComponentStatus{Name: name, Health: model.CrossplaneHealthDegraded, Unhealthy: []string{}}
+}
@@ -17,10 +17,16 @@ package resolvers
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/99designs/gqlgen/graphql"
 	"github.com/vektah/gqlparser/v2/gqlerror"
+	authv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
+	kextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -35,6 +41,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 	extv1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
 	pkgv1 "github.com/crossplane/crossplane/apis/pkg/v1"
+	pkgv1beta1 "github.com/crossplane/crossplane/apis/pkg/v1beta1"
 
 	"github.com/upbound/xgql/internal/auth"
 	"github.com/upbound/xgql/internal/clients"
@@ -69,7 +76,7 @@ func TestCrossplaneResourceTree(t *testing.T) {
 	}{
 		"GetKubernetesResourceError": {
 			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, errBoom
 			}),
 			args: args{
@@ -83,7 +90,7 @@ func TestCrossplaneResourceTree(t *testing.T) {
 		},
 		"SuccessWithNoComposite": {
 			reason: "It is a successful call",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
 						switch key.Name {
@@ -118,7 +125,7 @@ func TestCrossplaneResourceTree(t *testing.T) {
 		},
 		"Success": {
 			reason: "It is a successful call",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
 						u := *obj.(*unstructured.Unstructured)
@@ -187,7 +194,7 @@ func TestCrossplaneResourceTree(t *testing.T) {
 						Resource: model.ManagedResource{
 							ID:       model.ReferenceID{Name: "managed2"},
 							Metadata: model.ObjectMeta{Name: "managed2"},
-							Spec:     model.ManagedResourceSpec{ProviderConfigRef: &model.ProviderConfigReference{}, DeletionPolicy: &deletionPolicyDelete},
+							Spec:     model.ManagedResourceSpec{ProviderConfigRef: &model.ProviderConfigReference{}, DeletionPolicy: &deletionPolicyDelete, ManagementPolicies: []model.ManagementAction{model.ManagementActionAll}},
 						},
 					},
 					{
@@ -195,7 +202,7 @@ func TestCrossplaneResourceTree(t *testing.T) {
 						Resource: model.ManagedResource{
 							ID:       model.ReferenceID{Name: "managed1"},
 							Metadata: model.ObjectMeta{Name: "managed1"},
-							Spec:     model.ManagedResourceSpec{ProviderConfigRef: &model.ProviderConfigReference{}, DeletionPolicy: &deletionPolicyDelete},
+							Spec:     model.ManagedResourceSpec{ProviderConfigRef: &model.ProviderConfigReference{}, DeletionPolicy: &deletionPolicyDelete, ManagementPolicies: []model.ManagementAction{model.ManagementActionAll}},
 						},
 					},
 				}},
@@ -257,7 +264,7 @@ func TestQueryKubernetesResource(t *testing.T) {
 	}{
 		"GetClientError": {
 			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, errBoom
 			}),
 			args: args{
@@ -271,7 +278,7 @@ func TestQueryKubernetesResource(t *testing.T) {
 		},
 		"GetKubernetesResourceError": {
 			reason: "If we can't get the resource we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(errBoom),
 				}, nil
@@ -287,7 +294,7 @@ func TestQueryKubernetesResource(t *testing.T) {
 		},
 		"Success": {
 			reason: "If we can get and model the resource we should return it.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(nil),
 				}, nil
@@ -323,6 +330,294 @@ func TestQueryKubernetesResource(t *testing.T) {
 	}
 }
 
+func TestQueryRecentChanges(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	since := time.Unix(1700000000, 0)
+	resource := func(name string, modified time.Time) unstructured.Unstructured {
+		u := unstructured.Unstructured{}
+		u.SetAPIVersion("example.org/v1")
+		u.SetKind("Example")
+		u.SetName(name)
+		u.SetCreationTimestamp(metav1.NewTime(since.Add(-time.Hour)))
+		u.SetManagedFields([]metav1.ManagedFieldsEntry{{Manager: "rando", Time: ptr.To(metav1.NewTime(modified))}})
+		return u
+	}
+	old := resource("old", since.Add(-time.Minute))
+	recent := resource("recent", since.Add(time.Minute))
+	newest := resource("newest", since.Add(time.Hour))
+	grecent, _ := model.GetKubernetesResource(&recent)
+	gnewest, _ := model.GetKubernetesResource(&newest)
+
+	type want struct {
+		krc  model.KubernetesResourceConnection
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		want    want
+	}{
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return nil, errBoom
+			}),
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"ListError": {
+			reason: "If we can't list resources we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockList: test.NewMockListFn(errBoom)}, nil
+			}),
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errListResources)),
+				},
+			},
+		},
+		"Success": {
+			reason: "We should return resources modified since the supplied time, most recently modified first.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+						got := obj.GetObjectKind().GroupVersionKind()
+						want := schema.GroupVersionKind{Group: "example.org", Version: "v1", Kind: "ExampleList"}
+						if diff := cmp.Diff(want, got); diff != "" {
+							t.Errorf("-want GVK, +got GVK:\n%s", diff)
+						}
+						obj.(*unstructured.UnstructuredList).Items = []unstructured.Unstructured{recent, old, newest}
+						return nil
+					}),
+				}, nil
+			}),
+			want: want{
+				krc: model.KubernetesResourceConnection{
+					Nodes:      []model.KubernetesResource{gnewest, grecent},
+					TotalCount: 2,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			q := &query{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			ctx := graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover)
+			got, err := q.RecentChanges(ctx, "example.org", "v1", "Example", since)
+			errs := graphql.GetErrors(ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nq.RecentChanges(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nq.RecentChanges(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.krc, got, cmpopts.IgnoreFields(model.GenericResource{}, "PavedAccess"), cmpopts.IgnoreUnexported(model.ObjectMeta{})); diff != "" {
+				t.Errorf("\n%s\nq.RecentChanges(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestListLimited(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	// paged returns a reader that serves total resources in pages, recording
+	// the limit of each page it's asked for.
+	paged := func(total int, limits *[]int64) client.Reader {
+		served := 0
+		return &test.MockClient{
+			MockList: func(_ context.Context, obj client.ObjectList, opts ...client.ListOption) error {
+				lo := &client.ListOptions{}
+				lo.ApplyOptions(opts)
+				*limits = append(*limits, lo.Limit)
+
+				l := obj.(*unstructured.UnstructuredList)
+				n := min(int(lo.Limit), total-served)
+				l.Items = make([]unstructured.Unstructured, n)
+				served += n
+				if served < total {
+					l.SetContinue("more")
+					l.SetRemainingItemCount(ptr.To(int64(total - served)))
+				}
+				return nil
+			},
+		}
+	}
+
+	type args struct {
+		total int
+		limit int
+		err   error
+	}
+	type want struct {
+		items     int
+		remaining *int64
+		limits    []int64
+		err       error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Paged": {
+			reason: "We should read multiple pages of bounded size until we've read limit resources.",
+			args:   args{total: 2000, limit: 1200},
+			want:   want{items: 1200, remaining: ptr.To[int64](800), limits: []int64{listPageSize, listPageSize, 200}},
+		},
+		"Exhausted": {
+			reason: "We should stop reading pages when there are no more resources.",
+			args:   args{total: 600, limit: 1200},
+			want:   want{items: 600, limits: []int64{listPageSize, listPageSize}},
+		},
+		"ZeroLimit": {
+			reason: "We should not read any pages if the limit is zero.",
+			args:   args{total: 600, limit: 0},
+			want:   want{items: 0},
+		},
+		"ListError": {
+			reason: "We should return any error encountered reading a page.",
+			args:   args{limit: 1, err: errBoom},
+			want:   want{err: errBoom},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var limits []int64
+			c := paged(tc.args.total, &limits)
+			if tc.args.err != nil {
+				c = &test.MockClient{MockList: test.NewMockListFn(tc.args.err)}
+			}
+
+			l := &unstructured.UnstructuredList{}
+			err := listLimited(context.Background(), c, l, tc.args.limit)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nlistLimited(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.items, len(l.Items)); diff != "" {
+				t.Errorf("\n%s\nlistLimited(...): -want items, +got items:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.remaining, l.GetRemainingItemCount()); diff != "" {
+				t.Errorf("\n%s\nlistLimited(...): -want remaining, +got remaining:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.limits, limits); diff != "" {
+				t.Errorf("\n%s\nlistLimited(...): -want page limits, +got page limits:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestQueryResources(t *testing.T) {
+	errBoom := errors.New("boom")
+	errForbidden := kerrors.NewForbidden(schema.GroupResource{Group: "example.org", Resource: "examples"}, "forbidden", errBoom)
+
+	found := &unstructured.Unstructured{}
+	found.SetAPIVersion("example.org/v1")
+	found.SetKind("Example")
+	found.SetName("found")
+	gkr, _ := model.GetKubernetesResource(found)
+
+	ref := func(name string) model.ObjectReferenceInput {
+		return model.ObjectReferenceInput{APIVersion: "example.org/v1", Kind: "Example", Name: name}
+	}
+
+	type args struct {
+		ctx  context.Context
+		refs []model.ObjectReferenceInput
+	}
+	type want struct {
+		batch model.KubernetesResourceBatch
+		err   error
+		errs  gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		args    args
+		want    want
+	}{
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, errBoom
+			}),
+			args: args{
+				ctx:  graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				refs: []model.ObjectReferenceInput{ref("found")},
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"Success": {
+			reason: "We should return resources in the order they were referenced, with nulls for resources that don't exist and errors for resources we can't get.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+						switch key.Name {
+						case "found":
+							found.DeepCopyInto(obj.(*unstructured.Unstructured))
+							return nil
+						case "forbidden":
+							return errForbidden
+						default:
+							return kerrors.NewNotFound(schema.GroupResource{Group: "example.org", Resource: "examples"}, key.Name)
+						}
+					},
+				}, nil
+			}),
+			args: args{
+				ctx:  graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				refs: []model.ObjectReferenceInput{ref("forbidden"), ref("found"), ref("missing")},
+			},
+			want: want{
+				batch: model.KubernetesResourceBatch{
+					Resources: []model.KubernetesResource{nil, gkr, nil},
+					Errors:    []*string{ptr.To(errors.Wrap(errForbidden, errGetResource).Error()), nil, nil},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			q := &query{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := q.Resources(tc.args.ctx, tc.args.refs)
+			errs := graphql.GetErrors(tc.args.ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ns.Resources(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ns.Resources(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.batch, got, cmpopts.IgnoreFields(model.GenericResource{}, "PavedAccess"), cmpopts.IgnoreUnexported(model.ObjectMeta{})); diff != "" {
+				t.Errorf("\n%s\ns.Resources(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
 func TestQueryKubernetesResources(t *testing.T) {
 	errBoom := errors.New("boom")
 
@@ -346,6 +641,7 @@ func TestQueryKubernetesResources(t *testing.T) {
 		kind       string
 		listKind   *string
 		namespace  *string
+		limit      *int
 	}
 	type want struct {
 		krc  model.KubernetesResourceConnection
@@ -361,7 +657,7 @@ func TestQueryKubernetesResources(t *testing.T) {
 	}{
 		"GetClientError": {
 			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, errBoom
 			}),
 			args: args{
@@ -375,7 +671,7 @@ func TestQueryKubernetesResources(t *testing.T) {
 		},
 		"ListKubernetesResourcesError": {
 			reason: "If we can't list defined claims we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(errBoom),
 				}, nil
@@ -391,7 +687,7 @@ func TestQueryKubernetesResources(t *testing.T) {
 		},
 		"GVKOnly": {
 			reason: "We should successfully return any Kubernetes resources of the specified GVK that we can list and model.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
 						u := *obj.(*unstructured.UnstructuredList)
@@ -422,7 +718,7 @@ func TestQueryKubernetesResources(t *testing.T) {
 		},
 		"WithListKind": {
 			reason: "We should successfully list, model, and return resources of a bespoke listKind.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
 						u := *obj.(*unstructured.UnstructuredList)
@@ -454,7 +750,7 @@ func TestQueryKubernetesResources(t *testing.T) {
 		},
 		"WithNamespace": {
 			reason: "We should successfully list, model, and return resources from within a specific namespace.",
-			clients: ClientCacheFn(func(_ auth.Credentials, o ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, o ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
 						if len(opts) != 1 {
@@ -488,6 +784,53 @@ func TestQueryKubernetesResources(t *testing.T) {
 				},
 			},
 		},
+		"CountOnly": {
+			reason: "We should not model resources when their nodes were not requested.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+						*obj.(*unstructured.UnstructuredList) = unstructured.UnstructuredList{Items: []unstructured.Unstructured{kr, kr}}
+						return nil
+					}),
+				}, nil
+			}),
+			args: args{
+				ctx:        withSelection(graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover), "totalCount"),
+				apiVersion: apiVersion,
+				kind:       kind,
+			},
+			want: want{
+				krc: model.KubernetesResourceConnection{
+					TotalCount: 2,
+				},
+			},
+		},
+		"WithLimit": {
+			reason: "We should return at most limit resources, and count those the API server reports remain.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+						l := obj.(*unstructured.UnstructuredList)
+						l.Items = []unstructured.Unstructured{kr, kr}
+						l.SetContinue("more")
+						l.SetRemainingItemCount(ptr.To[int64](3))
+						return nil
+					}),
+				}, nil
+			}),
+			args: args{
+				ctx:        graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				apiVersion: apiVersion,
+				kind:       kind,
+				limit:      ptr.To(1),
+			},
+			want: want{
+				krc: model.KubernetesResourceConnection{
+					Nodes:      []model.KubernetesResource{gkr},
+					TotalCount: 4,
+				},
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -496,7 +839,7 @@ func TestQueryKubernetesResources(t *testing.T) {
 
 			// Our GraphQL resolvers never return errors. We instead add an
 			// error to the GraphQL context and return early.
-			got, err := q.KubernetesResources(tc.args.ctx, tc.args.apiVersion, tc.args.kind, tc.args.listKind, tc.args.namespace)
+			got, err := q.KubernetesResources(tc.args.ctx, tc.args.apiVersion, tc.args.kind, tc.args.listKind, tc.args.namespace, tc.args.limit)
 			errs := graphql.GetErrors(tc.args.ctx)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
@@ -536,7 +879,7 @@ func TestQuerySecret(t *testing.T) {
 	}{
 		"GetClientError": {
 			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, errBoom
 			}),
 			args: args{
@@ -550,7 +893,7 @@ func TestQuerySecret(t *testing.T) {
 		},
 		"GetSecretError": {
 			reason: "If we can't get the secret we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(errBoom),
 				}, nil
@@ -566,7 +909,7 @@ func TestQuerySecret(t *testing.T) {
 		},
 		"Success": {
 			reason: "If we can get and model the secret we should return it.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(nil),
 				}, nil
@@ -626,7 +969,7 @@ func TestQueryConfigMap(t *testing.T) {
 	}{
 		"GetClientError": {
 			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, errBoom
 			}),
 			args: args{
@@ -640,7 +983,7 @@ func TestQueryConfigMap(t *testing.T) {
 		},
 		"GetConfigMapError": {
 			reason: "If we can't get the config map we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(errBoom),
 				}, nil
@@ -654,9 +997,21 @@ func TestQueryConfigMap(t *testing.T) {
 				},
 			},
 		},
+		"ConfigMapNotFound": {
+			reason: "If the config map doesn't exist we should return null without an error.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "cool")),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{},
+		},
 		"Success": {
 			reason: "If we can get and model the config map we should return it.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(nil),
 				}, nil
@@ -692,17 +1047,24 @@ func TestQueryConfigMap(t *testing.T) {
 	}
 }
 
-func TestQueryProviders(t *testing.T) {
+func TestQueryResourceQuotas(t *testing.T) {
 	errBoom := errors.New("boom")
 
-	p := pkgv1.Provider{ObjectMeta: metav1.ObjectMeta{Name: "coolprovider"}}
-	gp := model.GetProvider(&p)
+	rq := corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cool"},
+		Status: corev1.ResourceQuotaStatus{
+			Hard: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("10")},
+			Used: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("3")},
+		},
+	}
+	grq := model.GetResourceQuota(&rq)
 
 	type args struct {
-		ctx context.Context
+		ctx       context.Context
+		namespace string
 	}
 	type want struct {
-		pc   model.ProviderConnection
+		rqc  model.ResourceQuotaConnection
 		err  error
 		errs gqlerror.List
 	}
@@ -715,7 +1077,7 @@ func TestQueryProviders(t *testing.T) {
 	}{
 		"GetClientError": {
 			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, errBoom
 			}),
 			args: args{
@@ -727,9 +1089,9 @@ func TestQueryProviders(t *testing.T) {
 				},
 			},
 		},
-		"ListProvidersError": {
-			reason: "If we can't list providers we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+		"ListResourceQuotasError": {
+			reason: "If we can't list resource quotas, for example because the caller may not, we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(errBoom),
 				}, nil
@@ -739,26 +1101,27 @@ func TestQueryProviders(t *testing.T) {
 			},
 			want: want{
 				errs: gqlerror.List{
-					gqlerror.Wrap(errors.Wrap(errBoom, errListProviders)),
+					gqlerror.Wrap(errors.Wrap(errBoom, errListQuotas)),
 				},
 			},
 		},
 		"Success": {
-			reason: "We should successfully return any providers we can list and model.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			reason: "We should list the resource quotas in the supplied namespace.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
-						*obj.(*pkgv1.ProviderList) = pkgv1.ProviderList{Items: []pkgv1.Provider{p}}
+						*obj.(*corev1.ResourceQuotaList) = corev1.ResourceQuotaList{Items: []corev1.ResourceQuota{rq}}
 						return nil
 					}),
 				}, nil
 			}),
 			args: args{
-				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				ctx:       graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				namespace: "default",
 			},
 			want: want{
-				pc: model.ProviderConnection{
-					Nodes:      []model.Provider{gp},
+				rqc: model.ResourceQuotaConnection{
+					Nodes:      []model.ResourceQuota{grq},
 					TotalCount: 1,
 				},
 			},
@@ -771,53 +1134,148 @@ func TestQueryProviders(t *testing.T) {
 
 			// Our GraphQL resolvers never return errors. We instead add an
 			// error to the GraphQL context and return early.
-			got, err := q.Providers(tc.args.ctx)
+			got, err := q.ResourceQuotas(tc.args.ctx, tc.args.namespace)
 			errs := graphql.GetErrors(tc.args.ctx)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("\n%s\nq.Providers(...): -want error, +got error:\n%s\n", tc.reason, diff)
+				t.Errorf("\n%s\nq.ResourceQuotas(...): -want error, +got error:\n%s\n", tc.reason, diff)
 			}
 			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
-				t.Errorf("\n%s\nq.Providers(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+				t.Errorf("\n%s\nq.ResourceQuotas(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
 			}
-			if diff := cmp.Diff(tc.want.pc, got, cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
-				t.Errorf("\n%s\nq.Providers(...): -want, +got:\n%s\n", tc.reason, diff)
+			if diff := cmp.Diff(tc.want.rqc, got, cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
+				t.Errorf("\n%s\nq.ResourceQuotas(...): -want, +got:\n%s\n", tc.reason, diff)
 			}
 		})
 	}
 }
 
-func TestQueryProviderRevisions(t *testing.T) {
+func TestQueryProviders(t *testing.T) {
 	errBoom := errors.New("boom")
 
-	id := model.ReferenceID{
-		APIVersion: pkgv1.ProviderGroupVersionKind.GroupVersion().String(),
-		Kind:       pkgv1.ProviderKind,
-		Name:       "coolprovider",
-	}
+	p := pkgv1.Provider{ObjectMeta: metav1.ObjectMeta{Name: "coolprovider"}}
+	gp := model.GetProvider(&p)
 
-	// The active ProviderRevision that we control.
-	active := pkgv1.ProviderRevision{
-		ObjectMeta: metav1.ObjectMeta{
-			Name: "coolrev",
-			OwnerReferences: []metav1.OwnerReference{{
-				APIVersion: id.APIVersion,
-				Kind:       id.Kind,
-				Name:       id.Name,
-			}},
-		},
-		Spec: pkgv1.ProviderRevisionSpec{PackageRevisionSpec: pkgv1.PackageRevisionSpec{DesiredState: pkgv1.PackageRevisionActive}},
+	type args struct {
+		ctx context.Context
+	}
+	type want struct {
+		pc   model.ProviderConnection
+		err  error
+		errs gqlerror.List
 	}
-	gactive := model.GetProviderRevision(&active)
 
-	// A ProviderRevision we control, but that is inactive.
-	inactive := pkgv1.ProviderRevision{
-		ObjectMeta: metav1.ObjectMeta{
-			Name: "inactiverev",
-			OwnerReferences: []metav1.OwnerReference{{
-				APIVersion: id.APIVersion,
-				Kind:       id.Kind,
-				Name:       id.Name,
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		args    args
+		want    want
+	}{
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, errBoom
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"ListProvidersError": {
+			reason: "If we can't list providers we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(errBoom),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errListProviders)),
+				},
+			},
+		},
+		"Success": {
+			reason: "We should successfully return any providers we can list and model.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+						*obj.(*pkgv1.ProviderList) = pkgv1.ProviderList{Items: []pkgv1.Provider{p}}
+						return nil
+					}),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				pc: model.ProviderConnection{
+					Nodes:      []model.Provider{gp},
+					TotalCount: 1,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			q := &query{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := q.Providers(tc.args.ctx)
+			errs := graphql.GetErrors(tc.args.ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nq.Providers(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nq.Providers(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.pc, got, cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
+				t.Errorf("\n%s\nq.Providers(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestQueryProviderRevisions(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	id := model.ReferenceID{
+		APIVersion: pkgv1.ProviderGroupVersionKind.GroupVersion().String(),
+		Kind:       pkgv1.ProviderKind,
+		Name:       "coolprovider",
+	}
+
+	// The active ProviderRevision that we control.
+	active := pkgv1.ProviderRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "coolrev",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: id.APIVersion,
+				Kind:       id.Kind,
+				Name:       id.Name,
+			}},
+		},
+		Spec: pkgv1.ProviderRevisionSpec{PackageRevisionSpec: pkgv1.PackageRevisionSpec{DesiredState: pkgv1.PackageRevisionActive}},
+	}
+	gactive := model.GetProviderRevision(&active)
+
+	// A ProviderRevision we control, but that is inactive.
+	inactive := pkgv1.ProviderRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "inactiverev",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: id.APIVersion,
+				Kind:       id.Kind,
+				Name:       id.Name,
 			}},
 		},
 		Spec: pkgv1.ProviderRevisionSpec{PackageRevisionSpec: pkgv1.PackageRevisionSpec{DesiredState: pkgv1.PackageRevisionInactive}},
@@ -856,7 +1314,7 @@ func TestQueryProviderRevisions(t *testing.T) {
 	}{
 		"GetClientError": {
 			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, errBoom
 			}),
 			args: args{
@@ -870,7 +1328,7 @@ func TestQueryProviderRevisions(t *testing.T) {
 		},
 		"ListRevisionsError": {
 			reason: "If we can't list revisions we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(errBoom),
 				}, nil
@@ -886,7 +1344,7 @@ func TestQueryProviderRevisions(t *testing.T) {
 		},
 		"AllRevisions": {
 			reason: "We should successfully return any revisions we own that we can list and model.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
 						*obj.(*pkgv1.ProviderRevisionList) = pkgv1.ProviderRevisionList{
@@ -908,7 +1366,7 @@ func TestQueryProviderRevisions(t *testing.T) {
 		},
 		"ProvidersRevisions": {
 			reason: "We should successfully return any revisions the supplied provider id owns that we can list and model.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
 						*obj.(*pkgv1.ProviderRevisionList) = pkgv1.ProviderRevisionList{
@@ -931,7 +1389,7 @@ func TestQueryProviderRevisions(t *testing.T) {
 		},
 		"ActiveRevisions": {
 			reason: "We should successfully return any active revisions that we can list and model.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
 						*obj.(*pkgv1.ProviderRevisionList) = pkgv1.ProviderRevisionList{
@@ -976,53 +1434,17 @@ func TestQueryProviderRevisions(t *testing.T) {
 	}
 }
 
-func TestQueryCustomResourceDefinitions(t *testing.T) {
+func TestQueryFunctions(t *testing.T) {
 	errBoom := errors.New("boom")
 
-	id := model.ReferenceID{
-		APIVersion: pkgv1.ConfigurationRevisionGroupVersionKind.GroupVersion().String(),
-		Kind:       pkgv1.ConfigurationRevisionKind,
-		Name:       "example",
-	}
-
-	owned := xunstructured.NewCRD()
-	owned.SetName("coolconfig")
-	owned.SetOwnerReferences([]metav1.OwnerReference{
-		// Some spurious owner references that we should ignore.
-		{
-			APIVersion: "wat",
-		},
-		{
-			APIVersion: id.APIVersion,
-			Kind:       "wat",
-		},
-		{
-			APIVersion: id.APIVersion,
-			Kind:       id.Kind,
-			Name:       "wat",
-		},
-		// The reference that indicates this XRD is owned by our desired
-		// ConfigurationRevision (or a ConfigurationRevision generally).
-		{
-			APIVersion: id.APIVersion,
-			Kind:       id.Kind,
-			Name:       id.Name,
-		},
-	})
-
-	gowned := model.GetCustomResourceDefinition(owned)
-
-	dangler := xunstructured.NewCRD()
-	dangler.SetName("coolconfig")
-
-	gdangler := model.GetCustomResourceDefinition(dangler)
+	p := pkgv1.Function{ObjectMeta: metav1.ObjectMeta{Name: "coolfunction"}}
+	gp := model.GetFunction(&p)
 
 	type args struct {
-		ctx      context.Context
-		revision *model.ReferenceID
+		ctx context.Context
 	}
 	type want struct {
-		xrdc model.CustomResourceDefinitionConnection
+		pc   model.FunctionConnection
 		err  error
 		errs gqlerror.List
 	}
@@ -1035,7 +1457,7 @@ func TestQueryCustomResourceDefinitions(t *testing.T) {
 	}{
 		"GetClientError": {
 			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, errBoom
 			}),
 			args: args{
@@ -1047,9 +1469,9 @@ func TestQueryCustomResourceDefinitions(t *testing.T) {
 				},
 			},
 		},
-		"ListCRDsError": {
-			reason: "If we can't list CRDs we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+		"ListFunctionsError": {
+			reason: "If we can't list functions we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(errBoom),
 				}, nil
@@ -1059,62 +1481,50 @@ func TestQueryCustomResourceDefinitions(t *testing.T) {
 			},
 			want: want{
 				errs: gqlerror.List{
-					gqlerror.Wrap(errors.Wrap(errBoom, errListConfigs)),
+					gqlerror.Wrap(errors.Wrap(errBoom, errListFunctions)),
 				},
 			},
 		},
-		"AllCRDs": {
-			reason: "We should successfully return all CRDs we can list and model when no arguments are supplied.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+		"V1Beta1": {
+			reason: "We should list functions at v1beta1 if v1 isn't served.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
-					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
-						*obj.(*unstructured.UnstructuredList) = unstructured.UnstructuredList{
-							Items: []unstructured.Unstructured{
-								dangler.Unstructured,
-								owned.Unstructured,
-							},
+					MockList: func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+						l, ok := obj.(*pkgv1beta1.FunctionList)
+						if !ok {
+							return &meta.NoKindMatchError{GroupKind: pkgv1.FunctionGroupVersionKind.GroupKind()}
 						}
+						l.Items = []pkgv1beta1.Function{{ObjectMeta: metav1.ObjectMeta{Name: "coolfunction"}}}
 						return nil
-					}),
+					},
 				}, nil
 			}),
 			args: args{
 				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
 			},
 			want: want{
-				xrdc: model.CustomResourceDefinitionConnection{
-					Nodes: []model.CustomResourceDefinition{
-						gdangler,
-						gowned,
-					},
-					TotalCount: 2,
+				pc: model.FunctionConnection{
+					Nodes:      []model.Function{gp},
+					TotalCount: 1,
 				},
 			},
 		},
-		"OwnedCRDs": {
-			reason: "We should successfully return the CRDs we can list and model that are owned by the supplied ID.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+		"Success": {
+			reason: "We should successfully return any functions we can list and model.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
-						*obj.(*unstructured.UnstructuredList) = unstructured.UnstructuredList{
-							Items: []unstructured.Unstructured{
-								dangler.Unstructured,
-								owned.Unstructured,
-							},
-						}
+						*obj.(*pkgv1.FunctionList) = pkgv1.FunctionList{Items: []pkgv1.Function{p}}
 						return nil
 					}),
 				}, nil
 			}),
 			args: args{
-				ctx:      graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
-				revision: &id,
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
 			},
 			want: want{
-				xrdc: model.CustomResourceDefinitionConnection{
-					Nodes: []model.CustomResourceDefinition{
-						gowned,
-					},
+				pc: model.FunctionConnection{
+					Nodes:      []model.Function{gp},
 					TotalCount: 1,
 				},
 			},
@@ -1127,36 +1537,79 @@ func TestQueryCustomResourceDefinitions(t *testing.T) {
 
 			// Our GraphQL resolvers never return errors. We instead add an
 			// error to the GraphQL context and return early.
-			got, err := q.CustomResourceDefinitions(tc.args.ctx, tc.args.revision)
+			got, err := q.Functions(tc.args.ctx)
 			errs := graphql.GetErrors(tc.args.ctx)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("\n%s\nq.Configurations(...): -want error, +got error:\n%s\n", tc.reason, diff)
+				t.Errorf("\n%s\nq.Functions(...): -want error, +got error:\n%s\n", tc.reason, diff)
 			}
 			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
-				t.Errorf("\n%s\nq.Configurations(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+				t.Errorf("\n%s\nq.Functions(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
 			}
-			if diff := cmp.Diff(tc.want.xrdc, got,
-				cmpopts.IgnoreUnexported(model.ObjectMeta{}),
-				cmpopts.IgnoreFields(model.CustomResourceDefinition{}, "PavedAccess"),
-			); diff != "" {
-				t.Errorf("\n%s\nq.Configurations(...): -want, +got:\n%s\n", tc.reason, diff)
+			if diff := cmp.Diff(tc.want.pc, got, cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
+				t.Errorf("\n%s\nq.Functions(...): -want, +got:\n%s\n", tc.reason, diff)
 			}
 		})
 	}
 }
 
-func TestQueryConfigurations(t *testing.T) {
+func TestQueryFunctionRevisions(t *testing.T) {
 	errBoom := errors.New("boom")
 
-	c := pkgv1.Configuration{ObjectMeta: metav1.ObjectMeta{Name: "coolconfig"}}
-	gc := model.GetConfiguration(&c)
+	id := model.ReferenceID{
+		APIVersion: pkgv1.FunctionGroupVersionKind.GroupVersion().String(),
+		Kind:       pkgv1.FunctionKind,
+		Name:       "coolfunction",
+	}
+
+	// The active FunctionRevision that we control.
+	active := pkgv1.FunctionRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "coolrev",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: id.APIVersion,
+				Kind:       id.Kind,
+				Name:       id.Name,
+			}},
+		},
+		Spec: pkgv1.FunctionRevisionSpec{PackageRevisionSpec: pkgv1.PackageRevisionSpec{DesiredState: pkgv1.PackageRevisionActive}},
+	}
+	gactive := model.GetFunctionRevision(&active)
+
+	// A FunctionRevision we control, but that is inactive.
+	inactive := pkgv1.FunctionRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "inactiverev",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: id.APIVersion,
+				Kind:       id.Kind,
+				Name:       id.Name,
+			}},
+		},
+		Spec: pkgv1.FunctionRevisionSpec{PackageRevisionSpec: pkgv1.PackageRevisionSpec{DesiredState: pkgv1.PackageRevisionInactive}},
+	}
+	ginactive := model.GetFunctionRevision(&inactive)
+
+	// A FunctionRevision which we do not control.
+	other := pkgv1.FunctionRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "not-ours",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: id.APIVersion,
+				Kind:       id.Kind,
+				Name:       "other",
+			}},
+		},
+	}
+	gother := model.GetFunctionRevision(&other)
 
 	type args struct {
-		ctx context.Context
+		ctx    context.Context
+		id     *model.ReferenceID
+		active *bool
 	}
 	type want struct {
-		cc   model.ConfigurationConnection
+		pc   model.FunctionRevisionConnection
 		err  error
 		errs gqlerror.List
 	}
@@ -1169,7 +1622,7 @@ func TestQueryConfigurations(t *testing.T) {
 	}{
 		"GetClientError": {
 			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, errBoom
 			}),
 			args: args{
@@ -1181,9 +1634,9 @@ func TestQueryConfigurations(t *testing.T) {
 				},
 			},
 		},
-		"ListConfigurationsError": {
-			reason: "If we can't list configurations we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+		"ListRevisionsError": {
+			reason: "If we can't list revisions we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(errBoom),
 				}, nil
@@ -1193,16 +1646,18 @@ func TestQueryConfigurations(t *testing.T) {
 			},
 			want: want{
 				errs: gqlerror.List{
-					gqlerror.Wrap(errors.Wrap(errBoom, errListConfigs)),
+					gqlerror.Wrap(errors.Wrap(errBoom, errListFunctionRevs)),
 				},
 			},
 		},
-		"Success": {
-			reason: "We should successfully return any configurations we can list and model.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+		"AllRevisions": {
+			reason: "We should successfully return any revisions we own that we can list and model.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
-						*obj.(*pkgv1.ConfigurationList) = pkgv1.ConfigurationList{Items: []pkgv1.Configuration{c}}
+						*obj.(*pkgv1.FunctionRevisionList) = pkgv1.FunctionRevisionList{
+							Items: []pkgv1.FunctionRevision{other, active, inactive},
+						}
 						return nil
 					}),
 				}, nil
@@ -1211,93 +1666,1267 @@ func TestQueryConfigurations(t *testing.T) {
 				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
 			},
 			want: want{
-				cc: model.ConfigurationConnection{
-					Nodes:      []model.Configuration{gc},
-					TotalCount: 1,
+				pc: model.FunctionRevisionConnection{
+					Nodes:      []model.FunctionRevision{gactive, ginactive, gother},
+					TotalCount: 3,
 				},
 			},
 		},
-	}
-
-	for name, tc := range cases {
-		t.Run(name, func(t *testing.T) {
-			q := &query{clients: tc.clients}
-
-			// Our GraphQL resolvers never return errors. We instead add an
-			// error to the GraphQL context and return early.
-			got, err := q.Configurations(tc.args.ctx)
-			errs := graphql.GetErrors(tc.args.ctx)
-
-			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("\n%s\nq.Configurations(...): -want error, +got error:\n%s\n", tc.reason, diff)
-			}
-			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+		"FunctionsRevisions": {
+			reason: "We should successfully return any revisions the supplied function id owns that we can list and model.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+						*obj.(*pkgv1.FunctionRevisionList) = pkgv1.FunctionRevisionList{
+							Items: []pkgv1.FunctionRevision{other, active, inactive},
+						}
+						return nil
+					}),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				id:  &id,
+			},
+			want: want{
+				pc: model.FunctionRevisionConnection{
+					Nodes:      []model.FunctionRevision{gactive, ginactive},
+					TotalCount: 2,
+				},
+			},
+		},
+		"ActiveRevisions": {
+			reason: "We should successfully return any active revisions that we can list and model.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+						*obj.(*pkgv1.FunctionRevisionList) = pkgv1.FunctionRevisionList{
+							Items: []pkgv1.FunctionRevision{other, active, inactive},
+						}
+						return nil
+					}),
+				}, nil
+			}),
+			args: args{
+				ctx:    graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				active: ptr.To(true),
+			},
+			want: want{
+				pc: model.FunctionRevisionConnection{
+					Nodes:      []model.FunctionRevision{gactive},
+					TotalCount: 1,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			q := &query{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := q.FunctionRevisions(tc.args.ctx, tc.args.id, tc.args.active)
+			errs := graphql.GetErrors(tc.args.ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nq.Revisions(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nq.Revisions(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.pc, got, cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
+				t.Errorf("\n%s\nq.Revisions(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestQueryCustomResourceDefinitions(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	id := model.ReferenceID{
+		APIVersion: pkgv1.ConfigurationRevisionGroupVersionKind.GroupVersion().String(),
+		Kind:       pkgv1.ConfigurationRevisionKind,
+		Name:       "example",
+	}
+
+	owned := xunstructured.NewCRD()
+	owned.SetName("coolconfig")
+	owned.SetOwnerReferences([]metav1.OwnerReference{
+		// Some spurious owner references that we should ignore.
+		{
+			APIVersion: "wat",
+		},
+		{
+			APIVersion: id.APIVersion,
+			Kind:       "wat",
+		},
+		{
+			APIVersion: id.APIVersion,
+			Kind:       id.Kind,
+			Name:       "wat",
+		},
+		// The reference that indicates this XRD is owned by our desired
+		// ConfigurationRevision (or a ConfigurationRevision generally).
+		{
+			APIVersion: id.APIVersion,
+			Kind:       id.Kind,
+			Name:       id.Name,
+		},
+	})
+
+	gowned := model.GetCustomResourceDefinition(owned)
+
+	dangler := xunstructured.NewCRD()
+	dangler.SetName("coolconfig")
+
+	gdangler := model.GetCustomResourceDefinition(dangler)
+
+	grouped := xunstructured.NewCRD()
+	grouped.SetName("coolgroup")
+	grouped.Object["spec"] = map[string]interface{}{"group": "example.org"}
+
+	ggrouped := model.GetCustomResourceDefinition(grouped)
+
+	list := test.NewMockListFn(nil, func(obj client.ObjectList) error {
+		*obj.(*unstructured.UnstructuredList) = unstructured.UnstructuredList{
+			Items: []unstructured.Unstructured{
+				dangler.Unstructured,
+				owned.Unstructured,
+				grouped.Unstructured,
+			},
+		}
+		return nil
+	})
+
+	type args struct {
+		ctx      context.Context
+		revision *model.ReferenceID
+		group    *string
+		offset   *int
+		limit    *int
+	}
+	type want struct {
+		xrdc model.CustomResourceDefinitionConnection
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		args    args
+		want    want
+	}{
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, errBoom
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"ListCRDsError": {
+			reason: "If we can't list CRDs we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(errBoom),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errListConfigs)),
+				},
+			},
+		},
+		"AllCRDs": {
+			reason: "We should successfully return all CRDs we can list and model when no arguments are supplied.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockList: list}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				xrdc: model.CustomResourceDefinitionConnection{
+					Nodes: []model.CustomResourceDefinition{
+						gdangler,
+						gowned,
+						ggrouped,
+					},
+					TotalCount: 3,
+				},
+			},
+		},
+		"OwnedCRDs": {
+			reason: "We should successfully return the CRDs we can list and model that are owned by the supplied ID.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockList: list}, nil
+			}),
+			args: args{
+				ctx:      graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				revision: &id,
+			},
+			want: want{
+				xrdc: model.CustomResourceDefinitionConnection{
+					Nodes: []model.CustomResourceDefinition{
+						gowned,
+					},
+					TotalCount: 1,
+				},
+			},
+		},
+		"GroupCRDs": {
+			reason: "We should successfully return the CRDs we can list and model that are in the supplied group.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockList: list}, nil
+			}),
+			args: args{
+				ctx:   graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				group: ptr.To("example.org"),
+			},
+			want: want{
+				xrdc: model.CustomResourceDefinitionConnection{
+					Nodes: []model.CustomResourceDefinition{
+						ggrouped,
+					},
+					TotalCount: 1,
+				},
+			},
+		},
+		"Paginated": {
+			reason: "We should return only the requested page of CRDs, while still counting all of them.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockList: list}, nil
+			}),
+			args: args{
+				ctx:    graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				offset: ptr.To(1),
+				limit:  ptr.To(1),
+			},
+			want: want{
+				xrdc: model.CustomResourceDefinitionConnection{
+					Nodes: []model.CustomResourceDefinition{
+						gowned,
+					},
+					TotalCount: 3,
+				},
+			},
+		},
+		"OffsetPastEnd": {
+			reason: "We should return no CRDs when the offset is past the last CRD.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockList: list}, nil
+			}),
+			args: args{
+				ctx:    graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				offset: ptr.To(5),
+			},
+			want: want{
+				xrdc: model.CustomResourceDefinitionConnection{
+					Nodes:      []model.CustomResourceDefinition{},
+					TotalCount: 3,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			q := &query{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := q.CustomResourceDefinitions(tc.args.ctx, tc.args.revision, tc.args.group, tc.args.offset, tc.args.limit)
+			errs := graphql.GetErrors(tc.args.ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nq.CustomResourceDefinitions(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nq.CustomResourceDefinitions(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.xrdc, got,
+				cmpopts.IgnoreUnexported(model.ObjectMeta{}),
+				cmpopts.IgnoreFields(model.CustomResourceDefinition{}, "PavedAccess"),
+			); diff != "" {
+				t.Errorf("\n%s\nq.CustomResourceDefinitions(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestQueryConfigurations(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	c := pkgv1.Configuration{ObjectMeta: metav1.ObjectMeta{Name: "coolconfig"}}
+	gc := model.GetConfiguration(&c)
+
+	type args struct {
+		ctx context.Context
+	}
+	type want struct {
+		cc   model.ConfigurationConnection
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		args    args
+		want    want
+	}{
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, errBoom
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"ListConfigurationsError": {
+			reason: "If we can't list configurations we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(errBoom),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errListConfigs)),
+				},
+			},
+		},
+		"Success": {
+			reason: "We should successfully return any configurations we can list and model.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+						*obj.(*pkgv1.ConfigurationList) = pkgv1.ConfigurationList{Items: []pkgv1.Configuration{c}}
+						return nil
+					}),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				cc: model.ConfigurationConnection{
+					Nodes:      []model.Configuration{gc},
+					TotalCount: 1,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			q := &query{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := q.Configurations(tc.args.ctx)
+			errs := graphql.GetErrors(tc.args.ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nq.Configurations(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nq.Configurations(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cc, got, cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
+				t.Errorf("\n%s\nq.Configurations(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestQueryConfigurationRevisions(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	id := model.ReferenceID{
+		APIVersion: pkgv1.ConfigurationGroupVersionKind.GroupVersion().String(),
+		Kind:       pkgv1.ConfigurationKind,
+		Name:       "coolconfig",
+	}
+
+	// The active ConfigurationRevision that we control.
+	active := pkgv1.ConfigurationRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "coolrev",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: id.APIVersion,
+				Kind:       id.Kind,
+				Name:       id.Name,
+			}},
+		},
+		Spec: pkgv1.PackageRevisionSpec{DesiredState: pkgv1.PackageRevisionActive},
+	}
+	gactive := model.GetConfigurationRevision(&active)
+
+	// A ConfigurationRevision we control, but that is inactive.
+	inactive := pkgv1.ConfigurationRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "inactiverev",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: id.APIVersion,
+				Kind:       id.Kind,
+				Name:       id.Name,
+			}},
+		},
+		Spec: pkgv1.PackageRevisionSpec{DesiredState: pkgv1.PackageRevisionInactive},
+	}
+	ginactive := model.GetConfigurationRevision(&inactive)
+
+	// A ConfigurationRevision which we do not control.
+	other := pkgv1.ConfigurationRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "not-ours",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: id.APIVersion,
+				Kind:       id.Kind,
+				Name:       "other",
+			}},
+		},
+	}
+	gother := model.GetConfigurationRevision(&other)
+
+	type args struct {
+		ctx    context.Context
+		id     *model.ReferenceID
+		active *bool
+	}
+	type want struct {
+		pc   model.ConfigurationRevisionConnection
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		args    args
+		want    want
+	}{
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, errBoom
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"ListRevisionsError": {
+			reason: "If we can't list revisions we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(errBoom),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errListConfigRevs)),
+				},
+			},
+		},
+		"AllRevisions": {
+			reason: "We should successfully return any revisions we own that we can list and model.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+						*obj.(*pkgv1.ConfigurationRevisionList) = pkgv1.ConfigurationRevisionList{
+							Items: []pkgv1.ConfigurationRevision{other, active, inactive},
+						}
+						return nil
+					}),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				pc: model.ConfigurationRevisionConnection{
+					Nodes:      []model.ConfigurationRevision{gactive, ginactive, gother},
+					TotalCount: 3,
+				},
+			},
+		},
+		"ConfigurationsRevisions": {
+			reason: "We should successfully return any revisions the supplied provider id owns that we can list and model.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+						*obj.(*pkgv1.ConfigurationRevisionList) = pkgv1.ConfigurationRevisionList{
+							Items: []pkgv1.ConfigurationRevision{other, active, inactive},
+						}
+						return nil
+					}),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				id:  &id,
+			},
+			want: want{
+				pc: model.ConfigurationRevisionConnection{
+					Nodes:      []model.ConfigurationRevision{gactive, ginactive},
+					TotalCount: 2,
+				},
+			},
+		},
+		"ActiveRevisions": {
+			reason: "We should successfully return any active revisions that we can list and model.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+						*obj.(*pkgv1.ConfigurationRevisionList) = pkgv1.ConfigurationRevisionList{
+							Items: []pkgv1.ConfigurationRevision{other, active, inactive},
+						}
+						return nil
+					}),
+				}, nil
+			}),
+			args: args{
+				ctx:    graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				active: ptr.To(true),
+			},
+			want: want{
+				pc: model.ConfigurationRevisionConnection{
+					Nodes:      []model.ConfigurationRevision{gactive},
+					TotalCount: 1,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			q := &query{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := q.ConfigurationRevisions(tc.args.ctx, tc.args.id, tc.args.active)
+			errs := graphql.GetErrors(tc.args.ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nq.Revisions(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nq.Revisions(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.pc, got, cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
+				t.Errorf("\n%s\nq.Revisions(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestQueryCompositeResourceDefinitions(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	id := model.ReferenceID{
+		APIVersion: pkgv1.ConfigurationRevisionGroupVersionKind.GroupVersion().String(),
+		Kind:       pkgv1.ConfigurationRevisionKind,
+		Name:       "example",
+	}
+
+	owned := extv1.CompositeResourceDefinition{ObjectMeta: metav1.ObjectMeta{
+		Name: "coolconfig",
+		OwnerReferences: []metav1.OwnerReference{
+			// Some spurious owner references that we should ignore.
+			{
+				APIVersion: "wat",
+			},
+			{
+				APIVersion: id.APIVersion,
+				Kind:       "wat",
+			},
+			{
+				APIVersion: id.APIVersion,
+				Kind:       id.Kind,
+				Name:       "wat",
+			},
+			// The reference that indicates this XRD is owned by our desired
+			// ConfigurationRevision (or a ConfigurationRevision generally).
+			{
+				APIVersion: id.APIVersion,
+				Kind:       id.Kind,
+				Name:       id.Name,
+			},
+		},
+	}}
+	gowned := model.GetCompositeResourceDefinition(&owned)
+
+	dangler := extv1.CompositeResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "coolconfig"}}
+	gdangler := model.GetCompositeResourceDefinition(&dangler)
+
+	type args struct {
+		ctx      context.Context
+		revision *model.ReferenceID
+		dangling *bool
+	}
+	type want struct {
+		xrdc model.CompositeResourceDefinitionConnection
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		args    args
+		want    want
+	}{
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, errBoom
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"ListXRDsError": {
+			reason: "If we can't list XRDs we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(errBoom),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errListConfigs)),
+				},
+			},
+		},
+		"AllXRDs": {
+			reason: "We should successfully return all XRDs we can list and model when no arguments are supplied.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+						*obj.(*extv1.CompositeResourceDefinitionList) = extv1.CompositeResourceDefinitionList{
+							Items: []extv1.CompositeResourceDefinition{
+								dangler,
+								owned,
+							},
+						}
+						return nil
+					}),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				xrdc: model.CompositeResourceDefinitionConnection{
+					Nodes: []model.CompositeResourceDefinition{
+						gdangler,
+						gowned,
+					},
+					TotalCount: 2,
+				},
+			},
+		},
+		"DanglingXRDs": {
+			reason: "We should successfully return dangling XRDs we can list and model.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+						*obj.(*extv1.CompositeResourceDefinitionList) = extv1.CompositeResourceDefinitionList{
+							Items: []extv1.CompositeResourceDefinition{
+								dangler,
+								owned,
+							},
+						}
+						return nil
+					}),
+				}, nil
+			}),
+			args: args{
+				ctx:      graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				dangling: ptr.To(true),
+			},
+			want: want{
+				xrdc: model.CompositeResourceDefinitionConnection{
+					Nodes: []model.CompositeResourceDefinition{
+						gdangler,
+					},
+					TotalCount: 1,
+				},
+			},
+		},
+		"OwnedXRDs": {
+			reason: "We should successfully return the XRDs we can list and model that are owned by the supplied ID.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+						*obj.(*extv1.CompositeResourceDefinitionList) = extv1.CompositeResourceDefinitionList{
+							Items: []extv1.CompositeResourceDefinition{
+								dangler,
+								owned,
+							},
+						}
+						return nil
+					}),
+				}, nil
+			}),
+			args: args{
+				ctx:      graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				revision: &id,
+			},
+			want: want{
+				xrdc: model.CompositeResourceDefinitionConnection{
+					Nodes: []model.CompositeResourceDefinition{
+						gowned,
+					},
+					TotalCount: 1,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			q := &query{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := q.CompositeResourceDefinitions(tc.args.ctx, tc.args.revision, tc.args.dangling)
+			errs := graphql.GetErrors(tc.args.ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nq.Configurations(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nq.Configurations(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.xrdc, got, cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
+				t.Errorf("\n%s\nq.Configurations(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestQueryCompositions(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	id := model.ReferenceID{
+		APIVersion: pkgv1.ConfigurationRevisionGroupVersionKind.GroupVersion().String(),
+		Kind:       pkgv1.ConfigurationRevisionKind,
+		Name:       "example",
+	}
+
+	owned := extv1.Composition{ObjectMeta: metav1.ObjectMeta{
+		Name: "coolconfig",
+		OwnerReferences: []metav1.OwnerReference{
+			// Some spurious owner references that we should ignore.
+			{
+				APIVersion: "wat",
+			},
+			{
+				APIVersion: id.APIVersion,
+				Kind:       "wat",
+			},
+			{
+				APIVersion: id.APIVersion,
+				Kind:       id.Kind,
+				Name:       "wat",
+			},
+			// The reference that indicates this Composition is owned by our
+			// desired ConfigurationRevision (or a ConfigurationRevision
+			// generally).
+			{
+				APIVersion: id.APIVersion,
+				Kind:       id.Kind,
+				Name:       id.Name,
+			},
+		},
+	}}
+	gowned := model.GetComposition(&owned)
+
+	dangler := extv1.Composition{ObjectMeta: metav1.ObjectMeta{Name: "coolconfig"}}
+	gdangler := model.GetComposition(&dangler)
+
+	type args struct {
+		ctx      context.Context
+		revision *model.ReferenceID
+		dangling *bool
+	}
+	type want struct {
+		cc   model.CompositionConnection
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		args    args
+		want    want
+	}{
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, errBoom
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"ListCompositionsError": {
+			reason: "If we can't list compositions we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(errBoom),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errListConfigs)),
+				},
+			},
+		},
+		"AllCompositions": {
+			reason: "We should successfully return all compositions we can list and model when no arguments are supplied.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+						*obj.(*extv1.CompositionList) = extv1.CompositionList{
+							Items: []extv1.Composition{
+								dangler,
+								owned,
+							},
+						}
+						return nil
+					}),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				cc: model.CompositionConnection{
+					Nodes: []model.Composition{
+						gdangler,
+						gowned,
+					},
+					TotalCount: 2,
+				},
+			},
+		},
+		"DanglingCompositions": {
+			reason: "We should successfully return dangling compositions we can list and model.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+						*obj.(*extv1.CompositionList) = extv1.CompositionList{
+							Items: []extv1.Composition{
+								dangler,
+								owned,
+							},
+						}
+						return nil
+					}),
+				}, nil
+			}),
+			args: args{
+				ctx:      graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				dangling: ptr.To(true),
+			},
+			want: want{
+				cc: model.CompositionConnection{
+					Nodes: []model.Composition{
+						gdangler,
+					},
+					TotalCount: 1,
+				},
+			},
+		},
+		"OwnedCompositions": {
+			reason: "We should successfully return the compositions we can list and model that are owned by the supplied ID.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+						*obj.(*extv1.CompositionList) = extv1.CompositionList{
+							Items: []extv1.Composition{
+								dangler,
+								owned,
+							},
+						}
+						return nil
+					}),
+				}, nil
+			}),
+			args: args{
+				ctx:      graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				revision: &id,
+			},
+			want: want{
+				cc: model.CompositionConnection{
+					Nodes: []model.Composition{
+						gowned,
+					},
+					TotalCount: 1,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			q := &query{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := q.Compositions(tc.args.ctx, tc.args.revision, tc.args.dangling)
+			errs := graphql.GetErrors(tc.args.ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nq.Configurations(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\nq.Configurations(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
 			}
-			if diff := cmp.Diff(tc.want.cc, got, cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
-				t.Errorf("\n%s\nq.Configurations(...): -want, +got:\n%s\n", tc.reason, diff)
+			if diff := cmp.Diff(tc.want.cc, got, cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
+				t.Errorf("\n%s\nq.Configurations(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestQueryClaims(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	xrds := []extv1.CompositeResourceDefinition{
+		{Spec: extv1.CompositeResourceDefinitionSpec{
+			Group:      "example.org",
+			Names:      kextv1.CustomResourceDefinitionNames{Kind: "XAlpha"},
+			ClaimNames: &kextv1.CustomResourceDefinitionNames{Kind: "Alpha"},
+			Versions:   []extv1.CompositeResourceDefinitionVersion{{Name: "v1", Served: true, Referenceable: true}},
+		}},
+		{Spec: extv1.CompositeResourceDefinitionSpec{
+			Group:      "example.org",
+			Names:      kextv1.CustomResourceDefinitionNames{Kind: "XBeta"},
+			ClaimNames: &kextv1.CustomResourceDefinitionNames{Kind: "Beta", ListKind: "Betas"},
+			Versions:   []extv1.CompositeResourceDefinitionVersion{{Name: "v1", Served: true, Referenceable: true}},
+		}},
+		{Spec: extv1.CompositeResourceDefinitionSpec{
+			Group:    "example.org",
+			Names:    kextv1.CustomResourceDefinitionNames{Kind: "XGamma"},
+			Versions: []extv1.CompositeResourceDefinitionVersion{{Name: "v1", Served: true, Referenceable: true}},
+		}},
+	}
+
+	alpha := unstructured.Unstructured{}
+	alpha.SetAPIVersion("example.org/v1")
+	alpha.SetKind("Alpha")
+	alpha.SetName("a")
+	galpha := model.GetCompositeResourceClaim(&alpha)
+
+	beta := unstructured.Unstructured{}
+	beta.SetAPIVersion("example.org/v1")
+	beta.SetKind("Beta")
+	beta.SetName("b")
+	gbeta := model.GetCompositeResourceClaim(&beta)
+
+	// list returns the supplied XRDs, and claims of each listable claim kind.
+	// Listing any other kind returns errBoom.
+	list := func(t *testing.T, claims map[string]unstructured.Unstructured) func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+		return func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+			switch l := obj.(type) {
+			case *extv1.CompositeResourceDefinitionList:
+				l.Items = xrds
+			case *unstructured.UnstructuredList:
+				if k := l.GetKind(); k == "XAlphaList" || k == "XBetaList" || k == "XGammaList" {
+					t.Errorf("unexpected list of composite resource kind %q", k)
+				}
+				c, ok := claims[l.GetKind()]
+				if !ok {
+					return errBoom
+				}
+				l.Items = []unstructured.Unstructured{c}
+			}
+			return nil
+		}
+	}
+
+	type args struct {
+		ctx       context.Context
+		namespace *string
+	}
+	type want struct {
+		xrcc model.CompositeResourceClaimConnection
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		kinds   DefinedKindCache
+		args    args
+		want    want
+	}{
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, errBoom
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"ListXRDsError": {
+			reason: "If we can't list XRDs we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(errBoom),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errListXRDs)),
+				},
+			},
+		},
+		"ListClaimsError": {
+			reason: "If we can't list a kind of claim we should add the error to the GraphQL context and return the claims we can list.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: list(t, map[string]unstructured.Unstructured{"AlphaList": alpha}),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				xrcc: model.CompositeResourceClaimConnection{
+					Nodes:      []model.CompositeResourceClaim{galpha},
+					TotalCount: 1,
+				},
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrapf(errBoom, errFmtListDefined, schema.GroupVersionKind{Group: "example.org", Version: "v1", Kind: "Beta"})),
+				},
+			},
+		},
+		"AllClaims": {
+			reason: "We should successfully return claims of every kind defined by an XRD.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: list(t, map[string]unstructured.Unstructured{"AlphaList": alpha, "Betas": beta}),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				xrcc: model.CompositeResourceClaimConnection{
+					Nodes:      []model.CompositeResourceClaim{galpha, gbeta},
+					TotalCount: 2,
+				},
+			},
+		},
+		"DefinedKindCacheError": {
+			reason: "If we can't get defined kinds from the cache we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, nil
+			}),
+			kinds: DefinedKindCacheFn(func(_ context.Context, _ auth.Credentials) (clients.DefinedKinds, error) {
+				return clients.DefinedKinds{}, errBoom
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetDefinedKinds)),
+				},
+			},
+		},
+		"WithDefinedKindCache": {
+			reason: "We should list claims of the kinds returned by the defined kind cache, rather than listing XRDs.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: func(ctx context.Context, obj client.ObjectList, opts ...client.ListOption) error {
+						if _, ok := obj.(*extv1.CompositeResourceDefinitionList); ok {
+							t.Errorf("unexpected list of XRDs")
+						}
+						return list(t, map[string]unstructured.Unstructured{"AlphaList": alpha})(ctx, obj, opts...)
+					},
+				}, nil
+			}),
+			kinds: DefinedKindCacheFn(func(_ context.Context, _ auth.Credentials) (clients.DefinedKinds, error) {
+				return clients.DefinedKinds{Claims: []clients.DefinedKind{{
+					GroupVersionKind: schema.GroupVersionKind{Group: "example.org", Version: "v1", Kind: "Alpha"},
+					ListKind:         "AlphaList",
+				}}}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				xrcc: model.CompositeResourceClaimConnection{
+					Nodes:      []model.CompositeResourceClaim{galpha},
+					TotalCount: 1,
+				},
+			},
+		},
+		"WithNamespace": {
+			reason: "We should only list claims in the supplied namespace.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: func(ctx context.Context, obj client.ObjectList, opts ...client.ListOption) error {
+						if _, ok := obj.(*unstructured.UnstructuredList); ok {
+							lo := &client.ListOptions{}
+							lo.ApplyOptions(opts)
+							if diff := cmp.Diff("default", lo.Namespace); diff != "" {
+								t.Errorf("-want namespace, +got namespace:\n%s", diff)
+							}
+						}
+						return list(t, map[string]unstructured.Unstructured{"AlphaList": alpha, "Betas": beta})(ctx, obj, opts...)
+					},
+				}, nil
+			}),
+			args: args{
+				ctx:       graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				namespace: ptr.To("default"),
+			},
+			want: want{
+				xrcc: model.CompositeResourceClaimConnection{
+					Nodes:      []model.CompositeResourceClaim{galpha, gbeta},
+					TotalCount: 2,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			q := &query{clients: tc.clients, kinds: tc.kinds}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := q.Claims(tc.args.ctx, tc.args.namespace)
+			errs := graphql.GetErrors(tc.args.ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nq.Claims(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nq.Claims(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.xrcc, got, cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
+				t.Errorf("\n%s\nq.Claims(...): -want, +got:\n%s\n", tc.reason, diff)
 			}
 		})
 	}
 }
 
-func TestQueryConfigurationRevisions(t *testing.T) {
+func TestQueryCompositeResources(t *testing.T) {
 	errBoom := errors.New("boom")
 
-	id := model.ReferenceID{
-		APIVersion: pkgv1.ConfigurationGroupVersionKind.GroupVersion().String(),
-		Kind:       pkgv1.ConfigurationKind,
-		Name:       "coolconfig",
-	}
-
-	// The active ConfigurationRevision that we control.
-	active := pkgv1.ConfigurationRevision{
-		ObjectMeta: metav1.ObjectMeta{
-			Name: "coolrev",
-			OwnerReferences: []metav1.OwnerReference{{
-				APIVersion: id.APIVersion,
-				Kind:       id.Kind,
-				Name:       id.Name,
-			}},
-		},
-		Spec: pkgv1.PackageRevisionSpec{DesiredState: pkgv1.PackageRevisionActive},
+	xrds := []extv1.CompositeResourceDefinition{
+		{Spec: extv1.CompositeResourceDefinitionSpec{
+			Group:    "example.org",
+			Names:    kextv1.CustomResourceDefinitionNames{Kind: "XAlpha"},
+			Versions: []extv1.CompositeResourceDefinitionVersion{{Name: "v1", Served: true, Referenceable: true}},
+		}},
+		{Spec: extv1.CompositeResourceDefinitionSpec{
+			Group:    "example.org",
+			Names:    kextv1.CustomResourceDefinitionNames{Kind: "XBeta", ListKind: "XBetas"},
+			Versions: []extv1.CompositeResourceDefinitionVersion{{Name: "v1", Served: true, Referenceable: true}},
+		}},
 	}
-	gactive := model.GetConfigurationRevision(&active)
 
-	// A ConfigurationRevision we control, but that is inactive.
-	inactive := pkgv1.ConfigurationRevision{
-		ObjectMeta: metav1.ObjectMeta{
-			Name: "inactiverev",
-			OwnerReferences: []metav1.OwnerReference{{
-				APIVersion: id.APIVersion,
-				Kind:       id.Kind,
-				Name:       id.Name,
-			}},
-		},
-		Spec: pkgv1.PackageRevisionSpec{DesiredState: pkgv1.PackageRevisionInactive},
+	newXR := func(kind, name string) unstructured.Unstructured {
+		u := unstructured.Unstructured{}
+		u.SetAPIVersion("example.org/v1")
+		u.SetKind(kind)
+		u.SetName(name)
+		return u
 	}
-	ginactive := model.GetConfigurationRevision(&inactive)
-
-	// A ConfigurationRevision which we do not control.
-	other := pkgv1.ConfigurationRevision{
-		ObjectMeta: metav1.ObjectMeta{
-			Name: "not-ours",
-			OwnerReferences: []metav1.OwnerReference{{
-				APIVersion: id.APIVersion,
-				Kind:       id.Kind,
-				Name:       "other",
-			}},
-		},
+	alpha1, alpha2, beta := newXR("XAlpha", "a1"), newXR("XAlpha", "a2"), newXR("XBeta", "b")
+	galpha1, galpha2, gbeta := model.GetCompositeResource(&alpha1), model.GetCompositeResource(&alpha2), model.GetCompositeResource(&beta)
+
+	// list returns the supplied XRDs, and the supplied composite resources of
+	// each listable kind. Listing any other kind returns errBoom.
+	list := func(xrs map[string][]unstructured.Unstructured) func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+		return func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+			switch l := obj.(type) {
+			case *extv1.CompositeResourceDefinitionList:
+				l.Items = xrds
+			case *unstructured.UnstructuredList:
+				items, ok := xrs[l.GetKind()]
+				if !ok {
+					return errBoom
+				}
+				l.Items = items
+			}
+			return nil
+		}
 	}
-	gother := model.GetConfigurationRevision(&other)
 
 	type args struct {
 		ctx    context.Context
-		id     *model.ReferenceID
-		active *bool
+		kind   *string
+		offset *int
+		limit  *int
 	}
 	type want struct {
-		pc   model.ConfigurationRevisionConnection
+		xrc  model.CompositeResourceConnection
 		err  error
 		errs gqlerror.List
 	}
@@ -1310,7 +2939,7 @@ func TestQueryConfigurationRevisions(t *testing.T) {
 	}{
 		"GetClientError": {
 			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, errBoom
 			}),
 			args: args{
@@ -1322,9 +2951,9 @@ func TestQueryConfigurationRevisions(t *testing.T) {
 				},
 			},
 		},
-		"ListRevisionsError": {
-			reason: "If we can't list revisions we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+		"ListXRDsError": {
+			reason: "If we can't list XRDs we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(errBoom),
 				}, nil
@@ -1334,78 +2963,85 @@ func TestQueryConfigurationRevisions(t *testing.T) {
 			},
 			want: want{
 				errs: gqlerror.List{
-					gqlerror.Wrap(errors.Wrap(errBoom, errListConfigRevs)),
+					gqlerror.Wrap(errors.Wrap(errBoom, errListXRDs)),
 				},
 			},
 		},
-		"AllRevisions": {
-			reason: "We should successfully return any revisions we own that we can list and model.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+		"ListCompositeResourcesError": {
+			reason: "If we can't list a kind of composite resource we should add the error to the GraphQL context and return the composite resources we can list.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
-					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
-						*obj.(*pkgv1.ConfigurationRevisionList) = pkgv1.ConfigurationRevisionList{
-							Items: []pkgv1.ConfigurationRevision{other, active, inactive},
-						}
-						return nil
-					}),
+					MockList: list(map[string][]unstructured.Unstructured{"XAlphaList": {alpha1}}),
 				}, nil
 			}),
 			args: args{
 				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
 			},
 			want: want{
-				pc: model.ConfigurationRevisionConnection{
-					Nodes:      []model.ConfigurationRevision{gactive, ginactive, gother},
-					TotalCount: 3,
+				xrc: model.CompositeResourceConnection{
+					Nodes:      []model.CompositeResource{galpha1},
+					TotalCount: 1,
+				},
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrapf(errBoom, errFmtListDefined, schema.GroupVersionKind{Group: "example.org", Version: "v1", Kind: "XBeta"})),
 				},
 			},
 		},
-		"ConfigurationsRevisions": {
-			reason: "We should successfully return any revisions the supplied provider id owns that we can list and model.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+		"AllCompositeResources": {
+			reason: "We should successfully return composite resources of every kind defined by an XRD.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
-					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
-						*obj.(*pkgv1.ConfigurationRevisionList) = pkgv1.ConfigurationRevisionList{
-							Items: []pkgv1.ConfigurationRevision{other, active, inactive},
-						}
-						return nil
-					}),
+					MockList: list(map[string][]unstructured.Unstructured{"XAlphaList": {alpha2, alpha1}, "XBetas": {beta}}),
 				}, nil
 			}),
 			args: args{
 				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
-				id:  &id,
 			},
 			want: want{
-				pc: model.ConfigurationRevisionConnection{
-					Nodes:      []model.ConfigurationRevision{gactive, ginactive},
-					TotalCount: 2,
+				xrc: model.CompositeResourceConnection{
+					Nodes:      []model.CompositeResource{galpha1, galpha2, gbeta},
+					TotalCount: 3,
 				},
 			},
 		},
-		"ActiveRevisions": {
-			reason: "We should successfully return any active revisions that we can list and model.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+		"WithKind": {
+			reason: "We should only list composite resources of the supplied kind.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
-					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
-						*obj.(*pkgv1.ConfigurationRevisionList) = pkgv1.ConfigurationRevisionList{
-							Items: []pkgv1.ConfigurationRevision{other, active, inactive},
-						}
-						return nil
-					}),
+					// Listing XBetas would return an error.
+					MockList: list(map[string][]unstructured.Unstructured{"XAlphaList": {alpha1}}),
 				}, nil
 			}),
 			args: args{
-				ctx:    graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
-				active: ptr.To(true),
+				ctx:  graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				kind: ptr.To("XAlpha"),
 			},
 			want: want{
-				pc: model.ConfigurationRevisionConnection{
-					Nodes:      []model.ConfigurationRevision{gactive},
+				xrc: model.CompositeResourceConnection{
+					Nodes:      []model.CompositeResource{galpha1},
 					TotalCount: 1,
 				},
 			},
 		},
+		"Paginated": {
+			reason: "We should return only the requested page of composite resources, without affecting the total count.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: list(map[string][]unstructured.Unstructured{"XAlphaList": {alpha2, alpha1}, "XBetas": {beta}}),
+				}, nil
+			}),
+			args: args{
+				ctx:    graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				offset: ptr.To(1),
+				limit:  ptr.To(1),
+			},
+			want: want{
+				xrc: model.CompositeResourceConnection{
+					Nodes:      []model.CompositeResource{galpha2},
+					TotalCount: 3,
+				},
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -1414,70 +3050,144 @@ func TestQueryConfigurationRevisions(t *testing.T) {
 
 			// Our GraphQL resolvers never return errors. We instead add an
 			// error to the GraphQL context and return early.
-			got, err := q.ConfigurationRevisions(tc.args.ctx, tc.args.id, tc.args.active)
+			got, err := q.CompositeResources(tc.args.ctx, tc.args.kind, tc.args.offset, tc.args.limit)
 			errs := graphql.GetErrors(tc.args.ctx)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("\n%s\nq.Revisions(...): -want error, +got error:\n%s\n", tc.reason, diff)
+				t.Errorf("\n%s\nq.CompositeResources(...): -want error, +got error:\n%s\n", tc.reason, diff)
 			}
 			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
-				t.Errorf("\n%s\nq.Revisions(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+				t.Errorf("\n%s\nq.CompositeResources(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
 			}
-			if diff := cmp.Diff(tc.want.pc, got, cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
-				t.Errorf("\n%s\nq.Revisions(...): -want, +got:\n%s\n", tc.reason, diff)
+			if diff := cmp.Diff(tc.want.xrc, got, cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
+				t.Errorf("\n%s\nq.CompositeResources(...): -want, +got:\n%s\n", tc.reason, diff)
 			}
 		})
 	}
 }
 
-func TestQueryCompositeResourceDefinitions(t *testing.T) {
+func TestQueryCan(t *testing.T) {
 	errBoom := errors.New("boom")
 
-	id := model.ReferenceID{
-		APIVersion: pkgv1.ConfigurationRevisionGroupVersionKind.GroupVersion().String(),
-		Kind:       pkgv1.ConfigurationRevisionKind,
-		Name:       "example",
+	get := model.ResourceAttributesInput{Verb: "get", Resource: "secrets", Namespace: ptr.To("default")}
+	del := model.ResourceAttributesInput{Verb: "delete", Group: ptr.To("pkg.crossplane.io"), Resource: "providers"}
+
+	type args struct {
+		ctx     context.Context
+		actions []model.ResourceAttributesInput
+	}
+	type want struct {
+		reviews []model.AccessReview
+		err     error
+		errs    gqlerror.List
 	}
 
-	owned := extv1.CompositeResourceDefinition{ObjectMeta: metav1.ObjectMeta{
-		Name: "coolconfig",
-		OwnerReferences: []metav1.OwnerReference{
-			// Some spurious owner references that we should ignore.
-			{
-				APIVersion: "wat",
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		args    args
+		want    want
+	}{
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, errBoom
+			}),
+			args: args{
+				ctx:     graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				actions: []model.ResourceAttributesInput{get},
 			},
-			{
-				APIVersion: id.APIVersion,
-				Kind:       "wat",
+			want: want{
+				reviews: []model.AccessReview{},
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
 			},
-			{
-				APIVersion: id.APIVersion,
-				Kind:       id.Kind,
-				Name:       "wat",
+		},
+		"ReviewError": {
+			reason: "If we can't review an action we should add the error to the GraphQL context and report it as not allowed.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockCreate: test.NewMockCreateFn(errBoom),
+				}, nil
+			}),
+			args: args{
+				ctx:     graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				actions: []model.ResourceAttributesInput{get},
 			},
-			// The reference that indicates this XRD is owned by our desired
-			// ConfigurationRevision (or a ConfigurationRevision generally).
-			{
-				APIVersion: id.APIVersion,
-				Kind:       id.Kind,
-				Name:       id.Name,
+			want: want{
+				reviews: []model.AccessReview{
+					{ResourceAttributes: model.ResourceAttributes{Verb: "get", Resource: "secrets", Namespace: ptr.To("default")}},
+				},
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errReviewAccess)),
+				},
 			},
 		},
-	}}
-	gowned := model.GetCompositeResourceDefinition(&owned)
+		"Success": {
+			reason: "We should return a review for each supplied action, in order.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockCreate: test.NewMockCreateFn(nil, func(obj client.Object) error {
+						ar := obj.(*authv1.SelfSubjectAccessReview)
+						ar.Status.Allowed = ar.Spec.ResourceAttributes.Verb == "get"
+						return nil
+					}),
+				}, nil
+			}),
+			args: args{
+				ctx:     graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				actions: []model.ResourceAttributesInput{get, del},
+			},
+			want: want{
+				reviews: []model.AccessReview{
+					{
+						ResourceAttributes: model.ResourceAttributes{Verb: "get", Resource: "secrets", Namespace: ptr.To("default")},
+						Allowed:            true,
+					},
+					{
+						ResourceAttributes: model.ResourceAttributes{Verb: "delete", Group: ptr.To("pkg.crossplane.io"), Resource: "providers"},
+						Allowed:            false,
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			q := &query{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := q.Can(tc.args.ctx, tc.args.actions)
+			errs := graphql.GetErrors(tc.args.ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nq.Can(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nq.Can(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.reviews, got); diff != "" {
+				t.Errorf("\n%s\nq.Can(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
 
-	dangler := extv1.CompositeResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "coolconfig"}}
-	gdangler := model.GetCompositeResourceDefinition(&dangler)
+func TestQuerySelfSubjectRules(t *testing.T) {
+	errBoom := errors.New("boom")
+	errForbidden := kerrors.NewForbidden(schema.GroupResource{Group: authv1.GroupName, Resource: "selfsubjectrulesreviews"}, "", errBoom)
 
 	type args struct {
-		ctx      context.Context
-		revision *model.ReferenceID
-		dangling *bool
+		ctx       context.Context
+		namespace string
 	}
 	type want struct {
-		xrdc model.CompositeResourceDefinitionConnection
-		err  error
-		errs gqlerror.List
+		rules *model.SubjectRules
+		err   error
+		errs  gqlerror.List
 	}
 
 	cases := map[string]struct {
@@ -1488,11 +3198,12 @@ func TestQueryCompositeResourceDefinitions(t *testing.T) {
 	}{
 		"GetClientError": {
 			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, errBoom
 			}),
 			args: args{
-				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				ctx:       graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				namespace: "default",
 			},
 			want: want{
 				errs: gqlerror.List{
@@ -1500,103 +3211,77 @@ func TestQueryCompositeResourceDefinitions(t *testing.T) {
 				},
 			},
 		},
-		"ListXRDsError": {
-			reason: "If we can't list XRDs we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+		"ReviewError": {
+			reason: "If we can't review rules we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
-					MockList: test.NewMockListFn(errBoom),
+					MockCreate: test.NewMockCreateFn(errBoom),
 				}, nil
 			}),
 			args: args{
-				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				ctx:       graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				namespace: "default",
 			},
 			want: want{
 				errs: gqlerror.List{
-					gqlerror.Wrap(errors.Wrap(errBoom, errListConfigs)),
-				},
-			},
-		},
-		"AllXRDs": {
-			reason: "We should successfully return all XRDs we can list and model when no arguments are supplied.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
-				return &test.MockClient{
-					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
-						*obj.(*extv1.CompositeResourceDefinitionList) = extv1.CompositeResourceDefinitionList{
-							Items: []extv1.CompositeResourceDefinition{
-								dangler,
-								owned,
-							},
-						}
-						return nil
-					}),
-				}, nil
-			}),
-			args: args{
-				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
-			},
-			want: want{
-				xrdc: model.CompositeResourceDefinitionConnection{
-					Nodes: []model.CompositeResourceDefinition{
-						gdangler,
-						gowned,
-					},
-					TotalCount: 2,
+					gqlerror.Wrap(errors.Wrap(errBoom, errReviewRules)),
 				},
 			},
 		},
-		"DanglingXRDs": {
-			reason: "We should successfully return dangling XRDs we can list and model.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+		"ReviewForbidden": {
+			reason: "If we're forbidden from reviewing rules we should return incomplete rules.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
-					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
-						*obj.(*extv1.CompositeResourceDefinitionList) = extv1.CompositeResourceDefinitionList{
-							Items: []extv1.CompositeResourceDefinition{
-								dangler,
-								owned,
-							},
-						}
-						return nil
-					}),
+					MockCreate: test.NewMockCreateFn(errForbidden),
 				}, nil
 			}),
 			args: args{
-				ctx:      graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
-				dangling: ptr.To(true),
+				ctx:       graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				namespace: "default",
 			},
 			want: want{
-				xrdc: model.CompositeResourceDefinitionConnection{
-					Nodes: []model.CompositeResourceDefinition{
-						gdangler,
-					},
-					TotalCount: 1,
+				rules: &model.SubjectRules{
+					ResourceRules:    []model.ResourceRule{},
+					NonResourceRules: []model.NonResourceRule{},
+					Incomplete:       true,
+					EvaluationError:  ptr.To(errors.Wrap(errForbidden, errReviewRules).Error()),
 				},
 			},
 		},
-		"OwnedXRDs": {
-			reason: "We should successfully return the XRDs we can list and model that are owned by the supplied ID.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+		"Success": {
+			reason: "We should return the rules for the supplied namespace.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
-					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
-						*obj.(*extv1.CompositeResourceDefinitionList) = extv1.CompositeResourceDefinitionList{
-							Items: []extv1.CompositeResourceDefinition{
-								dangler,
-								owned,
-							},
-						}
+					MockCreate: test.NewMockCreateFn(nil, func(obj client.Object) error {
+						rr := obj.(*authv1.SelfSubjectRulesReview)
+						rr.Status.ResourceRules = []authv1.ResourceRule{{
+							Verbs:     []string{"get"},
+							APIGroups: []string{""},
+							Resources: []string{"secrets"},
+						}}
+						rr.Status.NonResourceRules = []authv1.NonResourceRule{{
+							Verbs:           []string{"get"},
+							NonResourceURLs: []string{"/" + rr.Spec.Namespace},
+						}}
 						return nil
 					}),
 				}, nil
 			}),
 			args: args{
-				ctx:      graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
-				revision: &id,
+				ctx:       graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				namespace: "default",
 			},
 			want: want{
-				xrdc: model.CompositeResourceDefinitionConnection{
-					Nodes: []model.CompositeResourceDefinition{
-						gowned,
-					},
-					TotalCount: 1,
+				rules: &model.SubjectRules{
+					ResourceRules: []model.ResourceRule{{
+						Verbs:     []string{"get"},
+						APIGroups: []string{""},
+						Resources: []string{"secrets"},
+					}},
+					NonResourceRules: []model.NonResourceRule{{
+						Verbs:           []string{"get"},
+						NonResourceURLs: []string{"/default"},
+					}},
 				},
 			},
 		},
@@ -1608,190 +3293,241 @@ func TestQueryCompositeResourceDefinitions(t *testing.T) {
 
 			// Our GraphQL resolvers never return errors. We instead add an
 			// error to the GraphQL context and return early.
-			got, err := q.CompositeResourceDefinitions(tc.args.ctx, tc.args.revision, tc.args.dangling)
+			got, err := q.SelfSubjectRules(tc.args.ctx, tc.args.namespace)
 			errs := graphql.GetErrors(tc.args.ctx)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("\n%s\nq.Configurations(...): -want error, +got error:\n%s\n", tc.reason, diff)
+				t.Errorf("\n%s\nq.SelfSubjectRules(...): -want error, +got error:\n%s\n", tc.reason, diff)
 			}
 			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
-				t.Errorf("\n%s\nq.Configurations(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+				t.Errorf("\n%s\nq.SelfSubjectRules(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
 			}
-			if diff := cmp.Diff(tc.want.xrdc, got, cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
-				t.Errorf("\n%s\nq.Configurations(...): -want, +got:\n%s\n", tc.reason, diff)
+			if diff := cmp.Diff(tc.want.rules, got); diff != "" {
+				t.Errorf("\n%s\nq.SelfSubjectRules(...): -want, +got:\n%s\n", tc.reason, diff)
 			}
 		})
 	}
 }
 
-func TestQueryCompositions(t *testing.T) {
+func TestQueryAPIResources(t *testing.T) {
 	errBoom := errors.New("boom")
 
-	id := model.ReferenceID{
-		APIVersion: pkgv1.ConfigurationRevisionGroupVersionKind.GroupVersion().String(),
-		Kind:       pkgv1.ConfigurationRevisionKind,
-		Name:       "example",
+	type args struct {
+		ctx   context.Context
+		group *string
+	}
+	type want struct {
+		resources []model.APIResource
+		err       error
+		errs      gqlerror.List
 	}
 
-	owned := extv1.Composition{ObjectMeta: metav1.ObjectMeta{
-		Name: "coolconfig",
-		OwnerReferences: []metav1.OwnerReference{
-			// Some spurious owner references that we should ignore.
-			{
-				APIVersion: "wat",
+	cases := map[string]struct {
+		reason    string
+		discovery Discoverer
+		args      args
+		want      want
+	}{
+		"NoDiscoverer": {
+			reason: "If no discoverer is configured we should add an error to the GraphQL context and return early.",
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
 			},
-			{
-				APIVersion: id.APIVersion,
-				Kind:       "wat",
+			want: want{
+				resources: []model.APIResource{},
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.New(errNoDiscovery)),
+				},
 			},
-			{
-				APIVersion: id.APIVersion,
-				Kind:       id.Kind,
-				Name:       "wat",
+		},
+		"DiscoveryError": {
+			reason: "If we can't discover API resources we should add the error to the GraphQL context and return early.",
+			discovery: DiscovererFn(func(_ context.Context, _ auth.Credentials) ([]*metav1.APIResourceList, error) {
+				return nil, errBoom
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
 			},
-			// The reference that indicates this Composition is owned by our
-			// desired ConfigurationRevision (or a ConfigurationRevision
-			// generally).
-			{
-				APIVersion: id.APIVersion,
-				Kind:       id.Kind,
-				Name:       id.Name,
+			want: want{
+				resources: []model.APIResource{},
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errDiscover)),
+				},
 			},
 		},
-	}}
-	gowned := model.GetComposition(&owned)
+		"Success": {
+			reason: "We should return the discovered API resources in the supplied group.",
+			discovery: DiscovererFn(func(_ context.Context, _ auth.Credentials) ([]*metav1.APIResourceList, error) {
+				return []*metav1.APIResourceList{
+					{
+						GroupVersion: "v1",
+						APIResources: []metav1.APIResource{{Name: "pods", Kind: "Pod", Namespaced: true, Verbs: []string{"get"}}},
+					},
+					{
+						GroupVersion: "pkg.crossplane.io/v1",
+						APIResources: []metav1.APIResource{{Name: "providers", Kind: "Provider", Verbs: []string{"get"}}},
+					},
+				}, nil
+			}),
+			args: args{
+				ctx:   graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				group: ptr.To("pkg.crossplane.io"),
+			},
+			want: want{
+				resources: []model.APIResource{{
+					Group:   "pkg.crossplane.io",
+					Version: "v1",
+					Kind:    "Provider",
+					Name:    "providers",
+					Verbs:   []string{"get"},
+				}},
+			},
+		},
+	}
 
-	dangler := extv1.Composition{ObjectMeta: metav1.ObjectMeta{Name: "coolconfig"}}
-	gdangler := model.GetComposition(&dangler)
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			q := &query{discovery: tc.discovery}
 
-	type args struct {
-		ctx      context.Context
-		revision *model.ReferenceID
-		dangling *bool
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := q.APIResources(tc.args.ctx, tc.args.group)
+			errs := graphql.GetErrors(tc.args.ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nq.APIResources(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nq.APIResources(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.resources, got); diff != "" {
+				t.Errorf("\n%s\nq.APIResources(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestQueryCrossplaneStatus(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	healthy := pkgv1.Provider{}
+	healthy.SetName("healthy")
+	healthy.SetConditions(pkgv1.Active(), pkgv1.Healthy())
+
+	unhealthy := pkgv1.Configuration{}
+	unhealthy.SetName("unhealthy")
+	unhealthy.SetConditions(pkgv1.Active(), pkgv1.Unhealthy())
+
+	none := func(name string) model.CrossplaneComponentStatus {
+		return model.CrossplaneComponentStatus{Name: name, Health: model.CrossplaneHealthHealthy, Unhealthy: []string{}}
 	}
+
 	type want struct {
-		cc   model.CompositionConnection
-		err  error
-		errs gqlerror.List
+		status *model.CrossplaneStatus
+		err    error
+		errs   gqlerror.List
 	}
 
 	cases := map[string]struct {
 		reason  string
 		clients ClientCache
-		args    args
 		want    want
 	}{
 		"GetClientError": {
 			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
-				return &test.MockClient{}, errBoom
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return nil, errBoom
 			}),
-			args: args{
-				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
-			},
 			want: want{
 				errs: gqlerror.List{
 					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
 				},
 			},
 		},
-		"ListCompositionsError": {
-			reason: "If we can't list compositions we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
-				return &test.MockClient{
-					MockList: test.NewMockListFn(errBoom),
-				}, nil
-			}),
-			args: args{
-				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
-			},
-			want: want{
-				errs: gqlerror.List{
-					gqlerror.Wrap(errors.Wrap(errBoom, errListConfigs)),
-				},
-			},
-		},
-		"AllCompositions": {
-			reason: "We should successfully return all compositions we can list and model when no arguments are supplied.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+		"HealthyByAbsence": {
+			reason: "Components that don't exist should be healthy.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
-					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
-						*obj.(*extv1.CompositionList) = extv1.CompositionList{
-							Items: []extv1.Composition{
-								dangler,
-								owned,
-							},
+					MockList: func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+						if _, ok := obj.(*pkgv1.FunctionList); ok {
+							return &meta.NoKindMatchError{GroupKind: pkgv1.FunctionGroupVersionKind.GroupKind()}
 						}
 						return nil
-					}),
+					},
+					MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, lockName)),
 				}, nil
 			}),
-			args: args{
-				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
-			},
 			want: want{
-				cc: model.CompositionConnection{
-					Nodes: []model.Composition{
-						gdangler,
-						gowned,
-					},
-					TotalCount: 2,
+				status: &model.CrossplaneStatus{
+					Health:     model.CrossplaneHealthHealthy,
+					Components: []model.CrossplaneComponentStatus{none("Providers"), none("Configurations"), none("Functions"), none("Lock")},
 				},
 			},
 		},
-		"DanglingCompositions": {
-			reason: "We should successfully return dangling compositions we can list and model.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+		"FunctionsV1Beta1": {
+			reason: "Functions should be checked at v1beta1 if v1 isn't served.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
-					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
-						*obj.(*extv1.CompositionList) = extv1.CompositionList{
-							Items: []extv1.Composition{
-								dangler,
-								owned,
-							},
+					MockList: func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+						switch l := obj.(type) {
+						case *pkgv1.FunctionList:
+							return &meta.NoKindMatchError{GroupKind: pkgv1.FunctionGroupVersionKind.GroupKind()}
+						case *pkgv1beta1.FunctionList:
+							fn := pkgv1beta1.Function{}
+							fn.SetName("unhealthy")
+							fn.Status.SetConditions(pkgv1.Active(), pkgv1.Unhealthy())
+							l.Items = []pkgv1beta1.Function{fn}
 						}
 						return nil
-					}),
+					},
+					MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, lockName)),
 				}, nil
 			}),
-			args: args{
-				ctx:      graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
-				dangling: ptr.To(true),
-			},
 			want: want{
-				cc: model.CompositionConnection{
-					Nodes: []model.Composition{
-						gdangler,
+				status: &model.CrossplaneStatus{
+					Health: model.CrossplaneHealthDegraded,
+					Components: []model.CrossplaneComponentStatus{
+						none("Providers"),
+						none("Configurations"),
+						{Name: "Functions", Health: model.CrossplaneHealthDegraded, Total: 1, Unhealthy: []string{"unhealthy"}},
+						none("Lock"),
 					},
-					TotalCount: 1,
 				},
 			},
 		},
-		"OwnedCompositions": {
-			reason: "We should successfully return the compositions we can list and model that are owned by the supplied ID.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+		"Degraded": {
+			reason: "Crossplane should be degraded if any package is unhealthy, or any component can't be checked.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
-					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
-						*obj.(*extv1.CompositionList) = extv1.CompositionList{
-							Items: []extv1.Composition{
-								dangler,
-								owned,
-							},
+					MockList: func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+						switch l := obj.(type) {
+						case *pkgv1.ProviderList:
+							l.Items = []pkgv1.Provider{healthy}
+						case *pkgv1.ConfigurationList:
+							l.Items = []pkgv1.Configuration{unhealthy}
+						case *pkgv1.FunctionList:
+							return errBoom
 						}
 						return nil
+					},
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						obj.(*pkgv1beta1.Lock).Packages = []pkgv1beta1.LockPackage{{Name: "healthy", Source: "example.org/healthy"}}
+						return nil
 					}),
 				}, nil
 			}),
-			args: args{
-				ctx:      graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
-				revision: &id,
-			},
 			want: want{
-				cc: model.CompositionConnection{
-					Nodes: []model.Composition{
-						gowned,
+				status: &model.CrossplaneStatus{
+					Health: model.CrossplaneHealthDegraded,
+					Components: []model.CrossplaneComponentStatus{
+						{Name: "Providers", Health: model.CrossplaneHealthHealthy, Total: 1, Unhealthy: []string{}},
+						{Name: "Configurations", Health: model.CrossplaneHealthDegraded, Total: 1, Unhealthy: []string{"unhealthy"}},
+						{Name: "Functions", Health: model.CrossplaneHealthDegraded, Unhealthy: []string{}},
+						{Name: "Lock", Health: model.CrossplaneHealthHealthy, Total: 1, Unhealthy: []string{}},
 					},
-					TotalCount: 1,
+				},
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errListFunctions)),
 				},
 			},
 		},
@@ -1803,17 +3539,18 @@ func TestQueryCompositions(t *testing.T) {
 
 			// Our GraphQL resolvers never return errors. We instead add an
 			// error to the GraphQL context and return early.
-			got, err := q.Compositions(tc.args.ctx, tc.args.revision, tc.args.dangling)
-			errs := graphql.GetErrors(tc.args.ctx)
+			ctx := graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover)
+			got, err := q.CrossplaneStatus(ctx)
+			errs := graphql.GetErrors(ctx)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("\n%s\nq.Configurations(...): -want error, +got error:\n%s\n", tc.reason, diff)
+				t.Errorf("\n%s\nq.CrossplaneStatus(...): -want error, +got error:\n%s\n", tc.reason, diff)
 			}
 			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
-				t.Errorf("\n%s\nq.Configurations(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+				t.Errorf("\n%s\nq.CrossplaneStatus(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
 			}
-			if diff := cmp.Diff(tc.want.cc, got, cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
-				t.Errorf("\n%s\nq.Configurations(...): -want, +got:\n%s\n", tc.reason, diff)
+			if diff := cmp.Diff(tc.want.status, got); diff != "" {
+				t.Errorf("\n%s\nq.CrossplaneStatus(...): -want, +got:\n%s\n", tc.reason, diff)
 			}
 		})
 	}
@@ -15,10 +15,14 @@
 package resolvers
 
 import (
+	"context"
 	"time"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
 	"github.com/upbound/xgql/internal/auth"
 	"github.com/upbound/xgql/internal/clients"
 	"github.com/upbound/xgql/internal/graph/generated"
@@ -29,36 +33,146 @@ const timeout = 5 * time.Second
 
 // A ClientCache can produce a client for a given token.
 type ClientCache interface {
-	// Get a client for the given token.
-	Get(cr auth.Credentials, o ...clients.GetOption) (client.Client, error)
+	// GetWithContext gets a client for the given token. Producing a new
+	// client is abandoned if the supplied context is done first.
+	GetWithContext(ctx context.Context, cr auth.Credentials, o ...clients.GetOption) (client.Client, error)
 }
 
 // A ClientCacheFn is a function that can produce a client for a given token.
-type ClientCacheFn func(cr auth.Credentials, o ...clients.GetOption) (client.Client, error)
+type ClientCacheFn func(ctx context.Context, cr auth.Credentials, o ...clients.GetOption) (client.Client, error)
+
+// GetWithContext gets a client for the given token.
+func (fn ClientCacheFn) GetWithContext(ctx context.Context, cr auth.Credentials, o ...clients.GetOption) (client.Client, error) {
+	return fn(ctx, cr, o...)
+}
+
+// A Discoverer discovers the API resources served by the API server.
+type Discoverer interface {
+	// ServerPreferredResources returns the preferred version of each API
+	// resource served by the API server, discovered using the supplied
+	// credentials.
+	ServerPreferredResources(ctx context.Context, cr auth.Credentials) ([]*metav1.APIResourceList, error)
+}
+
+// A DiscovererFn is a function that can discover API resources.
+type DiscovererFn func(ctx context.Context, cr auth.Credentials) ([]*metav1.APIResourceList, error)
+
+// ServerPreferredResources discovers API resources using the supplied
+// credentials.
+func (fn DiscovererFn) ServerPreferredResources(ctx context.Context, cr auth.Credentials) ([]*metav1.APIResourceList, error) {
+	return fn(ctx, cr)
+}
+
+// A DefinedKindCache returns the kinds of composite resource and claim defined
+// by XRDs.
+type DefinedKindCache interface {
+	// DefinedKinds returns the kinds defined by the XRDs that can be listed
+	// using the supplied credentials.
+	DefinedKinds(ctx context.Context, cr auth.Credentials) (clients.DefinedKinds, error)
+}
+
+// A DefinedKindCacheFn is a function that returns the kinds defined by XRDs.
+type DefinedKindCacheFn func(ctx context.Context, cr auth.Credentials) (clients.DefinedKinds, error)
 
-// Get a client for the given token.
-func (fn ClientCacheFn) Get(cr auth.Credentials, o ...clients.GetOption) (client.Client, error) {
-	return fn(cr, o...)
+// DefinedKinds returns the kinds defined by XRDs using the supplied
+// credentials.
+func (fn DefinedKindCacheFn) DefinedKinds(ctx context.Context, cr auth.Credentials) (clients.DefinedKinds, error) {
+	return fn(ctx, cr)
 }
 
 // The Root resolver.
 type Root struct {
-	clients ClientCache
+	clients   ClientCache
+	discovery Discoverer
+	kinds     DefinedKindCache
+	pcKinds   *providerConfigKinds
+	log       logging.Logger
+
+	// finalizers is true if the removeFinalizer mutation may remove
+	// finalizers.
+	finalizers bool
+
+	// secrets is true if the values of secrets may be returned to callers
+	// who may get them.
+	secrets bool
+
+	// configMaps is true if the values of config maps may be returned.
+	configMaps bool
+}
+
+// An Option configures the root resolver.
+type Option func(r *Root)
+
+// WithDiscoverer configures the root resolver to discover API resources using
+// the supplied Discoverer. The apiResources query returns an error if no
+// Discoverer is configured.
+func WithDiscoverer(d Discoverer) Option {
+	return func(r *Root) {
+		r.discovery = d
+	}
+}
+
+// WithDefinedKindCache configures the root resolver to get the kinds of
+// composite resource and claim defined by XRDs from the supplied
+// DefinedKindCache. The claims and compositeResources queries list XRDs on
+// every request if no DefinedKindCache is configured.
+func WithDefinedKindCache(kc DefinedKindCache) Option {
+	return func(r *Root) {
+		r.kinds = kc
+	}
+}
+
+// WithLogger configures the logger used by the root resolver. A no-op logger
+// is used by default.
+func WithLogger(l logging.Logger) Option {
+	return func(r *Root) {
+		r.log = l
+	}
+}
+
+// EnableFinalizerRemoval allows the removeFinalizer mutation to remove
+// finalizers. Removing a finalizer skips whatever cleanup it guards, which may
+// orphan external resources, so the mutation returns an error by default.
+func EnableFinalizerRemoval() Option {
+	return func(r *Root) {
+		r.finalizers = true
+	}
+}
+
+// ExposeSecretValues allows the data field of a secret to return the secret's
+// values to callers who may get the secret. Only the secret's keys are
+// returned by default.
+func ExposeSecretValues() Option {
+	return func(r *Root) {
+		r.secrets = true
+	}
+}
+
+// ExposeConfigMapValues allows the data field of a config map to return the
+// config map's values. Only the config map's keys are returned by default.
+func ExposeConfigMapValues() Option {
+	return func(r *Root) {
+		r.configMaps = true
+	}
 }
 
 // New returns a new root resolver.
-func New(cc ClientCache) *Root {
-	return &Root{clients: cc}
+func New(cc ClientCache, o ...Option) *Root {
+	r := &Root{clients: cc, pcKinds: newProviderConfigKinds(), log: logging.NewNopLogger()}
+	for _, fn := range o {
+		fn(r)
+	}
+	return r
 }
 
 // Query resolves GraphQL queries.
 func (r *Root) Query() generated.QueryResolver {
-	return &query{clients: r.clients}
+	return &query{clients: r.clients, discovery: r.discovery, kinds: r.kinds}
 }
 
 // Mutation resolves GraphQL mutations.
 func (r *Root) Mutation() generated.MutationResolver {
-	return &mutation{clients: r.clients}
+	return &mutation{clients: r.clients, log: r.log, finalizers: r.finalizers}
 }
 
 // ObjectMeta resolves properties of the ObjectMeta GraphQL type.
@@ -66,14 +180,24 @@ func (r *Root) ObjectMeta() generated.ObjectMetaResolver {
 	return &objectMeta{clients: r.clients}
 }
 
+// ResourceTreeNode resolves properties of the ResourceTreeNode GraphQL type.
+func (r *Root) ResourceTreeNode() generated.ResourceTreeNodeResolver {
+	return &resourceTreeNode{clients: r.clients}
+}
+
 // Secret resolves properties of the Secret GraphQL type.
 func (r *Root) Secret() generated.SecretResolver {
-	return &secret{clients: r.clients}
+	return &secret{clients: r.clients, log: r.log, expose: r.secrets}
 }
 
 // ConfigMap resolves properties of the ConfigMap GraphQL type.
 func (r *Root) ConfigMap() generated.ConfigMapResolver {
-	return &configMap{clients: r.clients}
+	return &configMap{clients: r.clients, expose: r.configMaps}
+}
+
+// ResourceQuota resolves properties of the ResourceQuota GraphQL type.
+func (r *Root) ResourceQuota() generated.ResourceQuotaResolver {
+	return &resourceQuota{clients: r.clients}
 }
 
 // CompositeResource resolves properties of the CompositeResource GraphQL type.
@@ -144,6 +268,16 @@ func (r *Root) Event() generated.EventResolver {
 	return &event{clients: r.clients}
 }
 
+// Function resolves properties of the Function GraphQL type.
+func (r *Root) Function() generated.FunctionResolver {
+	return &function{clients: r.clients}
+}
+
+// FunctionRevision resolves properties of the FunctionRevision GraphQL type.
+func (r *Root) FunctionRevision() generated.FunctionRevisionResolver {
+	return &functionRevision{clients: r.clients}
+}
+
 // GenericResource resolves properties of the GenericResource GraphQL type.
 func (r *Root) GenericResource() generated.GenericResourceResolver {
 	return &genericResource{clients: r.clients}
@@ -152,7 +286,7 @@ func (r *Root) GenericResource() generated.GenericResourceResolver {
 // ManagedResource resolves properties of the CustomResourceDefinition GraphQL
 // type.
 func (r *Root) ManagedResource() generated.ManagedResourceResolver {
-	return &managedResource{clients: r.clients}
+	return &managedResource{clients: r.clients, kinds: r.pcKinds}
 }
 
 // ManagedResourceSpec resolves properties of the CustomResourceDefinition GraphQL
@@ -161,6 +295,11 @@ func (r *Root) ManagedResourceSpec() generated.ManagedResourceSpecResolver {
 	return &managedResourceSpec{clients: r.clients}
 }
 
+// PipelineStep resolves properties of the PipelineStep GraphQL type.
+func (r *Root) PipelineStep() generated.PipelineStepResolver {
+	return &pipelineStep{clients: r.clients}
+}
+
 // Provider resolves properties of the Provider GraphQL type.
 func (r *Root) Provider() generated.ProviderResolver {
 	return &provider{clients: r.clients}
@@ -181,3 +320,24 @@ func (r *Root) ProviderRevisionStatus() generated.ProviderRevisionStatusResolver
 func (r *Root) ProviderConfig() generated.ProviderConfigResolver {
 	return &providerConfig{clients: r.clients}
 }
+
+// Usage resolves properties of the Usage GraphQL type.
+func (r *Root) Usage() generated.UsageResolver {
+	return &usage{clients: r.clients}
+}
+
+// UsageResource resolves properties of the UsageResource GraphQL type.
+func (r *Root) UsageResource() generated.UsageResourceResolver {
+	return &usageResource{clients: r.clients}
+}
+
+// DeploymentRuntimeConfig resolves properties of the DeploymentRuntimeConfig
+// GraphQL type.
+func (r *Root) DeploymentRuntimeConfig() generated.DeploymentRuntimeConfigResolver {
+	return &deploymentRuntimeConfig{clients: r.clients}
+}
+
+// EnvironmentConfig resolves properties of the EnvironmentConfig GraphQL type.
+func (r *Root) EnvironmentConfig() generated.EnvironmentConfigResolver {
+	return &environmentConfig{clients: r.clients}
+}
@@ -14,6 +14,123 @@
 
 package resolvers
 
-import "github.com/upbound/xgql/internal/graph/generated"
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+
+	"github.com/upbound/xgql/internal/auth"
+	"github.com/upbound/xgql/internal/clients"
+	"github.com/upbound/xgql/internal/graph/generated"
+)
 
 var _ generated.ResolverRoot = &Root{}
+
+// An unsyncedCache never syncs. It runs until it's stopped.
+type unsyncedCache struct {
+	cache.Cache
+}
+
+func (c *unsyncedCache) Start(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+func (c *unsyncedCache) WaitForCacheSync(ctx context.Context) bool {
+	<-ctx.Done()
+	return false
+}
+
+func TestClientCacheRequestContext(t *testing.T) {
+	neverSyncs := clients.UseNewCacheMiddleware(func(_ clients.NewCacheFn) clients.NewCacheFn {
+		return func(_ *rest.Config, _ cache.Options) (cache.Cache, error) {
+			return &unsyncedCache{}, nil
+		}
+	})
+
+	// Caches that are slow to create block until the test is done.
+	unblock := make(chan struct{})
+	defer close(unblock)
+	slowToCreate := clients.UseNewCacheMiddleware(func(fn clients.NewCacheFn) clients.NewCacheFn {
+		return func(cfg *rest.Config, o cache.Options) (cache.Cache, error) {
+			<-unblock
+			return fn(cfg, o)
+		}
+	})
+
+	cases := map[string]struct {
+		reason string
+		opts   []clients.CacheOption
+		// busy makes the cache busy creating a client before the request
+		// starts. It returns once the cache is busy.
+		busy func(ctx context.Context, c *clients.Cache)
+	}{
+		"CacheNeverSyncs": {
+			reason: "A request should stop waiting for its client's cache to sync when its context is done.",
+		},
+		"WaitingToCreate": {
+			reason: "A request should stop waiting to create a client when its context is done, if the Cache is already creating as many clients as it may.",
+			opts:   []clients.CacheOption{clients.WithMaxConcurrentCreates(1)},
+			busy:   busyCreating(auth.Credentials{Impersonate: auth.Impersonation{Username: "someone-else"}}),
+		},
+		"WaitingForSameCredentials": {
+			reason: "A request should stop waiting to create a client when its context is done, if another request is already creating a client with the same credentials.",
+			opts:   []clients.CacheOption{slowToCreate},
+			busy:   busyCreating(auth.Credentials{}),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			opts := append([]clients.CacheOption{clients.WithRESTMapper(meta.NewDefaultRESTMapper(nil)), neverSyncs}, tc.opts...)
+			c := clients.NewCache(runtime.NewScheme(), &rest.Config{}, opts...)
+
+			bctx, bcancel := context.WithCancel(context.Background())
+			defer bcancel()
+			if tc.busy != nil {
+				tc.busy(bctx, c)
+			}
+
+			// The request's deadline is well before the resolver's timeout.
+			ctx := graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover)
+			ctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+			defer cancel()
+
+			done := make(chan struct{})
+			go func() {
+				_, _ = (&query{clients: c}).Providers(ctx)
+				close(done)
+			}()
+
+			select {
+			case <-done:
+			case <-time.After(timeout / 2):
+				t.Fatalf("\n%s\nq.Providers(...): did not return promptly after the request context was done", tc.reason)
+			}
+
+			errs := graphql.GetErrors(ctx)
+			if len(errs) != 1 || !errors.Is(errs[0], context.DeadlineExceeded) {
+				t.Errorf("\n%s\nq.Providers(...): want a deadline exceeded GraphQL error, got %v", tc.reason, errs)
+			}
+		})
+	}
+}
+
+// busyCreating returns a function that makes a Cache busy creating a client
+// that uses the supplied credentials, until the supplied context is done.
+func busyCreating(cr auth.Credentials) func(ctx context.Context, c *clients.Cache) {
+	return func(ctx context.Context, c *clients.Cache) {
+		go func() { _, _ = c.GetWithContext(ctx, cr) }()
+
+		// Give the client creation time to start.
+		time.Sleep(50 * time.Millisecond)
+	}
+}
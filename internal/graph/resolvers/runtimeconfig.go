@@ -0,0 +1,131 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolvers
+
+import (
+	"context"
+	"sort"
+
+	"github.com/99designs/gqlgen/graphql"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	pkgv1 "github.com/crossplane/crossplane/apis/pkg/v1"
+	pkgv1beta1 "github.com/crossplane/crossplane/apis/pkg/v1beta1"
+
+	"github.com/upbound/xgql/internal/auth"
+	"github.com/upbound/xgql/internal/graph/model"
+)
+
+const (
+	errListDeploymentRuntimeConfigs = "cannot list deployment runtime configs"
+)
+
+type deploymentRuntimeConfigs struct {
+	clients ClientCache
+}
+
+// Resolve all deployment runtime configs. Deployment runtime configs were
+// introduced in Crossplane v1.14; if the API isn't served we return no
+// deployment runtime configs.
+func (r *deploymentRuntimeConfigs) Resolve(ctx context.Context) (model.DeploymentRuntimeConfigConnection, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.GetWithContext(ctx, creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.DeploymentRuntimeConfigConnection{}, nil
+	}
+
+	in := &pkgv1beta1.DeploymentRuntimeConfigList{}
+	if err := c.List(ctx, in); err != nil {
+		if meta.IsNoMatchError(err) || kerrors.IsNotFound(err) {
+			return model.DeploymentRuntimeConfigConnection{Nodes: make([]model.DeploymentRuntimeConfig, 0)}, nil
+		}
+		graphql.AddError(ctx, errors.Wrap(err, errListDeploymentRuntimeConfigs))
+		return model.DeploymentRuntimeConfigConnection{}, nil
+	}
+
+	out := &model.DeploymentRuntimeConfigConnection{
+		Nodes:      make([]model.DeploymentRuntimeConfig, 0, len(in.Items)),
+		TotalCount: len(in.Items),
+	}
+
+	for i := range in.Items {
+		out.Nodes = append(out.Nodes, model.GetDeploymentRuntimeConfig(&in.Items[i]))
+	}
+
+	sort.Stable(out)
+	return *out, nil
+}
+
+type deploymentRuntimeConfig struct {
+	clients ClientCache
+}
+
+func (r *deploymentRuntimeConfig) Events(ctx context.Context, obj *model.DeploymentRuntimeConfig) (model.EventConnection, error) {
+	e := &events{clients: r.clients}
+	return e.Resolve(ctx, &corev1.ObjectReference{
+		APIVersion: obj.APIVersion,
+		Kind:       obj.Kind,
+		Name:       obj.Metadata.Name,
+		UID:        types.UID(obj.Metadata.UID),
+	})
+}
+
+func (r *deploymentRuntimeConfig) Providers(ctx context.Context, obj *model.DeploymentRuntimeConfig) (model.ProviderConnection, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.GetWithContext(ctx, creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.ProviderConnection{}, nil
+	}
+
+	in := &pkgv1.ProviderList{}
+	if err := c.List(ctx, in); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errListProviders))
+		return model.ProviderConnection{}, nil
+	}
+
+	out := &model.ProviderConnection{
+		Nodes: make([]model.Provider, 0),
+	}
+
+	for i := range in.Items {
+		ref := in.Items[i].Spec.RuntimeConfigReference
+		if ref == nil || ref.Name != obj.Metadata.Name {
+			continue
+		}
+		// Crossplane defaults the kind of a runtime config reference to
+		// DeploymentRuntimeConfig, but we may be talking to an API server
+		// that doesn't apply that default.
+		if ref.Kind != nil && *ref.Kind != obj.Kind {
+			continue
+		}
+		out.Nodes = append(out.Nodes, model.GetProvider(&in.Items[i]))
+		out.TotalCount++
+	}
+
+	sort.Stable(out)
+	return *out, nil
+}
@@ -0,0 +1,238 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolvers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	pkgv1 "github.com/crossplane/crossplane/apis/pkg/v1"
+	pkgv1beta1 "github.com/crossplane/crossplane/apis/pkg/v1beta1"
+
+	"github.com/upbound/xgql/internal/auth"
+	"github.com/upbound/xgql/internal/clients"
+	"github.com/upbound/xgql/internal/graph/generated"
+	"github.com/upbound/xgql/internal/graph/model"
+)
+
+var _ generated.DeploymentRuntimeConfigResolver = &deploymentRuntimeConfig{}
+
+func TestDeploymentRuntimeConfigsResolve(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	rca := pkgv1beta1.DeploymentRuntimeConfig{}
+	rca.SetName("a")
+	grca := model.GetDeploymentRuntimeConfig(&rca)
+
+	rcb := pkgv1beta1.DeploymentRuntimeConfig{}
+	rcb.SetName("b")
+	grcb := model.GetDeploymentRuntimeConfig(&rcb)
+
+	type want struct {
+		rcc  model.DeploymentRuntimeConfigConnection
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		want    want
+	}{
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, errBoom
+			}),
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"ListDeploymentRuntimeConfigsError": {
+			reason: "If we can't list deployment runtime configs we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(errBoom),
+				}, nil
+			}),
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errListDeploymentRuntimeConfigs)),
+				},
+			},
+		},
+		"DeploymentRuntimeConfigAPINotEnabled": {
+			reason: "If the DeploymentRuntimeConfig API isn't served we should return no deployment runtime configs without an error.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(&meta.NoKindMatchError{GroupKind: pkgv1beta1.DeploymentRuntimeConfigGroupVersionKind.GroupKind()}),
+				}, nil
+			}),
+			want: want{
+				rcc: model.DeploymentRuntimeConfigConnection{
+					Nodes: []model.DeploymentRuntimeConfig{},
+				},
+			},
+		},
+		"Success": {
+			reason: "We should return all deployment runtime configs, sorted.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+						*obj.(*pkgv1beta1.DeploymentRuntimeConfigList) = pkgv1beta1.DeploymentRuntimeConfigList{Items: []pkgv1beta1.DeploymentRuntimeConfig{rcb, rca}}
+						return nil
+					},
+				}, nil
+			}),
+			want: want{
+				rcc: model.DeploymentRuntimeConfigConnection{
+					Nodes:      []model.DeploymentRuntimeConfig{grca, grcb},
+					TotalCount: 2,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rc := &deploymentRuntimeConfigs{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			ctx := graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover)
+			got, err := rc.Resolve(ctx)
+			errs := graphql.GetErrors(ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nrc.Resolve(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nrc.Resolve(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.rcc, got, cmpopts.IgnoreFields(model.DeploymentRuntimeConfig{}, "PavedAccess"), cmpopts.IgnoreUnexported(model.ObjectMeta{})); diff != "" {
+				t.Errorf("\n%s\nrc.Resolve(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDeploymentRuntimeConfigProviders(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	rc := model.DeploymentRuntimeConfig{
+		Kind:     pkgv1beta1.DeploymentRuntimeConfigKind,
+		Metadata: model.ObjectMeta{Name: "cool"},
+	}
+
+	uses := pkgv1.Provider{}
+	uses.SetName("uses")
+	uses.Spec.RuntimeConfigReference = &pkgv1.RuntimeConfigReference{Name: "cool", Kind: ptr.To(pkgv1beta1.DeploymentRuntimeConfigKind)}
+	guses := model.GetProvider(&uses)
+
+	other := pkgv1.Provider{}
+	other.SetName("other")
+	other.Spec.RuntimeConfigReference = &pkgv1.RuntimeConfigReference{Name: "default"}
+
+	none := pkgv1.Provider{}
+	none.SetName("none")
+
+	type want struct {
+		pc   model.ProviderConnection
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		want    want
+	}{
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return nil, errBoom
+			}),
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"ListProvidersError": {
+			reason: "If we can't list providers we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(errBoom),
+				}, nil
+			}),
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errListProviders)),
+				},
+			},
+		},
+		"Success": {
+			reason: "We should return only the providers that use the deployment runtime config.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+						*obj.(*pkgv1.ProviderList) = pkgv1.ProviderList{Items: []pkgv1.Provider{other, uses, none}}
+						return nil
+					},
+				}, nil
+			}),
+			want: want{
+				pc: model.ProviderConnection{
+					Nodes:      []model.Provider{guses},
+					TotalCount: 1,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &deploymentRuntimeConfig{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			ctx := graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover)
+			got, err := r.Providers(ctx, &rc)
+			errs := graphql.GetErrors(ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Providers(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Providers(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.pc, got, cmpopts.IgnoreFields(model.Provider{}, "PavedAccess"), cmpopts.IgnoreUnexported(model.ObjectMeta{})); diff != "" {
+				t.Errorf("\n%s\nr.Providers(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
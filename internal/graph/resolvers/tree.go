@@ -0,0 +1,231 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolvers
+
+import (
+	"context"
+	"maps"
+	"sync"
+
+	"github.com/99designs/gqlgen/graphql"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	extv1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+
+	"github.com/upbound/xgql/internal/auth"
+	"github.com/upbound/xgql/internal/graph/model"
+)
+
+const (
+	errGetTreeNode   = "cannot get resource"
+	errModelTreeNode = "cannot model resource"
+	errTreeCycle     = "resource references one of its ancestors; not resolving it again"
+	errTreeDepth     = "maximum tree depth reached; not resolving children"
+)
+
+// treeWorkers is the default maximum number of resources a tree reads
+// concurrently.
+const treeWorkers = 16
+
+// A resourceTree resolves the tree of resources rooted at a claim or
+// composite resource.
+type resourceTree struct {
+	client   client.Client
+	maxDepth int
+	workers  chan struct{}
+}
+
+// newResourceTree returns a resourceTree that reads at most the supplied
+// number of resources concurrently, or treeWorkers if it isn't positive. Each
+// tree has its own workers, so one large tree can't starve other requests'.
+func newResourceTree(c client.Client, maxDepth, workers int) *resourceTree {
+	if workers <= 0 {
+		workers = treeWorkers
+	}
+	return &resourceTree{client: c, maxDepth: maxDepth, workers: make(chan struct{}, workers)}
+}
+
+// Resolve the tree rooted at the supplied resource. Errors are recorded on
+// the node at which they occur rather than failing the whole tree.
+func (t *resourceTree) Resolve(ctx context.Context, id model.ReferenceID, kr model.KubernetesResource) model.ResourceTreeNode {
+	return t.node(ctx, id, kr, 0, map[model.ReferenceID]bool{})
+}
+
+func (t *resourceTree) node(ctx context.Context, id model.ReferenceID, kr model.KubernetesResource, depth int, ancestors map[model.ReferenceID]bool) model.ResourceTreeNode {
+	n := model.ResourceTreeNode{
+		ID:       id,
+		Resource: kr,
+		Ready:    ready(kr),
+		Children: []model.ResourceTreeNode{},
+	}
+
+	refs := childRefs(kr)
+	if len(refs) == 0 {
+		return n
+	}
+	if depth >= t.maxDepth {
+		n.Errors = append(n.Errors, errTreeDepth)
+		return n
+	}
+
+	path := maps.Clone(ancestors)
+	path[id] = true
+
+	// The readiness checks of composed resources are defined by the
+	// composition their composite resource uses.
+	var cref *corev1.ObjectReference
+	if xr, ok := kr.(model.CompositeResource); ok {
+		cref = xr.Spec.CompositionReference
+	}
+
+	n.Children = make([]model.ResourceTreeNode, len(refs))
+	wg := sync.WaitGroup{}
+	for i, ref := range refs {
+		cid := model.ReferenceID{
+			APIVersion: ref.APIVersion,
+			Kind:       ref.Kind,
+			Namespace:  ref.Namespace,
+			Name:       ref.Name,
+		}
+		if path[cid] {
+			n.Children[i] = model.ResourceTreeNode{ID: cid, Errors: []string{errTreeCycle}, Children: []model.ResourceTreeNode{}, CompositionReference: cref}
+			continue
+		}
+
+		// Wait for a worker before starting to read a child, so that reads
+		// beyond our bound queue here rather than each spawning a goroutine.
+		// The child releases its worker once it has been read; holding one
+		// while resolving its children could deadlock a deep tree.
+		select {
+		case t.workers <- struct{}{}:
+		case <-ctx.Done():
+			n.Children[i] = model.ResourceTreeNode{ID: cid, Errors: []string{errors.Wrap(ctx.Err(), errGetTreeNode).Error()}, Children: []model.ResourceTreeNode{}, CompositionReference: cref}
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			n.Children[i] = t.child(ctx, cid, depth+1, path)
+			n.Children[i].CompositionReference = cref
+		}(i)
+	}
+	wg.Wait()
+
+	return n
+}
+
+// child reads and resolves a child of a node. The caller must hold a worker,
+// which child releases once it has read the child.
+func (t *resourceTree) child(ctx context.Context, id model.ReferenceID, depth int, ancestors map[model.ReferenceID]bool) model.ResourceTreeNode {
+	n := model.ResourceTreeNode{ID: id, Children: []model.ResourceTreeNode{}}
+
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion(id.APIVersion)
+	u.SetKind(id.Kind)
+	err := t.client.Get(ctx, types.NamespacedName{Namespace: id.Namespace, Name: id.Name}, u)
+	<-t.workers
+	if err != nil {
+		n.Errors = []string{errors.Wrap(err, errGetTreeNode).Error()}
+		return n
+	}
+
+	kr, err := model.GetKubernetesResource(u)
+	if err != nil {
+		n.Errors = []string{errors.Wrap(err, errModelTreeNode).Error()}
+		return n
+	}
+
+	return t.node(ctx, id, kr, depth, ancestors)
+}
+
+type resourceTreeNode struct {
+	clients ClientCache
+}
+
+func (r *resourceTreeNode) ReadinessChecks(ctx context.Context, obj *model.ResourceTreeNode) ([]model.ReadinessCheckResult, error) {
+	if obj.CompositionReference == nil {
+		return nil, nil
+	}
+	u, ok := obj.Resource.(interface{ UnstructuredContent() map[string]any })
+	if !ok {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.GetWithContext(ctx, creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return nil, nil
+	}
+
+	cmp := &extv1.Composition{}
+	if err := c.Get(ctx, types.NamespacedName{Name: obj.CompositionReference.Name}, cmp); err != nil {
+		if !apierrors.IsNotFound(err) {
+			graphql.AddError(ctx, errors.Wrap(err, errGetComposition))
+		}
+		return nil, nil
+	}
+
+	return model.GetReadinessCheckResults(cmp, fieldpath.Pave(u.UnstructuredContent())), nil
+}
+
+// childRefs returns references to the children of the supplied resource in a
+// resource tree. Only claims and composite resources have children.
+func childRefs(kr model.KubernetesResource) []corev1.ObjectReference {
+	var refs []corev1.ObjectReference
+	switch r := kr.(type) {
+	case model.CompositeResourceClaim:
+		if r.Spec.ResourceReference != nil {
+			refs = []corev1.ObjectReference{*r.Spec.ResourceReference}
+		}
+	case model.CompositeResource:
+		refs = r.Spec.ResourceReferences
+	}
+
+	out := make([]corev1.ObjectReference, 0, len(refs))
+	for _, ref := range refs {
+		// Ignore nameless resource references.
+		if ref.Name == "" {
+			continue
+		}
+		out = append(out, ref)
+	}
+	return out
+}
+
+// ready returns true if the supplied resource has a true Ready condition.
+func ready(kr model.KubernetesResource) bool {
+	c, ok := kr.(interface{ Conditions() []model.Condition })
+	if !ok {
+		return false
+	}
+	for _, cd := range c.Conditions() {
+		if cd.Type == string(xpv1.TypeReady) && cd.Status == model.ConditionStatusTrue {
+			return true
+		}
+	}
+	return false
+}
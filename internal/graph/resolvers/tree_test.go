@@ -0,0 +1,423 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	extv1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+
+	"github.com/upbound/xgql/internal/auth"
+	"github.com/upbound/xgql/internal/clients"
+	"github.com/upbound/xgql/internal/graph/model"
+)
+
+func treeObject(apiVersion, kind, namespace, name string, spec map[string]any, ready bool) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]any{"spec": spec}}
+	u.SetAPIVersion(apiVersion)
+	u.SetKind(kind)
+	u.SetNamespace(namespace)
+	u.SetName(name)
+	if ready {
+		u.Object["status"] = map[string]any{
+			"conditions": []any{map[string]any{"type": "Ready", "status": "True"}},
+		}
+	}
+	return u
+}
+
+func treeRef(kind, name string) map[string]any {
+	return map[string]any{"apiVersion": "example.org/v1", "kind": kind, "name": name}
+}
+
+func treeID(kind, namespace, name string) model.ReferenceID {
+	return model.ReferenceID{APIVersion: "example.org/v1", Kind: kind, Namespace: namespace, Name: name}
+}
+
+// treeGetFn returns a MockGetFn that reads from the supplied objects, keyed
+// by name.
+func treeGetFn(objs ...*unstructured.Unstructured) test.MockGetFn {
+	byName := map[string]*unstructured.Unstructured{}
+	for _, o := range objs {
+		byName[o.GetName()] = o
+	}
+	return func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+		o, ok := byName[key.Name]
+		if !ok {
+			return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+		}
+		*obj.(*unstructured.Unstructured) = *o.DeepCopy()
+		return nil
+	}
+}
+
+func TestResourceTreeResolve(t *testing.T) {
+	errNotFound := errors.Wrap(kerrors.NewNotFound(schema.GroupResource{}, "missing"), errGetTreeNode).Error()
+
+	claim := treeObject("example.org/v1", "Claim", "default", "claim", map[string]any{"resourceRef": treeRef("XR", "xr")}, true)
+	xr := treeObject("example.org/v1", "XR", "", "xr", map[string]any{
+		"compositionRef": map[string]any{"name": "cmp"},
+		"resourceRefs":   []any{treeRef("Managed", "mr"), treeRef("Managed", "missing"), treeRef("Managed", "")},
+	}, false)
+	cref := &corev1.ObjectReference{Name: "cmp"}
+	mr := treeObject("example.org/v1", "Managed", "", "mr", map[string]any{"providerConfigRef": map[string]any{"name": "default"}}, true)
+	cyclic := treeObject("example.org/v1", "XR", "", "cyclic", map[string]any{"resourceRefs": []any{treeRef("XR", "cyclic")}}, false)
+
+	type args struct {
+		get      test.MockGetFn
+		maxDepth int
+		root     *unstructured.Unstructured
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   model.ResourceTreeNode
+	}{
+		"Tree": {
+			reason: "We should resolve a claim, its XR, and the XR's composed resources, recording errors on the nodes at which they occur.",
+			args: args{
+				get:      treeGetFn(claim, xr, mr),
+				maxDepth: 10,
+				root:     claim,
+			},
+			want: model.ResourceTreeNode{
+				ID:    treeID("Claim", "default", "claim"),
+				Ready: true,
+				Children: []model.ResourceTreeNode{{
+					ID: treeID("XR", "", "xr"),
+					Children: []model.ResourceTreeNode{
+						{
+							ID:                   treeID("Managed", "", "mr"),
+							Ready:                true,
+							Children:             []model.ResourceTreeNode{},
+							CompositionReference: cref,
+						},
+						{
+							ID:                   treeID("Managed", "", "missing"),
+							Errors:               []string{errNotFound},
+							Children:             []model.ResourceTreeNode{},
+							CompositionReference: cref,
+						},
+					},
+				}},
+			},
+		},
+		"Cycle": {
+			reason: "We should not resolve a resource that references one of its ancestors.",
+			args: args{
+				get:      treeGetFn(cyclic),
+				maxDepth: 10,
+				root:     cyclic,
+			},
+			want: model.ResourceTreeNode{
+				ID: treeID("XR", "", "cyclic"),
+				Children: []model.ResourceTreeNode{{
+					ID:       treeID("XR", "", "cyclic"),
+					Errors:   []string{errTreeCycle},
+					Children: []model.ResourceTreeNode{},
+				}},
+			},
+		},
+		"MaxDepth": {
+			reason: "We should not resolve the children of a resource at the maximum tree depth.",
+			args: args{
+				get:      treeGetFn(claim, xr, mr),
+				maxDepth: 1,
+				root:     claim,
+			},
+			want: model.ResourceTreeNode{
+				ID:    treeID("Claim", "default", "claim"),
+				Ready: true,
+				Children: []model.ResourceTreeNode{{
+					ID:       treeID("XR", "", "xr"),
+					Errors:   []string{errTreeDepth},
+					Children: []model.ResourceTreeNode{},
+				}},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			kr, err := model.GetKubernetesResource(tc.args.root)
+			if err != nil {
+				t.Fatal(err)
+			}
+			id := treeID(tc.args.root.GetKind(), tc.args.root.GetNamespace(), tc.args.root.GetName())
+
+			rt := newResourceTree(&test.MockClient{MockGet: tc.args.get}, tc.args.maxDepth, treeWorkers)
+			got := rt.Resolve(context.Background(), id, kr)
+			if diff := cmp.Diff(tc.want, got, cmpopts.IgnoreFields(model.ResourceTreeNode{}, "Resource")); diff != "" {
+				t.Errorf("\n%s\nt.Resolve(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+// wideTree returns an XR with the supplied number of nested XRs, each of which
+// composes the supplied number of managed resources, and a MockGetFn that
+// reads them after the supplied latency. The MockGetFn records the largest
+// number of concurrent reads.
+func wideTree(width int, latency time.Duration, peak *atomic.Int64) (*unstructured.Unstructured, test.MockGetFn) {
+	objs := make([]*unstructured.Unstructured, 0, width*width+width)
+	xrRefs := make([]any, width)
+	for i := 0; i < width; i++ {
+		mrRefs := make([]any, width)
+		for j := 0; j < width; j++ {
+			name := fmt.Sprintf("mr-%d-%d", i, j)
+			mrRefs[j] = treeRef("Managed", name)
+			objs = append(objs, treeObject("example.org/v1", "Managed", "", name, map[string]any{}, true))
+		}
+		name := fmt.Sprintf("xr-%d", i)
+		xrRefs[i] = treeRef("XR", name)
+		objs = append(objs, treeObject("example.org/v1", "XR", "", name, map[string]any{"resourceRefs": mrRefs}, true))
+	}
+	root := treeObject("example.org/v1", "XR", "", "root", map[string]any{"resourceRefs": xrRefs}, true)
+
+	get := treeGetFn(objs...)
+	var inflight atomic.Int64
+	return root, func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+		n := inflight.Add(1)
+		defer inflight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(latency)
+		return get(ctx, key, obj)
+	}
+}
+
+func TestResourceTreeConcurrency(t *testing.T) {
+	for _, workers := range []int{1, 4} {
+		t.Run(fmt.Sprintf("Workers%d", workers), func(t *testing.T) {
+			peak := &atomic.Int64{}
+			root, get := wideTree(8, time.Millisecond, peak)
+			kr, err := model.GetKubernetesResource(root)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rt := newResourceTree(&test.MockClient{MockGet: get}, 10, workers)
+			got := rt.Resolve(context.Background(), treeID("XR", "", "root"), kr)
+
+			if diff := cmp.Diff(8, len(got.Children)); diff != "" {
+				t.Errorf("t.Resolve(...): -want children, +got children:\n%s", diff)
+			}
+			if p := peak.Load(); p > int64(workers) {
+				t.Errorf("t.Resolve(...): want at most %d concurrent reads, got %d", workers, p)
+			}
+		})
+	}
+}
+
+// BenchmarkResourceTree resolves a wide tree whose reads each take a
+// millisecond, at different concurrency levels.
+func BenchmarkResourceTree(b *testing.B) {
+	for _, workers := range []int{1, 4, 16, 64} {
+		b.Run(fmt.Sprintf("Workers%d", workers), func(b *testing.B) {
+			peak := &atomic.Int64{}
+			root, get := wideTree(16, time.Millisecond, peak)
+			kr, err := model.GetKubernetesResource(root)
+			if err != nil {
+				b.Fatal(err)
+			}
+			id := treeID("XR", "", "root")
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				rt := newResourceTree(&test.MockClient{MockGet: get}, 10, workers)
+				rt.Resolve(context.Background(), id, kr)
+			}
+			b.ReportMetric(float64(peak.Load()), "peak-reads")
+		})
+	}
+}
+
+func TestCompositeResourceTree(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	xr := model.GetCompositeResource(treeObject("example.org/v1", "XR", "", "xr", map[string]any{}, false))
+
+	type want struct {
+		tn   model.ResourceTreeNode
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		want    want
+	}{
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return only the root of the tree.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return nil, errBoom
+			}),
+			want: want{
+				tn: model.ResourceTreeNode{ID: xr.ID, Resource: xr, Children: []model.ResourceTreeNode{}},
+				errs: gqlerror.List{
+					gqlerror.Errorf(errors.Wrap(errBoom, errGetClient).Error()),
+				},
+			},
+		},
+		"Success": {
+			reason: "We should return the tree rooted at the composite resource.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockGet: treeGetFn()}, nil
+			}),
+			want: want{
+				tn: model.ResourceTreeNode{ID: xr.ID, Resource: xr, Children: []model.ResourceTreeNode{}},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &compositeResource{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add errors
+			// to the GraphQL context and return early.
+			ctx := graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover)
+			got, err := r.Tree(ctx, &xr)
+			errs := graphql.GetErrors(ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nq.Tree(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nq.Tree(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.tn, got, cmpopts.IgnoreUnexported(model.ObjectMeta{}), cmpopts.IgnoreFields(model.CompositeResource{}, "PavedAccess")); diff != "" {
+				t.Errorf("\n%s\nq.Tree(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestResourceTreeNodeReadinessChecks(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	mr := treeObject("example.org/v1", "Managed", "", "mr", map[string]any{}, true)
+	mr.SetAnnotations(map[string]string{model.AnnotationKeyCompositionResourceName: "bucket"})
+	kr, _ := model.GetKubernetesResource(mr)
+
+	cmpGetFn := func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+		*obj.(*extv1.Composition) = extv1.Composition{Spec: extv1.CompositionSpec{
+			Resources: []extv1.ComposedTemplate{{Name: ptr.To("bucket")}},
+		}}
+		return nil
+	}
+
+	type want struct {
+		rcr  []model.ReadinessCheckResult
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		tn      *model.ResourceTreeNode
+		want    want
+	}{
+		"NotComposed": {
+			reason: "A resource that has no parent composite resource has no readiness checks.",
+			tn:     &model.ResourceTreeNode{Resource: kr},
+			want:   want{},
+		},
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return nil, errBoom
+			}),
+			tn: &model.ResourceTreeNode{Resource: kr, CompositionReference: &corev1.ObjectReference{Name: "cmp"}},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Errorf(errors.Wrap(errBoom, errGetClient).Error()),
+				},
+			},
+		},
+		"GetCompositionError": {
+			reason: "If we can't get the composition we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockGet: test.NewMockGetFn(errBoom)}, nil
+			}),
+			tn: &model.ResourceTreeNode{Resource: kr, CompositionReference: &corev1.ObjectReference{Name: "cmp"}},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Errorf(errors.Wrap(errBoom, errGetComposition).Error()),
+				},
+			},
+		},
+		"Success": {
+			reason: "We should evaluate the readiness checks of the resource's template.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockGet: cmpGetFn}, nil
+			}),
+			tn: &model.ResourceTreeNode{Resource: kr, CompositionReference: &corev1.ObjectReference{Name: "cmp"}},
+			want: want{
+				rcr: []model.ReadinessCheckResult{{
+					Type:           "MatchCondition",
+					MatchCondition: &model.ReadinessCheckCondition{Type: "Ready", Status: model.ConditionStatusTrue},
+					Passed:         true,
+				}},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &resourceTreeNode{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add errors
+			// to the GraphQL context and return early.
+			ctx := graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover)
+			got, err := r.ReadinessChecks(ctx, tc.tn)
+			errs := graphql.GetErrors(ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.ReadinessChecks(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.ReadinessChecks(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.rcr, got); diff != "" {
+				t.Errorf("\n%s\nr.ReadinessChecks(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
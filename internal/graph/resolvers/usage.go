@@ -0,0 +1,161 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolvers
+
+import (
+	"context"
+	"sort"
+
+	"github.com/99designs/gqlgen/graphql"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	extv1alpha1 "github.com/crossplane/crossplane/apis/apiextensions/v1alpha1"
+
+	"github.com/upbound/xgql/internal/auth"
+	"github.com/upbound/xgql/internal/graph/model"
+)
+
+const (
+	errListUsages       = "cannot list usages"
+	errGetUsageResource = "cannot get usage resource"
+	errModelUsageRes    = "cannot model usage resource"
+)
+
+// A usageFilter returns true if the supplied usage should be resolved.
+type usageFilter func(u *extv1alpha1.Usage) bool
+
+// usageOf returns a filter that matches usages of the supplied resource.
+func usageOf(apiVersion, kind, name string) usageFilter {
+	return func(u *extv1alpha1.Usage) bool {
+		return refers(u.Spec.Of, apiVersion, kind, name)
+	}
+}
+
+// usageBy returns a filter that matches usages by the supplied resource.
+func usageBy(apiVersion, kind, name string) usageFilter {
+	return func(u *extv1alpha1.Usage) bool {
+		return u.Spec.By != nil && refers(*u.Spec.By, apiVersion, kind, name)
+	}
+}
+
+// refers returns true if the supplied usage resource refers to the resource
+// with the supplied API version, kind, and name. Crossplane resolves resource
+// selectors to a resource reference, so we only consider the latter.
+func refers(r extv1alpha1.Resource, apiVersion, kind, name string) bool {
+	return r.APIVersion == apiVersion && r.Kind == kind && r.ResourceRef != nil && r.ResourceRef.Name == name
+}
+
+type usages struct {
+	clients ClientCache
+}
+
+// Resolve usages that pass the supplied filter, or all usages if the filter is
+// nil. Usages are an alpha Crossplane API that may not be enabled; if it isn't
+// we return no usages.
+func (r *usages) Resolve(ctx context.Context, filter usageFilter) (model.UsageConnection, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.GetWithContext(ctx, creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.UsageConnection{}, nil
+	}
+
+	in := &extv1alpha1.UsageList{}
+	if err := c.List(ctx, in); err != nil {
+		if meta.IsNoMatchError(err) || kerrors.IsNotFound(err) {
+			return model.UsageConnection{Nodes: make([]model.Usage, 0)}, nil
+		}
+		graphql.AddError(ctx, errors.Wrap(err, errListUsages))
+		return model.UsageConnection{}, nil
+	}
+
+	out := &model.UsageConnection{
+		Nodes: make([]model.Usage, 0, len(in.Items)),
+	}
+
+	for i := range in.Items {
+		u := &in.Items[i] // So we don't take the address of a range variable.
+
+		if filter != nil && !filter(u) {
+			continue
+		}
+
+		out.Nodes = append(out.Nodes, model.GetUsage(u))
+		out.TotalCount++
+	}
+
+	sort.Stable(out)
+	return *out, nil
+}
+
+type usage struct {
+	clients ClientCache
+}
+
+func (r *usage) Events(ctx context.Context, obj *model.Usage) (model.EventConnection, error) {
+	e := &events{clients: r.clients}
+	return e.Resolve(ctx, &corev1.ObjectReference{
+		APIVersion: obj.APIVersion,
+		Kind:       obj.Kind,
+		Name:       obj.Metadata.Name,
+		UID:        types.UID(obj.Metadata.UID),
+	})
+}
+
+type usageResource struct {
+	clients ClientCache
+}
+
+func (r *usageResource) Resource(ctx context.Context, obj *model.UsageResource) (model.KubernetesResource, error) {
+	if obj == nil || obj.APIVersion == nil || obj.Kind == nil || obj.ResourceRef == nil {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.GetWithContext(ctx, creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return nil, nil
+	}
+
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion(*obj.APIVersion)
+	u.SetKind(*obj.Kind)
+	if err := c.Get(ctx, types.NamespacedName{Name: obj.ResourceRef.Name}, u); err != nil {
+		if !kerrors.IsNotFound(err) {
+			graphql.AddError(ctx, errors.Wrap(err, errGetUsageResource))
+		}
+		return nil, nil
+	}
+
+	out, err := model.GetKubernetesResource(u)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errModelUsageRes))
+		return nil, nil
+	}
+
+	return out, nil
+}
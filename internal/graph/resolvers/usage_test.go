@@ -0,0 +1,342 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolvers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	extv1alpha1 "github.com/crossplane/crossplane/apis/apiextensions/v1alpha1"
+
+	"github.com/upbound/xgql/internal/auth"
+	"github.com/upbound/xgql/internal/clients"
+	"github.com/upbound/xgql/internal/graph/generated"
+	"github.com/upbound/xgql/internal/graph/model"
+)
+
+var (
+	_ generated.UsageResolver         = &usage{}
+	_ generated.UsageResourceResolver = &usageResource{}
+)
+
+func TestUsagesResolve(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	db := extv1alpha1.Resource{
+		APIVersion:  "example.org/v1",
+		Kind:        "Database",
+		ResourceRef: &extv1alpha1.ResourceRef{Name: "cool-db"},
+	}
+	cluster := extv1alpha1.Resource{
+		APIVersion:  "example.org/v1",
+		Kind:        "Cluster",
+		ResourceRef: &extv1alpha1.ResourceRef{Name: "cool-cluster"},
+	}
+
+	ua := extv1alpha1.Usage{}
+	ua.SetName("a")
+	ua.Spec.Of = db
+	ua.Spec.By = &cluster
+	gua := model.GetUsage(&ua)
+
+	ub := extv1alpha1.Usage{}
+	ub.SetName("b")
+	ub.Spec.Of = cluster
+	ub.Spec.Reason = ptr.To("production")
+	gub := model.GetUsage(&ub)
+
+	list := func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+		*obj.(*extv1alpha1.UsageList) = extv1alpha1.UsageList{Items: []extv1alpha1.Usage{ub, ua}}
+		return nil
+	}
+
+	type args struct {
+		ctx    context.Context
+		filter usageFilter
+	}
+	type want struct {
+		uc   model.UsageConnection
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		args    args
+		want    want
+	}{
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, errBoom
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"ListUsagesError": {
+			reason: "If we can't list usages we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(errBoom),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errListUsages)),
+				},
+			},
+		},
+		"UsageAPINotEnabled": {
+			reason: "If the Usage API isn't enabled we should return no usages without an error.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(&meta.NoKindMatchError{GroupKind: extv1alpha1.SchemeGroupVersion.WithKind(extv1alpha1.UsageKind).GroupKind()}),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				uc: model.UsageConnection{
+					Nodes: []model.Usage{},
+				},
+			},
+		},
+		"AllUsages": {
+			reason: "If no filter is supplied we should return all usages.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: list,
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				uc: model.UsageConnection{
+					Nodes:      []model.Usage{gua, gub},
+					TotalCount: 2,
+				},
+			},
+		},
+		"UsageOf": {
+			reason: "We should only return usages of the supplied resource.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: list,
+				}, nil
+			}),
+			args: args{
+				ctx:    graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				filter: usageOf(cluster.APIVersion, cluster.Kind, cluster.ResourceRef.Name),
+			},
+			want: want{
+				uc: model.UsageConnection{
+					Nodes:      []model.Usage{gub},
+					TotalCount: 1,
+				},
+			},
+		},
+		"UsageBy": {
+			reason: "We should only return usages by the supplied resource.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: list,
+				}, nil
+			}),
+			args: args{
+				ctx:    graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				filter: usageBy(cluster.APIVersion, cluster.Kind, cluster.ResourceRef.Name),
+			},
+			want: want{
+				uc: model.UsageConnection{
+					Nodes:      []model.Usage{gua},
+					TotalCount: 1,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			u := &usages{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := u.Resolve(tc.args.ctx, tc.args.filter)
+			errs := graphql.GetErrors(tc.args.ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nu.Resolve(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nu.Resolve(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.uc, got, cmpopts.IgnoreFields(model.Usage{}, "PavedAccess"), cmpopts.IgnoreUnexported(model.ObjectMeta{})); diff != "" {
+				t.Errorf("\n%s\nu.Resolve(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUsageResourceResource(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	kr := &unstructured.Unstructured{}
+	kr.SetAPIVersion("example.org/v1")
+	kr.SetKind("Database")
+	kr.SetName("cool-db")
+	gkr, _ := model.GetKubernetesResource(kr)
+
+	ref := &model.UsageResource{
+		APIVersion:  ptr.To(kr.GetAPIVersion()),
+		Kind:        ptr.To(kr.GetKind()),
+		ResourceRef: &model.UsageResourceReference{Name: kr.GetName()},
+	}
+
+	type args struct {
+		ctx context.Context
+		obj *model.UsageResource
+	}
+	type want struct {
+		kr   model.KubernetesResource
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		args    args
+		want    want
+	}{
+		"NoReference": {
+			reason: "If the usage resource hasn't been resolved to a reference we should return early.",
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &model.UsageResource{
+					APIVersion: ptr.To(kr.GetAPIVersion()),
+					Kind:       ptr.To(kr.GetKind()),
+				},
+			},
+		},
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, errBoom
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: ref,
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"GetResourceError": {
+			reason: "If we can't get the resource we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: ref,
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetUsageResource)),
+				},
+			},
+		},
+		"ResourceNotFound": {
+			reason: "If the resource doesn't exist we should return early without an error.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, kr.GetName())),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: ref,
+			},
+		},
+		"Success": {
+			reason: "If we can get and model the resource we should return it.",
+			clients: ClientCacheFn(func(_ context.Context, _ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						*obj.(*unstructured.Unstructured) = *kr
+						return nil
+					}),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: ref,
+			},
+			want: want{
+				kr: gkr,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			u := &usageResource{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := u.Resource(tc.args.ctx, tc.args.obj)
+			errs := graphql.GetErrors(tc.args.ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nu.Resource(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nu.Resource(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.kr, got, cmpopts.IgnoreFields(model.GenericResource{}, "PavedAccess"), cmpopts.IgnoreUnexported(model.ObjectMeta{})); diff != "" {
+				t.Errorf("\n%s\nu.Resource(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
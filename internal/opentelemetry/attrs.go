@@ -26,6 +26,8 @@ const (
 	query     = attribute.Key("crossplane.io/gql-query")
 	path      = attribute.Key("crossplane.io/gql-path")
 	alias     = attribute.Key("crossplane.io/gql-alias")
+	coalesced = attribute.Key("crossplane.io/client-coalesced")
+	apiGroup  = attribute.Key("crossplane.io/api-group")
 )
 
 func variable(v string) attribute.Key { return attribute.Key("crossplane.io/gql-variable/" + v) }
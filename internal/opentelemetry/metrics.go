@@ -17,6 +17,7 @@ package opentelemetry
 import (
 	"context"
 	"log"
+	"sync"
 	"time"
 
 	"github.com/99designs/gqlgen/graphql"
@@ -38,10 +39,16 @@ var _ interface {
 var (
 	reqStarted   api.Int64Counter
 	reqCompleted api.Int64Counter
+	reqErrored   api.Int64Counter
 	reqDuration  api.Float64Histogram
 	resStarted   api.Int64Counter
 	resCompleted api.Int64Counter
 	resDuration  api.Float64Histogram
+	resPanicked  api.Int64Counter
+	cliCreated   api.Int64Counter
+	cliWaiting   api.Int64UpDownCounter
+	mapFailed    api.Int64Counter
+	mapRecovered api.Int64Counter
 )
 
 // OpenTelemetry metrics.
@@ -71,6 +78,14 @@ func init() {
 		panic(err)
 	}
 
+	reqErrored, err = meter.Int64Counter("request.errored.total",
+		api.WithDescription("Total number of requests completed with errors"),
+		api.WithUnit("1"),
+	)
+	if err != nil {
+		panic(err)
+	}
+
 	reqDuration, err = meter.Float64Histogram("request.duration.ms",
 		api.WithDescription("The time taken to complete a request"),
 		api.WithUnit("ms"),
@@ -102,6 +117,81 @@ func init() {
 	if err != nil {
 		panic(err)
 	}
+
+	resPanicked, err = meter.Int64Counter("resolver.panicked.total",
+		api.WithDescription("Total number of resolvers that panicked"),
+		api.WithUnit("1"),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	cliCreated, err = meter.Int64Counter("client.created.total",
+		api.WithDescription("Total number of requests that needed a new client, by whether they used a client created by a concurrent request"),
+		api.WithUnit("1"),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	cliWaiting, err = meter.Int64UpDownCounter("client.create.waiting",
+		api.WithDescription("Number of requests waiting for a concurrent request to create the client they need"),
+		api.WithUnit("1"),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	mapFailed, err = meter.Int64Counter("restmapper.failed.total",
+		api.WithDescription("Total number of REST mapper resolutions that failed, by API group"),
+		api.WithUnit("1"),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	mapRecovered, err = meter.Int64Counter("restmapper.recovered.total",
+		api.WithDescription("Total number of API groups whose REST mapper resolutions succeeded after failing, by API group"),
+		api.WithUnit("1"),
+	)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// RecordClientCreate records that a request needed a new client. A coalesced
+// request used a client created by a concurrent request with the same
+// credentials, rather than creating its own.
+func RecordClientCreate(ctx context.Context, wasCoalesced bool) {
+	cliCreated.Add(ctx, 1, api.WithAttributes(coalesced.Bool(wasCoalesced)))
+}
+
+// RecordClientCreateWaiting records a change in the number of requests waiting
+// for a concurrent request to create the client they need.
+func RecordClientCreateWaiting(ctx context.Context, delta int64) {
+	cliWaiting.Add(ctx, delta)
+}
+
+// RecordRESTMappingFailure records that the REST mapper couldn't resolve a
+// kind or resource in the supplied API group.
+func RecordRESTMappingFailure(ctx context.Context, group string) {
+	mapFailed.Add(ctx, 1, api.WithAttributes(apiGroup.String(group)))
+}
+
+// RecordRESTMappingRecovery records that the REST mapper resolved a kind or
+// resource in the supplied API group after previously failing to.
+func RecordRESTMappingRecovery(ctx context.Context, group string) {
+	mapRecovered.Add(ctx, 1, api.WithAttributes(apiGroup.String(group)))
+}
+
+// RecordPanic records that a resolver panicked.
+func RecordPanic(ctx context.Context) {
+	fc := graphql.GetFieldContext(ctx)
+	if fc == nil {
+		resPanicked.Add(ctx, 1)
+		return
+	}
+	resPanicked.Add(ctx, 1, api.WithAttributes(object.String(fc.Object), field.String(fc.Field.Name)))
 }
 
 // ExtensionName of this extension.
@@ -118,7 +208,7 @@ func (t MetricEmitter) Validate(schema graphql.ExecutableSchema) error {
 func (t MetricEmitter) InterceptOperation(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
 	if graphql.HasOperationContext(ctx) {
 		oc := graphql.GetOperationContext(ctx)
-		reqStarted.Add(ctx, 1, api.WithAttributes(operation.String(oc.OperationName)))
+		reqStarted.Add(ctx, 1, api.WithAttributes(operation.String(operationMetricName(oc))))
 	}
 	return next(ctx)
 }
@@ -129,9 +219,12 @@ func (t MetricEmitter) InterceptResponse(ctx context.Context, next graphql.Respo
 		errs := graphql.GetErrors(ctx)
 		oc := graphql.GetOperationContext(ctx)
 		ms := time.Since(oc.Stats.OperationStart).Milliseconds()
-		attrs := api.WithAttributes(operation.String(oc.OperationName), success.Bool(len(errs) > 0))
+		attrs := api.WithAttributes(operation.String(operationMetricName(oc)), success.Bool(len(errs) == 0))
 		reqCompleted.Add(ctx, 1, attrs)
 		reqDuration.Record(ctx, float64(ms), attrs)
+		if len(errs) > 0 {
+			reqErrored.Add(ctx, 1, attrs)
+		}
 	}
 
 	return next(ctx)
@@ -153,8 +246,52 @@ func (t MetricEmitter) InterceptField(ctx context.Context, next graphql.Resolver
 	ms := time.Since(started).Milliseconds()
 	errs := graphql.GetFieldErrors(ctx, fc)
 
-	resCompleted.Add(ctx, 1, attrs, api.WithAttributes(success.Bool(errs != nil)))
-	resDuration.Record(ctx, float64(ms), attrs, api.WithAttributes(success.Bool(errs != nil)))
+	resCompleted.Add(ctx, 1, attrs, api.WithAttributes(success.Bool(errs == nil)))
+	resDuration.Record(ctx, float64(ms), attrs, api.WithAttributes(success.Bool(errs == nil)))
 
 	return rsp, err
 }
+
+// Metric names for operations that aren't distinguished by name.
+const (
+	operationAnonymous = "anonymous"
+	operationOther     = "other"
+)
+
+// maxOperationNames is the maximum number of distinct operation names used in
+// metrics. Callers choose operation names, so we bound them to bound the
+// cardinality of our metrics.
+const maxOperationNames = 100
+
+// operationNames records the operation names used in metrics.
+type operationNames struct {
+	max  int
+	mx   sync.Mutex
+	seen map[string]bool
+}
+
+var operations = &operationNames{max: maxOperationNames, seen: make(map[string]bool)}
+
+// operationMetricName returns the name of the supplied operation for use in
+// metrics. Anonymous operations share one name. Named operations use their
+// name, until the maximum number of names has been used; operations with
+// names seen after that share one name.
+func operationMetricName(oc *graphql.OperationContext) string {
+	return operations.name(oc.OperationName)
+}
+
+func (o *operationNames) name(n string) string {
+	if n == "" {
+		return operationAnonymous
+	}
+	o.mx.Lock()
+	defer o.mx.Unlock()
+	if o.seen[n] {
+		return n
+	}
+	if len(o.seen) >= o.max {
+		return operationOther
+	}
+	o.seen[n] = true
+	return n
+}
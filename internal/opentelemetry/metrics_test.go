@@ -0,0 +1,67 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetry
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestOperationMetricName(t *testing.T) {
+	type args struct {
+		seen []string
+		name string
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   string
+	}{
+		"Named": {
+			reason: "Named operations should use their name.",
+			args:   args{name: "cool"},
+			want:   "cool",
+		},
+		"Anonymous": {
+			reason: "Anonymous operations should share one name.",
+			args:   args{seen: []string{"cool", "lame"}},
+			want:   operationAnonymous,
+		},
+		"Seen": {
+			reason: "Operations whose names have been used should keep using them.",
+			args:   args{seen: []string{"cool", "lame"}, name: "cool"},
+			want:   "cool",
+		},
+		"TooManyNames": {
+			reason: "Operations with new names should share one name once the maximum number of names has been used.",
+			args:   args{seen: []string{"cool", "lame"}, name: "new"},
+			want:   operationOther,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o := &operationNames{max: 2, seen: make(map[string]bool)}
+			for _, n := range tc.args.seen {
+				o.name(n)
+			}
+			if diff := cmp.Diff(tc.want, o.name(tc.args.name)); diff != "" {
+				t.Errorf("\n%s\no.name(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/upbound/xgql/internal/auth"
+)
+
+// ETag is middleware that supports conditional GET requests. It computes an
+// ETag over the body of each successful GET response and, if the request's
+// If-None-Match header matches it, responds 304 Not Modified without a body.
+// Responses are scoped to the credentials they were made with, so the ETag is
+// computed over the request's credentials as well as the response body. This
+// ensures two callers never share an ETag for the same response. Responses vary
+// on the Authorization header and on the supplied header, from which the
+// caller's bearer token is read. Requests that aren't GET requests, including
+// websocket upgrades, are passed through.
+func ETag(tokenHeader string) func(http.Handler) http.Handler {
+	vary := []string{"Authorization"}
+	if h := http.CanonicalHeaderKey(tokenHeader); h != "" && h != "Authorization" {
+		vary = append(vary, h)
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet || r.Header.Get("Upgrade") != "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			bw := &bufferedWriter{header: make(http.Header), status: http.StatusOK}
+			next.ServeHTTP(bw, r)
+
+			for k, v := range bw.header {
+				w.Header()[k] = v
+			}
+
+			if bw.status != http.StatusOK {
+				w.WriteHeader(bw.status)
+				_, _ = w.Write(bw.body.Bytes())
+				return
+			}
+
+			creds, _ := auth.FromContext(r.Context())
+			tag := etag(creds, bw.body.Bytes())
+			w.Header().Set("ETag", tag)
+			for _, h := range vary {
+				w.Header().Add("Vary", h)
+			}
+
+			if matches(r.Header.Get("If-None-Match"), tag) {
+				w.Header().Del("Content-Length")
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+
+			w.WriteHeader(bw.status)
+			_, _ = w.Write(bw.body.Bytes())
+		})
+	}
+}
+
+// etag returns a strong ETag for the supplied body, as returned to a caller
+// with the supplied credentials.
+func etag(cr auth.Credentials, body []byte) string {
+	h := sha256.New()
+	for _, s := range []string{cr.BearerToken, cr.BasicUsername, cr.BasicPassword} {
+		h.Write([]byte(s))
+		h.Write([]byte{0})
+	}
+	h.Write([]byte(cr.Hash(body)))
+	return fmt.Sprintf("%q", fmt.Sprintf("%x", h.Sum(nil)))
+}
+
+// matches returns true if the supplied If-None-Match header value matches the
+// supplied ETag.
+func matches(inm, tag string) bool {
+	for _, t := range strings.Split(inm, ",") {
+		t = strings.TrimPrefix(strings.TrimSpace(t), "W/")
+		if t == tag || t == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// A bufferedWriter buffers a response so that we can compute its ETag before
+// sending it.
+type bufferedWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+	wrote  bool
+}
+
+func (w *bufferedWriter) Header() http.Header { return w.header }
+
+func (w *bufferedWriter) WriteHeader(status int) {
+	if w.wrote {
+		return
+	}
+	w.status = status
+	w.wrote = true
+}
+
+func (w *bufferedWriter) Write(b []byte) (int, error) {
+	w.wrote = true
+	return w.body.Write(b)
+}
@@ -0,0 +1,142 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/upbound/xgql/internal/auth"
+)
+
+func TestETag(t *testing.T) {
+	body := `{"data":{"cool":true}}`
+	ok := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(body))
+	})
+	tag := etag(auth.Credentials{BearerToken: "cool"}, []byte(body))
+
+	type want struct {
+		status int
+		etag   string
+		vary   []string
+		body   string
+	}
+
+	cases := map[string]struct {
+		reason string
+		header string
+		next   http.Handler
+		r      func() *http.Request
+		want   want
+	}{
+		"NotGET": {
+			reason: "Requests that aren't GET requests should be passed through without an ETag.",
+			next:   ok,
+			r: func() *http.Request {
+				return httptest.NewRequest(http.MethodPost, "/query", nil)
+			},
+			want: want{status: http.StatusOK, body: body},
+		},
+		"WebsocketUpgrade": {
+			reason: "Websocket upgrades should be passed through without an ETag.",
+			next:   ok,
+			r: func() *http.Request {
+				r := httptest.NewRequest(http.MethodGet, "/query", nil)
+				r.Header.Set("Upgrade", "websocket")
+				return r
+			},
+			want: want{status: http.StatusOK, body: body},
+		},
+		"NotOK": {
+			reason: "Unsuccessful responses should be passed through without an ETag.",
+			next: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("nope"))
+			}),
+			r: func() *http.Request {
+				return httptest.NewRequest(http.MethodGet, "/query", nil)
+			},
+			want: want{status: http.StatusBadRequest, body: "nope"},
+		},
+		"NoIfNoneMatch": {
+			reason: "Successful GET responses should include an ETag.",
+			next:   ok,
+			r: func() *http.Request {
+				r := httptest.NewRequest(http.MethodGet, "/query", nil)
+				r.Header.Set("Authorization", "Bearer cool")
+				return r
+			},
+			want: want{status: http.StatusOK, etag: tag, vary: []string{"Authorization"}, body: body},
+		},
+		"IfNoneMatch": {
+			reason: "GET requests should get a 304 without a body if their If-None-Match header matches.",
+			next:   ok,
+			r: func() *http.Request {
+				r := httptest.NewRequest(http.MethodGet, "/query", nil)
+				r.Header.Set("Authorization", "Bearer cool")
+				r.Header.Set("If-None-Match", `"other", `+tag)
+				return r
+			},
+			want: want{status: http.StatusNotModified, etag: tag, vary: []string{"Authorization"}},
+		},
+		"IfNoneMatchOtherCredentials": {
+			reason: "An ETag computed for one caller's credentials should not match another caller's response.",
+			next:   ok,
+			r: func() *http.Request {
+				r := httptest.NewRequest(http.MethodGet, "/query", nil)
+				r.Header.Set("Authorization", "Bearer lame")
+				r.Header.Set("If-None-Match", tag)
+				return r
+			},
+			want: want{
+				status: http.StatusOK,
+				etag:   etag(auth.Credentials{BearerToken: "lame"}, []byte(body)),
+				vary:   []string{"Authorization"},
+				body:   body,
+			},
+		},
+		"TokenHeader": {
+			reason: "Responses should vary on the header the caller's bearer token is read from.",
+			header: "x-token",
+			next:   ok,
+			r: func() *http.Request {
+				r := httptest.NewRequest(http.MethodGet, "/query", nil)
+				r.Header.Set("X-Token", "cool")
+				return r
+			},
+			want: want{status: http.StatusOK, etag: tag, vary: []string{"Authorization", "X-Token"}, body: body},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			header := tc.header
+			if header == "" {
+				header = "Authorization"
+			}
+			w := httptest.NewRecorder()
+			auth.TokenHeaderMiddleware(header)(ETag(header)(tc.next)).ServeHTTP(w, tc.r())
+
+			got := want{status: w.Code, etag: w.Header().Get("ETag"), vary: w.Header().Values("Vary"), body: w.Body.String()}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nETag(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
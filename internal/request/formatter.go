@@ -15,15 +15,45 @@
 package request
 
 import (
+	"net"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/go-chi/chi/v5/middleware"
 )
 
+const (
+	headerForwardedFor = "X-Forwarded-For"
+	headerRealIP       = "X-Real-IP"
+)
+
 // Formatter provides a request logging formatter for incoming requests.
-type Formatter struct{ Log logging.Logger }
+type Formatter struct {
+	Log logging.Logger
+
+	// TrustedProxies are the networks from which X-Forwarded-For and
+	// X-Real-IP headers are honored when logging a request's remote address.
+	// Forwarded headers from any other remote address are ignored, since
+	// clients could otherwise spoof them.
+	TrustedProxies []*net.IPNet
+}
+
+// ParseCIDRs parses the supplied CIDR strings, e.g. for use as trusted
+// proxies.
+func ParseCIDRs(cidrs []string) ([]*net.IPNet, error) {
+	out := make([]*net.IPNet, 0, len(cidrs))
+	for _, c := range cidrs {
+		_, n, err := net.ParseCIDR(c)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot parse CIDR %q", c)
+		}
+		out = append(out, n)
+	}
+	return out, nil
+}
 
 // NewLogEntry emits a new log entry that includes request details.
 func (f *Formatter) NewLogEntry(r *http.Request) middleware.LogEntry {
@@ -34,10 +64,61 @@ func (f *Formatter) NewLogEntry(r *http.Request) middleware.LogEntry {
 		"host", r.Host,
 		"uri", r.RequestURI,
 		"protocol", r.Proto,
-		"remote", r.RemoteAddr,
+		"remote", f.remote(r),
 	)}
 }
 
+// remote returns the address of the client that made the supplied request. If
+// the request came from a trusted proxy the address is read from its forwarded
+// headers. X-Forwarded-For is read from right to left, returning the first
+// address that is not itself a trusted proxy.
+func (f *Formatter) remote(r *http.Request) string {
+	if !f.trusted(host(r.RemoteAddr)) {
+		return r.RemoteAddr
+	}
+
+	if xff := r.Header.Values(headerForwardedFor); len(xff) > 0 {
+		addrs := strings.Split(strings.Join(xff, ","), ",")
+		for i := len(addrs) - 1; i >= 0; i-- {
+			a := strings.TrimSpace(addrs[i])
+			if a == "" {
+				continue
+			}
+			if i == 0 || !f.trusted(a) {
+				return a
+			}
+		}
+	}
+
+	if xrip := strings.TrimSpace(r.Header.Get(headerRealIP)); xrip != "" {
+		return xrip
+	}
+
+	return r.RemoteAddr
+}
+
+func (f *Formatter) trusted(addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	for _, n := range f.TrustedProxies {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// host strips the port, if any, from the supplied address.
+func host(addr string) string {
+	h, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return h
+}
+
 type entry struct{ log logging.Logger }
 
 func (e *entry) Write(status, bytes int, _ http.Header, elapsed time.Duration, _ interface{}) {
@@ -49,5 +130,5 @@ func (e *entry) Write(status, bytes int, _ http.Header, elapsed time.Duration, _
 }
 
 func (e *entry) Panic(v interface{}, stack []byte) {
-	e.log.Debug("Paniced while handling request", "stack", stack, "panic", v)
+	e.log.Info("Panicked while handling request", "stack", string(stack), "panic", v)
 }
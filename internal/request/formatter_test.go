@@ -0,0 +1,101 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFormatterRemote(t *testing.T) {
+	_, proxies, _ := net.ParseCIDR("10.0.0.0/8")
+
+	cases := map[string]struct {
+		reason  string
+		trusted []*net.IPNet
+		remote  string
+		headers map[string]string
+		want    string
+	}{
+		"NoTrustedProxies": {
+			reason:  "Forwarded headers should be ignored when no proxies are trusted.",
+			remote:  "10.0.0.1:1234",
+			headers: map[string]string{headerForwardedFor: "192.0.2.1"},
+			want:    "10.0.0.1:1234",
+		},
+		"UntrustedRemote": {
+			reason:  "Forwarded headers should be ignored when the remote address isn't a trusted proxy.",
+			trusted: []*net.IPNet{proxies},
+			remote:  "192.0.2.9:1234",
+			headers: map[string]string{headerForwardedFor: "192.0.2.1"},
+			want:    "192.0.2.9:1234",
+		},
+		"ForwardedFor": {
+			reason:  "The rightmost untrusted X-Forwarded-For address should be used when the remote address is a trusted proxy.",
+			trusted: []*net.IPNet{proxies},
+			remote:  "10.0.0.1:1234",
+			headers: map[string]string{headerForwardedFor: "198.51.100.1, 192.0.2.1, 10.0.0.2"},
+			want:    "192.0.2.1",
+		},
+		"ForwardedForAllTrusted": {
+			reason:  "The leftmost X-Forwarded-For address should be used when all forwarded addresses are trusted proxies.",
+			trusted: []*net.IPNet{proxies},
+			remote:  "10.0.0.1:1234",
+			headers: map[string]string{headerForwardedFor: "10.0.0.3, 10.0.0.2"},
+			want:    "10.0.0.3",
+		},
+		"RealIP": {
+			reason:  "X-Real-IP should be used when the remote address is a trusted proxy that doesn't set X-Forwarded-For.",
+			trusted: []*net.IPNet{proxies},
+			remote:  "10.0.0.1:1234",
+			headers: map[string]string{headerRealIP: "192.0.2.1"},
+			want:    "192.0.2.1",
+		},
+		"NoForwardedHeaders": {
+			reason:  "The remote address should be used when a trusted proxy doesn't set forwarded headers.",
+			trusted: []*net.IPNet{proxies},
+			remote:  "10.0.0.1:1234",
+			want:    "10.0.0.1:1234",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/query", nil)
+			r.RemoteAddr = tc.remote
+			for k, v := range tc.headers {
+				r.Header.Set(k, v)
+			}
+
+			f := &Formatter{TrustedProxies: tc.trusted}
+			if diff := cmp.Diff(tc.want, f.remote(r)); diff != "" {
+				t.Errorf("\n%s\nf.remote(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestParseCIDRs(t *testing.T) {
+	if _, err := ParseCIDRs([]string{"10.0.0.0/8", "fd00::/8"}); err != nil {
+		t.Errorf("ParseCIDRs(...): unexpected error: %s", err)
+	}
+	if _, err := ParseCIDRs([]string{"10.0.0.1"}); err == nil {
+		t.Errorf("ParseCIDRs(...): expected an error parsing an address without a prefix length")
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+)
+
+// MaxBodyBytes returns middleware that rejects requests whose bodies are larger
+// than n bytes with 413 Request Entity Too Large. Bodies are read in full
+// before the request is passed on, so oversized requests are rejected before
+// they reach the GraphQL server rather than failing part way through parsing.
+// A limit of zero or less disables the check.
+func MaxBodyBytes(n int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if n <= 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > n {
+				tooLarge(w, n)
+				return
+			}
+			if r.Body == nil || r.Body == http.NoBody {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, n))
+			if err != nil {
+				mbe := &http.MaxBytesError{}
+				if errors.As(err, &mbe) {
+					tooLarge(w, n)
+					return
+				}
+				http.Error(w, "cannot read request body", http.StatusBadRequest)
+				return
+			}
+
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func tooLarge(w http.ResponseWriter, n int64) {
+	http.Error(w, fmt.Sprintf("request body must not exceed %d bytes", n), http.StatusRequestEntityTooLarge)
+}
@@ -0,0 +1,96 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMaxBodyBytes(t *testing.T) {
+	echo := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		_, _ = w.Write(b)
+	})
+
+	type want struct {
+		status int
+		body   string
+	}
+
+	cases := map[string]struct {
+		reason string
+		n      int64
+		r      func() *http.Request
+		want   want
+	}{
+		"WithinLimit": {
+			reason: "Requests with bodies within the limit should be passed through intact.",
+			n:      8,
+			r: func() *http.Request {
+				return httptest.NewRequest(http.MethodPost, "/query", strings.NewReader("cool"))
+			},
+			want: want{status: http.StatusOK, body: "cool"},
+		},
+		"ContentLengthTooLarge": {
+			reason: "Requests that declare a body larger than the limit should be rejected.",
+			n:      2,
+			r: func() *http.Request {
+				return httptest.NewRequest(http.MethodPost, "/query", strings.NewReader("cool"))
+			},
+			want: want{status: http.StatusRequestEntityTooLarge},
+		},
+		"BodyTooLarge": {
+			reason: "Requests that don't declare their length but send a body larger than the limit should be rejected.",
+			n:      2,
+			r: func() *http.Request {
+				r := httptest.NewRequest(http.MethodPost, "/query", strings.NewReader("cool"))
+				r.ContentLength = -1
+				return r
+			},
+			want: want{status: http.StatusRequestEntityTooLarge},
+		},
+		"Disabled": {
+			reason: "A limit of zero should disable the check.",
+			n:      0,
+			r: func() *http.Request {
+				return httptest.NewRequest(http.MethodPost, "/query", strings.NewReader("cool"))
+			},
+			want: want{status: http.StatusOK, body: "cool"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			MaxBodyBytes(tc.n)(echo).ServeHTTP(w, tc.r())
+
+			if diff := cmp.Diff(tc.want.status, w.Code); diff != "" {
+				t.Errorf("\n%s\nMaxBodyBytes(...): -want status, +got status:\n%s\n", tc.reason, diff)
+			}
+			if tc.want.status != http.StatusOK {
+				return
+			}
+			if diff := cmp.Diff(tc.want.body, w.Body.String()); diff != "" {
+				t.Errorf("\n%s\nMaxBodyBytes(...): -want body, +got body:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
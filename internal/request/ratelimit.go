@@ -0,0 +1,130 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"bytes"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/upbound/xgql/internal/auth"
+)
+
+// Rate limiters that haven't been used for this long are forgotten. A caller
+// whose limiter is forgotten starts again with a full bucket.
+const limiterIdleTimeout = 10 * time.Minute
+
+// The longest a rejected caller is told to wait before retrying. A request that
+// can never be allowed, for example because the burst is zero, would otherwise
+// be told to wait forever.
+const maxRetryAfter = time.Hour
+
+// The key of the rate limiter shared by all unauthenticated requests.
+const keyUnauthenticated = "unauthenticated"
+
+// A RateLimiter limits the rate of requests each caller may make. Callers are
+// identified by a hash of their credentials.
+type RateLimiter struct {
+	limit rate.Limit
+	burst int
+
+	mx        sync.Mutex
+	limiters  map[string]*limiter
+	lastSweep time.Time
+}
+
+type limiter struct {
+	*rate.Limiter
+	lastUsed time.Time
+}
+
+// NewRateLimiter returns a RateLimiter that allows each caller to make rps
+// requests per second on average, and up to burst requests at once.
+func NewRateLimiter(rps float64, burst int) *RateLimiter {
+	return &RateLimiter{
+		limit:     rate.Limit(rps),
+		burst:     burst,
+		limiters:  make(map[string]*limiter),
+		lastSweep: time.Now(),
+	}
+}
+
+// Middleware rate limits requests. Requests that exceed their caller's rate
+// limit are rejected as 429 Too Many Requests, with a Retry-After header
+// indicating how many seconds the caller should wait before retrying. Callers
+// are identified by a hash of their bearer token or basic auth credentials,
+// along with any impersonation configuration. All unauthenticated requests
+// share one rate limit. Middleware must run after auth.Middleware.
+func (l *RateLimiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		creds, _ := auth.FromContext(r.Context())
+		rv := l.get(key(creds), time.Now()).Reserve()
+		if d := rv.Delay(); !rv.OK() || d > 0 {
+			rv.Cancel()
+			if !rv.OK() || d > maxRetryAfter {
+				d = maxRetryAfter
+			}
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(d.Seconds()))))
+			http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (l *RateLimiter) get(k string, now time.Time) *limiter {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	// Periodically forget idle limiters so we don't retain one for every
+	// caller we've ever seen.
+	if now.Sub(l.lastSweep) > limiterIdleTimeout {
+		for k, lm := range l.limiters {
+			if now.Sub(lm.lastUsed) > limiterIdleTimeout {
+				delete(l.limiters, k)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	lm, ok := l.limiters[k]
+	if !ok {
+		lm = &limiter{Limiter: rate.NewLimiter(l.limit, l.burst)}
+		l.limiters[k] = lm
+	}
+	lm.lastUsed = now
+	return lm
+}
+
+// key returns the rate limiter key for the supplied credentials. The key is
+// computed using the same hash the client cache uses, with the credentials
+// themselves supplied as extra input so that callers with distinct bearer
+// tokens never share a rate limit.
+func key(cr auth.Credentials) string {
+	if cr.BearerToken == "" && cr.BasicUsername == "" && cr.BasicPassword == "" {
+		return keyUnauthenticated
+	}
+	extra := bytes.Buffer{}
+	for _, s := range []string{cr.BearerToken, cr.BasicUsername, cr.BasicPassword} {
+		extra.WriteString(s)
+		extra.WriteByte(0)
+	}
+	return cr.Hash(extra.Bytes())
+}
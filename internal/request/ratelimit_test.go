@@ -0,0 +1,139 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/upbound/xgql/internal/auth"
+)
+
+func TestRateLimiter(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	bearer := func(token string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "/query", nil)
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		return r
+	}
+
+	type result struct {
+		status     int
+		retryAfter string
+	}
+
+	cases := map[string]struct {
+		reason   string
+		requests []*http.Request
+		want     []result
+	}{
+		"WithinLimit": {
+			reason:   "Requests within a caller's burst should be allowed.",
+			requests: []*http.Request{bearer("cool"), bearer("cool")},
+			want: []result{
+				{status: http.StatusOK},
+				{status: http.StatusOK},
+			},
+		},
+		"ExceedsLimit": {
+			reason:   "Requests that exceed a caller's burst should be rejected with a Retry-After header.",
+			requests: []*http.Request{bearer("cool"), bearer("cool"), bearer("cool")},
+			want: []result{
+				{status: http.StatusOK},
+				{status: http.StatusOK},
+				{status: http.StatusTooManyRequests, retryAfter: "1"},
+			},
+		},
+		"DistinctTokens": {
+			reason:   "Callers with distinct bearer tokens should not share a rate limit.",
+			requests: []*http.Request{bearer("cool"), bearer("cool"), bearer("lame"), bearer("lame")},
+			want: []result{
+				{status: http.StatusOK},
+				{status: http.StatusOK},
+				{status: http.StatusOK},
+				{status: http.StatusOK},
+			},
+		},
+		"Unauthenticated": {
+			reason:   "Unauthenticated callers should share a rate limit that is distinct from authenticated callers.",
+			requests: []*http.Request{bearer(""), bearer(""), bearer("cool"), bearer("")},
+			want: []result{
+				{status: http.StatusOK},
+				{status: http.StatusOK},
+				{status: http.StatusOK},
+				{status: http.StatusTooManyRequests, retryAfter: "1"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			// One request per second, with bursts of two.
+			h := auth.Middleware(NewRateLimiter(1, 2).Middleware(ok))
+
+			got := make([]result, len(tc.requests))
+			for i, r := range tc.requests {
+				w := httptest.NewRecorder()
+				h.ServeHTTP(w, r)
+				got[i] = result{status: w.Code, retryAfter: w.Header().Get("Retry-After")}
+			}
+
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(result{})); diff != "" {
+				t.Errorf("\n%s\nMiddleware(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRateLimiterZeroBurst(t *testing.T) {
+	h := auth.Middleware(NewRateLimiter(1, 0).Middleware(http.NotFoundHandler()))
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/query", nil))
+
+	if diff := cmp.Diff(http.StatusTooManyRequests, w.Code); diff != "" {
+		t.Errorf("Middleware(...): -want status, +got status:\n%s", diff)
+	}
+	// A request can never be allowed with a zero burst, so its delay is
+	// infinite. We should suggest a finite wait.
+	if diff := cmp.Diff("3600", w.Header().Get("Retry-After")); diff != "" {
+		t.Errorf("Middleware(...): -want Retry-After, +got Retry-After:\n%s", diff)
+	}
+}
+
+func TestRateLimiterForgetsIdleLimiters(t *testing.T) {
+	l := NewRateLimiter(1, 1)
+	now := time.Now()
+
+	l.get("cool", now)
+	l.get("lame", now.Add(limiterIdleTimeout))
+	l.get("lame", now.Add(2*limiterIdleTimeout+time.Second))
+
+	if _, ok := l.limiters["cool"]; ok {
+		t.Errorf("l.get(...): want idle limiter to be forgotten")
+	}
+	if _, ok := l.limiters["lame"]; !ok {
+		t.Errorf("l.get(...): want recently used limiter to be kept")
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClearStreamWriteDeadline(t *testing.T) {
+	const timeout = 50 * time.Millisecond
+
+	// stream writes an event, then another after the server's write timeout.
+	stream := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = w.Write([]byte("event: next\n\n"))
+		w.(http.Flusher).Flush()
+		time.Sleep(3 * timeout)
+		_, _ = w.Write([]byte("event: complete\n\n"))
+	})
+
+	cases := map[string]struct {
+		reason string
+		accept string
+		want   string
+	}{
+		"Stream": {
+			reason: "A request for an event stream should outlive the server's write timeout.",
+			accept: "text/event-stream",
+			want:   "event: next\n\nevent: complete\n\n",
+		},
+		"NotStream": {
+			reason: "Any other request should be bound by the server's write timeout.",
+			accept: "application/json",
+			want:   "event: next\n\n",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			srv := httptest.NewUnstartedServer(ClearStreamWriteDeadline(stream))
+			srv.Config.WriteTimeout = timeout
+			srv.Start()
+			defer srv.Close()
+
+			req, _ := http.NewRequest(http.MethodPost, srv.URL, nil)
+			req.Header.Set("Accept", tc.accept)
+			rsp, err := srv.Client().Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer rsp.Body.Close()
+
+			// Reading a response cut short by the write timeout may fail;
+			// we only care what we read before it did.
+			got, _ := io.ReadAll(rsp.Body)
+			if diff := cmp.Diff(tc.want, string(got)); diff != "" {
+				t.Errorf("\n%s\nClearStreamWriteDeadline(...): -want body, +got body:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package scheme contains the scheme of types xgql reads and writes as typed
+// objects.
+package scheme
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	authnv1 "k8s.io/api/authentication/v1"
+	authv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	kextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	extv1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+	extv1alpha1 "github.com/crossplane/crossplane/apis/apiextensions/v1alpha1"
+	pkgv1 "github.com/crossplane/crossplane/apis/pkg/v1"
+	pkgv1beta1 "github.com/crossplane/crossplane/apis/pkg/v1beta1"
+)
+
+// AddToSchemes register the types xgql reads and writes as typed objects.
+// Other types, for example managed resources, are read and written as
+// unstructured objects. A build of xgql may register additional types, for
+// example those of a particular provider, by appending their AddToScheme
+// functions to AddToSchemes in an init function.
+var AddToSchemes = runtime.SchemeBuilder{
+	corev1.AddToScheme,
+	kextv1.AddToScheme,
+	pkgv1.AddToScheme,
+	pkgv1beta1.AddToScheme,
+	extv1.AddToScheme,
+	extv1alpha1.AddToScheme,
+	appsv1.AddToScheme,
+	rbacv1.AddToScheme,
+	authv1.AddToScheme,
+	authnv1.AddToScheme,
+}
+
+// NewScheme returns a scheme with the types registered by AddToSchemes.
+func NewScheme() (*runtime.Scheme, error) {
+	s := runtime.NewScheme()
+	if err := AddToSchemes.AddToScheme(s); err != nil {
+		return nil, errors.Wrap(err, "cannot add types to scheme")
+	}
+	return s, nil
+}
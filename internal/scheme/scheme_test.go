@@ -0,0 +1,73 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheme
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	appsv1 "k8s.io/api/apps/v1"
+	authnv1 "k8s.io/api/authentication/v1"
+	authv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	kextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	extv1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+	extv1alpha1 "github.com/crossplane/crossplane/apis/apiextensions/v1alpha1"
+	pkgv1 "github.com/crossplane/crossplane/apis/pkg/v1"
+	pkgv1beta1 "github.com/crossplane/crossplane/apis/pkg/v1beta1"
+)
+
+func TestNewScheme(t *testing.T) {
+	s, err := NewScheme()
+	if err != nil {
+		t.Fatalf("NewScheme(): %s", err)
+	}
+
+	// One type from each registered group.
+	for _, o := range []runtime.Object{
+		&corev1.Secret{},
+		&kextv1.CustomResourceDefinition{},
+		&pkgv1.Provider{},
+		&pkgv1beta1.DeploymentRuntimeConfig{},
+		&extv1.Composition{},
+		&extv1alpha1.Usage{},
+		&appsv1.Deployment{},
+		&rbacv1.ClusterRole{},
+		&authv1.SelfSubjectAccessReview{},
+		&authnv1.SelfSubjectReview{},
+	} {
+		if _, _, err := s.ObjectKinds(o); err != nil {
+			t.Errorf("s.ObjectKinds(%T): %s", o, err)
+		}
+	}
+}
+
+func TestNewSchemeError(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	orig := AddToSchemes
+	t.Cleanup(func() { AddToSchemes = orig })
+	AddToSchemes = append(AddToSchemes, func(_ *runtime.Scheme) error { return errBoom })
+
+	_, err := NewScheme()
+	if diff := cmp.Diff(errors.Wrap(errBoom, "cannot add types to scheme"), err, test.EquateErrors()); diff != "" {
+		t.Errorf("NewScheme(): -want error, +got error:\n%s", diff)
+	}
+}
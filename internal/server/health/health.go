@@ -27,12 +27,23 @@ func (h *Probes) GetLiveness(w http.ResponseWriter, r *http.Request) {
 
 // GetReadiness gets the service readiness.
 func (h *Probes) GetReadiness(w http.ResponseWriter, r *http.Request) {
+	for _, check := range h.ready {
+		if err := check(); err != nil {
+			h.log.Debug("Service is not ready", "error", err)
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+	}
 	w.WriteHeader(http.StatusOK)
 }
 
+// A Check returns an error if the service is unhealthy.
+type Check func() error
+
 // Probes indicates the health of a service.
 type Probes struct {
-	log logging.Logger
+	log   logging.Logger
+	ready []Check
 }
 
 // Opt sets an option on the probes API.
@@ -45,6 +56,13 @@ func WithLogger(l logging.Logger) Opt {
 	}
 }
 
+// WithReadinessChecks sets checks that must pass for the service to be ready.
+func WithReadinessChecks(c ...Check) Opt {
+	return func(p *Probes) {
+		p.ready = append(p.ready, c...)
+	}
+}
+
 // New constructs a new probes API.
 func New(opts ...Opt) *Probes {
 	p := &Probes{
@@ -29,13 +29,13 @@ import (
 )
 
 // Server is a liveness and readiness server.
-func Server(opts internal.HealthOptions, log logging.Logger) (*http.Server, error) {
+func Server(opts internal.HealthOptions, log logging.Logger, o ...Opt) (*http.Server, error) {
 	r := chi.NewRouter()
 	r.Use(chimid.RedirectSlashes)
 	r.Use(chimid.RequestLogger(&request.Formatter{Log: log}))
 	r.Use(chimid.Compress(5))
 
-	h := New(WithLogger(log))
+	h := New(append([]Opt{WithLogger(log)}, o...)...)
 
 	r.Get("/livez", h.GetLiveness)
 	r.Get("/readyz", h.GetReadiness)
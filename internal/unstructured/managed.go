@@ -149,7 +149,7 @@ func (u *Managed) SetPublishConnectionDetailsTo(ref *xpv1.PublishConnectionDetai
 // GetManagementPolicies of this managed resource.
 func (u *Managed) GetManagementPolicies() xpv1.ManagementPolicies {
 	out := xpv1.ManagementPolicies{}
-	if err := fieldpath.Pave(u.Object).GetValueInto("spec.managementPolicies", out); err != nil {
+	if err := fieldpath.Pave(u.Object).GetValueInto("spec.managementPolicies", &out); err != nil {
 		return nil
 	}
 	return out
@@ -280,3 +280,27 @@ func TestManagedDeletionPolicy(t *testing.T) {
 		})
 	}
 }
+
+func TestManagedManagementPolicies(t *testing.T) {
+	cases := map[string]struct {
+		u    *Managed
+		set  xpv1.ManagementPolicies
+		want xpv1.ManagementPolicies
+	}{
+		"NewPolicies": {
+			u:    emptyMR(),
+			set:  xpv1.ManagementPolicies{xpv1.ManagementActionObserve, xpv1.ManagementActionDelete},
+			want: xpv1.ManagementPolicies{xpv1.ManagementActionObserve, xpv1.ManagementActionDelete},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			tc.u.SetManagementPolicies(tc.set)
+			got := tc.u.GetManagementPolicies()
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\nu.GetManagementPolicies(): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
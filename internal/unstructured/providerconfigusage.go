@@ -0,0 +1,58 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unstructured
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+)
+
+// A ProviderConfigUsage resource.
+type ProviderConfigUsage struct {
+	unstructured.Unstructured
+}
+
+// GetUnstructured returns the underlying *Unstructured.
+func (u *ProviderConfigUsage) GetUnstructured() *unstructured.Unstructured {
+	return &u.Unstructured
+}
+
+// GetProviderConfigReference of this provider config usage.
+func (u *ProviderConfigUsage) GetProviderConfigReference() xpv1.Reference {
+	out := xpv1.Reference{}
+	// The path is directly `providerConfigRef` because the usage is inline.
+	_ = fieldpath.Pave(u.Object).GetValueInto("providerConfigRef", &out)
+	return out
+}
+
+// SetProviderConfigReference of this provider config usage.
+func (u *ProviderConfigUsage) SetProviderConfigReference(ref xpv1.Reference) {
+	_ = fieldpath.Pave(u.Object).SetValue("providerConfigRef", ref)
+}
+
+// GetResourceReference of this provider config usage.
+func (u *ProviderConfigUsage) GetResourceReference() xpv1.TypedReference {
+	out := xpv1.TypedReference{}
+	// The path is directly `resourceRef` because the usage is inline.
+	_ = fieldpath.Pave(u.Object).GetValueInto("resourceRef", &out)
+	return out
+}
+
+// SetResourceReference of this provider config usage.
+func (u *ProviderConfigUsage) SetResourceReference(ref xpv1.TypedReference) {
+	_ = fieldpath.Pave(u.Object).SetValue("resourceRef", ref)
+}
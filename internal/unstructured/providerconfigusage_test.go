@@ -0,0 +1,82 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unstructured
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/google/go-cmp/cmp"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+var _ resource.ProviderConfigUsage = &ProviderConfigUsage{}
+
+func emptyPCU() *ProviderConfigUsage {
+	return &ProviderConfigUsage{Unstructured: unstructured.Unstructured{Object: map[string]interface{}{}}}
+}
+
+func TestProviderConfigUsageProviderConfigReference(t *testing.T) {
+	ref := xpv1.Reference{Name: "cool"}
+	cases := map[string]struct {
+		u    *ProviderConfigUsage
+		set  xpv1.Reference
+		want xpv1.Reference
+	}{
+		"NewRef": {
+			u:    emptyPCU(),
+			set:  ref,
+			want: ref,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			tc.u.SetProviderConfigReference(tc.set)
+			got := tc.u.GetProviderConfigReference()
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\nu.GetProviderConfigReference(): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestProviderConfigUsageResourceReference(t *testing.T) {
+	ref := xpv1.TypedReference{APIVersion: "example.org/v1", Kind: "Example", Name: "cool"}
+	cases := map[string]struct {
+		u    *ProviderConfigUsage
+		set  xpv1.TypedReference
+		want xpv1.TypedReference
+	}{
+		"NewRef": {
+			u:    emptyPCU(),
+			set:  ref,
+			want: ref,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			tc.u.SetResourceReference(tc.set)
+			got := tc.u.GetResourceReference()
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\nu.GetResourceReference(): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}